@@ -46,7 +46,7 @@ func main() {
 	fmt.Fprintf(file, "-- Database: Supabase PostgreSQL\n\n")
 
 	// Tables to backup (in order due to foreign keys)
-	tables := []string{"users", "tasks", "task_history"}
+	tables := []string{"users", "tasks", "task_history", "saved_views", "tags", "task_tags", "task_comments", "task_comment_edits", "task_attachments", "time_entries", "focus_sessions", "task_reminders"}
 
 	for _, table := range tables {
 		if err := backupTable(ctx, conn, file, table); err != nil {
@@ -15,12 +15,15 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
+	"github.com/notkevinvu/taskflow/backend/internal/blobstore"
 	"github.com/notkevinvu/taskflow/backend/internal/config"
 	"github.com/notkevinvu/taskflow/backend/internal/domain"
 	"github.com/notkevinvu/taskflow/backend/internal/handler"
 	"github.com/notkevinvu/taskflow/backend/internal/logger"
 	"github.com/notkevinvu/taskflow/backend/internal/metrics"
 	"github.com/notkevinvu/taskflow/backend/internal/middleware"
+	"github.com/notkevinvu/taskflow/backend/internal/notify"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
 	"github.com/notkevinvu/taskflow/backend/internal/ratelimit"
 	"github.com/notkevinvu/taskflow/backend/internal/repository"
 	"github.com/notkevinvu/taskflow/backend/internal/service"
@@ -96,7 +99,37 @@ func main() {
 	userPrefsRepo := repository.NewUserPreferencesRepository(dbPool)
 	dependencyRepo := repository.NewDependencyRepository(dbPool)
 	templateRepo := repository.NewTaskTemplateRepository(dbPool)
+	savedViewRepo := repository.NewSavedViewRepository(dbPool)
+	tagRepo := repository.NewTagRepository(dbPool)
+	commentRepo := repository.NewCommentRepository(dbPool)
+	attachmentRepo := repository.NewAttachmentRepository(dbPool)
+	timeEntryRepo := repository.NewTimeEntryRepository(dbPool)
+	focusSessionRepo := repository.NewFocusSessionRepository(dbPool)
 	gamificationRepo := repository.NewGamificationRepository(dbPool)
+	reminderRepo := repository.NewReminderRepository(dbPool)
+	workflowRepo := repository.NewWorkflowRepository(dbPool)
+
+	// Initialize blob storage for attachments
+	var blobStore ports.BlobStore
+	switch cfg.BlobStore {
+	case "s3":
+		blobStore, err = blobstore.NewS3Store(blobstore.S3Config{
+			Endpoint:        cfg.S3Endpoint,
+			Bucket:          cfg.S3Bucket,
+			Region:          cfg.S3Region,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+		})
+	case "local":
+		blobStore, err = blobstore.NewLocalStore(cfg.BlobLocalDir)
+	default:
+		err = fmt.Errorf("unknown BLOB_STORE %q (expected \"local\" or \"s3\")", cfg.BlobStore)
+	}
+	if err != nil {
+		slog.Error("Failed to initialize blob store", "error", err)
+		os.Exit(1)
+	}
+	slog.Info("Initialized blob store", "type", cfg.BlobStore)
 
 	// Initialize services
 	authService := service.NewAuthService(userRepo, cfg.JWTSecret, cfg.JWTExpiryHours)
@@ -106,8 +139,39 @@ func main() {
 	subtaskService := service.NewSubtaskService(taskRepo, taskHistoryRepo)
 	dependencyService := service.NewDependencyService(dependencyRepo, taskRepo)
 	templateService := service.NewTaskTemplateService(templateRepo)
+	savedViewService := service.NewSavedViewService(savedViewRepo, taskService)
+	tagService := service.NewTagService(tagRepo)
+	commentService := service.NewCommentService(commentRepo, taskService, taskHistoryRepo)
+	timeEntryService := service.NewTimeEntryService(timeEntryRepo, taskService)
+	focusSessionService := service.NewFocusSessionService(focusSessionRepo, userPrefsRepo, taskService)
+	attachmentService := service.NewAttachmentService(attachmentRepo, blobStore, taskService, cfg.AttachmentMaxBytes, cfg.AttachmentQuotaBytes)
 	gamificationService := service.NewGamificationService(gamificationRepo, taskRepo)
 	cleanupService := service.NewCleanupService(userRepo)
+	reminderService := service.NewReminderService(reminderRepo, userRepo, taskService)
+	undoService := service.NewUndoService(taskRepo, taskHistoryRepo)
+	trashService := service.NewTrashService(taskRepo, cfg.TrashRetentionDays)
+	duplicateService := service.NewDuplicateService(taskRepo, taskHistoryRepo, dependencyRepo)
+	convertService := service.NewConvertService(taskRepo, taskHistoryRepo, dependencyRepo)
+	moveService := service.NewMoveService(taskRepo)
+	workflowService := service.NewWorkflowService(workflowRepo)
+	boardService := service.NewBoardService(taskRepo, userPrefsRepo, taskService, moveService, workflowService)
+
+	// Register reminder delivery channels (email only when SMTP is configured)
+	reminderService.SetNotifier(domain.ReminderChannelWebhook, notify.NewWebhookNotifier(cfg.WebhookSecret))
+	if cfg.SMTPHost != "" {
+		smtpNotifier, err := notify.NewSMTPNotifier(notify.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		})
+		if err != nil {
+			slog.Error("Failed to initialize SMTP notifier", "error", err)
+			os.Exit(1)
+		}
+		reminderService.SetNotifier(domain.ReminderChannelEmail, smtpNotifier)
+	}
 
 	// Wire recurrence service into task service for recurring task completion support
 	taskService.SetRecurrenceService(recurrenceService)
@@ -115,12 +179,36 @@ func main() {
 	// Wire subtask service into task service for parent completion validation
 	taskService.SetSubtaskService(subtaskService)
 
+	// Apply the configured hierarchy depth wherever tasks are placed under a parent
+	subtaskService.SetMaxDepth(cfg.TaskMaxDepth)
+	convertService.SetMaxDepth(cfg.TaskMaxDepth)
+
 	// Wire dependency service into task service for blocker validation
 	taskService.SetDependencyService(dependencyService)
 
 	// Wire gamification service into task service for completion rewards
 	taskService.SetGamificationService(gamificationService)
 
+	// Wire workflow service into task service so status changes follow the user's workflow
+	taskService.SetWorkflowService(workflowService)
+
+	// Wire preferences into insights so board columns over their WIP limits are flagged
+	insightsService.SetUserPreferencesRepository(userPrefsRepo)
+
+	// Wire gamification service into focus sessions for the focus achievement
+	focusSessionService.SetGamificationService(gamificationService)
+
+	// Wire attachment service so trash purges and user cleanup remove attachment blobs
+	cleanupService.SetAttachmentService(attachmentService)
+	trashService.SetAttachmentService(attachmentService)
+
+	// Wire reminder service so reminders follow due date changes and recurring instances
+	taskService.SetReminderService(reminderService)
+
+	// Wire gamification and reminder services so undo reverses completion rewards and moves reminders
+	undoService.SetGamificationService(gamificationService)
+	undoService.SetReminderService(reminderService)
+
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(authService)
 	taskHandler := handler.NewTaskHandler(taskService)
@@ -131,7 +219,21 @@ func main() {
 	subtaskHandler := handler.NewSubtaskHandler(subtaskService)
 	dependencyHandler := handler.NewDependencyHandler(dependencyService)
 	templateHandler := handler.NewTaskTemplateHandler(templateService)
+	savedViewHandler := handler.NewSavedViewHandler(savedViewService)
+	tagHandler := handler.NewTagHandler(tagService)
+	commentHandler := handler.NewCommentHandler(commentService)
+	attachmentHandler := handler.NewAttachmentHandler(attachmentService, cfg.AttachmentMaxBytes)
+	timeEntryHandler := handler.NewTimeEntryHandler(timeEntryService)
+	focusSessionHandler := handler.NewFocusSessionHandler(focusSessionService)
 	gamificationHandler := handler.NewGamificationHandler(gamificationService)
+	reminderHandler := handler.NewReminderHandler(reminderService)
+	undoHandler := handler.NewUndoHandler(undoService)
+	trashHandler := handler.NewTrashHandler(trashService)
+	duplicateHandler := handler.NewDuplicateHandler(duplicateService)
+	convertHandler := handler.NewConvertHandler(convertService)
+	moveHandler := handler.NewMoveHandler(moveService)
+	boardHandler := handler.NewBoardHandler(boardService)
+	workflowHandler := handler.NewWorkflowHandler(workflowService)
 
 	// Set Gin mode
 	gin.SetMode(cfg.GinMode)
@@ -194,6 +296,7 @@ func main() {
 		// Task routes (protected)
 		tasks := v1.Group("/tasks")
 		tasks.Use(middleware.AuthRequired(cfg.JWTSecret))
+		tasks.Use(middleware.Operation()) // Groups each write's history so it can be undone
 		{
 			tasks.POST("", taskHandler.Create)
 			tasks.GET("", taskHandler.List)
@@ -201,25 +304,97 @@ func main() {
 			tasks.POST("/suggest-category", insightsHandler.SuggestCategory)
 			tasks.POST("/bulk-delete", taskHandler.BulkDelete)
 			tasks.POST("/bulk-restore", taskHandler.BulkRestore)
+			tasks.POST("/bulk-update", taskHandler.BulkUpdate)
+			tasks.POST("/bulk-complete", taskHandler.BulkComplete)
+			tasks.GET("/trash", trashHandler.List)
+			tasks.POST("/trash/empty", trashHandler.Empty)
 			tasks.GET("/:id", taskHandler.Get)
 			tasks.PUT("/:id", taskHandler.Update)
+			tasks.PATCH("/:id", taskHandler.Patch)
 			tasks.DELETE("/:id", taskHandler.Delete)
+			tasks.DELETE("/:id/permanent", trashHandler.DeletePermanently)
 			tasks.POST("/:id/bump", taskHandler.Bump)
+			tasks.POST("/:id/snooze", taskHandler.Snooze)
+			tasks.GET("/:id/history", taskHandler.GetHistory)
 			tasks.POST("/:id/complete", taskHandler.Complete)
 			tasks.POST("/:id/uncomplete", taskHandler.Uncomplete)
 			tasks.POST("/:id/restore", taskHandler.Restore)
+			tasks.POST("/:id/duplicate", duplicateHandler.Duplicate)
+			tasks.POST("/:id/convert", convertHandler.Convert)
+			tasks.POST("/:id/move", moveHandler.Move)
 			tasks.GET("/:id/estimate", insightsHandler.GetTimeEstimate)
+			tasks.POST("/:id/comments", commentHandler.CreateComment)
+			tasks.GET("/:id/comments", commentHandler.ListComments)
+			tasks.PUT("/:id/comments/:comment_id", commentHandler.UpdateComment)
+			tasks.DELETE("/:id/comments/:comment_id", commentHandler.DeleteComment)
+			tasks.GET("/:id/comments/:comment_id/edits", commentHandler.ListCommentEdits)
+			tasks.POST("/:id/attachments", attachmentHandler.UploadAttachment)
+			tasks.GET("/:id/attachments", attachmentHandler.ListAttachments)
+			tasks.GET("/:id/attachments/:attachment_id/download", attachmentHandler.DownloadAttachment)
+			tasks.DELETE("/:id/attachments/:attachment_id", attachmentHandler.DeleteAttachment)
+			tasks.POST("/:id/timer/start", timeEntryHandler.StartTimer)
+			tasks.POST("/:id/timer/stop", timeEntryHandler.StopTimer)
+			tasks.POST("/:id/time-entries", timeEntryHandler.CreateTimeEntry)
+			tasks.GET("/:id/time-entries", timeEntryHandler.ListTimeEntries)
+			tasks.DELETE("/:id/time-entries/:entry_id", timeEntryHandler.DeleteTimeEntry)
+			tasks.POST("/:id/reminders", reminderHandler.Create)
+			tasks.GET("/:id/reminders", reminderHandler.List)
+			tasks.DELETE("/:id/reminders/:reminder_id", reminderHandler.Delete)
+			tasks.POST("/:id/focus-sessions", focusSessionHandler.StartSession)
+			tasks.GET("/:id/focus-sessions", focusSessionHandler.ListTaskSessions)
+		}
+
+		// Timer routes (protected)
+		timer := v1.Group("/timer")
+		timer.Use(middleware.AuthRequired(cfg.JWTSecret))
+		{
+			timer.GET("", timeEntryHandler.GetRunningTimer)
+		}
+
+		// Board routes (protected)
+		board := v1.Group("/board")
+		board.Use(middleware.AuthRequired(cfg.JWTSecret))
+		board.Use(middleware.Operation()) // Moves record task history, so they can be undone
+		{
+			board.GET("", boardHandler.GetBoard)
+			board.GET("/wip-limits", boardHandler.GetWIPLimits)
+			board.PUT("/wip-limits", boardHandler.UpdateWIPLimits)
+			board.POST("/tasks/:id/move", boardHandler.MoveTask)
+		}
+
+		// Workflow routes (protected)
+		workflow := v1.Group("/workflow")
+		workflow.Use(middleware.AuthRequired(cfg.JWTSecret))
+		{
+			workflow.GET("", workflowHandler.GetWorkflow)
+			workflow.PUT("", workflowHandler.UpdateWorkflow)
+			workflow.DELETE("", workflowHandler.ResetWorkflow)
+		}
+
+		// Focus session routes (protected)
+		focus := v1.Group("/focus")
+		focus.Use(middleware.AuthRequired(cfg.JWTSecret))
+		{
+			focus.GET("/settings", focusSessionHandler.GetSettings)
+			focus.PUT("/settings", focusSessionHandler.UpdateSettings)
+			focus.GET("/current", focusSessionHandler.GetActiveSession)
+			focus.POST("/sessions/:session_id/pause", focusSessionHandler.PauseSession)
+			focus.POST("/sessions/:session_id/resume", focusSessionHandler.ResumeSession)
+			focus.POST("/sessions/:session_id/complete", focusSessionHandler.CompleteSession)
+			focus.POST("/sessions/:session_id/interrupt", focusSessionHandler.InterruptSession)
 		}
 
 		// Subtask routes (nested under tasks, restricted to registered users)
 		taskSubtasks := v1.Group("/tasks/:id")
 		taskSubtasks.Use(middleware.AuthRequired(cfg.JWTSecret))
 		taskSubtasks.Use(middleware.RequireFeature(domain.FeatureSubtasks))
+		taskSubtasks.Use(middleware.Operation())
 		{
 			taskSubtasks.POST("/subtasks", subtaskHandler.CreateSubtask)
 			taskSubtasks.GET("/subtasks", subtaskHandler.GetSubtasks)
 			taskSubtasks.GET("/subtask-info", subtaskHandler.GetSubtaskInfo)
 			taskSubtasks.GET("/expanded", subtaskHandler.GetTaskExpanded)
+			taskSubtasks.GET("/tree", subtaskHandler.GetTree)
 			taskSubtasks.GET("/can-complete", subtaskHandler.CanCompleteParent)
 		}
 
@@ -238,6 +413,7 @@ func main() {
 		subtasks := v1.Group("/subtasks")
 		subtasks.Use(middleware.AuthRequired(cfg.JWTSecret))
 		subtasks.Use(middleware.RequireFeature(domain.FeatureSubtasks))
+		subtasks.Use(middleware.Operation())
 		{
 			subtasks.POST("/:id/complete", subtaskHandler.CompleteSubtask)
 		}
@@ -245,11 +421,21 @@ func main() {
 		// Category routes (protected)
 		categories := v1.Group("/categories")
 		categories.Use(middleware.AuthRequired(cfg.JWTSecret))
+		categories.Use(middleware.Operation())
 		{
 			categories.PUT("/rename", categoryHandler.Rename)
 			categories.DELETE("/:name", categoryHandler.Delete)
 		}
 
+		// Undo routes (protected)
+		undo := v1.Group("/undo")
+		undo.Use(middleware.AuthRequired(cfg.JWTSecret))
+		undo.Use(middleware.Operation())
+		{
+			undo.GET("", undoHandler.ListOperations)
+			undo.POST("", undoHandler.Undo)
+		}
+
 		// Analytics routes (protected)
 		analytics := v1.Group("/analytics")
 		analytics.Use(middleware.AuthRequired(cfg.JWTSecret))
@@ -257,6 +443,7 @@ func main() {
 			analytics.GET("/summary", analyticsHandler.GetSummary)
 			analytics.GET("/trends", analyticsHandler.GetTrends)
 			analytics.GET("/heatmap", analyticsHandler.GetProductivityHeatmap)
+			analytics.GET("/focus-heatmap", analyticsHandler.GetFocusHeatmap)
 			analytics.GET("/category-trends", analyticsHandler.GetCategoryTrends)
 		}
 
@@ -299,10 +486,35 @@ func main() {
 			templates.GET("", templateHandler.ListTemplates)
 			templates.GET("/:id", templateHandler.GetTemplate)
 			templates.PUT("/:id", templateHandler.UpdateTemplate)
+			templates.PATCH("/:id", templateHandler.PatchTemplate)
 			templates.DELETE("/:id", templateHandler.DeleteTemplate)
 			templates.POST("/:id/use", templateHandler.UseTemplate)
 		}
 
+		// Saved view routes (protected)
+		views := v1.Group("/views")
+		views.Use(middleware.AuthRequired(cfg.JWTSecret))
+		{
+			views.POST("", savedViewHandler.CreateView)
+			views.GET("", savedViewHandler.ListViews)
+			views.GET("/:id", savedViewHandler.GetView)
+			views.PUT("/:id", savedViewHandler.UpdateView)
+			views.DELETE("/:id", savedViewHandler.DeleteView)
+			views.GET("/:id/tasks", savedViewHandler.ListViewTasks)
+		}
+
+		// Tag routes (protected)
+		tags := v1.Group("/tags")
+		tags.Use(middleware.AuthRequired(cfg.JWTSecret))
+		{
+			tags.POST("", tagHandler.CreateTag)
+			tags.GET("", tagHandler.ListTags)
+			tags.GET("/:id", tagHandler.GetTag)
+			tags.PUT("/:id", tagHandler.UpdateTag)
+			tags.DELETE("/:id", tagHandler.DeleteTag)
+			tags.POST("/:id/merge", tagHandler.MergeTag)
+		}
+
 		// Gamification routes (protected, restricted to registered users)
 		gamification := v1.Group("/gamification")
 		gamification.Use(middleware.AuthRequired(cfg.JWTSecret))
@@ -340,6 +552,22 @@ func main() {
 	defer cleanupCancel()
 	go cleanupService.RunCleanupLoop(cleanupCtx, 6*time.Hour)
 
+	// Start reminder delivery loop in background; safe to run on every instance
+	reminderCtx, reminderCancel := context.WithCancel(context.Background())
+	defer reminderCancel()
+	reminderInterval := time.Duration(cfg.ReminderPollSeconds) * time.Second
+	if reminderInterval <= 0 {
+		reminderInterval = 30 * time.Second
+	}
+	go reminderService.RunReminderLoop(reminderCtx, reminderInterval)
+
+	// Start trash retention purge loop in background (runs hourly) unless retention is disabled
+	trashCtx, trashCancel := context.WithCancel(context.Background())
+	defer trashCancel()
+	if cfg.TrashRetentionDays > 0 {
+		go trashService.RunPurgeLoop(trashCtx, time.Hour)
+	}
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
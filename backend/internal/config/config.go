@@ -18,6 +18,28 @@ type Config struct {
 	AllowedOrigins  []string
 	LogLevel        string
 	LogFormat       string
+	// Attachment storage
+	BlobStore            string // "local" or "s3"
+	BlobLocalDir         string
+	S3Endpoint           string
+	S3Bucket             string
+	S3Region             string
+	S3AccessKeyID        string
+	S3SecretAccessKey    string
+	AttachmentMaxBytes   int64 // Per file
+	AttachmentQuotaBytes int64 // Per user, across all attachments
+	// Reminder delivery
+	SMTPHost             string // Email reminders are disabled when empty
+	SMTPPort             int
+	SMTPUsername         string
+	SMTPPassword         string
+	SMTPFrom             string
+	WebhookSecret        string // Signs webhook reminder bodies when set
+	ReminderPollSeconds  int
+	// Trash
+	TrashRetentionDays int // Deleted tasks are purged after this many days; 0 keeps them until removed
+	// Subtasks
+	TaskMaxDepth int // Levels a task hierarchy may have, counting the top-level task
 }
 
 // Load reads configuration from environment variables
@@ -34,6 +56,24 @@ func Load() *Config {
 		AllowedOrigins:  getEnvAsSlice("ALLOWED_ORIGINS", []string{"http://localhost:3000"}),
 		LogLevel:        getEnv("LOG_LEVEL", "info"),
 		LogFormat:       getEnv("LOG_FORMAT", "text"),
+		BlobStore:            getEnv("BLOB_STORE", "local"),
+		BlobLocalDir:         getEnv("BLOB_LOCAL_DIR", "./data/attachments"),
+		S3Endpoint:           getEnv("S3_ENDPOINT", ""),
+		S3Bucket:             getEnv("S3_BUCKET", ""),
+		S3Region:             getEnv("S3_REGION", "us-east-1"),
+		S3AccessKeyID:        getEnv("S3_ACCESS_KEY_ID", ""),
+		S3SecretAccessKey:    getEnv("S3_SECRET_ACCESS_KEY", ""),
+		AttachmentMaxBytes:   getEnvAsInt64("ATTACHMENT_MAX_BYTES", 10<<20),
+		AttachmentQuotaBytes: getEnvAsInt64("ATTACHMENT_QUOTA_BYTES", 100<<20),
+		SMTPHost:             getEnv("SMTP_HOST", ""),
+		SMTPPort:             getEnvAsInt("SMTP_PORT", 587),
+		SMTPUsername:         getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:         getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:             getEnv("SMTP_FROM", ""),
+		WebhookSecret:        getEnv("NOTIFY_WEBHOOK_SECRET", ""),
+		ReminderPollSeconds:  getEnvAsInt("REMINDER_POLL_SECONDS", 30),
+		TrashRetentionDays:   getEnvAsInt("TRASH_RETENTION_DAYS", 30),
+		TaskMaxDepth:         getEnvAsInt("TASK_MAX_DEPTH", 5),
 	}
 }
 
@@ -52,6 +92,14 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsInt64(key string, defaultValue int64) int64 {
+	valueStr := getEnv(key, "")
+	if value, err := strconv.ParseInt(valueStr, 10, 64); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
 func getEnvAsSlice(key string, defaultValue []string) []string {
 	valueStr := getEnv(key, "")
 	if valueStr == "" {
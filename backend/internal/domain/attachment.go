@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	ErrAttachmentNotFound      = errors.New("attachment not found")
+	ErrAttachmentTooLarge      = errors.New("attachment exceeds the maximum file size")
+	ErrAttachmentQuotaExceeded = errors.New("attachment storage quota exceeded")
+	ErrBlobNotFound            = errors.New("blob not found")
+)
+
+// Attachment is a file attached to a task. The file itself lives in the blob store
+// under StorageKey; the row holds its metadata.
+type Attachment struct {
+	ID          string    `json:"id"`
+	TaskID      string    `json:"task_id"`
+	UserID      string    `json:"user_id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"` // Sniffed from the content, not taken from the client
+	SizeBytes   int64     `json:"size_bytes"`
+	StorageKey  string    `json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// AttachmentUpload is a file received from the client
+type AttachmentUpload struct {
+	Filename string
+	Size     int64
+	Content  io.Reader
+}
+
+// AttachmentListResponse is the response for listing a task's attachments
+type AttachmentListResponse struct {
+	Attachments []*Attachment `json:"attachments"`
+	TotalCount  int           `json:"total_count"`
+	UsedBytes   int64         `json:"used_bytes"`  // Across all of the user's attachments
+	QuotaBytes  int64         `json:"quota_bytes"` // Per-user storage quota
+}
+
+// AttachmentStorageKey returns the blob key for an attachment
+func AttachmentStorageKey(userID, taskID, attachmentID string) string {
+	return "attachments/" + userID + "/" + taskID + "/" + attachmentID
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	ErrInvalidStatusTransition = errors.New("status transition is not allowed")
+	ErrWIPLimitExceeded        = errors.New("column is at its WIP limit")
+)
+
+// Board page sizes
+const (
+	DefaultBoardColumnLimit = 20
+	MaxBoardColumnLimit     = 100
+)
+
+// MaxWIPLimit is the largest WIP limit a column can have
+const MaxWIPLimit = 999
+
+// WIPLimits caps how many tasks each board column may hold, keyed by workflow status and
+// stored in user_preferences. Columns without an entry are unlimited.
+type WIPLimits map[TaskStatus]int
+
+// Validate checks every limit is for a column of the workflow outside the done category,
+// and in range
+func (l WIPLimits) Validate(workflow *Workflow) error {
+	for status, limit := range l {
+		column, ok := workflow.Status(status)
+		if !ok {
+			return NewValidationError("limits", fmt.Sprintf("unknown status %q", status))
+		}
+		if column.Category == StatusCategoryDone {
+			return NewValidationError("limits", fmt.Sprintf("the %s column is done and cannot have a WIP limit", status))
+		}
+		if limit < 1 || limit > MaxWIPLimit {
+			return NewValidationError("limits", fmt.Sprintf("limit for %s must be between 1 and %d", status, MaxWIPLimit))
+		}
+	}
+	return nil
+}
+
+// Limit returns the column's WIP limit, or nil if it is unlimited
+func (l WIPLimits) Limit(status TaskStatus) *int {
+	limit, ok := l[status]
+	if !ok {
+		return nil
+	}
+	return &limit
+}
+
+// Statuses returns the limited columns in a stable order
+func (l WIPLimits) Statuses() []TaskStatus {
+	statuses := make([]TaskStatus, 0, len(l))
+	for status := range l {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i] < statuses[j] })
+	return statuses
+}
+
+// UpdateWIPLimitsDTO replaces all of a user's WIP limits; columns left out become unlimited
+type UpdateWIPLimitsDTO struct {
+	Limits WIPLimits `json:"limits" binding:"required"`
+}
+
+// BoardFilter selects a page of the board. Without a status every column's first page
+// is returned; with one, only that column, continuing from the cursor if given.
+type BoardFilter struct {
+	Status *TaskStatus
+	Cursor *string
+	Limit  int
+}
+
+// BoardColumn is one workflow status column, in the order the user arranged the tasks
+type BoardColumn struct {
+	Status     TaskStatus     `json:"status"`
+	Name       string         `json:"name"`
+	Category   StatusCategory `json:"category"`
+	Tasks      []*Task        `json:"tasks"`
+	TotalCount int            `json:"total_count"`
+	NextCursor *string        `json:"next_cursor"` // Null when the column has no more pages
+	WIPLimit   *int           `json:"wip_limit"`   // Null when the column is unlimited
+	OverLimit  bool           `json:"over_limit"`
+}
+
+// Board is the user's top-level tasks grouped by workflow status, in workflow order
+type Board struct {
+	Columns []*BoardColumn `json:"columns"`
+}
+
+// MoveBoardTaskRequest moves a task to a column (a workflow status key), and optionally between two of the
+// column's tasks (see MoveTaskRequest)
+type MoveBoardTaskRequest struct {
+	Status       TaskStatus `json:"status" binding:"required"`
+	AfterTaskID  *string    `json:"after_task_id,omitempty" binding:"omitempty,uuid"`
+	BeforeTaskID *string    `json:"before_task_id,omitempty" binding:"omitempty,uuid"`
+}
+
+// BoardPlacement is where a board move puts a task: the column, and the rank between the
+// requested neighbors (empty keeps the task's rank)
+type BoardPlacement struct {
+	TaskID string
+	Column *WorkflowStatus
+	Rank   string
+}
+
+// boardPlacementKey is the context key for a board move in progress
+type boardPlacementKey struct{}
+
+// WithBoardPlacement returns a context whose write of placement.TaskID also places the
+// task in the column. The write checks the column's WIP limit, with the user's limits
+// locked, and sets the column and rank in the same transaction, so a move is applied
+// whole and concurrent moves cannot overfill a column.
+func WithBoardPlacement(ctx context.Context, placement *BoardPlacement) context.Context {
+	return context.WithValue(ctx, boardPlacementKey{}, placement)
+}
+
+// BoardPlacementFor returns the board placement carried by ctx for a task, if any
+func BoardPlacementFor(ctx context.Context, taskID string) (*BoardPlacement, bool) {
+	placement, ok := ctx.Value(boardPlacementKey{}).(*BoardPlacement)
+	if !ok || placement == nil || placement.TaskID != taskID {
+		return nil, false
+	}
+	return placement, true
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrCommentNotFound = errors.New("comment not found")
+)
+
+// MaxCommentLength is the maximum length of a comment body in characters
+const MaxCommentLength = 10000
+
+// TaskComment is a markdown note on a task.
+// Deleted comments are kept (soft delete) but hidden from listings and counts.
+type TaskComment struct {
+	ID        string     `json:"id"`
+	TaskID    string     `json:"task_id"`
+	UserID    string     `json:"user_id"`
+	Body      string     `json:"body"`                // Markdown, rendered by the client
+	EditedAt  *time.Time `json:"edited_at,omitempty"` // Set once the body has been changed
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// TaskCommentEdit is a previous body of an edited comment
+type TaskCommentEdit struct {
+	ID        string    `json:"id"`
+	CommentID string    `json:"comment_id"`
+	Body      string    `json:"body"`
+	EditedAt  time.Time `json:"edited_at"` // When this body was replaced
+}
+
+// CreateCommentDTO is used for adding a comment to a task
+type CreateCommentDTO struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// UpdateCommentDTO is used for editing a comment
+type UpdateCommentDTO struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// CommentListResponse is the response for listing a task's comments
+type CommentListResponse struct {
+	Comments   []*TaskComment `json:"comments"`
+	TotalCount int            `json:"total_count"`
+}
+
+// CommentEditListResponse is the response for listing a comment's edit history
+type CommentEditListResponse struct {
+	Comment *TaskComment       `json:"comment"`
+	Edits   []*TaskCommentEdit `json:"edits"`
+}
@@ -0,0 +1,17 @@
+package domain
+
+// ConvertTaskRequest changes a task's type. Converting to a subtask needs the new
+// parent; converting to a recurring task needs the recurrence rule.
+type ConvertTaskRequest struct {
+	To           TaskType        `json:"to" binding:"required,oneof=regular subtask recurring"`
+	ParentTaskID *string         `json:"parent_task_id,omitempty" binding:"omitempty,uuid"`
+	Recurrence   *RecurrenceRule `json:"recurrence,omitempty"`
+}
+
+// TaskConversion is a task's new relationships after a type conversion, written in a
+// single transaction. Task carries the new type, parent, series, category and priority.
+type TaskConversion struct {
+	Task               *Task
+	Series             *TaskSeries // Optional: new series the task starts
+	DeactivateSeriesID *string     // Optional: series the task leaves that has no pending instance left
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -302,6 +304,982 @@ func TestParseDate_Invalid(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// Task Sort Tests
+// =============================================================================
+
+func TestParseTaskSort_Valid(t *testing.T) {
+	sorts, err := ParseTaskSort("due_date,-priority_score, created_at")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []TaskSort{
+		{Field: TaskSortDueDate, Descending: false},
+		{Field: TaskSortPriorityScore, Descending: true},
+		{Field: TaskSortCreatedAt, Descending: false},
+	}, sorts)
+}
+
+func TestParseTaskSort_Invalid(t *testing.T) {
+	invalidSpecs := []string{
+		"",
+		"-",
+		"due_date,,title",
+		"unknown_field",
+		"due_date,-due_date",
+		"id",
+	}
+
+	for _, spec := range invalidSpecs {
+		t.Run(spec, func(t *testing.T) {
+			sorts, err := ParseTaskSort(spec)
+			assert.Nil(t, sorts)
+			var validationErr *ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, "sort", validationErr.Field)
+		})
+	}
+}
+
+func TestFormatTaskSort(t *testing.T) {
+	spec := "-bump_count,title,-completed_at"
+	sorts, err := ParseTaskSort(spec)
+
+	assert.NoError(t, err)
+	assert.Equal(t, spec, FormatTaskSort(sorts))
+}
+
+func TestTaskListFilter_SortOrder(t *testing.T) {
+	var nilFilter *TaskListFilter
+	assert.Equal(t, DefaultTaskSort, nilFilter.SortOrder())
+	assert.Equal(t, DefaultTaskSort, (&TaskListFilter{}).SortOrder())
+
+	custom := []TaskSort{{Field: TaskSortTitle}}
+	assert.Equal(t, custom, (&TaskListFilter{Sort: custom}).SortOrder())
+}
+
+func TestParseTaskSort_Manual(t *testing.T) {
+	sorts, err := ParseTaskSort("manual")
+	assert.NoError(t, err)
+	assert.Equal(t, []TaskSort{{Field: TaskSortManual}}, sorts)
+
+	// Manual order pages by rank like any other string key
+	task := &Task{ID: "3f0a6c1e-8d5b-4b7a-9c2e-1a2b3c4d5e6f", Rank: "0000000003V"}
+	decoded, err := DecodeTaskCursor(NewTaskCursor(task, sorts).Encode(), sorts)
+	assert.NoError(t, err)
+	assert.Equal(t, "0000000003V", decoded.Values[0])
+}
+
+// =============================================================================
+// Rank Tests
+// =============================================================================
+
+func TestRankBetween(t *testing.T) {
+	tests := []struct {
+		before, after string
+	}{
+		{"", ""},
+		{"V", ""},
+		{"", "V"},
+		{"V", "W"},
+		{"V", "V1"},
+		{"a", "b"},
+		{"", "01"},
+		{"0000000001V", "0000000002V"},
+		{"0000000001V", ""},
+		{"", "0000000001V"},
+		{"zz", ""},
+		{"Vz", "W"},
+	}
+
+	for _, tt := range tests {
+		rank, err := RankBetween(tt.before, tt.after)
+		assert.NoError(t, err, "%q..%q", tt.before, tt.after)
+		assert.Greater(t, rank, tt.before, "%q..%q", tt.before, tt.after)
+		if tt.after != "" {
+			assert.Less(t, rank, tt.after, "%q..%q", tt.before, tt.after)
+		}
+		assert.True(t, validRank(rank), "%q is not a valid rank", rank)
+	}
+}
+
+func TestRankBetween_Invalid(t *testing.T) {
+	_, err := RankBetween("V", "V")
+	assert.ErrorIs(t, err, ErrRankConflict)
+	_, err = RankBetween("W", "V")
+	assert.ErrorIs(t, err, ErrRankConflict)
+	_, err = RankBetween("V0", "")
+	assert.ErrorIs(t, err, ErrInvalidRank)
+	_, err = RankBetween("", "a-b")
+	assert.ErrorIs(t, err, ErrInvalidRank)
+}
+
+func TestRankBetween_RepeatedInsertsStayOrdered(t *testing.T) {
+	// Always inserting right after the first task is the worst case for rank length
+	first, err := RankBetween("", "")
+	assert.NoError(t, err)
+	last, err := RankBetween(first, "")
+	assert.NoError(t, err)
+
+	upper := last
+	for i := 0; i < 50; i++ {
+		rank, err := RankBetween(first, upper)
+		assert.NoError(t, err)
+		assert.True(t, first < rank && rank < upper)
+		upper = rank
+	}
+	assert.Greater(t, len(upper), MaxRankLength/4)
+}
+
+func TestRankBetween_AppendsStayShort(t *testing.T) {
+	last := ""
+	for i := 0; i < 1000; i++ {
+		rank, err := RankBetween(last, "")
+		assert.NoError(t, err)
+		assert.Greater(t, rank, last)
+		assert.True(t, validRank(rank), "%q is not a valid rank", rank)
+		last = rank
+	}
+	assert.LessOrEqual(t, len(last), 3)
+
+	// Appending after a long rank left by moves starts over with a short one
+	rank, err := RankBetween("Vzzzzzzzzzzzzzzzzzzzzz1", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "W", rank)
+}
+
+func TestSpreadRanks(t *testing.T) {
+	for _, n := range []int{1, 2, 7, 100, 5000} {
+		ranks := SpreadRanks(n)
+		assert.Len(t, ranks, n)
+		for i, rank := range ranks {
+			assert.True(t, validRank(rank), "%q is not a valid rank", rank)
+			if i > 0 {
+				assert.Less(t, ranks[i-1], rank)
+				// There is room for a move between any two neighbors
+				between, err := RankBetween(ranks[i-1], rank)
+				assert.NoError(t, err)
+				assert.LessOrEqual(t, len(between), len(rank)+1)
+			}
+		}
+	}
+	assert.Nil(t, SpreadRanks(0))
+}
+
+func TestTask_RankScope(t *testing.T) {
+	parentID := "parent-1"
+	seriesID := "series-1"
+
+	top := (&Task{UserID: "user-1"}).RankScope()
+	subtask := (&Task{UserID: "user-1", ParentTaskID: &parentID}).RankScope()
+	instance := (&Task{UserID: "user-1", ParentTaskID: &parentID, SeriesID: &seriesID}).RankScope()
+
+	assert.Nil(t, top.ParentTaskID)
+	assert.Equal(t, "parent-1", *subtask.ParentTaskID)
+	// A recurring instance's parent is its previous instance, so it stays top-level
+	assert.True(t, instance.Equal(top))
+	assert.False(t, subtask.Equal(top))
+}
+
+// =============================================================================
+// Task Query Language Tests
+// =============================================================================
+
+func TestParseTaskQuery_FieldsAndNegation(t *testing.T) {
+	now := time.Date(2025, 3, 10, 15, 30, 0, 0, time.UTC)
+
+	query, err := ParseTaskQuery(`category:work due:<7d priority:>70 -status:done "quarterly report" budget`, now)
+
+	assert.NoError(t, err)
+	assert.Len(t, query.Clauses, 6)
+
+	category := query.Clauses[0].Terms[0]
+	assert.Equal(t, TaskQueryCategory, category.Field)
+	assert.Equal(t, "work", category.Value)
+
+	due := query.Clauses[1].Terms[0]
+	assert.Equal(t, TaskQueryDue, due.Field)
+	assert.Equal(t, TaskQueryLt, due.Op)
+	assert.Equal(t, time.Date(2025, 3, 17, 0, 0, 0, 0, time.UTC), *due.From)
+	assert.Equal(t, time.Date(2025, 3, 18, 0, 0, 0, 0, time.UTC), *due.To)
+
+	priority := query.Clauses[2].Terms[0]
+	assert.Equal(t, TaskQueryGt, priority.Op)
+	assert.Equal(t, 70, priority.Number)
+
+	status := query.Clauses[3].Terms[0]
+	assert.True(t, status.Negate)
+	assert.Equal(t, "done", status.Value)
+
+	phrase := query.Clauses[4].Terms[0]
+	assert.Equal(t, TaskQueryText, phrase.Field)
+	assert.True(t, phrase.Phrase)
+	assert.Equal(t, "quarterly report", phrase.Value)
+
+	word := query.Clauses[5].Terms[0]
+	assert.Equal(t, TaskQueryText, word.Field)
+	assert.False(t, word.Phrase)
+	assert.Equal(t, "budget", word.Value)
+}
+
+func TestParseTaskQuery_OrGroups(t *testing.T) {
+	query, err := ParseTaskQuery(`person:alice OR people:bob OR context:"call mom" effort:small`, time.Now())
+
+	assert.NoError(t, err)
+	assert.Len(t, query.Clauses, 2)
+	assert.Len(t, query.Clauses[0].Terms, 3)
+	assert.Equal(t, TaskQueryPerson, query.Clauses[0].Terms[1].Field)
+	assert.Equal(t, "bob", query.Clauses[0].Terms[1].Value)
+	assert.Equal(t, TaskQueryContext, query.Clauses[0].Terms[2].Field)
+	assert.Equal(t, "call mom", query.Clauses[0].Terms[2].Value)
+	assert.Equal(t, TaskQueryEffort, query.Clauses[1].Terms[0].Field)
+}
+
+func TestParseTaskQuery_Tags(t *testing.T) {
+	query, err := ParseTaskQuery(`tag:urgent OR tags:"deep work" -tag:someday`, time.Now())
+
+	assert.NoError(t, err)
+	assert.Len(t, query.Clauses, 2)
+	assert.Equal(t, TaskQueryTag, query.Clauses[0].Terms[0].Field)
+	assert.Equal(t, "urgent", query.Clauses[0].Terms[0].Value)
+	assert.Equal(t, "deep work", query.Clauses[0].Terms[1].Value)
+	assert.True(t, query.Clauses[1].Terms[0].Negate)
+	assert.Equal(t, TaskQueryTag, query.Clauses[1].Terms[0].Field)
+}
+
+func TestValidateTagColor(t *testing.T) {
+	valid := "#3B82f6"
+	assert.NoError(t, ValidateTagColor(nil))
+	assert.NoError(t, ValidateTagColor(&valid))
+
+	for _, color := range []string{"", "3b82f6", "#fff", "#3b82fz", "blue"} {
+		err := ValidateTagColor(&color)
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr, "color %q", color)
+	}
+}
+
+func TestParseTaskQuery_Dates(t *testing.T) {
+	now := time.Date(2025, 3, 10, 15, 30, 0, 0, time.UTC)
+	tests := []struct {
+		input    string
+		expected time.Time
+	}{
+		{"due:today", time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{"due:tomorrow", time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC)},
+		{"due:yesterday", time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC)},
+		{"due:2w", time.Date(2025, 3, 24, 0, 0, 0, 0, time.UTC)},
+		{"created:>=-3d", time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC)},
+		{"due:<=2025-12-31", time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			query, err := ParseTaskQuery(tt.input, now)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, *query.Clauses[0].Terms[0].From)
+		})
+	}
+}
+
+func TestParseTaskQuery_DueNone(t *testing.T) {
+	query, err := ParseTaskQuery("-due:none", time.Now())
+
+	assert.NoError(t, err)
+	term := query.Clauses[0].Terms[0]
+	assert.True(t, term.Negate)
+	assert.Nil(t, term.From)
+	assert.Nil(t, term.To)
+}
+
+func TestParseTaskQuery_PlainText(t *testing.T) {
+	query, err := ParseTaskQuery("  meeting at 12:30  ", time.Now())
+
+	assert.NoError(t, err)
+	assert.Len(t, query.Clauses, 3)
+	assert.Equal(t, "12:30", query.Clauses[2].Terms[0].Value)
+}
+
+func TestParseTaskQuery_Errors(t *testing.T) {
+	tests := []struct {
+		input   string
+		message string
+	}{
+		{"colour:red", `unknown field "colour" (at position 1)`},
+		{"status:finished", `invalid status "finished" (at position 1)`},
+		{"work category:", "category: needs a value (at position 6)"},
+		{"priority:>high", `priority must be a number between 0 and 100, got "high" (at position 1)`},
+		{"priority:101", `priority must be a number between 0 and 100, got "101" (at position 1)`},
+		{"due:<", "due: needs a value after < (at position 1)"},
+		{"due:someday", `invalid date "someday" (use today, tomorrow, yesterday, 7d, -2w or YYYY-MM-DD) (at position 1)`},
+		{"created:none", "created:none cannot be used here (at position 1)"},
+		{"effort:huge", `invalid effort "huge" (at position 1)`},
+		{`"unfinished phrase`, "unterminated quote (at position 1)"},
+		{`""`, "empty quoted phrase (at position 1)"},
+		{"OR work", "OR must follow a search term (at position 1)"},
+		{"work OR", "OR must be followed by a search term (at position 6)"},
+		{"work OR OR home", "OR must follow a search term (at position 9)"},
+		{`wo"rk`, "unexpected quote inside a word (at position 3)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			query, err := ParseTaskQuery(tt.input, time.Now())
+			assert.Nil(t, query)
+			var validationErr *ValidationError
+			if assert.ErrorAs(t, err, &validationErr) {
+				assert.Equal(t, "search", validationErr.Field)
+				assert.Equal(t, tt.message, validationErr.Message)
+			}
+		})
+	}
+}
+
+func TestParseTaskQuery_TooManyTerms(t *testing.T) {
+	input := ""
+	for i := 0; i <= MaxTaskQueryTerms; i++ {
+		input += "word "
+	}
+
+	_, err := ParseTaskQuery(input, time.Now())
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+// =============================================================================
+// Saved View Tests
+// =============================================================================
+
+func TestSavedViewFilter_ToTaskListFilter(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	status := TaskStatusTodo
+	search := "due:<7d"
+	sort := "-due_date"
+	filter := &SavedViewFilter{Status: &status, Search: &search, Sort: &sort}
+
+	result, err := filter.ToTaskListFilter(now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &status, result.Status)
+	assert.Equal(t, time.Date(2025, 3, 17, 0, 0, 0, 0, time.UTC), *result.Query.Clauses[0].Terms[0].From)
+	assert.Equal(t, []TaskSort{{Field: TaskSortDueDate, Descending: true}}, result.Sort)
+}
+
+func TestSavedViewFilter_ToTaskListFilter_Invalid(t *testing.T) {
+	invalidStatus := TaskStatus("archived")
+	badSearch := "colour:red"
+	badSort := "priority"
+	tooHigh := 101
+	start := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, -1)
+
+	tests := []struct {
+		name   string
+		filter SavedViewFilter
+		field  string
+	}{
+		{"invalid status", SavedViewFilter{Status: &invalidStatus}, "filter.status"},
+		{"invalid search", SavedViewFilter{Search: &badSearch}, "search"},
+		{"invalid sort", SavedViewFilter{Sort: &badSort}, "sort"},
+		{"priority out of range", SavedViewFilter{MinPriority: &tooHigh}, "filter.min_priority"},
+		{"due range reversed", SavedViewFilter{DueDateStart: &start, DueDateEnd: &end}, "filter.due_date_end"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.filter.ToTaskListFilter(time.Now())
+			var validationErr *ValidationError
+			if assert.ErrorAs(t, err, &validationErr) {
+				assert.Equal(t, tt.field, validationErr.Field)
+			}
+		})
+	}
+}
+
+func TestSavedView_Validate(t *testing.T) {
+	assert.Error(t, (&SavedView{}).Validate())
+	assert.Error(t, (&SavedView{Name: "Inbox", Position: -1}).Validate())
+	assert.NoError(t, (&SavedView{Name: "Inbox"}).Validate())
+}
+
+// =============================================================================
+// Task Version (ETag / If-Match) Tests
+// =============================================================================
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      string
+		wantVersion int
+		wantOK      bool
+		wantErr     bool
+	}{
+		{name: "strong tag", header: `"3"`, wantVersion: 3, wantOK: true},
+		{name: "weak tag", header: `W/"12"`, wantVersion: 12, wantOK: true},
+		{name: "surrounding whitespace", header: ` "5" `, wantVersion: 5, wantOK: true},
+		{name: "wildcard", header: "*", wantOK: false},
+		{name: "unquoted", header: "3", wantErr: true},
+		{name: "not a number", header: `"abc"`, wantErr: true},
+		{name: "zero version", header: `"0"`, wantErr: true},
+		{name: "list of tags", header: `"3", "4"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, ok, err := ParseIfMatch(tt.header)
+			if tt.wantErr {
+				var validationErr *ValidationError
+				assert.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, "If-Match", validationErr.Field)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantVersion, version)
+		})
+	}
+}
+
+func TestExpectedVersion_Context(t *testing.T) {
+	_, ok := ExpectedVersion(context.Background())
+	assert.False(t, ok)
+
+	version, ok := ExpectedVersion(WithExpectedVersion(context.Background(), 4))
+	assert.True(t, ok)
+	assert.Equal(t, 4, version)
+}
+
+func TestTask_ETag(t *testing.T) {
+	task := &Task{Version: 9}
+	assert.Equal(t, `"9"`, task.ETag())
+}
+
+func TestPreconditionFailedError_Error(t *testing.T) {
+	err := NewPreconditionFailedError("task", &Task{ID: "task-123"})
+	assert.Equal(t, "precondition failed: task has been modified", err.Error())
+}
+
+// =============================================================================
+// Merge Patch Tests
+// =============================================================================
+
+func TestPatchField_Unmarshal(t *testing.T) {
+	var patch TaskMergePatch
+	err := json.Unmarshal([]byte(`{"title": "New", "due_date": null, "related_people": []}`), &patch)
+	assert.NoError(t, err)
+
+	assert.True(t, patch.Title.Set)
+	assert.Equal(t, "New", *patch.Title.Value)
+	assert.True(t, patch.DueDate.IsNull())
+	assert.True(t, patch.RelatedPeople.Set)
+	assert.Empty(t, *patch.RelatedPeople.Value)
+
+	// Omitted members are neither set nor null
+	assert.False(t, patch.Category.Set)
+	assert.False(t, patch.Category.IsNull())
+}
+
+func TestPatchField_UnmarshalTypeMismatch(t *testing.T) {
+	var patch TaskMergePatch
+	err := json.Unmarshal([]byte(`{"user_priority": "high"}`), &patch)
+	assert.Error(t, err)
+}
+
+func TestUpdateTaskDTO_ToMergePatch(t *testing.T) {
+	title := "Renamed"
+	dto := &UpdateTaskDTO{Title: &title, RelatedPeople: []string{"Ana"}}
+
+	patch := dto.ToMergePatch()
+
+	assert.True(t, patch.Title.Set)
+	assert.Equal(t, "Renamed", *patch.Title.Value)
+	assert.Equal(t, []string{"Ana"}, *patch.RelatedPeople.Value)
+	// A nil PUT field never becomes a clear
+	assert.False(t, patch.DueDate.Set)
+	assert.False(t, patch.Description.Set)
+}
+
+// =============================================================================
+// Bulk Update Tests
+// =============================================================================
+
+func TestParseDueDateShift(t *testing.T) {
+	tests := []struct {
+		shift    string
+		wantDays int
+		wantErr  bool
+	}{
+		{shift: "+2d", wantDays: 2},
+		{shift: "3d", wantDays: 3},
+		{shift: "-1w", wantDays: -7},
+		{shift: "+0d", wantDays: 0},
+		{shift: "2", wantErr: true},
+		{shift: "+2m", wantErr: true},
+		{shift: "+1000d", wantErr: true},
+		{shift: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shift, func(t *testing.T) {
+			days, err := ParseDueDateShift(tt.shift)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDays, days)
+		})
+	}
+}
+
+func TestBulkTaskPatch_IsEmpty(t *testing.T) {
+	assert.True(t, (&BulkTaskPatch{}).IsEmpty())
+
+	var patch BulkTaskPatch
+	assert.NoError(t, json.Unmarshal([]byte(`{"context": null}`), &patch))
+	assert.False(t, patch.IsEmpty())
+}
+
+// =============================================================================
+// Pagination Cursor Tests
+// =============================================================================
+
+func TestTaskCursor_EncodeDecode_RoundTrip(t *testing.T) {
+	createdAt := time.Date(2025, 6, 1, 12, 30, 0, 123456000, time.UTC)
+	task := &Task{
+		ID:            "3f0a6c1e-8d5b-4b7a-9c2e-1a2b3c4d5e6f",
+		Title:         "Write report",
+		PriorityScore: 64,
+		BumpCount:     2,
+		CreatedAt:     createdAt,
+	}
+	sorts := []TaskSort{
+		{Field: TaskSortDueDate},
+		{Field: TaskSortPriorityScore, Descending: true},
+		{Field: TaskSortTitle},
+		{Field: TaskSortCreatedAt},
+	}
+
+	decoded, err := DecodeTaskCursor(NewTaskCursor(task, sorts).Encode(), sorts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, task.ID, decoded.ID)
+	assert.Nil(t, decoded.Values[0]) // No due date
+	assert.Equal(t, 64, decoded.Values[1])
+	assert.Equal(t, "Write report", decoded.Values[2])
+	assert.True(t, createdAt.Equal(decoded.Values[3].(time.Time)))
+}
+
+func TestDecodeTaskCursor_DifferentSort(t *testing.T) {
+	task := &Task{ID: "3f0a6c1e-8d5b-4b7a-9c2e-1a2b3c4d5e6f", CreatedAt: time.Now()}
+	cursor := NewTaskCursor(task, DefaultTaskSort).Encode()
+
+	_, err := DecodeTaskCursor(cursor, []TaskSort{{Field: TaskSortCreatedAt}, {Field: TaskSortPriorityScore}})
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "cursor", validationErr.Field)
+}
+
+func TestDecodeTaskCursor_Malformed(t *testing.T) {
+	invalidCursors := []string{
+		"",
+		"not-base64!!",
+		// "not-json"
+		"bm90LWpzb24",
+		// Non-UUID id
+		"eyJzIjoiLXByaW9yaXR5X3Njb3JlLC1jcmVhdGVkX2F0IiwidiI6WzEwLCIyMDI1LTAxLTAxVDAwOjAwOjAwWiJdLCJpIjoiYWJjIn0",
+		// NULL value for a non-nullable field
+		"eyJzIjoiLXByaW9yaXR5X3Njb3JlLC1jcmVhdGVkX2F0IiwidiI6WzEwLG51bGxdLCJpIjoiM2YwYTZjMWUtOGQ1Yi00YjdhLTljMmUtMWEyYjNjNGQ1ZTZmIn0",
+		// Wrong number of values
+		"eyJzIjoiLXByaW9yaXR5X3Njb3JlLC1jcmVhdGVkX2F0IiwidiI6WzEwXSwiaSI6IjNmMGE2YzFlLThkNWItNGI3YS05YzJlLTFhMmIzYzRkNWU2ZiJ9",
+	}
+
+	for _, s := range invalidCursors {
+		t.Run(s, func(t *testing.T) {
+			cursor, err := DecodeTaskCursor(s, DefaultTaskSort)
+			assert.Nil(t, cursor)
+			var validationErr *ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+		})
+	}
+}
+
+// =============================================================================
+// Focus Session Tests
+// =============================================================================
+
+func TestFocusSession_PauseResumeComplete(t *testing.T) {
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	session := &FocusSession{Status: FocusSessionRunning, PlannedMinutes: 25, StartedAt: start}
+
+	assert.NoError(t, session.Pause(start.Add(10*time.Minute)))
+	assert.Equal(t, 10*60, session.ElapsedFocusSeconds(start.Add(20*time.Minute)), "paused time is not focus time")
+	assert.ErrorIs(t, session.Pause(start.Add(11*time.Minute)), ErrInvalidFocusSessionChange)
+
+	assert.NoError(t, session.Resume(start.Add(15*time.Minute)))
+	assert.Equal(t, 5*60, session.PausedSeconds)
+	assert.Nil(t, session.PausedAt)
+
+	assert.NoError(t, session.Complete(start.Add(30*time.Minute)))
+	assert.Equal(t, FocusSessionCompleted, session.Status)
+	assert.Equal(t, 25*60, session.FocusedSeconds)
+	assert.NotNil(t, session.EndedAt)
+	assert.ErrorIs(t, session.Resume(start.Add(31*time.Minute)), ErrInvalidFocusSessionChange)
+	assert.ErrorIs(t, session.Complete(start.Add(31*time.Minute)), ErrInvalidFocusSessionChange)
+}
+
+func TestFocusSession_InterruptWhilePaused(t *testing.T) {
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	session := &FocusSession{Status: FocusSessionRunning, PlannedMinutes: 25, StartedAt: start}
+
+	assert.NoError(t, session.Pause(start.Add(8*time.Minute)))
+	assert.NoError(t, session.Interrupt(start.Add(12*time.Minute), "meeting"))
+
+	assert.Equal(t, FocusSessionInterrupted, session.Status)
+	assert.Equal(t, 8*60, session.FocusedSeconds)
+	assert.Equal(t, 4*60, session.PausedSeconds)
+	assert.Nil(t, session.PausedAt)
+	assert.Equal(t, "meeting", *session.InterruptReason)
+}
+
+func TestNextFocusBreak(t *testing.T) {
+	settings := DefaultFocusSettings()
+
+	tests := []struct {
+		completedToday int
+		expected       FocusBreak
+	}{
+		{completedToday: 1, expected: FocusBreak{Type: FocusBreakShort, Minutes: 5}},
+		{completedToday: 3, expected: FocusBreak{Type: FocusBreakShort, Minutes: 5}},
+		{completedToday: 4, expected: FocusBreak{Type: FocusBreakLong, Minutes: 15}},
+		{completedToday: 8, expected: FocusBreak{Type: FocusBreakLong, Minutes: 15}},
+		{completedToday: 0, expected: FocusBreak{Type: FocusBreakShort, Minutes: 5}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, NextFocusBreak(settings, tt.completedToday), "completed today: %d", tt.completedToday)
+	}
+}
+
+func TestUpdateFocusSettingsDTO_Apply(t *testing.T) {
+	settings := DefaultFocusSettings()
+	work := 50
+	countToward := false
+
+	dto := UpdateFocusSettingsDTO{WorkMinutes: &work, CountTowardAchievements: &countToward}
+	dto.Apply(&settings)
+
+	assert.Equal(t, 50, settings.WorkMinutes)
+	assert.False(t, settings.CountTowardAchievements)
+	assert.Equal(t, 5, settings.ShortBreakMinutes, "omitted fields are unchanged")
+	assert.Equal(t, 4, settings.SessionsUntilLongBreak)
+}
+
+// =============================================================================
+// Snooze Tests
+// =============================================================================
+
+func TestSnoozePreset_Resolve(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	// Wednesday 2025-03-12
+	morning := time.Date(2025, 3, 12, 10, 0, 0, 0, loc)
+	evening := time.Date(2025, 3, 12, 20, 0, 0, 0, loc)
+	monday := time.Date(2025, 3, 10, 8, 0, 0, 0, loc)
+
+	tests := []struct {
+		name     string
+		preset   SnoozePreset
+		now      time.Time
+		expected time.Time
+	}{
+		{"tonight before evening", SnoozeTonight, morning, time.Date(2025, 3, 12, 18, 0, 0, 0, loc)},
+		{"tonight after evening rolls to tomorrow", SnoozeTonight, evening, time.Date(2025, 3, 13, 18, 0, 0, 0, loc)},
+		{"tomorrow", SnoozeTomorrow, evening, time.Date(2025, 3, 13, 9, 0, 0, 0, loc)},
+		{"next week from Wednesday", SnoozeNextWeek, morning, time.Date(2025, 3, 17, 9, 0, 0, 0, loc)},
+		{"next week from Monday", SnoozeNextWeek, monday, time.Date(2025, 3, 17, 9, 0, 0, 0, loc)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Resolve works on the instant, whatever zone now is expressed in
+			got, err := tt.preset.Resolve(tt.now.UTC(), loc)
+			assert.NoError(t, err)
+			assert.True(t, tt.expected.Equal(got), "expected %v, got %v", tt.expected, got)
+		})
+	}
+
+	_, err = SnoozePreset("someday").Resolve(morning, loc)
+	assert.ErrorIs(t, err, ErrInvalidSnoozePreset)
+}
+
+func TestTask_AgeStart(t *testing.T) {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := created.AddDate(0, 0, 10)
+	earlier := created.AddDate(0, 0, -1)
+
+	task := Task{CreatedAt: created}
+	assert.Equal(t, created, task.AgeStart())
+	assert.False(t, task.IsDeferred(created))
+
+	task.DeferUntil = &later
+	assert.Equal(t, later, task.AgeStart())
+	assert.True(t, task.IsDeferred(created))
+	assert.False(t, task.IsDeferred(later))
+
+	// A start date before creation doesn't make the task older
+	task.DeferUntil = &earlier
+	assert.Equal(t, created, task.AgeStart())
+}
+
+// =============================================================================
+// Subtask Hierarchy Tests
+// =============================================================================
+
+func hierarchyTask(id, parentID string, status TaskStatus) *Task {
+	task := &Task{ID: id, Status: status, TaskType: TaskTypeSubtask}
+	if parentID != "" {
+		task.ParentTaskID = &parentID
+	} else {
+		task.TaskType = TaskTypeRegular
+	}
+	return task
+}
+
+// root
+// ├── a (done)
+// └── b
+//     ├── b1 (done)
+//     └── b2
+//         └── b2x
+func hierarchyFixture() (*Task, []*Task) {
+	return hierarchyTask("root", "", TaskStatusTodo), []*Task{
+		hierarchyTask("a", "root", TaskStatusDone),
+		hierarchyTask("b", "root", TaskStatusInProgress),
+		hierarchyTask("b1", "b", TaskStatusDone),
+		hierarchyTask("b2", "b", TaskStatusTodo),
+		hierarchyTask("b2x", "b2", TaskStatusTodo),
+	}
+}
+
+func TestNewSubtaskInfo_RollsUpEveryLevel(t *testing.T) {
+	_, descendants := hierarchyFixture()
+
+	info := NewSubtaskInfo("root", descendants)
+
+	// Direct subtasks only
+	assert.Equal(t, 2, info.TotalCount)
+	assert.Equal(t, 1, info.CompletedCount)
+	assert.Equal(t, 1, info.InProgressCount)
+	assert.InDelta(t, 0.5, info.CompletionRate, 0.001)
+	assert.False(t, info.AllComplete)
+
+	// Every level: a counts 1, b counts (1 + 0) / 2
+	assert.Equal(t, 5, info.DescendantCount)
+	assert.Equal(t, 2, info.DescendantCompletedCount)
+	assert.InDelta(t, 0.75, info.Progress, 0.001)
+
+	empty := NewSubtaskInfo("a", descendants)
+	assert.Zero(t, empty.TotalCount)
+	assert.Zero(t, empty.Progress)
+}
+
+func TestSubtreeHeight(t *testing.T) {
+	_, descendants := hierarchyFixture()
+
+	assert.Equal(t, 3, SubtreeHeight("root", descendants))
+	assert.Equal(t, 2, SubtreeHeight("b", descendants))
+	assert.Equal(t, 0, SubtreeHeight("a", descendants))
+}
+
+func TestBuildTaskTree(t *testing.T) {
+	root, descendants := hierarchyFixture()
+
+	tree := BuildTaskTree(root, descendants)
+
+	assert.Equal(t, "root", tree.ID)
+	assert.Len(t, tree.Subtasks, 2)
+	assert.Equal(t, "a", tree.Subtasks[0].ID)
+	assert.Empty(t, tree.Subtasks[0].Subtasks)
+
+	b := tree.Subtasks[1]
+	assert.Equal(t, []string{"b1", "b2"}, []string{b.Subtasks[0].ID, b.Subtasks[1].ID})
+	assert.Equal(t, 3, b.SubtaskInfo.DescendantCount)
+	assert.InDelta(t, 0.5, b.SubtaskInfo.Progress, 0.001)
+	assert.Equal(t, "b2x", b.Subtasks[1].Subtasks[0].ID)
+}
+
+func TestTask_CanHaveSubtasks(t *testing.T) {
+	assert.True(t, (&Task{TaskType: TaskTypeRegular}).CanHaveSubtasks())
+	assert.True(t, (&Task{TaskType: TaskTypeSubtask}).CanHaveSubtasks())
+	assert.False(t, (&Task{TaskType: TaskTypeRecurring}).CanHaveSubtasks())
+}
+
+// =============================================================================
+// Task History Tests
+// =============================================================================
+
+func historySnapshot(t *testing.T, task Task) *string {
+	data, err := json.Marshal(task)
+	assert.NoError(t, err)
+	s := string(data)
+	return &s
+}
+
+func TestTaskHistory_Changes_DiffsSnapshots(t *testing.T) {
+	dueDate := time.Date(2025, 3, 14, 17, 0, 0, 0, time.UTC)
+	before := Task{ID: "task-1", Title: "Draft", Status: TaskStatusTodo, UserPriority: 5, Tags: []string{"a"}, Version: 1, PriorityScore: 40}
+	after := before
+	after.Title = "Final"
+	after.Status = TaskStatusInProgress
+	after.DueDate = &dueDate
+	after.Tags = nil
+	after.Version = 2
+	after.PriorityScore = 75
+	after.UpdatedAt = time.Now()
+
+	h := TaskHistory{EventType: EventStatusChanged, OldValue: historySnapshot(t, before), NewValue: historySnapshot(t, after)}
+
+	assert.Equal(t, []FieldChange{
+		{Field: "due_date", Old: json.RawMessage(`null`), New: json.RawMessage(`"2025-03-14T17:00:00Z"`)},
+		{Field: "status", Old: json.RawMessage(`"todo"`), New: json.RawMessage(`"in_progress"`)},
+		{Field: "tags", Old: json.RawMessage(`["a"]`), New: json.RawMessage(`null`)},
+		{Field: "title", Old: json.RawMessage(`"Draft"`), New: json.RawMessage(`"Final"`)},
+	}, h.Changes(), "bookkeeping and derived fields are left out")
+}
+
+func TestTaskHistory_Changes_EventShapes(t *testing.T) {
+	created := TaskHistory{EventType: EventTaskCreated, NewValue: historySnapshot(t, Task{Title: "New", Status: TaskStatusTodo})}
+	changes := created.Changes()
+	assert.Contains(t, changes, FieldChange{Field: "title", Old: json.RawMessage(`null`), New: json.RawMessage(`"New"`)})
+	for _, change := range changes {
+		assert.NotEqual(t, "description", change.Field, "unset fields are not listed")
+	}
+
+	// Older delete entries only kept the state before the delete
+	legacyDelete := TaskHistory{EventType: EventTaskDeleted, OldValue: historySnapshot(t, Task{Title: "Gone"})}
+	assert.Empty(t, legacyDelete.Changes())
+
+	nine, ten := "9", string(rune(10+'0'))
+	legacyBump := TaskHistory{EventType: EventTaskBumped, OldValue: &nine, NewValue: &ten}
+	assert.Equal(t, []FieldChange{{Field: "bump_count", Old: json.RawMessage(`9`), New: json.RawMessage(`10`)}}, legacyBump.Changes())
+
+	bump := TaskHistory{
+		EventType: EventTaskBumped,
+		OldValue:  historySnapshot(t, Task{Title: "Bumped", BumpCount: 1, Version: 4}),
+		NewValue:  historySnapshot(t, Task{Title: "Bumped", BumpCount: 2, Version: 6}),
+	}
+	assert.Equal(t, []FieldChange{{Field: "bump_count", Old: json.RawMessage(`1`), New: json.RawMessage(`2`)}}, bump.Changes())
+
+	comment := `{"id":"c1","body":"Looks good"}`
+	commentAdded := TaskHistory{EventType: EventCommentAdded, NewValue: &comment}
+	assert.Equal(t, []FieldChange{{Field: "comment", Old: json.RawMessage(`null`), New: json.RawMessage(`"Looks good"`)}}, commentAdded.Changes())
+}
+
+func TestTaskHistoryEventType_Validate(t *testing.T) {
+	assert.NoError(t, EventTaskRestored.Validate())
+	assert.NoError(t, EventCommentRemoved.Validate())
+	assert.NoError(t, EventTaskConverted.Validate())
+	assert.Error(t, TaskHistoryEventType("renamed").Validate())
+}
+
+// =============================================================================
+// Undo Tests
+// =============================================================================
+
+func TestOperationID_Context(t *testing.T) {
+	_, ok := OperationID(context.Background())
+	assert.False(t, ok)
+
+	operationID, ok := OperationID(WithOperationID(context.Background(), "op-1"))
+	assert.True(t, ok)
+	assert.Equal(t, "op-1", operationID)
+}
+
+func TestSameTaskState(t *testing.T) {
+	dueDate := time.Date(2025, 3, 12, 17, 0, 0, 123456789, time.UTC)
+	stored := dueDate.Truncate(time.Microsecond).In(time.FixedZone("EST", -5*3600))
+	deletedAt := time.Now()
+
+	a := &Task{ID: "t1", Title: "Same", DueDate: &dueDate, Tags: []string{"b", "a"}, Version: 2}
+	b := &Task{ID: "t1", Title: "Same", DueDate: &stored, Tags: []string{"a", "b"}, Version: 7, RelatedPeople: []string{}}
+	assert.True(t, SameTaskState(a, b), "versions, tag order, empty lists and sub-microsecond time differences are ignored")
+
+	b.DeletedAt = &deletedAt
+	assert.False(t, SameTaskState(a, b), "trashed state matters")
+
+	b.DeletedAt = nil
+	b.BumpCount = 1
+	assert.False(t, SameTaskState(a, b))
+}
+
+func TestRestoreTaskState(t *testing.T) {
+	category := "Work"
+	task := &Task{ID: "t1", Title: "Now", Tags: []string{"x"}, Version: 5}
+	snapshot := &Task{ID: "t1", Title: "Before", Category: &category, Version: 2}
+
+	RestoreTaskState(task, snapshot)
+
+	assert.Equal(t, "Before", task.Title)
+	assert.Equal(t, &category, task.Category)
+	assert.NotNil(t, task.Tags, "nil tags would leave the current tags in place")
+	assert.Empty(t, task.Tags)
+	assert.Equal(t, 5, task.Version, "the version is not restored")
+}
+
+func TestDecodeTaskSnapshot(t *testing.T) {
+	assert.Nil(t, DecodeTaskSnapshot(nil))
+
+	legacy := "3"
+	assert.Nil(t, DecodeTaskSnapshot(&legacy))
+
+	task := DecodeTaskSnapshot(historySnapshot(t, Task{ID: "t1", Title: "Snap"}))
+	if assert.NotNil(t, task) {
+		assert.Equal(t, "Snap", task.Title)
+	}
+}
+
+// =============================================================================
+// Reminder Tests
+// =============================================================================
+
+func TestReminder_RemindAtForDueDate(t *testing.T) {
+	dueDate := time.Date(2025, 3, 12, 17, 0, 0, 0, time.UTC)
+	offset := 90
+
+	relative := Reminder{OffsetMinutes: &offset}
+	assert.True(t, relative.IsRelative())
+	assert.Equal(t, time.Date(2025, 3, 12, 15, 30, 0, 0, time.UTC), *relative.RemindAtForDueDate(&dueDate))
+	assert.Nil(t, relative.RemindAtForDueDate(nil), "unscheduled until the task has a due date")
+
+	absolute := Reminder{}
+	assert.False(t, absolute.IsRelative())
+	assert.Nil(t, absolute.RemindAtForDueDate(&dueDate))
+}
+
+func TestNewReminderNotification(t *testing.T) {
+	remindAt := time.Date(2025, 3, 12, 16, 0, 0, 0, time.UTC)
+	dueDate := time.Date(2025, 3, 12, 17, 0, 0, 0, time.UTC)
+	due := &DueReminder{
+		Reminder:    &Reminder{ID: "reminder-1", TaskID: "task-1", RemindAt: &remindAt},
+		TaskTitle:   "File taxes",
+		TaskDueDate: &dueDate,
+	}
+
+	notification := NewReminderNotification(due, "owner@example.com")
+	assert.Equal(t, "reminder-1", notification.ID)
+	assert.Equal(t, NotificationEventTaskReminder, notification.Event)
+	assert.Equal(t, "owner@example.com", notification.Recipient)
+	assert.Equal(t, "Reminder: File taxes", notification.Subject)
+	assert.Equal(t, remindAt, notification.RemindAt)
+	assert.Contains(t, notification.Body, "Wed, 12 Mar 2025 17:00 UTC")
+
+	due.TaskDueDate = nil
+	assert.Equal(t, `This is your reminder for "File taxes".`, NewReminderNotification(due, "").Body)
+}
+
 // =============================================================================
 // DTO and Struct Tests
 // =============================================================================
@@ -340,3 +1318,123 @@ func TestTask_DefaultValues(t *testing.T) {
 	assert.Equal(t, 0, task.BumpCount)
 	assert.Equal(t, 0, task.PriorityScore)
 }
+
+func TestWorkflow_CanTransition(t *testing.T) {
+	workflow := DefaultWorkflow()
+	assert.True(t, workflow.CanTransition(TaskStatusTodo, TaskStatusTodo))
+	assert.True(t, workflow.CanTransition(TaskStatusTodo, TaskStatusDone))
+	assert.True(t, workflow.CanTransition(TaskStatusBlocked, TaskStatusInProgress))
+	assert.True(t, workflow.CanTransition(TaskStatusDone, TaskStatusInProgress))
+
+	// The built-in workflow does not restrict transitions
+	assert.True(t, workflow.CanTransition(TaskStatusOnHold, TaskStatusDone))
+	assert.True(t, workflow.CanTransition(TaskStatusBlocked, TaskStatusDone))
+	assert.True(t, workflow.CanTransition(TaskStatusDone, TaskStatusBlocked))
+
+	custom := &Workflow{Statuses: []WorkflowStatus{
+		{Key: "backlog", Name: "Backlog", Category: StatusCategoryOpen},
+		{Key: "in_review", Name: "In Review", Category: StatusCategoryActive, Transitions: []TaskStatus{"shipped"}},
+		{Key: "shipped", Name: "Shipped", Category: StatusCategoryDone},
+	}}
+	// Statuses without transitions lead anywhere in the workflow
+	assert.True(t, custom.CanTransition("backlog", "shipped"))
+	assert.True(t, custom.CanTransition("in_review", "shipped"))
+	assert.False(t, custom.CanTransition("in_review", "backlog"))
+	assert.False(t, custom.CanTransition("backlog", TaskStatusTodo))
+	// Tasks left in a removed status can move on
+	assert.True(t, custom.CanTransition(TaskStatusOnHold, "backlog"))
+
+	done, ok := custom.CompletionStatus("backlog")
+	if assert.True(t, ok) {
+		assert.Equal(t, TaskStatus("shipped"), done.Key)
+	}
+	custom.Statuses[0].Transitions = []TaskStatus{"in_review"}
+	_, ok = custom.CompletionStatus("backlog")
+	assert.False(t, ok)
+}
+
+func TestWorkflow_Validate(t *testing.T) {
+	assert.NoError(t, DefaultWorkflow().Validate())
+
+	valid := func() []WorkflowStatus {
+		return []WorkflowStatus{
+			{Key: "backlog", Name: " Backlog ", Category: StatusCategoryOpen},
+			{Key: "in_review", Name: "In Review", Category: StatusCategoryActive, Transitions: []TaskStatus{"done"}},
+			{Key: TaskStatusDone, Name: "Done", Category: StatusCategoryDone},
+		}
+	}
+	workflow := &Workflow{Statuses: valid()}
+	if assert.NoError(t, workflow.Validate()) {
+		assert.Equal(t, "Backlog", workflow.Statuses[0].Name)
+	}
+
+	for name, mutate := range map[string]func([]WorkflowStatus) []WorkflowStatus{
+		"bad key":            func(s []WorkflowStatus) []WorkflowStatus { s[0].Key = "In Review"; return s },
+		"duplicate key":      func(s []WorkflowStatus) []WorkflowStatus { s[1].Key = "backlog"; return s },
+		"empty name":         func(s []WorkflowStatus) []WorkflowStatus { s[0].Name = "  "; return s },
+		"unknown category":   func(s []WorkflowStatus) []WorkflowStatus { s[0].Category = "waiting"; return s },
+		"built-in category":  func(s []WorkflowStatus) []WorkflowStatus { s[2].Category = StatusCategoryActive; return s },
+		"missing category":   func(s []WorkflowStatus) []WorkflowStatus { return s[1:] },
+		"unknown transition": func(s []WorkflowStatus) []WorkflowStatus { s[0].Transitions = []TaskStatus{"todo"}; return s },
+		"self transition":    func(s []WorkflowStatus) []WorkflowStatus { s[0].Transitions = []TaskStatus{"backlog"}; return s },
+	} {
+		var validationErr *ValidationError
+		assert.ErrorAs(t, (&Workflow{Statuses: mutate(valid())}).Validate(), &validationErr, name)
+	}
+}
+
+func TestWorkflowStatus_BaseStatus(t *testing.T) {
+	assert.Equal(t, TaskStatusBlocked, WorkflowStatus{Key: TaskStatusBlocked, Category: StatusCategoryActive}.BaseStatus())
+	assert.Equal(t, TaskStatusTodo, WorkflowStatus{Key: "backlog", Category: StatusCategoryOpen}.BaseStatus())
+	assert.Equal(t, TaskStatusInProgress, WorkflowStatus{Key: "in_review", Category: StatusCategoryActive}.BaseStatus())
+	assert.Equal(t, TaskStatusDone, WorkflowStatus{Key: "shipped", Category: StatusCategoryDone}.BaseStatus())
+
+	// Every built-in status keeps its category in the default workflow
+	for _, status := range DefaultWorkflow().Statuses {
+		assert.Equal(t, status.Key.Category(), status.Category, status.Key)
+	}
+}
+
+func TestUpdateWorkflowDTO_ValidateMapping(t *testing.T) {
+	updated := &Workflow{Statuses: []WorkflowStatus{
+		{Key: "backlog", Name: "Backlog", Category: StatusCategoryOpen},
+		{Key: TaskStatusInProgress, Name: "In Progress", Category: StatusCategoryActive},
+		{Key: TaskStatusDone, Name: "Done", Category: StatusCategoryDone},
+	}}
+
+	dto := &UpdateWorkflowDTO{StatusMapping: map[TaskStatus]TaskStatus{TaskStatusOnHold: "backlog"}}
+	assert.NoError(t, dto.ValidateMapping(DefaultWorkflow(), updated))
+
+	for name, mapping := range map[string]map[TaskStatus]TaskStatus{
+		"not in current": {"waiting": "backlog"},
+		"still kept":     {TaskStatusInProgress: "backlog"},
+		"unknown target": {TaskStatusTodo: "icebox"},
+		"other category": {TaskStatusBlocked: "backlog"},
+	} {
+		dto := &UpdateWorkflowDTO{StatusMapping: mapping}
+		var validationErr *ValidationError
+		assert.ErrorAs(t, dto.ValidateMapping(DefaultWorkflow(), updated), &validationErr, name)
+	}
+}
+
+func TestWIPLimits_Validate(t *testing.T) {
+	workflow := DefaultWorkflow()
+	assert.NoError(t, WIPLimits{}.Validate(workflow))
+	assert.NoError(t, WIPLimits{TaskStatusInProgress: 3, TaskStatusBlocked: 1}.Validate(workflow))
+
+	for name, limits := range map[string]WIPLimits{
+		"unknown status": {TaskStatus("review"): 2},
+		"done column":    {TaskStatusDone: 10},
+		"zero":           {TaskStatusInProgress: 0},
+		"too large":      {TaskStatusTodo: MaxWIPLimit + 1},
+	} {
+		var validationErr *ValidationError
+		assert.ErrorAs(t, limits.Validate(workflow), &validationErr, name)
+	}
+
+	limit := WIPLimits{TaskStatusInProgress: 3}.Limit(TaskStatusInProgress)
+	if assert.NotNil(t, limit) {
+		assert.Equal(t, 3, *limit)
+	}
+	assert.Nil(t, WIPLimits{}.Limit(TaskStatusTodo))
+}
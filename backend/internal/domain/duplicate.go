@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// DuplicateTaskRequest selects what a task's duplicate copies. The copy always starts
+// as a fresh todo task with the source's details and tags.
+type DuplicateTaskRequest struct {
+	Title               *string         `json:"title,omitempty" binding:"omitempty,max=200"` // Defaults to the source title
+	IncludeSubtasks     bool            `json:"include_subtasks"`                            // Copy the live subtasks
+	IncludeDependencies bool            `json:"include_dependencies"`                        // Copy blocker relationships
+	Recurrence          *RecurrenceRule `json:"recurrence,omitempty"`                        // Attach a new recurrence series to the copy
+	ShiftDates          bool            `json:"shift_dates"`                                 // Keep due and start dates the same distance from today as they were from the source's creation
+}
+
+// TaskDuplicate is a prepared copy of a task, written in a single transaction
+type TaskDuplicate struct {
+	Task         *Task
+	Subtasks     []*Task
+	Series       *TaskSeries      // Optional: new recurrence series for Task
+	Dependencies []TaskDependency // Blocker edges of the copies
+}
+
+// DaysBetween returns the number of calendar days from the day of from to the day of to, in UTC
+func DaysBetween(from, to time.Time) int {
+	fromDay := time.Date(from.UTC().Year(), from.UTC().Month(), from.UTC().Day(), 0, 0, 0, 0, time.UTC)
+	toDay := time.Date(to.UTC().Year(), to.UTC().Month(), to.UTC().Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDay.Sub(fromDay).Hours() / 24)
+}
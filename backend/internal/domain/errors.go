@@ -123,3 +123,22 @@ func NewInternalError(message string, cause error) *InternalError {
 		Cause:   cause,
 	}
 }
+
+// PreconditionFailedError represents a failed conditional request (If-Match).
+// Current holds the server's copy of the resource so the client can merge.
+type PreconditionFailedError struct {
+	Resource string
+	Current  interface{}
+}
+
+func (e *PreconditionFailedError) Error() string {
+	return fmt.Sprintf("precondition failed: %s has been modified", e.Resource)
+}
+
+// NewPreconditionFailedError creates a new precondition failed error
+func NewPreconditionFailedError(resource string, current interface{}) *PreconditionFailedError {
+	return &PreconditionFailedError{
+		Resource: resource,
+		Current:  current,
+	}
+}
@@ -0,0 +1,219 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrFocusSessionNotFound      = errors.New("focus session not found")
+	ErrFocusSessionActive        = errors.New("a focus session is already in progress; complete or interrupt it first")
+	ErrInvalidFocusSessionChange = errors.New("focus session cannot make that change in its current state")
+)
+
+// FocusSessionStatus represents the state of a focus session
+type FocusSessionStatus string
+
+const (
+	FocusSessionRunning     FocusSessionStatus = "running"
+	FocusSessionPaused      FocusSessionStatus = "paused"
+	FocusSessionCompleted   FocusSessionStatus = "completed"
+	FocusSessionInterrupted FocusSessionStatus = "interrupted"
+)
+
+// FocusBreakType is the kind of break suggested after a completed session
+type FocusBreakType string
+
+const (
+	FocusBreakShort FocusBreakType = "short_break"
+	FocusBreakLong  FocusBreakType = "long_break"
+)
+
+// MaxFocusInterruptReasonLength is the maximum length of an interrupt reason in characters
+const MaxFocusInterruptReasonLength = 500
+
+// FocusSettings holds a user's Pomodoro lengths, stored in user_preferences
+type FocusSettings struct {
+	WorkMinutes             int  `json:"work_minutes"`
+	ShortBreakMinutes       int  `json:"short_break_minutes"`
+	LongBreakMinutes        int  `json:"long_break_minutes"`
+	SessionsUntilLongBreak  int  `json:"sessions_until_long_break"` // Every Nth completed session of the day earns a long break
+	CountTowardAchievements bool `json:"count_toward_achievements"` // Completed sessions count toward the focus_master achievement
+}
+
+// DefaultFocusSettings returns the classic Pomodoro settings used until a user changes them
+func DefaultFocusSettings() FocusSettings {
+	return FocusSettings{
+		WorkMinutes:             25,
+		ShortBreakMinutes:       5,
+		LongBreakMinutes:        15,
+		SessionsUntilLongBreak:  4,
+		CountTowardAchievements: true,
+	}
+}
+
+// UpdateFocusSettingsDTO is used for updating focus settings; omitted fields are unchanged
+type UpdateFocusSettingsDTO struct {
+	WorkMinutes             *int  `json:"work_minutes,omitempty" binding:"omitempty,min=1,max=180"`
+	ShortBreakMinutes       *int  `json:"short_break_minutes,omitempty" binding:"omitempty,min=1,max=60"`
+	LongBreakMinutes        *int  `json:"long_break_minutes,omitempty" binding:"omitempty,min=1,max=120"`
+	SessionsUntilLongBreak  *int  `json:"sessions_until_long_break,omitempty" binding:"omitempty,min=1,max=12"`
+	CountTowardAchievements *bool `json:"count_toward_achievements,omitempty"`
+}
+
+// Apply merges the DTO's set fields into the settings
+func (dto *UpdateFocusSettingsDTO) Apply(settings *FocusSettings) {
+	if dto.WorkMinutes != nil {
+		settings.WorkMinutes = *dto.WorkMinutes
+	}
+	if dto.ShortBreakMinutes != nil {
+		settings.ShortBreakMinutes = *dto.ShortBreakMinutes
+	}
+	if dto.LongBreakMinutes != nil {
+		settings.LongBreakMinutes = *dto.LongBreakMinutes
+	}
+	if dto.SessionsUntilLongBreak != nil {
+		settings.SessionsUntilLongBreak = *dto.SessionsUntilLongBreak
+	}
+	if dto.CountTowardAchievements != nil {
+		settings.CountTowardAchievements = *dto.CountTowardAchievements
+	}
+}
+
+// FocusSession is a timed work session on a task.
+// A user has at most one active (running or paused) session.
+type FocusSession struct {
+	ID              string             `json:"id"`
+	TaskID          string             `json:"task_id"`
+	UserID          string             `json:"user_id"`
+	Status          FocusSessionStatus `json:"status"`
+	PlannedMinutes  int                `json:"planned_minutes"`
+	StartedAt       time.Time          `json:"started_at"`
+	PausedAt        *time.Time         `json:"paused_at,omitempty"` // Set while paused
+	PausedSeconds   int                `json:"paused_seconds"`      // Total of finished pauses
+	EndedAt         *time.Time         `json:"ended_at,omitempty"`  // Set once completed or interrupted
+	FocusedSeconds  int                `json:"focused_seconds"`     // Unpaused time; so far for an active session
+	InterruptReason *string            `json:"interrupt_reason,omitempty"`
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+}
+
+// IsActive reports whether the session is running or paused
+func (s *FocusSession) IsActive() bool {
+	return s.Status == FocusSessionRunning || s.Status == FocusSessionPaused
+}
+
+// ElapsedFocusSeconds returns the unpaused time of an active session at now
+func (s *FocusSession) ElapsedFocusSeconds(now time.Time) int {
+	end := now
+	if s.PausedAt != nil {
+		end = *s.PausedAt
+	}
+	elapsed := int(end.Sub(s.StartedAt).Seconds()) - s.PausedSeconds
+	if elapsed < 0 {
+		return 0
+	}
+	return elapsed
+}
+
+// Pause pauses a running session
+func (s *FocusSession) Pause(now time.Time) error {
+	if s.Status != FocusSessionRunning {
+		return ErrInvalidFocusSessionChange
+	}
+	s.Status = FocusSessionPaused
+	s.PausedAt = &now
+	return nil
+}
+
+// Resume resumes a paused session, adding the pause to PausedSeconds
+func (s *FocusSession) Resume(now time.Time) error {
+	if s.Status != FocusSessionPaused || s.PausedAt == nil {
+		return ErrInvalidFocusSessionChange
+	}
+	if now.After(*s.PausedAt) {
+		s.PausedSeconds += int(now.Sub(*s.PausedAt).Seconds())
+	}
+	s.Status = FocusSessionRunning
+	s.PausedAt = nil
+	return nil
+}
+
+// Complete ends an active session as completed
+func (s *FocusSession) Complete(now time.Time) error {
+	return s.end(now, FocusSessionCompleted, nil)
+}
+
+// Interrupt ends an active session early with the reason it was abandoned
+func (s *FocusSession) Interrupt(now time.Time, reason string) error {
+	return s.end(now, FocusSessionInterrupted, &reason)
+}
+
+func (s *FocusSession) end(now time.Time, status FocusSessionStatus, reason *string) error {
+	if !s.IsActive() {
+		return ErrInvalidFocusSessionChange
+	}
+	s.FocusedSeconds = s.ElapsedFocusSeconds(now)
+	if s.PausedAt != nil {
+		// A session ended while paused keeps that pause out of its focus time
+		s.PausedSeconds += int(now.Sub(*s.PausedAt).Seconds())
+		s.PausedAt = nil
+	}
+	s.Status = status
+	s.EndedAt = &now
+	s.InterruptReason = reason
+	return nil
+}
+
+// StartFocusSessionDTO is used for starting a focus session; the length defaults to the user's work minutes
+type StartFocusSessionDTO struct {
+	PlannedMinutes *int `json:"planned_minutes,omitempty" binding:"omitempty,min=1,max=180"`
+}
+
+// InterruptFocusSessionDTO is used for abandoning a focus session
+type InterruptFocusSessionDTO struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// FocusBreak is the break suggested after a completed session
+type FocusBreak struct {
+	Type    FocusBreakType `json:"type"`
+	Minutes int            `json:"minutes"`
+}
+
+// FocusSessionCompletion is the response for completing a focus session
+type FocusSessionCompletion struct {
+	Session        *FocusSession           `json:"session"`
+	CompletedToday int                     `json:"completed_today"` // Including this session, in the user's timezone
+	NextBreak      FocusBreak              `json:"next_break"`
+	NewAchievement *AchievementEarnedEvent `json:"new_achievement,omitempty"`
+}
+
+// NextFocusBreak suggests a long break after every SessionsUntilLongBreak-th session of the day
+func NextFocusBreak(settings FocusSettings, completedToday int) FocusBreak {
+	if settings.SessionsUntilLongBreak > 0 && completedToday > 0 && completedToday%settings.SessionsUntilLongBreak == 0 {
+		return FocusBreak{Type: FocusBreakLong, Minutes: settings.LongBreakMinutes}
+	}
+	return FocusBreak{Type: FocusBreakShort, Minutes: settings.ShortBreakMinutes}
+}
+
+// FocusSessionListResponse is the response for listing a task's focus sessions
+type FocusSessionListResponse struct {
+	Sessions         []*FocusSession `json:"sessions"`
+	CompletedCount   int             `json:"completed_count"`
+	InterruptedCount int             `json:"interrupted_count"`
+	FocusedSeconds   int             `json:"focused_seconds"` // Ended sessions only
+}
+
+// FocusHeatmapCell is the focus time started in one hour of the week
+type FocusHeatmapCell struct {
+	DayOfWeek int `json:"day_of_week"` // 0 = Sunday, 6 = Saturday
+	Hour      int `json:"hour"`        // 0-23
+	Minutes   int `json:"minutes"`     // Focused minutes of sessions started in this hour
+}
+
+// FocusHeatmap contains focus minutes by day of week and hour
+type FocusHeatmap struct {
+	Cells      []FocusHeatmapCell `json:"cells"`
+	MaxMinutes int                `json:"max_minutes"` // For color scaling
+}
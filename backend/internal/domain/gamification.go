@@ -36,6 +36,9 @@ const (
 
 	// Consistency achievements
 	AchievementConsistencyKing AchievementType = "consistency_king"
+
+	// Focus achievements (completed focus sessions, if the user opts in)
+	AchievementFocusMaster AchievementType = "focus_master"
 )
 
 // UserAchievement represents an earned achievement badge
@@ -53,7 +56,7 @@ type AchievementDefinition struct {
 	Title       string          `json:"title"`
 	Description string          `json:"description"`
 	Icon        string          `json:"icon"`     // Emoji for badge display
-	Category    string          `json:"category"` // "milestone", "streak", "mastery", "speed", "consistency"
+	Category    string          `json:"category"` // "milestone", "streak", "mastery", "speed", "consistency", "focus"
 }
 
 // GetAchievementDefinitions returns all available achievement definitions
@@ -79,6 +82,9 @@ func GetAchievementDefinitions() []AchievementDefinition {
 
 		// Consistency
 		{Type: AchievementConsistencyKing, Title: "Consistency King", Description: "Complete tasks on 5+ days in a week", Icon: "📅", Category: "consistency"},
+
+		// Focus
+		{Type: AchievementFocusMaster, Title: "Deep Focus", Description: "Complete 25 focus sessions", Icon: "🍅", Category: "focus"},
 	}
 }
 
@@ -193,6 +199,9 @@ const SpeedDemonDuration = 24 * time.Hour
 // ConsistencyKingThreshold is the number of active days per week needed
 const ConsistencyKingThreshold = 5
 
+// FocusMasterThreshold is the number of completed focus sessions needed
+const FocusMasterThreshold = 25
+
 // UpdateTimezoneDTO is used for updating user timezone
 type UpdateTimezoneDTO struct {
 	Timezone string `json:"timezone" binding:"required"`
@@ -12,6 +12,7 @@ const (
 	InsightDeadlineClustering InsightType = "deadline_clustering"
 	InsightAtRiskAlert        InsightType = "at_risk_alert"
 	InsightCategoryOverload   InsightType = "category_overload"
+	InsightWIPLimitExceeded   InsightType = "wip_limit_exceeded"
 )
 
 // InsightPriority represents the importance level of an insight (1-5, higher = more important)
@@ -44,15 +45,26 @@ type InsightResponse struct {
 
 // TimeEstimate represents an estimated completion time for a task
 type TimeEstimate struct {
-	EstimatedDays   float64           `json:"estimated_days"`
-	ConfidenceLevel string            `json:"confidence_level"` // "low", "medium", "high"
-	BasedOn         int               `json:"based_on"`         // Number of similar tasks used for estimation
-	Factors         TimeEstimateFactor `json:"factors"`
+	EstimatedDays    float64            `json:"estimated_days"`
+	EstimatedMinutes float64            `json:"estimated_minutes,omitempty"` // Hands-on effort; set when Basis is tracked_time
+	ConfidenceLevel  string             `json:"confidence_level"`            // "low", "medium", "high"
+	BasedOn          int                `json:"based_on"`                    // Number of similar tasks used for estimation
+	Basis            EstimateBasis      `json:"basis"`
+	Factors          TimeEstimateFactor `json:"factors"`
 }
 
+// EstimateBasis is the historical data a time estimate was derived from
+type EstimateBasis string
+
+const (
+	EstimateBasisTrackedTime EstimateBasis = "tracked_time" // Time logged on completed tasks
+	EstimateBasisWallTime    EstimateBasis = "wall_time"    // Created-to-completed time of completed tasks
+)
+
 // TimeEstimateFactor contains the individual factors used in time estimation
 type TimeEstimateFactor struct {
 	BaseEstimate   float64 `json:"base_estimate"`
+	BaseMinutes    float64 `json:"base_minutes,omitempty"` // Median tracked effort; set when Basis is tracked_time
 	CategoryFactor float64 `json:"category_factor"`
 	EffortFactor   float64 `json:"effort_factor"`
 	BumpFactor     float64 `json:"bump_factor"`
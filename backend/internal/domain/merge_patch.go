@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// PatchField is one member of a JSON Merge Patch document (RFC 7396).
+// Unlike a plain pointer it distinguishes an omitted member (leave the field
+// alone) from an explicit null (clear the field).
+type PatchField[T any] struct {
+	Set   bool // Member was present in the document
+	Value *T   // nil when the member was null
+}
+
+// UnmarshalJSON records that the member was present and decodes its value
+func (f *PatchField[T]) UnmarshalJSON(data []byte) error {
+	f.Set = true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		f.Value = nil
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	f.Value = &value
+	return nil
+}
+
+// IsNull reports whether the member was explicitly set to null
+func (f PatchField[T]) IsNull() bool {
+	return f.Set && f.Value == nil
+}
+
+// patchFieldFromPtr converts an optional PUT-style field into a patch member;
+// nil means "not provided", never "clear"
+func patchFieldFromPtr[T any](value *T) PatchField[T] {
+	return PatchField[T]{Set: value != nil, Value: value}
+}
+
+// TaskMergePatch is the body of PATCH /tasks/:id (application/merge-patch+json).
+// Members set to null clear optional fields; required fields reject null.
+type TaskMergePatch struct {
+	Title           PatchField[string]     `json:"title"`
+	Description     PatchField[string]     `json:"description"`
+	Status          PatchField[TaskStatus] `json:"status"`
+	UserPriority    PatchField[int]        `json:"user_priority"`
+	DueDate         PatchField[time.Time]  `json:"due_date"`
+	EstimatedEffort PatchField[TaskEffort] `json:"estimated_effort"`
+	Category        PatchField[string]     `json:"category"`
+	Context         PatchField[string]     `json:"context"`
+	RelatedPeople   PatchField[[]string]   `json:"related_people"`
+	Tags            PatchField[[]string]   `json:"tags"`
+	DeferUntil      PatchField[time.Time]  `json:"defer_until"`
+}
+
+// ToMergePatch converts a PUT body into the equivalent merge patch
+func (dto *UpdateTaskDTO) ToMergePatch() *TaskMergePatch {
+	patch := &TaskMergePatch{
+		Title:           patchFieldFromPtr(dto.Title),
+		Description:     patchFieldFromPtr(dto.Description),
+		Status:          patchFieldFromPtr(dto.Status),
+		UserPriority:    patchFieldFromPtr(dto.UserPriority),
+		DueDate:         patchFieldFromPtr(dto.DueDate),
+		EstimatedEffort: patchFieldFromPtr(dto.EstimatedEffort),
+		Category:        patchFieldFromPtr(dto.Category),
+		Context:         patchFieldFromPtr(dto.Context),
+		DeferUntil:      patchFieldFromPtr(dto.DeferUntil),
+	}
+	if dto.RelatedPeople != nil {
+		patch.RelatedPeople = PatchField[[]string]{Set: true, Value: &dto.RelatedPeople}
+	}
+	if dto.Tags != nil {
+		patch.Tags = PatchField[[]string]{Set: true, Value: &dto.Tags}
+	}
+	return patch
+}
+
+// TaskTemplateMergePatch is the body of PATCH /templates/:id (application/merge-patch+json)
+type TaskTemplateMergePatch struct {
+	Name            PatchField[string]     `json:"name"`
+	Title           PatchField[string]     `json:"title"`
+	Description     PatchField[string]     `json:"description"`
+	Category        PatchField[string]     `json:"category"`
+	EstimatedEffort PatchField[TaskEffort] `json:"estimated_effort"`
+	UserPriority    PatchField[int]        `json:"user_priority"`
+	Context         PatchField[string]     `json:"context"`
+	RelatedPeople   PatchField[[]string]   `json:"related_people"`
+	Tags            PatchField[[]string]   `json:"tags"`
+	DueDateOffset   PatchField[int]        `json:"due_date_offset"`
+}
+
+// ToMergePatch converts a PUT body into the equivalent merge patch
+func (dto *UpdateTaskTemplateDTO) ToMergePatch() *TaskTemplateMergePatch {
+	patch := &TaskTemplateMergePatch{
+		Name:            patchFieldFromPtr(dto.Name),
+		Title:           patchFieldFromPtr(dto.Title),
+		Description:     patchFieldFromPtr(dto.Description),
+		Category:        patchFieldFromPtr(dto.Category),
+		EstimatedEffort: patchFieldFromPtr(dto.EstimatedEffort),
+		UserPriority:    patchFieldFromPtr(dto.UserPriority),
+		Context:         patchFieldFromPtr(dto.Context),
+		DueDateOffset:   patchFieldFromPtr(dto.DueDateOffset),
+	}
+	if dto.RelatedPeople != nil {
+		patch.RelatedPeople = PatchField[[]string]{Set: true, Value: &dto.RelatedPeople}
+	}
+	if dto.Tags != nil {
+		patch.Tags = PatchField[[]string]{Set: true, Value: &dto.Tags}
+	}
+	return patch
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"regexp"
+)
+
+var cursorIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// TaskCursor marks a position in a sorted task list: the sort-key values of the
+// last task on the previous page, plus its ID as the final tie-breaker (id DESC).
+// It is serialized to an opaque string so clients never depend on its contents.
+type TaskCursor struct {
+	Sort   string        `json:"s"` // Canonical sort spec the cursor was issued for
+	Values []interface{} `json:"v"` // One value per sort key, nil for NULL
+	ID     string        `json:"i"`
+}
+
+// NewTaskCursor creates a cursor pointing just after the given task in the given ordering
+func NewTaskCursor(task *Task, sorts []TaskSort) *TaskCursor {
+	values := make([]interface{}, len(sorts))
+	for i, s := range sorts {
+		values[i] = s.Field.ValueOf(task)
+	}
+
+	return &TaskCursor{
+		Sort:   FormatTaskSort(sorts),
+		Values: values,
+		ID:     task.ID,
+	}
+}
+
+// Encode returns the opaque string form of the cursor
+func (c *TaskCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeTaskCursor parses an opaque cursor string produced by Encode.
+// The cursor must have been issued for the same sort order it is used with.
+func DecodeTaskCursor(s string, sorts []TaskSort) (*TaskCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, NewValidationError("cursor", "is malformed")
+	}
+
+	var cursor TaskCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, NewValidationError("cursor", "is malformed")
+	}
+	if !cursorIDPattern.MatchString(cursor.ID) || len(cursor.Values) != len(sorts) {
+		return nil, NewValidationError("cursor", "is malformed")
+	}
+	if cursor.Sort != FormatTaskSort(sorts) {
+		return nil, NewValidationError("cursor", "was issued for a different sort order")
+	}
+
+	for i, s := range sorts {
+		value, ok := parseSortValue(s.Field, cursor.Values[i])
+		if !ok {
+			return nil, NewValidationError("cursor", "is malformed")
+		}
+		cursor.Values[i] = value
+	}
+
+	return &cursor, nil
+}
+
+// TaskListPage is a single page of tasks along with the total count for the filter
+type TaskListPage struct {
+	Tasks      []*Task `json:"tasks"`
+	TotalCount int     `json:"total_count"` // Total tasks matching the filter, across all pages
+	NextCursor *string `json:"next_cursor"` // Null when there are no more pages
+}
@@ -28,7 +28,7 @@ func (calc *Calculator) CalculateWithBreakdown(task *domain.Task) (int, *domain.
 	// Scale user priority from 1-10 to 0-100 for calculation
 	// 1 → 10, 5 → 50, 10 → 100
 	userPriority := float64(task.UserPriority * 10)
-	timeDecay := calc.calculateTimeDecay(task.CreatedAt)
+	timeDecay := calc.calculateTimeDecay(task.AgeStart())
 	deadlineUrgency := calc.calculateDeadlineUrgency(task.DueDate)
 	bumpPenalty := calc.calculateBumpPenalty(task.BumpCount)
 	effortBoost := calc.getEffortBoost(task.EstimatedEffort)
@@ -62,17 +62,18 @@ func (calc *Calculator) CalculateWithBreakdown(task *domain.Task) (int, *domain.
 	return int(math.Min(100, math.Max(0, score))), breakdown
 }
 
-// calculateTimeDecay returns 0-100 based on task age
+// calculateTimeDecay returns 0-100 based on task age, measured from its start date
+// (see Task.AgeStart) so a deferred task doesn't age while it is hidden
 // Linear increase over 30 days: 0 days = 0, 30 days = 100
-func (calc *Calculator) calculateTimeDecay(createdAt time.Time) float64 {
-	age := time.Since(createdAt)
+func (calc *Calculator) calculateTimeDecay(startDate time.Time) float64 {
+	age := time.Since(startDate)
 	days := age.Hours() / 24
 
 	// Linear growth over 30 days
 	decay := (days / 30.0) * 100
 
-	// Cap at 100
-	return math.Min(100, decay)
+	// Clamp to 0-100 (a start date in the future has no decay yet)
+	return math.Min(100, math.Max(0, decay))
 }
 
 // calculateDeadlineUrgency returns 0-100 based on proximity to due date
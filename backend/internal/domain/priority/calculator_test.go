@@ -175,6 +175,11 @@ func TestCalculateTimeDecay(t *testing.T) {
 			createdAt: time.Now().AddDate(0, 0, -60),
 			expected:  100,
 		},
+		{
+			name:      "Start date in the future",
+			createdAt: time.Now().AddDate(0, 0, 5),
+			expected:  0,
+		},
 	}
 
 	for _, tt := range tests {
@@ -187,6 +192,23 @@ func TestCalculateTimeDecay(t *testing.T) {
 	}
 }
 
+func TestCalculateWithBreakdown_DeferredTaskAgesFromStartDate(t *testing.T) {
+	calc := NewCalculator()
+
+	// Created 30 days ago but hidden until 3 days ago: ~10 time decay, not 100
+	task := &domain.Task{
+		UserPriority: 5,
+		CreatedAt:    time.Now().AddDate(0, 0, -30),
+		DeferUntil:   timePtr(time.Now().AddDate(0, 0, -3)),
+	}
+
+	_, breakdown := calc.CalculateWithBreakdown(task)
+
+	if abs(int(breakdown.TimeDecay)-10) > 2 {
+		t.Errorf("expected time_decay≈10, got %.1f", breakdown.TimeDecay)
+	}
+}
+
 func TestCalculateDeadlineUrgency(t *testing.T) {
 	calc := NewCalculator()
 
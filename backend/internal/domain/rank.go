@@ -0,0 +1,192 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// Manual ordering uses fractional indexing: every task has a rank string, and a list
+// ordered by hand sorts by rank (byte order). Moving a task only rewrites its own rank,
+// picked strictly between the ranks of its new neighbors.
+var (
+	ErrInvalidRank  = errors.New("invalid rank")
+	ErrRankConflict = errors.New("no rank fits between the given neighbors")
+)
+
+// rankDigits are the characters ranks are made of, in byte order
+const rankDigits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// MaxRankLength is the longest rank a move or append may produce; longer ranks mean a
+// list has been reordered in the same spot many times, and its ranks are spread out again
+const MaxRankLength = 24
+
+// RankScope identifies a list that is ordered by hand: a user's top-level tasks
+// (regular and recurring), or the subtasks of one parent
+type RankScope struct {
+	UserID       string
+	ParentTaskID *string // nil for top-level tasks
+}
+
+// RankScope returns the list the task is ordered in. Like task_type in the database, it
+// follows the relationship columns: a recurring instance's parent is its previous instance.
+func (t *Task) RankScope() RankScope {
+	if t.ParentTaskID != nil && t.SeriesID == nil {
+		return RankScope{UserID: t.UserID, ParentTaskID: t.ParentTaskID}
+	}
+	return RankScope{UserID: t.UserID}
+}
+
+// Equal reports whether two scopes are the same list
+func (s RankScope) Equal(other RankScope) bool {
+	return s.UserID == other.UserID && equalStringPtr(s.ParentTaskID, other.ParentTaskID)
+}
+
+// MoveTaskRequest places a task between two neighbors of the same list. Either neighbor
+// may be omitted: after_task_id alone moves the task right after it, before_task_id
+// alone right before it.
+type MoveTaskRequest struct {
+	AfterTaskID  *string `json:"after_task_id,omitempty" binding:"omitempty,uuid"`
+	BeforeTaskID *string `json:"before_task_id,omitempty" binding:"omitempty,uuid"`
+}
+
+// RankBetween returns a rank that sorts strictly between before and after.
+// An empty before means the start of the list and an empty after its end.
+func RankBetween(before, after string) (string, error) {
+	if !validRank(before) || !validRank(after) {
+		return "", ErrInvalidRank
+	}
+	if after == "" {
+		return rankAfter(before), nil
+	}
+	if before >= after {
+		return "", ErrRankConflict
+	}
+	return rankMidpoint(before, after), nil
+}
+
+// rankAfter finds a short rank after last, for the end of a list. Rather than halving the
+// space that is left, it counts up the digits that follow last's leading run of highest
+// digits, using one more digit than the run is long. Appends only lengthen ranks once
+// those digits are used up: "U" to "z" are followed by "z01" to "zzz", and so on.
+func rankAfter(last string) string {
+	if last == "" {
+		return rankMidpoint("", "")
+	}
+
+	highest := rankDigits[len(rankDigits)-1]
+	run := 0
+	for run < len(last) && last[run] == highest {
+		run++
+	}
+	digits := make([]byte, run+1)
+	for i := range digits {
+		digits[i] = rankDigitAt(last, run+i)
+	}
+
+	// The first digit is below the highest, so counting up never carries out of it
+	for i := len(digits) - 1; i >= 0; i-- {
+		j := strings.IndexByte(rankDigits, digits[i])
+		if j < len(rankDigits)-1 {
+			digits[i] = rankDigits[j+1]
+			break
+		}
+		digits[i] = rankDigits[0]
+	}
+	return strings.TrimRight(last[:run]+string(digits), rankDigits[:1])
+}
+
+// rankMidpoint finds a rank between a and b (b == "" is unbounded). Ranks never end in
+// the lowest digit, so there is always room before any rank.
+func rankMidpoint(a, b string) string {
+	if b != "" {
+		// Keep the common prefix; a is read as padded with the lowest digit
+		n := 0
+		for n < len(b) && rankDigitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+			return b[:n] + rankMidpoint(rest, b[n:])
+		}
+	}
+
+	low := 0
+	if a != "" {
+		low = strings.IndexByte(rankDigits, a[0])
+	}
+	high := len(rankDigits)
+	if b != "" {
+		high = strings.IndexByte(rankDigits, b[0])
+	}
+	if high-low > 1 {
+		return string(rankDigits[(low+high)/2])
+	}
+
+	// The first digits are adjacent: b's first digit alone works if b goes on,
+	// otherwise keep a's first digit and go one level deeper
+	if len(b) > 1 {
+		return b[:1]
+	}
+	rest := ""
+	if len(a) > 1 {
+		rest = a[1:]
+	}
+	return string(rankDigits[low]) + rankMidpoint(rest, "")
+}
+
+func rankDigitAt(rank string, i int) byte {
+	if i < len(rank) {
+		return rank[i]
+	}
+	return rankDigits[0]
+}
+
+// validRank reports whether rank is empty or a well-formed rank
+func validRank(rank string) bool {
+	if rank == "" {
+		return true
+	}
+	for i := 0; i < len(rank); i++ {
+		if strings.IndexByte(rankDigits, rank[i]) < 0 {
+			return false
+		}
+	}
+	return rank[len(rank)-1] != rankDigits[0]
+}
+
+// SpreadRanks returns n evenly spaced ranks in increasing order, short and with room
+// for later moves between any two of them
+func SpreadRanks(n int) []string {
+	if n <= 0 {
+		return nil
+	}
+
+	// Use enough digits for at least 8 free slots between neighbors
+	base := len(rankDigits)
+	width, slots := 1, base
+	for slots < (n+1)*8 {
+		width++
+		slots *= base
+	}
+	step := slots / (n + 1)
+
+	ranks := make([]string, n)
+	for i := range ranks {
+		value := (i + 1) * step
+		digits := make([]byte, width)
+		for j := width - 1; j >= 0; j-- {
+			digits[j] = rankDigits[value%base]
+			value /= base
+		}
+		rank := string(digits)
+		// A trailing lowest digit would leave no room before the rank
+		if rank[len(rank)-1] == rankDigits[0] {
+			rank += string(rankDigits[base/2])
+		}
+		ranks[i] = rank
+	}
+	return ranks
+}
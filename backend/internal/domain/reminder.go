@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrReminderNotFound       = errors.New("reminder not found")
+	ErrInvalidReminderChannel = errors.New("invalid reminder channel: must be email or webhook")
+)
+
+// ReminderChannel is how a reminder is delivered
+type ReminderChannel string
+
+const (
+	ReminderChannelEmail   ReminderChannel = "email"   // Target is an email address (default: the account email)
+	ReminderChannelWebhook ReminderChannel = "webhook" // Target is an http(s) URL that receives a JSON POST
+)
+
+// ReminderStatus represents the delivery state of a reminder
+type ReminderStatus string
+
+const (
+	ReminderStatusPending ReminderStatus = "pending"
+	ReminderStatusSent    ReminderStatus = "sent"
+	ReminderStatusFailed  ReminderStatus = "failed" // Gave up after MaxReminderAttempts
+)
+
+const (
+	// MaxReminderAttempts is how many deliveries are tried before a reminder is marked failed
+	MaxReminderAttempts = 5
+	// MaxReminderOffsetMinutes bounds a relative reminder to a year before the due date
+	MaxReminderOffsetMinutes = 525600
+	// MaxReminderTargetLength is the maximum length of a reminder target in characters
+	MaxReminderTargetLength = 2000
+)
+
+// Validate validates the reminder channel
+func (c ReminderChannel) Validate() error {
+	switch c {
+	case ReminderChannelEmail, ReminderChannelWebhook:
+		return nil
+	default:
+		return ErrInvalidReminderChannel
+	}
+}
+
+// Reminder notifies the user about a task at a set time.
+// A relative reminder (OffsetMinutes set) fires that long before the task's due date
+// and moves with it; RemindAt is nil while the task has no due date.
+type Reminder struct {
+	ID            string          `json:"id"`
+	TaskID        string          `json:"task_id"`
+	UserID        string          `json:"user_id"`
+	OffsetMinutes *int            `json:"offset_minutes,omitempty"` // Minutes before the due date; nil = absolute
+	RemindAt      *time.Time      `json:"remind_at,omitempty"`      // Resolved fire time
+	Channel       ReminderChannel `json:"channel"`
+	Target        *string         `json:"target,omitempty"` // Email address or webhook URL
+	Status        ReminderStatus  `json:"status"`
+	Attempts      int             `json:"attempts"`
+	LastError     *string         `json:"last_error,omitempty"`
+	SentAt        *time.Time      `json:"sent_at,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// IsRelative reports whether the reminder follows the task's due date
+func (r *Reminder) IsRelative() bool {
+	return r.OffsetMinutes != nil
+}
+
+// RemindAtForDueDate returns the fire time of a relative reminder for a due date (nil if there is none)
+func (r *Reminder) RemindAtForDueDate(dueDate *time.Time) *time.Time {
+	if r.OffsetMinutes == nil || dueDate == nil {
+		return nil
+	}
+	remindAt := dueDate.Add(-time.Duration(*r.OffsetMinutes) * time.Minute)
+	return &remindAt
+}
+
+// CreateReminderDTO is used for creating a reminder; exactly one of RemindAt or OffsetMinutes is required
+type CreateReminderDTO struct {
+	RemindAt      *time.Time      `json:"remind_at,omitempty"`
+	OffsetMinutes *int            `json:"offset_minutes,omitempty" binding:"omitempty,min=0,max=525600"`
+	Channel       ReminderChannel `json:"channel" binding:"required"`
+	Target        *string         `json:"target,omitempty"`
+}
+
+// DueReminder is a reminder claimed by the scheduler, with what it needs to deliver it
+type DueReminder struct {
+	Reminder    *Reminder
+	TaskTitle   string
+	TaskDueDate *time.Time
+	UserEmail   *string
+}
+
+// Notification is a message handed to a Notifier
+type Notification struct {
+	ID        string     `json:"id"` // The reminder ID; stable across retries so receivers can deduplicate
+	Event     string     `json:"event"`
+	Recipient string     `json:"-"` // Email address or webhook URL
+	Subject   string     `json:"subject"`
+	Body      string     `json:"body"`
+	TaskID    string     `json:"task_id"`
+	TaskTitle string     `json:"task_title"`
+	DueDate   *time.Time `json:"due_date,omitempty"`
+	RemindAt  time.Time  `json:"remind_at"`
+}
+
+// NotificationEventTaskReminder is the event name of reminder notifications
+const NotificationEventTaskReminder = "task.reminder"
+
+// NewReminderNotification builds the notification for a due reminder
+func NewReminderNotification(due *DueReminder, recipient string) *Notification {
+	notification := &Notification{
+		ID:        due.Reminder.ID,
+		Event:     NotificationEventTaskReminder,
+		Recipient: recipient,
+		Subject:   "Reminder: " + due.TaskTitle,
+		TaskID:    due.Reminder.TaskID,
+		TaskTitle: due.TaskTitle,
+		DueDate:   due.TaskDueDate,
+	}
+	if due.Reminder.RemindAt != nil {
+		notification.RemindAt = *due.Reminder.RemindAt
+	}
+
+	if due.TaskDueDate != nil {
+		notification.Body = fmt.Sprintf("%q is due %s.", due.TaskTitle, due.TaskDueDate.UTC().Format("Mon, 02 Jan 2006 15:04 MST"))
+	} else {
+		notification.Body = fmt.Sprintf("This is your reminder for %q.", due.TaskTitle)
+	}
+	return notification
+}
+
+// ReminderRunResult summarizes one scheduler pass
+type ReminderRunResult struct {
+	Claimed  int // Due reminders this instance took
+	Sent     int
+	Retried  int // Failed deliveries that will be tried again
+	Failed   int // Reminders given up on
+	Released int // Claimed reminders handed back undelivered before their lease ran out
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrSavedViewNotFound      = errors.New("saved view not found")
+	ErrSavedViewDuplicateName = errors.New("saved view with this name already exists")
+)
+
+// SavedView is a named, reusable task list filter (a "smart list")
+type SavedView struct {
+	ID        string          `json:"id"`
+	UserID    string          `json:"user_id"`
+	Name      string          `json:"name"`
+	Icon      *string         `json:"icon,omitempty"`
+	Position  int             `json:"position"` // Display order in the sidebar (ascending)
+	Filter    SavedViewFilter `json:"filter"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// SavedViewFilter is the persisted form of a TaskListFilter.
+// Search and Sort are stored as the strings the user typed so relative dates
+// (e.g. "due:<7d") are re-resolved every time the view is opened.
+type SavedViewFilter struct {
+	Status       *TaskStatus `json:"status,omitempty"`
+	Category     *string     `json:"category,omitempty"`
+	Tags         []string    `json:"tags,omitempty"`
+	Search       *string     `json:"search,omitempty"` // Task query language, see ParseTaskQuery
+	MinPriority  *int        `json:"min_priority,omitempty"`
+	MaxPriority  *int        `json:"max_priority,omitempty"`
+	DueDateStart *time.Time  `json:"due_date_start,omitempty"`
+	DueDateEnd   *time.Time  `json:"due_date_end,omitempty"`
+	Sort         *string     `json:"sort,omitempty"` // e.g. "due_date,-priority_score"
+}
+
+// ToTaskListFilter converts the saved filter into a TaskListFilter, resolving relative dates against now
+func (f *SavedViewFilter) ToTaskListFilter(now time.Time) (*TaskListFilter, error) {
+	filter := &TaskListFilter{
+		Status:       f.Status,
+		Category:     f.Category,
+		Tags:         f.Tags,
+		MinPriority:  f.MinPriority,
+		MaxPriority:  f.MaxPriority,
+		DueDateStart: f.DueDateStart,
+		DueDateEnd:   f.DueDateEnd,
+	}
+
+	if f.Status != nil {
+		if err := f.Status.Validate(); err != nil {
+			return nil, NewValidationError("filter.status", err.Error())
+		}
+	}
+	if f.MinPriority != nil && (*f.MinPriority < 0 || *f.MinPriority > 100) {
+		return nil, NewValidationError("filter.min_priority", "must be between 0 and 100")
+	}
+	if f.MaxPriority != nil && (*f.MaxPriority < 0 || *f.MaxPriority > 100) {
+		return nil, NewValidationError("filter.max_priority", "must be between 0 and 100")
+	}
+	if f.DueDateStart != nil && f.DueDateEnd != nil && f.DueDateEnd.Before(*f.DueDateStart) {
+		return nil, NewValidationError("filter.due_date_end", "must be on or after due_date_start")
+	}
+
+	if f.Search != nil && *f.Search != "" {
+		query, err := ParseTaskQuery(*f.Search, now)
+		if err != nil {
+			return nil, err
+		}
+		filter.Query = query
+	}
+
+	if f.Sort != nil && *f.Sort != "" {
+		sorts, err := ParseTaskSort(*f.Sort)
+		if err != nil {
+			return nil, err
+		}
+		filter.Sort = sorts
+	}
+
+	return filter, nil
+}
+
+// Validate validates the saved view
+func (v *SavedView) Validate() error {
+	if v.Name == "" {
+		return NewValidationError("name", "view name is required")
+	}
+	if v.Position < 0 {
+		return NewValidationError("position", "must not be negative")
+	}
+	_, err := v.Filter.ToTaskListFilter(time.Now())
+	return err
+}
+
+// CreateSavedViewDTO is used for creating saved views
+type CreateSavedViewDTO struct {
+	Name     string          `json:"name" binding:"required,max=100"`
+	Icon     *string         `json:"icon,omitempty" binding:"omitempty,max=50"`
+	Position *int            `json:"position,omitempty" binding:"omitempty,min=0"` // Defaults to the end of the list
+	Filter   SavedViewFilter `json:"filter"`
+}
+
+// UpdateSavedViewDTO is used for updating saved views
+type UpdateSavedViewDTO struct {
+	Name     *string          `json:"name,omitempty" binding:"omitempty,max=100"`
+	Icon     *string          `json:"icon,omitempty" binding:"omitempty,max=50"`
+	Position *int             `json:"position,omitempty" binding:"omitempty,min=0"`
+	Filter   *SavedViewFilter `json:"filter,omitempty"` // Replaces the whole filter when provided
+}
+
+// SavedViewListResponse is the response for listing saved views
+type SavedViewListResponse struct {
+	Views      []*SavedView `json:"views"`
+	TotalCount int          `json:"total_count"`
+}
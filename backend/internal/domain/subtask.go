@@ -4,13 +4,19 @@ import "errors"
 
 // Subtask-related errors
 var (
-	ErrSubtaskDepthExceeded = errors.New("subtasks cannot have subtasks (single-level nesting only)")
+	ErrSubtaskDepthExceeded = errors.New("subtask would exceed the maximum hierarchy depth")
+	ErrSubtaskCycle         = errors.New("a task cannot be placed under itself or one of its own subtasks")
 	ErrParentNotFound       = errors.New("parent task not found")
 	ErrCannotCompleteParent = errors.New("cannot complete task with incomplete subtasks")
 	ErrCannotCreateSubtask  = errors.New("cannot create subtask for this task type")
 )
 
-// SubtaskInfo contains aggregated subtask statistics for a parent task
+// DefaultMaxTaskDepth is how many levels a task hierarchy may have, counting the
+// top-level task (e.g. epic -> story -> step is 3 levels)
+const DefaultMaxTaskDepth = 5
+
+// SubtaskInfo contains aggregated subtask statistics for a parent task.
+// The counts cover direct subtasks; the descendant counts and progress roll up every level.
 type SubtaskInfo struct {
 	TotalCount      int     `json:"total_count"`
 	CompletedCount  int     `json:"completed_count"`
@@ -18,6 +24,118 @@ type SubtaskInfo struct {
 	TodoCount       int     `json:"todo_count"`
 	CompletionRate  float64 `json:"completion_rate"` // 0.0 - 1.0
 	AllComplete     bool    `json:"all_complete"`
+
+	DescendantCount          int     `json:"descendant_count"`           // Subtasks at every level
+	DescendantCompletedCount int     `json:"descendant_completed_count"` // Done subtasks at every level
+	Progress                 float64 `json:"progress"`                   // 0.0 - 1.0; an open subtask counts by its own subtasks' progress
+}
+
+// NewSubtaskInfo aggregates the subtree below parentID. descendants holds every live
+// subtask below the parent, at any level.
+func NewSubtaskInfo(parentID string, descendants []*Task) *SubtaskInfo {
+	return newSubtaskInfo(parentID, childrenByParent(descendants))
+}
+
+func newSubtaskInfo(parentID string, children map[string][]*Task) *SubtaskInfo {
+	info := &SubtaskInfo{}
+	for _, child := range children[parentID] {
+		info.TotalCount++
+		switch child.Status {
+		case TaskStatusDone:
+			info.CompletedCount++
+		case TaskStatusInProgress:
+			info.InProgressCount++
+		case TaskStatusTodo:
+			info.TodoCount++
+		}
+	}
+	info.CalculateCompletionRate()
+
+	info.DescendantCount, info.DescendantCompletedCount = countDescendants(parentID, children)
+	info.Progress = rollupProgress(parentID, children)
+	return info
+}
+
+// countDescendants counts all and done tasks below id
+func countDescendants(id string, children map[string][]*Task) (total, completed int) {
+	for _, child := range children[id] {
+		total++
+		if child.Status == TaskStatusDone {
+			completed++
+		}
+		childTotal, childCompleted := countDescendants(child.ID, children)
+		total += childTotal
+		completed += childCompleted
+	}
+	return total, completed
+}
+
+// rollupProgress averages the progress of id's direct subtasks: a done subtask counts
+// as 1, an open one as the progress of its own subtasks (0 when it has none)
+func rollupProgress(id string, children map[string][]*Task) float64 {
+	if len(children[id]) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, child := range children[id] {
+		if child.Status == TaskStatusDone {
+			sum++
+			continue
+		}
+		sum += rollupProgress(child.ID, children)
+	}
+	return sum / float64(len(children[id]))
+}
+
+// childrenByParent indexes tasks by their parent, keeping the given order
+func childrenByParent(tasks []*Task) map[string][]*Task {
+	children := make(map[string][]*Task)
+	for _, task := range tasks {
+		if task.ParentTaskID != nil {
+			children[*task.ParentTaskID] = append(children[*task.ParentTaskID], task)
+		}
+	}
+	return children
+}
+
+// SubtreeHeight returns how many levels of subtasks lie below rootID (0 for a leaf)
+func SubtreeHeight(rootID string, descendants []*Task) int {
+	return subtreeHeight(rootID, childrenByParent(descendants))
+}
+
+func subtreeHeight(id string, children map[string][]*Task) int {
+	height := 0
+	for _, child := range children[id] {
+		if h := subtreeHeight(child.ID, children) + 1; h > height {
+			height = h
+		}
+	}
+	return height
+}
+
+// TaskTree is a task with its whole subtree, each level carrying its own rollup
+type TaskTree struct {
+	*Task
+	SubtaskInfo *SubtaskInfo `json:"subtask_info"`
+	Subtasks    []*TaskTree  `json:"subtasks"`
+}
+
+// BuildTaskTree assembles root and its descendants (every live subtask below it, in
+// display order) into a tree
+func BuildTaskTree(root *Task, descendants []*Task) *TaskTree {
+	return buildTaskTree(root, childrenByParent(descendants))
+}
+
+func buildTaskTree(task *Task, children map[string][]*Task) *TaskTree {
+	tree := &TaskTree{
+		Task:        task,
+		SubtaskInfo: newSubtaskInfo(task.ID, children),
+		Subtasks:    make([]*TaskTree, 0, len(children[task.ID])),
+	}
+	for _, child := range children[task.ID] {
+		tree.Subtasks = append(tree.Subtasks, buildTaskTree(child, children))
+	}
+	return tree
 }
 
 // CalculateCompletionRate calculates the completion percentage and sets AllComplete flag
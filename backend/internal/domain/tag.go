@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+var (
+	ErrTagNotFound      = errors.New("tag not found")
+	ErrTagDuplicateName = errors.New("tag with this name already exists")
+	ErrTagMergeSelf     = errors.New("cannot merge a tag into itself")
+)
+
+// MaxTaskTags is the maximum number of tags on a single task or template
+const MaxTaskTags = 20
+
+var tagColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Tag is a user-defined label. Unlike the single category, a task can carry many tags.
+// Names are unique per user, ignoring case.
+type Tag struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Name      string    `json:"name"`
+	Color     *string   `json:"color,omitempty"` // Hex color, e.g. "#3b82f6"
+	TaskCount int       `json:"task_count"`      // Number of tasks carrying the tag (populated when listing)
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateTagDTO is used for creating tags
+type CreateTagDTO struct {
+	Name  string  `json:"name" binding:"required,max=50"`
+	Color *string `json:"color,omitempty"`
+}
+
+// UpdateTagDTO is used for renaming or recoloring a tag.
+// Renaming updates every task carrying the tag at once.
+type UpdateTagDTO struct {
+	Name  *string `json:"name,omitempty" binding:"omitempty,max=50"`
+	Color *string `json:"color,omitempty"`
+}
+
+// MergeTagsDTO is the body of POST /tags/:id/merge
+type MergeTagsDTO struct {
+	TargetTagID string `json:"target_tag_id" binding:"required"`
+}
+
+// MergeTagsResponse is returned after merging one tag into another
+type MergeTagsResponse struct {
+	Tag          *Tag `json:"tag"`           // The surviving tag
+	UpdatedCount int  `json:"updated_count"` // Tasks moved from the merged tag
+}
+
+// TagListResponse is the response for listing tags
+type TagListResponse struct {
+	Tags       []*Tag `json:"tags"`
+	TotalCount int    `json:"total_count"`
+}
+
+// TagStats represents task statistics for a single tag
+type TagStats struct {
+	Tag            string  `json:"tag"`
+	Color          *string `json:"color,omitempty"`
+	TotalCount     int     `json:"task_count"`
+	CompletedCount int     `json:"completed_count"`
+	CompletionRate float64 `json:"completion_rate"`
+}
+
+// ValidateTagColor checks that a color is a six digit hex color
+func ValidateTagColor(color *string) error {
+	if color != nil && !tagColorPattern.MatchString(*color) {
+		return NewValidationError("color", "must be a hex color like #3b82f6")
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 
 var (
 	ErrTaskNotFound     = errors.New("task not found")
+	ErrTaskVersionConflict = errors.New("task was modified concurrently")
 	ErrUnauthorized     = errors.New("unauthorized to access this task")
 	ErrInvalidTaskStatus = errors.New("invalid task status")
 	ErrInvalidEffort     = errors.New("invalid effort estimate")
@@ -49,6 +50,7 @@ type Task struct {
 	Title           string      `json:"title"`
 	Description     *string     `json:"description,omitempty"`
 	Status          TaskStatus  `json:"status"`
+	WorkflowStatus  TaskStatus  `json:"workflow_status,omitempty"` // Key in the owner's workflow (see Workflow); Status holds its base status
 	TaskType        TaskType    `json:"task_type"`         // regular, recurring, or subtask
 	UserPriority    int         `json:"user_priority"`     // 1-10
 	DueDate         *time.Time  `json:"due_date,omitempty"`
@@ -56,12 +58,20 @@ type Task struct {
 	Category        *string     `json:"category,omitempty"`
 	Context         *string     `json:"context,omitempty"`
 	RelatedPeople   []string    `json:"related_people,omitempty"`
+	Tags            []string    `json:"tags,omitempty"` // Tag names; nil on write leaves the task's tags unchanged
 	PriorityScore   int         `json:"priority_score"` // 0-100, calculated
 	BumpCount       int         `json:"bump_count"`
 	CreatedAt       time.Time   `json:"created_at"`
 	UpdatedAt       time.Time   `json:"updated_at"`
 	CompletedAt     *time.Time  `json:"completed_at,omitempty"`
 	DeletedAt       *time.Time  `json:"deleted_at,omitempty"` // Soft delete timestamp
+	DeletionGroupID *string     `json:"deletion_group_id,omitempty"` // Shared by the task and the subtasks trashed with it
+	DeferUntil      *time.Time  `json:"defer_until,omitempty"` // Start date: hidden from default lists and at-risk queries until then
+	Version         int         `json:"version"`              // Incremented on every write; exposed as the ETag
+	Rank            string      `json:"rank"`                 // Position in its manually ordered list (see RankBetween); set on create when empty
+	CommentCount    int         `json:"comment_count"`        // Live comments; populated by FindByID and List
+	TrackedSeconds  int64       `json:"tracked_seconds"`      // Actual time from finished time entries; populated by FindByID and List
+	FocusSessionCount int       `json:"focus_session_count"`  // Completed focus sessions; populated by FindByID and List
 	// Relationship fields (interpretation depends on TaskType)
 	SeriesID     *string `json:"series_id,omitempty"`      // Links to task_series if recurring
 	ParentTaskID *string `json:"parent_task_id,omitempty"` // For subtasks: parent task; for recurring: previous in series
@@ -80,6 +90,8 @@ type CreateTaskDTO struct {
 	Category        *string         `json:"category,omitempty" binding:"omitempty,max=50"`
 	Context         *string         `json:"context,omitempty" binding:"omitempty,max=500"`
 	RelatedPeople   []string        `json:"related_people,omitempty"`
+	Tags            []string        `json:"tags,omitempty"`       // Tag names; unknown tags are created
+	DeferUntil      *time.Time      `json:"defer_until,omitempty"` // Optional: hide the task until this start date
 	Recurrence      *RecurrenceRule `json:"recurrence,omitempty"` // Optional: make this a recurring task
 	ParentTaskID    *string         `json:"parent_task_id,omitempty" binding:"omitempty,uuid"` // Optional: make this a subtask
 }
@@ -95,21 +107,37 @@ type UpdateTaskDTO struct {
 	Category        *string     `json:"category,omitempty" binding:"omitempty,max=50"`
 	Context         *string     `json:"context,omitempty" binding:"omitempty,max=500"`
 	RelatedPeople   []string    `json:"related_people,omitempty"`
+	Tags            []string    `json:"tags,omitempty"`
+	DeferUntil      *time.Time  `json:"defer_until,omitempty"`
 }
 
 // TaskListFilter is used for filtering tasks
 type TaskListFilter struct {
 	Status         *TaskStatus
+	WorkflowStatus *TaskStatus // Filter by status key in the user's workflow
 	Category       *string
+	Tags           []string   // Tasks must carry every listed tag (case-insensitive)
 	Search         *string
+	Query          *TaskQuery // Parsed search grammar (see ParseTaskQuery), ANDed with the other filters
 	MinPriority    *int       // Filter by minimum priority score (0-100)
 	MaxPriority    *int       // Filter by maximum priority score (0-100)
 	DueDateStart   *time.Time // Filter by due date >= this date
 	DueDateEnd     *time.Time // Filter by due date <= this date
+	Sort           []TaskSort  // Sort keys in priority order (nil = DefaultTaskSort)
+	Cursor         *TaskCursor // Keyset pagination: return tasks after this position (takes precedence over Offset)
+	IncludeDeferred bool       // Include tasks whose start date (defer_until) is still in the future
 	Limit          int
 	Offset         int
 }
 
+// SortOrder returns the effective sort keys for the filter
+func (f *TaskListFilter) SortOrder() []TaskSort {
+	if f == nil || len(f.Sort) == 0 {
+		return DefaultTaskSort
+	}
+	return f.Sort
+}
+
 // CalendarFilter is used for filtering calendar tasks
 type CalendarFilter struct {
 	StartDate time.Time
@@ -165,9 +193,9 @@ func (t *Task) IsSubtask() bool {
 }
 
 // CanHaveSubtasks returns true if the task can have subtasks
-// Only regular tasks can have subtasks (subtasks cannot have subtasks - single level only)
+// Regular tasks and subtasks can (hierarchies nest up to a configured depth); recurring tasks cannot
 func (t *Task) CanHaveSubtasks() bool {
-	return t.TaskType == TaskTypeRegular
+	return t.TaskType == TaskTypeRegular || t.TaskType == TaskTypeSubtask
 }
 
 // GetEffortMultiplier returns the priority multiplier for the effort
@@ -191,6 +219,20 @@ func ParseDate(dateStr string) (time.Time, error) {
 	return time.Parse("2006-01-02", dateStr)
 }
 
+// IsDeferred reports whether the task's start date is still in the future
+func (t *Task) IsDeferred(now time.Time) bool {
+	return t.DeferUntil != nil && t.DeferUntil.After(now)
+}
+
+// AgeStart returns when the task's age starts counting for time decay:
+// its start date if that is later than creation, otherwise CreatedAt
+func (t *Task) AgeStart() time.Time {
+	if t.DeferUntil != nil && t.DeferUntil.After(t.CreatedAt) {
+		return *t.DeferUntil
+	}
+	return t.CreatedAt
+}
+
 // IsRecurring returns true if the task is part of a recurring series
 func (t *Task) IsRecurring() bool {
 	return t.SeriesID != nil
@@ -203,9 +245,10 @@ type BulkOperationRequest struct {
 
 // BulkOperationResponse is the response for bulk operations
 type BulkOperationResponse struct {
-	SuccessCount int      `json:"success_count"`
-	FailedIDs    []string `json:"failed_ids,omitempty"`
-	Message      string   `json:"message"`
+	SuccessCount int               `json:"success_count"`
+	FailedIDs    []string          `json:"failed_ids,omitempty"`
+	Errors       map[string]string `json:"errors,omitempty"` // Failure reason per failed ID, when known
+	Message      string            `json:"message"`
 }
 
 // PriorityBreakdown shows the individual components of the priority calculation
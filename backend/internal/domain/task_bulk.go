@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// MaxBulkTaskIDs is the maximum number of tasks a single bulk request may touch
+const MaxBulkTaskIDs = 100
+
+var dueDateShiftPattern = regexp.MustCompile(`^([+-]?)(\d{1,3})([dw])$`)
+
+// BulkTaskPatch is the change applied to every task in a bulk update.
+// Omitted members are left unchanged; category, effort and context accept null to clear.
+type BulkTaskPatch struct {
+	Status              *TaskStatus            `json:"status,omitempty"`
+	Category            PatchField[string]     `json:"category"`
+	UserPriority        *int                   `json:"user_priority,omitempty"`
+	DueDateShift        *string                `json:"due_date_shift,omitempty"` // e.g. "+2d", "-1w"; tasks without a due date are unaffected
+	EstimatedEffort     PatchField[TaskEffort] `json:"estimated_effort"`
+	Context             PatchField[string]     `json:"context"`
+	AddRelatedPeople    []string               `json:"add_related_people,omitempty"`
+	RemoveRelatedPeople []string               `json:"remove_related_people,omitempty"`
+}
+
+// IsEmpty reports whether the patch would change nothing
+func (p *BulkTaskPatch) IsEmpty() bool {
+	return p.Status == nil && !p.Category.Set && p.UserPriority == nil && p.DueDateShift == nil &&
+		!p.EstimatedEffort.Set && !p.Context.Set &&
+		len(p.AddRelatedPeople) == 0 && len(p.RemoveRelatedPeople) == 0
+}
+
+// BulkUpdateRequest is the body of POST /tasks/bulk-update
+type BulkUpdateRequest struct {
+	TaskIDs []string      `json:"task_ids" binding:"required,min=1,max=100"`
+	Patch   BulkTaskPatch `json:"patch"`
+}
+
+// ParseDueDateShift parses a relative due date shift such as "+2d", "-1w" or "3d"
+// into a number of days
+func ParseDueDateShift(shift string) (int, error) {
+	match := dueDateShiftPattern.FindStringSubmatch(shift)
+	if match == nil {
+		return 0, NewValidationError("due_date_shift", "must look like +2d, -1w or 3d")
+	}
+
+	days, _ := strconv.Atoi(match[2])
+	if match[3] == "w" {
+		days *= 7
+	}
+	if match[1] == "-" {
+		days = -days
+	}
+
+	return days, nil
+}
+
+// BulkCompleteResult is the outcome of completing one task in a bulk completion
+type BulkCompleteResult struct {
+	TaskID        string `json:"task_id"`
+	Success       bool   `json:"success"`
+	Error         string `json:"error,omitempty"`
+	CompletedTask *Task  `json:"completed_task,omitempty"`
+	NextTask      *Task  `json:"next_task,omitempty"` // Next instance of a recurring task, if one was generated
+}
+
+// BulkCompleteResponse is the response for POST /tasks/bulk-complete.
+// Results are listed in the order the tasks were processed (blockers first).
+type BulkCompleteResponse struct {
+	SuccessCount int                               `json:"success_count"`
+	FailedIDs    []string                          `json:"failed_ids,omitempty"`
+	Results      []*BulkCompleteResult             `json:"results"`
+	Gamification *TaskCompletionGamificationResult `json:"gamification,omitempty"` // Aggregated rewards for the whole batch
+	Message      string                            `json:"message"`
+}
@@ -1,6 +1,13 @@
 package domain
 
-import "time"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
 
 // TaskHistoryEventType represents the type of event in task history
 type TaskHistoryEventType string
@@ -14,6 +21,9 @@ const (
 	EventTaskDeleted     TaskHistoryEventType = "deleted"
 	EventTaskRestored    TaskHistoryEventType = "restored"
 	EventStatusChanged   TaskHistoryEventType = "status_changed"
+	EventCommentAdded    TaskHistoryEventType = "comment_added"
+	EventCommentRemoved  TaskHistoryEventType = "comment_removed"
+	EventTaskConverted   TaskHistoryEventType = "converted"
 )
 
 // TaskHistory represents an audit log entry for task changes
@@ -25,4 +35,183 @@ type TaskHistory struct {
 	OldValue  *string              `json:"old_value,omitempty"`
 	NewValue  *string              `json:"new_value,omitempty"`
 	CreatedAt time.Time            `json:"created_at"`
+	// OperationID groups the entries written by one request; taken from the context when unset
+	OperationID *string `json:"operation_id,omitempty"`
+	// RevertsOperationID is set on entries written by an undo, pointing at the undone operation
+	RevertsOperationID *string `json:"reverts_operation_id,omitempty"`
+}
+
+// MaxTaskHistoryLimit is the largest page of history entries returned at once
+const MaxTaskHistoryLimit = 100
+
+// Validate validates the event type
+func (t TaskHistoryEventType) Validate() error {
+	switch t {
+	case EventTaskCreated, EventTaskUpdated, EventTaskBumped, EventTaskCompleted, EventTaskUncompleted,
+		EventTaskDeleted, EventTaskRestored, EventStatusChanged, EventCommentAdded, EventCommentRemoved,
+		EventTaskConverted:
+		return nil
+	default:
+		return fmt.Errorf("invalid event type: %s", t)
+	}
+}
+
+// TaskHistoryFilter selects a page of a task's history, newest first
+type TaskHistoryFilter struct {
+	EventTypes []TaskHistoryEventType // Empty = all events
+	Limit      int
+	Offset     int
+}
+
+// FieldChange is one field's value before and after an event; null when the field was unset
+type FieldChange struct {
+	Field string          `json:"field"`
+	Old   json.RawMessage `json:"old"`
+	New   json.RawMessage `json:"new"`
+}
+
+// TaskHistoryEntry is a history event as returned by the API, with its field-level changes
+type TaskHistoryEntry struct {
+	ID        string               `json:"id"`
+	TaskID    string               `json:"task_id"`
+	EventType TaskHistoryEventType `json:"event_type"`
+	Changes   []FieldChange        `json:"changes"`
+	CreatedAt time.Time            `json:"created_at"`
+	// OperationID identifies the request that made the change; pass it to POST /undo to revert it
+	OperationID *string `json:"operation_id,omitempty"`
+}
+
+// TaskHistoryPage is a page of a task's history along with the total count for the filter
+type TaskHistoryPage struct {
+	History    []*TaskHistoryEntry `json:"history"`
+	TotalCount int                 `json:"total_count"`
+	Limit      int                 `json:"limit"`
+	Offset     int                 `json:"offset"`
+}
+
+// historyIgnoredFields are task snapshot fields left out of diffs: identity, bookkeeping
+// that changes on every write, values derived from other fields, and read-time counts.
+var historyIgnoredFields = map[string]bool{
+	"id":                  true,
+	"user_id":             true,
+	"created_at":          true,
+	"updated_at":          true,
+	"version":             true,
+	"priority_score":      true,
+	"priority_breakdown":  true,
+	"comment_count":       true,
+	"tracked_seconds":     true,
+	"focus_session_count": true,
+}
+
+// ToEntry converts a stored history row into its API form
+func (h *TaskHistory) ToEntry() *TaskHistoryEntry {
+	return &TaskHistoryEntry{
+		ID:          h.ID,
+		TaskID:      h.TaskID,
+		EventType:   h.EventType,
+		Changes:     h.Changes(),
+		CreatedAt:   h.CreatedAt,
+		OperationID: h.OperationID,
+	}
+}
+
+// Changes normalizes the stored old/new values into field-level changes, sorted by field.
+// Task events store whole-task JSON snapshots, which are diffed field by field; a snapshot
+// on one side only (e.g. created) lists the fields that were set. Bumps store the bump
+// counts and comment events store the comment, reported as its body.
+func (h *TaskHistory) Changes() []FieldChange {
+	switch h.EventType {
+	case EventTaskBumped:
+		if decodeSnapshot(h.NewValue) != nil {
+			// Bumps are recorded as task snapshots now; older entries store just the counts
+			break
+		}
+		return []FieldChange{{
+			Field: "bump_count",
+			Old:   legacyBumpCount(h.OldValue),
+			New:   legacyBumpCount(h.NewValue),
+		}}
+	case EventCommentAdded, EventCommentRemoved:
+		return []FieldChange{{
+			Field: "comment",
+			Old:   snapshotField(h.OldValue, "body"),
+			New:   snapshotField(h.NewValue, "body"),
+		}}
+	}
+
+	oldFields := decodeSnapshot(h.OldValue)
+	newFields := decodeSnapshot(h.NewValue)
+	if oldFields != nil && newFields == nil {
+		// Only the state before the event was recorded (older delete entries)
+		return []FieldChange{}
+	}
+
+	names := make([]string, 0, len(oldFields)+len(newFields))
+	seen := make(map[string]bool, len(oldFields)+len(newFields))
+	for _, fields := range []map[string]json.RawMessage{oldFields, newFields} {
+		for name := range fields {
+			if !seen[name] && !historyIgnoredFields[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	changes := []FieldChange{}
+	for _, name := range names {
+		oldValue, newValue := normalizeJSON(oldFields[name]), normalizeJSON(newFields[name])
+		if bytes.Equal(oldValue, newValue) {
+			continue
+		}
+		changes = append(changes, FieldChange{Field: name, Old: oldValue, New: newValue})
+	}
+	return changes
+}
+
+// decodeSnapshot parses a JSON object snapshot; nil if there is none or it is not an object
+func decodeSnapshot(value *string) map[string]json.RawMessage {
+	if value == nil {
+		return nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(*value), &fields); err != nil {
+		return nil
+	}
+	return fields
+}
+
+// snapshotField returns one field of a JSON object snapshot, or null
+func snapshotField(value *string, field string) json.RawMessage {
+	return normalizeJSON(decodeSnapshot(value)[field])
+}
+
+// normalizeJSON compacts a JSON value so equal values compare equal; missing values,
+// empty strings and empty arrays become null, matching how omitempty fields are stored.
+func normalizeJSON(value json.RawMessage) json.RawMessage {
+	var compacted bytes.Buffer
+	if len(value) == 0 || json.Compact(&compacted, value) != nil {
+		return json.RawMessage("null")
+	}
+	switch compacted.String() {
+	case `""`, `[]`:
+		return json.RawMessage("null")
+	}
+	return compacted.Bytes()
+}
+
+// legacyBumpCount reads a stored bump count. Counts were once written as the single
+// character '0'+n, so anything that is not a number is decoded that way.
+func legacyBumpCount(value *string) json.RawMessage {
+	if value == nil {
+		return json.RawMessage("null")
+	}
+	if n, err := strconv.Atoi(*value); err == nil {
+		return json.RawMessage(strconv.Itoa(n))
+	}
+	if runes := []rune(*value); len(runes) == 1 && runes[0] >= '0' {
+		return json.RawMessage(strconv.Itoa(int(runes[0] - '0')))
+	}
+	return json.RawMessage("null")
 }
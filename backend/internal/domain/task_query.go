@@ -0,0 +1,345 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxTaskQueryTerms bounds the size of a parsed search query (and the SQL built from it)
+const MaxTaskQueryTerms = 20
+
+// TaskQueryField identifies what a search term matches against
+type TaskQueryField string
+
+const (
+	TaskQueryText     TaskQueryField = "text"     // Free text or "quoted phrase" (full-text search)
+	TaskQueryStatus   TaskQueryField = "status"   // status:done
+	TaskQueryCategory TaskQueryField = "category" // category:work (case-insensitive)
+	TaskQueryPriority TaskQueryField = "priority" // priority:>70 (priority score, 0-100)
+	TaskQueryDue      TaskQueryField = "due"      // due:<7d, due:today, due:2025-12-31, due:none
+	TaskQueryCreated  TaskQueryField = "created"  // created:>-7d
+	TaskQueryEffort   TaskQueryField = "effort"   // effort:small
+	TaskQueryPerson   TaskQueryField = "person"   // person:alice (any related person, case-insensitive)
+	TaskQueryContext  TaskQueryField = "context"  // context:"call mom" (substring, case-insensitive)
+	TaskQueryTag      TaskQueryField = "tag"      // tag:urgent (case-insensitive)
+)
+
+// taskQueryFieldAliases maps the qualifiers users can type to fields
+var taskQueryFieldAliases = map[string]TaskQueryField{
+	"status":   TaskQueryStatus,
+	"category": TaskQueryCategory,
+	"priority": TaskQueryPriority,
+	"due":      TaskQueryDue,
+	"created":  TaskQueryCreated,
+	"effort":   TaskQueryEffort,
+	"person":   TaskQueryPerson,
+	"people":   TaskQueryPerson,
+	"context":  TaskQueryContext,
+	"tag":      TaskQueryTag,
+	"tags":     TaskQueryTag,
+}
+
+// TaskQueryOp is the comparison applied by a search term
+type TaskQueryOp string
+
+const (
+	TaskQueryEq  TaskQueryOp = "="
+	TaskQueryLt  TaskQueryOp = "<"
+	TaskQueryLte TaskQueryOp = "<="
+	TaskQueryGt  TaskQueryOp = ">"
+	TaskQueryGte TaskQueryOp = ">="
+)
+
+// TaskQuery is a parsed search query. A task matches when every clause matches.
+type TaskQuery struct {
+	Clauses []TaskQueryClause
+}
+
+// TaskQueryClause is a group of terms joined by OR. It matches when any term matches.
+type TaskQueryClause struct {
+	Terms []TaskQueryTerm
+}
+
+// TaskQueryTerm is a single (possibly negated) condition
+type TaskQueryTerm struct {
+	Field  TaskQueryField
+	Op     TaskQueryOp
+	Negate bool
+	Value  string // Text, status, category, effort, person, context, and tag terms
+	Phrase bool   // Text terms: quoted, so words must appear in order
+	Number int    // Priority terms
+	// Date terms resolve to the day range [From, To). Both nil means "no date" (due:none).
+	From *time.Time
+	To   *time.Time
+}
+
+var (
+	taskQueryQualifierPattern = regexp.MustCompile(`^[a-zA-Z_]+$`)
+	taskQueryRelativePattern  = regexp.MustCompile(`^([+-]?\d+)([dw])$`)
+)
+
+// taskQueryToken is a lexical unit of the search grammar
+type taskQueryToken struct {
+	pos       int // 1-based character position, for error messages
+	or        bool
+	negate    bool
+	qualifier string
+	value     string
+	quoted    bool
+}
+
+// ParseTaskQuery parses the task search grammar:
+//
+//	category:work due:<7d priority:>70 -status:done "quarterly report" person:alice OR person:bob
+//
+// Terms are ANDed; OR joins adjacent terms into a group; "-" negates a term; quoted text
+// is a phrase. Relative dates (today, tomorrow, yesterday, 7d, -2w) are resolved against now.
+// Errors are returned as ValidationErrors on the "search" field with the offending position.
+func ParseTaskQuery(input string, now time.Time) (*TaskQuery, error) {
+	tokens, err := tokenizeTaskQuery(input)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &TaskQuery{}
+	pendingOr := false
+	termCount := 0
+
+	for i, tok := range tokens {
+		if tok.or {
+			if i == 0 || tokens[i-1].or {
+				return nil, taskQueryError(tok.pos, "OR must follow a search term")
+			}
+			if i == len(tokens)-1 {
+				return nil, taskQueryError(tok.pos, "OR must be followed by a search term")
+			}
+			pendingOr = true
+			continue
+		}
+
+		term, err := parseTaskQueryTerm(tok, now)
+		if err != nil {
+			return nil, err
+		}
+
+		termCount++
+		if termCount > MaxTaskQueryTerms {
+			return nil, NewValidationError("search", fmt.Sprintf("too many terms (maximum %d)", MaxTaskQueryTerms))
+		}
+
+		if pendingOr {
+			last := &query.Clauses[len(query.Clauses)-1]
+			last.Terms = append(last.Terms, *term)
+			pendingOr = false
+		} else {
+			query.Clauses = append(query.Clauses, TaskQueryClause{Terms: []TaskQueryTerm{*term}})
+		}
+	}
+
+	return query, nil
+}
+
+// tokenizeTaskQuery splits the input into terms and OR keywords
+func tokenizeTaskQuery(input string) ([]taskQueryToken, error) {
+	runes := []rune(input)
+	var tokens []taskQueryToken
+
+	i := 0
+	for i < len(runes) {
+		if isTaskQuerySpace(runes[i]) {
+			i++
+			continue
+		}
+
+		tok := taskQueryToken{pos: i + 1}
+
+		if runes[i] == '-' && i+1 < len(runes) && !isTaskQuerySpace(runes[i+1]) {
+			tok.negate = true
+			i++
+		}
+
+		if runes[i] == '"' {
+			value, next, err := readTaskQueryQuoted(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			tok.value, tok.quoted, i = value, true, next
+			tokens = append(tokens, tok)
+			continue
+		}
+
+		start := i
+		for i < len(runes) && !isTaskQuerySpace(runes[i]) && runes[i] != ':' && runes[i] != '"' {
+			i++
+		}
+		word := string(runes[start:i])
+
+		if i < len(runes) && runes[i] == ':' && taskQueryQualifierPattern.MatchString(word) {
+			// Qualified term: the value is either quoted or runs to the next space
+			tok.qualifier = strings.ToLower(word)
+			i++
+			if i < len(runes) && runes[i] == '"' {
+				value, next, err := readTaskQueryQuoted(runes, i)
+				if err != nil {
+					return nil, err
+				}
+				tok.value, tok.quoted, i = value, true, next
+			} else {
+				valueStart := i
+				for i < len(runes) && !isTaskQuerySpace(runes[i]) {
+					i++
+				}
+				tok.value = string(runes[valueStart:i])
+			}
+			if strings.TrimSpace(tok.value) == "" {
+				return nil, taskQueryError(tok.pos, fmt.Sprintf("%s: needs a value", tok.qualifier))
+			}
+			tokens = append(tokens, tok)
+			continue
+		}
+
+		// Plain word: consume the rest of it (including any ':' that wasn't a qualifier)
+		for i < len(runes) && !isTaskQuerySpace(runes[i]) {
+			if runes[i] == '"' {
+				return nil, taskQueryError(i+1, "unexpected quote inside a word")
+			}
+			i++
+		}
+		tok.value = string(runes[start:i])
+
+		if tok.value == "OR" && !tok.negate {
+			tok.or = true
+		}
+		tokens = append(tokens, tok)
+	}
+
+	return tokens, nil
+}
+
+// readTaskQueryQuoted reads a quoted string starting at runes[start] == '"'
+func readTaskQueryQuoted(runes []rune, start int) (string, int, error) {
+	for j := start + 1; j < len(runes); j++ {
+		if runes[j] == '"' {
+			value := strings.TrimSpace(string(runes[start+1 : j]))
+			if value == "" {
+				return "", 0, taskQueryError(start+1, "empty quoted phrase")
+			}
+			return value, j + 1, nil
+		}
+	}
+	return "", 0, taskQueryError(start+1, "unterminated quote")
+}
+
+// parseTaskQueryTerm validates a token and converts it into a typed term
+func parseTaskQueryTerm(tok taskQueryToken, now time.Time) (*TaskQueryTerm, error) {
+	term := &TaskQueryTerm{Op: TaskQueryEq, Negate: tok.negate}
+
+	if tok.qualifier == "" {
+		term.Field = TaskQueryText
+		term.Value = tok.value
+		term.Phrase = tok.quoted
+		return term, nil
+	}
+
+	field, ok := taskQueryFieldAliases[tok.qualifier]
+	if !ok {
+		return nil, taskQueryError(tok.pos, fmt.Sprintf("unknown field %q", tok.qualifier))
+	}
+	term.Field = field
+
+	value := tok.value
+	if !tok.quoted && (field == TaskQueryPriority || field == TaskQueryDue || field == TaskQueryCreated) {
+		term.Op, value = splitTaskQueryOp(value)
+		if value == "" {
+			return nil, taskQueryError(tok.pos, fmt.Sprintf("%s: needs a value after %s", tok.qualifier, term.Op))
+		}
+	}
+
+	switch field {
+	case TaskQueryStatus:
+		if TaskStatus(value).Validate() != nil {
+			return nil, taskQueryError(tok.pos, fmt.Sprintf("invalid status %q", value))
+		}
+		term.Value = value
+
+	case TaskQueryEffort:
+		if TaskEffort(value).Validate() != nil {
+			return nil, taskQueryError(tok.pos, fmt.Sprintf("invalid effort %q", value))
+		}
+		term.Value = value
+
+	case TaskQueryCategory, TaskQueryPerson, TaskQueryContext, TaskQueryTag:
+		term.Value = value
+
+	case TaskQueryPriority:
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 || n > 100 {
+			return nil, taskQueryError(tok.pos, fmt.Sprintf("priority must be a number between 0 and 100, got %q", value))
+		}
+		term.Number = n
+
+	case TaskQueryDue, TaskQueryCreated:
+		if strings.EqualFold(value, "none") {
+			if field != TaskQueryDue || term.Op != TaskQueryEq {
+				return nil, taskQueryError(tok.pos, fmt.Sprintf("%s:none cannot be used here", tok.qualifier))
+			}
+			return term, nil
+		}
+		from, err := resolveTaskQueryDate(value, now)
+		if err != nil {
+			return nil, taskQueryError(tok.pos, fmt.Sprintf("invalid date %q (use today, tomorrow, yesterday, 7d, -2w or YYYY-MM-DD)", value))
+		}
+		to := from.AddDate(0, 0, 1)
+		term.From, term.To = &from, &to
+	}
+
+	return term, nil
+}
+
+// splitTaskQueryOp splits a leading comparison operator from a value
+func splitTaskQueryOp(value string) (TaskQueryOp, string) {
+	for _, op := range []TaskQueryOp{TaskQueryLte, TaskQueryGte, TaskQueryLt, TaskQueryGt, TaskQueryEq} {
+		if strings.HasPrefix(value, string(op)) {
+			return op, value[len(op):]
+		}
+	}
+	return TaskQueryEq, value
+}
+
+// resolveTaskQueryDate resolves an absolute or relative date to the start of that day
+func resolveTaskQueryDate(value string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch strings.ToLower(value) {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	if m := taskQueryRelativePattern.FindStringSubmatch(strings.ToLower(value)); m != nil {
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			return time.Time{}, err
+		}
+		if m[2] == "w" {
+			n *= 7
+		}
+		return today.AddDate(0, 0, n), nil
+	}
+
+	return time.ParseInLocation("2006-01-02", value, now.Location())
+}
+
+func isTaskQuerySpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
+
+func taskQueryError(pos int, message string) *ValidationError {
+	return NewValidationError("search", fmt.Sprintf("%s (at position %d)", message, pos))
+}
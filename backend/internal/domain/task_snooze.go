@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidSnoozePreset = errors.New("invalid snooze preset: must be tonight, tomorrow or next_week")
+
+// SnoozePreset names a common snooze target, resolved in the user's timezone
+type SnoozePreset string
+
+const (
+	SnoozeTonight  SnoozePreset = "tonight"   // 18:00 today, or 18:00 tomorrow once that has passed
+	SnoozeTomorrow SnoozePreset = "tomorrow"  // 09:00 tomorrow
+	SnoozeNextWeek SnoozePreset = "next_week" // 09:00 next Monday
+)
+
+const (
+	snoozeMorningHour = 9
+	snoozeEveningHour = 18
+)
+
+// SnoozeTaskDTO is used for snoozing a task; exactly one of Preset or Until is required
+type SnoozeTaskDTO struct {
+	Preset *SnoozePreset `json:"preset,omitempty"`
+	Until  *time.Time    `json:"until,omitempty"`
+}
+
+// Validate validates the snooze preset
+func (p SnoozePreset) Validate() error {
+	switch p {
+	case SnoozeTonight, SnoozeTomorrow, SnoozeNextWeek:
+		return nil
+	default:
+		return ErrInvalidSnoozePreset
+	}
+}
+
+// Resolve returns the start date the preset means at now, in loc
+func (p SnoozePreset) Resolve(now time.Time, loc *time.Location) (time.Time, error) {
+	local := now.In(loc)
+	year, month, day := local.Date()
+	at := func(dayOffset, hour int) time.Time {
+		return time.Date(year, month, day+dayOffset, hour, 0, 0, 0, loc)
+	}
+
+	switch p {
+	case SnoozeTonight:
+		tonight := at(0, snoozeEveningHour)
+		if !tonight.After(now) {
+			return at(1, snoozeEveningHour), nil
+		}
+		return tonight, nil
+	case SnoozeTomorrow:
+		return at(1, snoozeMorningHour), nil
+	case SnoozeNextWeek:
+		// Days until the next Monday; a Monday snoozes to the following one
+		days := (int(time.Monday) - int(local.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return at(days, snoozeMorningHour), nil
+	default:
+		return time.Time{}, ErrInvalidSnoozePreset
+	}
+}
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// TaskSortField is a task attribute that task lists can be ordered by
+type TaskSortField string
+
+const (
+	TaskSortDueDate       TaskSortField = "due_date"
+	TaskSortPriorityScore TaskSortField = "priority_score"
+	TaskSortUserPriority  TaskSortField = "user_priority"
+	TaskSortCreatedAt     TaskSortField = "created_at"
+	TaskSortUpdatedAt     TaskSortField = "updated_at"
+	TaskSortCompletedAt   TaskSortField = "completed_at"
+	TaskSortTitle         TaskSortField = "title"
+	TaskSortBumpCount     TaskSortField = "bump_count"
+	TaskSortManual        TaskSortField = "manual" // The order the user arranged by hand (rank)
+)
+
+// Validate validates the sort field
+func (f TaskSortField) Validate() error {
+	switch f {
+	case TaskSortDueDate, TaskSortPriorityScore, TaskSortUserPriority, TaskSortCreatedAt,
+		TaskSortUpdatedAt, TaskSortCompletedAt, TaskSortTitle, TaskSortBumpCount, TaskSortManual:
+		return nil
+	default:
+		return NewValidationError("sort", "unknown sort field: "+string(f))
+	}
+}
+
+// IsNullable reports whether tasks may have no value for this field.
+// Tasks without a value always sort last, regardless of direction.
+func (f TaskSortField) IsNullable() bool {
+	return f == TaskSortDueDate || f == TaskSortCompletedAt
+}
+
+// IsTime reports whether the field holds a timestamp
+func (f TaskSortField) IsTime() bool {
+	switch f {
+	case TaskSortDueDate, TaskSortCreatedAt, TaskSortUpdatedAt, TaskSortCompletedAt:
+		return true
+	default:
+		return false
+	}
+}
+
+// ValueOf returns the task's value for this field (nil when unset)
+func (f TaskSortField) ValueOf(task *Task) interface{} {
+	switch f {
+	case TaskSortDueDate:
+		if task.DueDate == nil {
+			return nil
+		}
+		return *task.DueDate
+	case TaskSortPriorityScore:
+		return task.PriorityScore
+	case TaskSortUserPriority:
+		return task.UserPriority
+	case TaskSortCreatedAt:
+		return task.CreatedAt
+	case TaskSortUpdatedAt:
+		return task.UpdatedAt
+	case TaskSortCompletedAt:
+		if task.CompletedAt == nil {
+			return nil
+		}
+		return *task.CompletedAt
+	case TaskSortTitle:
+		return task.Title
+	case TaskSortBumpCount:
+		return task.BumpCount
+	case TaskSortManual:
+		return task.Rank
+	default:
+		return nil
+	}
+}
+
+// TaskSort is a single sort key
+type TaskSort struct {
+	Field      TaskSortField
+	Descending bool
+}
+
+// DefaultTaskSort is the ordering used when the client does not ask for one
+var DefaultTaskSort = []TaskSort{
+	{Field: TaskSortPriorityScore, Descending: true},
+	{Field: TaskSortCreatedAt, Descending: true},
+}
+
+// ParseTaskSort parses a comma-separated sort spec such as "due_date,-priority_score".
+// A leading "-" sorts that key in descending order.
+func ParseTaskSort(spec string) ([]TaskSort, error) {
+	var sorts []TaskSort
+	seen := make(map[TaskSortField]bool)
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		descending := strings.HasPrefix(part, "-")
+		field := TaskSortField(strings.TrimPrefix(part, "-"))
+
+		if field == "" {
+			return nil, NewValidationError("sort", "contains an empty sort key")
+		}
+		if err := field.Validate(); err != nil {
+			return nil, err
+		}
+		if seen[field] {
+			return nil, NewValidationError("sort", "duplicate sort field: "+string(field))
+		}
+		seen[field] = true
+
+		sorts = append(sorts, TaskSort{Field: field, Descending: descending})
+	}
+
+	return sorts, nil
+}
+
+// FormatTaskSort returns the canonical spec string for a list of sort keys
+func FormatTaskSort(sorts []TaskSort) string {
+	parts := make([]string, len(sorts))
+	for i, s := range sorts {
+		if s.Descending {
+			parts[i] = "-" + string(s.Field)
+		} else {
+			parts[i] = string(s.Field)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// parseSortValue converts a JSON-decoded cursor value back into the Go type for the field
+func parseSortValue(field TaskSortField, raw interface{}) (interface{}, bool) {
+	if raw == nil {
+		return nil, field.IsNullable()
+	}
+
+	switch {
+	case field.IsTime():
+		s, ok := raw.(string)
+		if !ok {
+			return nil, false
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return nil, false
+		}
+		return t, true
+	case field == TaskSortTitle, field == TaskSortManual:
+		s, ok := raw.(string)
+		return s, ok
+	default:
+		n, ok := raw.(float64)
+		if !ok || n != float64(int(n)) {
+			return nil, false
+		}
+		return int(n), true
+	}
+}
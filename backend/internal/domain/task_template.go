@@ -25,6 +25,7 @@ type TaskTemplate struct {
 	UserPriority    int         `json:"user_priority"` // 1-10
 	Context         *string     `json:"context,omitempty"`
 	RelatedPeople   []string    `json:"related_people,omitempty"`
+	Tags            []string    `json:"tags,omitempty"`
 
 	// Relative due date (days from creation)
 	DueDateOffset *int `json:"due_date_offset,omitempty"` // NULL = no due date
@@ -43,6 +44,7 @@ type CreateTaskTemplateDTO struct {
 	UserPriority    *int        `json:"user_priority,omitempty" binding:"omitempty,min=1,max=10"`
 	Context         *string     `json:"context,omitempty" binding:"omitempty,max=500"`
 	RelatedPeople   []string    `json:"related_people,omitempty"`
+	Tags            []string    `json:"tags,omitempty"`
 	DueDateOffset   *int        `json:"due_date_offset,omitempty" binding:"omitempty,min=0,max=365"`
 }
 
@@ -56,6 +58,7 @@ type UpdateTaskTemplateDTO struct {
 	UserPriority    *int        `json:"user_priority,omitempty" binding:"omitempty,min=1,max=10"`
 	Context         *string     `json:"context,omitempty" binding:"omitempty,max=500"`
 	RelatedPeople   []string    `json:"related_people,omitempty"`
+	Tags            []string    `json:"tags,omitempty"`
 	DueDateOffset   *int        `json:"due_date_offset,omitempty" binding:"omitempty,min=0,max=365"`
 }
 
@@ -75,6 +78,7 @@ func (t *TaskTemplate) ToCreateTaskDTO() *CreateTaskDTO {
 		EstimatedEffort: t.EstimatedEffort,
 		Context:         t.Context,
 		RelatedPeople:   t.RelatedPeople,
+		Tags:            t.Tags,
 	}
 
 	// Set user priority if not default
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// expectedVersionKey is the context key for a request's If-Match precondition
+type expectedVersionKey struct{}
+
+// WithExpectedVersion returns a context carrying the task version the caller
+// last saw. Task writes made with this context fail with a
+// PreconditionFailedError if the stored task has moved past that version.
+func WithExpectedVersion(ctx context.Context, version int) context.Context {
+	return context.WithValue(ctx, expectedVersionKey{}, version)
+}
+
+// ExpectedVersion returns the version precondition carried by ctx, if any
+func ExpectedVersion(ctx context.Context) (int, bool) {
+	version, ok := ctx.Value(expectedVersionKey{}).(int)
+	return version, ok
+}
+
+// ETag returns the task's entity tag, derived from its version
+func (t *Task) ETag() string {
+	return FormatETag(t.Version)
+}
+
+// FormatETag formats a version number as a strong entity tag
+func FormatETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// ParseIfMatch parses an If-Match header value into the version it expects.
+// Returns ok=false for "*", which matches any current version.
+// Weak tags (W/"3") are accepted since versions are only ever compared exactly.
+func ParseIfMatch(header string) (version int, ok bool, err error) {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return 0, false, nil
+	}
+
+	tag := strings.TrimPrefix(header, "W/")
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, false, NewValidationError("If-Match", "must be a single quoted entity tag or *")
+	}
+
+	version, err = strconv.Atoi(tag[1 : len(tag)-1])
+	if err != nil || version < 1 {
+		return 0, false, NewValidationError("If-Match", "entity tag does not identify a task version")
+	}
+
+	return version, true, nil
+}
+
+// WithoutExpectedVersion returns a context with no version precondition, for follow-up
+// writes to a task whose version the caller already checked and has since bumped itself
+func WithoutExpectedVersion(ctx context.Context) context.Context {
+	return context.WithValue(ctx, expectedVersionKey{}, nil)
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrTimeEntryNotFound   = errors.New("time entry not found")
+	ErrTimerAlreadyRunning = errors.New("a timer is already running; stop it before starting another")
+	ErrTimerNotRunning     = errors.New("no timer is running for this task")
+)
+
+// TimeEntrySource records how a time entry was created
+type TimeEntrySource string
+
+const (
+	TimeEntrySourceTimer  TimeEntrySource = "timer"  // Started and stopped live
+	TimeEntrySourceManual TimeEntrySource = "manual" // Logged after the fact
+)
+
+// MaxTimeEntryNoteLength is the maximum length of a time entry note in characters
+const MaxTimeEntryNoteLength = 500
+
+// MaxManualTimeEntryDuration bounds a single manually logged entry
+const MaxManualTimeEntryDuration = 24 * time.Hour
+
+// TimeEntry is a span of time spent on a task.
+// A user has at most one running entry (EndedAt == nil), their active timer.
+type TimeEntry struct {
+	ID              string          `json:"id"`
+	TaskID          string          `json:"task_id"`
+	UserID          string          `json:"user_id"`
+	StartedAt       time.Time       `json:"started_at"`
+	EndedAt         *time.Time      `json:"ended_at,omitempty"` // Nil while the timer is running
+	DurationSeconds int64           `json:"duration_seconds"`   // Elapsed so far for a running timer
+	Source          TimeEntrySource `json:"source"`
+	Note            *string         `json:"note,omitempty"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+// StartTimerDTO is used for starting a timer on a task
+type StartTimerDTO struct {
+	Note *string `json:"note,omitempty"`
+}
+
+// CreateTimeEntryDTO is used for logging time manually.
+// Exactly one of EndedAt and DurationMinutes must be set.
+type CreateTimeEntryDTO struct {
+	StartedAt       time.Time  `json:"started_at" binding:"required"`
+	EndedAt         *time.Time `json:"ended_at,omitempty"`
+	DurationMinutes *int       `json:"duration_minutes,omitempty"`
+	Note            *string    `json:"note,omitempty"`
+}
+
+// TimeEntryListResponse is the response for listing a task's time entries
+type TimeEntryListResponse struct {
+	Entries      []*TimeEntry `json:"entries"`
+	TotalCount   int          `json:"total_count"`
+	TotalSeconds int64        `json:"total_seconds"` // Finished entries only
+}
+
+// TrackedTimeStats summarizes the tracked time of completed tasks,
+// i.e. how much actual effort a finished task took
+type TrackedTimeStats struct {
+	SampleSize    int     `json:"sample_size"` // Completed tasks with tracked time
+	MedianMinutes float64 `json:"median_minutes"`
+	AvgMinutes    float64 `json:"avg_minutes"`
+}
+
+// CategoryTimeStats is the tracked time spent on one category
+type CategoryTimeStats struct {
+	Category     string  `json:"category"`
+	TotalMinutes float64 `json:"total_minutes"`
+	TaskCount    int     `json:"task_count"`
+}
+
+// TimeTrackingSummary is the time tracking section of the analytics summary
+type TimeTrackingSummary struct {
+	TotalMinutes               float64             `json:"total_minutes"`      // Tracked in the period
+	TrackedTaskCount           int                 `json:"tracked_task_count"` // Tasks with time tracked in the period
+	CompletedTaskCount         int                 `json:"completed_task_count"`
+	AvgMinutesPerCompletedTask float64             `json:"avg_minutes_per_completed_task"` // Over completed tasks with tracked time
+	CategoryBreakdown          []CategoryTimeStats `json:"category_breakdown"`
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// TaskPurgeReason records why trashed tasks were permanently deleted
+type TaskPurgeReason string
+
+const (
+	TaskPurgeReasonPermanent  TaskPurgeReason = "permanent"   // One task deleted from the trash
+	TaskPurgeReasonEmptyTrash TaskPurgeReason = "empty_trash" // The user emptied the trash
+	TaskPurgeReasonRetention  TaskPurgeReason = "retention"   // Trashed longer than the retention period
+)
+
+// TrashPurge selects a user's trashed tasks to delete permanently. Subtasks of a purged
+// task go with it; history, comments, dependencies and other task data cascade.
+type TrashPurge struct {
+	Reason        TaskPurgeReason
+	TaskID        *string    // Only this task
+	DeletedBefore *time.Time // Only tasks trashed before this time
+}
+
+// TrashPage is a page of the user's deleted tasks, most recently deleted first
+type TrashPage struct {
+	Tasks         []*Task `json:"tasks"`
+	TotalCount    int     `json:"total_count"`
+	Limit         int     `json:"limit"`
+	Offset        int     `json:"offset"`
+	RetentionDays int     `json:"retention_days"` // Tasks are purged this many days after deletion; 0 = kept until removed
+}
+
+// EmptyTrashResponse reports how many tasks emptying the trash deleted, subtasks included
+type EmptyTrashResponse struct {
+	DeletedCount int `json:"deleted_count"`
+}
+
+// TrashPurgeResult summarizes one run of the retention job
+type TrashPurgeResult struct {
+	UserCount    int
+	DeletedCount int
+	FailedCount  int
+	Duration     time.Duration
+}
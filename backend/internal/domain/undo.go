@@ -0,0 +1,193 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+var (
+	ErrOperationNotFound      = errors.New("operation not found among your recent operations")
+	ErrOperationAlreadyUndone = errors.New("operation has already been undone")
+	ErrOperationNotUndoable   = errors.New("operation cannot be undone")
+	ErrUndoConflict           = errors.New("a task in this operation has changed since; undo the later operations first")
+)
+
+// MaxUndoableOperations is how many of a user's most recent operations can be undone
+const MaxUndoableOperations = 20
+
+// operationIDKey is the context key for the operation a request's writes belong to
+type operationIDKey struct{}
+
+// WithOperationID returns a context carrying the ID of the operation being performed.
+// History entries written with this context are grouped under it, so the operation
+// can later be undone as a whole.
+func WithOperationID(ctx context.Context, operationID string) context.Context {
+	return context.WithValue(ctx, operationIDKey{}, operationID)
+}
+
+// OperationID returns the operation ID carried by ctx, if any
+func OperationID(ctx context.Context) (string, bool) {
+	operationID, ok := ctx.Value(operationIDKey{}).(string)
+	return operationID, ok && operationID != ""
+}
+
+// IsUndoable reports whether events of this type record task snapshots that undo can restore.
+// Comment events are part of an operation but are never reverted.
+func (t TaskHistoryEventType) IsUndoable() bool {
+	switch t {
+	case EventTaskCreated, EventTaskUpdated, EventTaskBumped, EventTaskCompleted, EventTaskUncompleted,
+		EventTaskDeleted, EventTaskRestored, EventStatusChanged:
+		return true
+	default:
+		return false
+	}
+}
+
+// UndoTargetEventTypes lists the undoable event types as strings, for repository filters
+func UndoTargetEventTypes() []string {
+	return []string{
+		string(EventTaskCreated), string(EventTaskUpdated), string(EventTaskBumped),
+		string(EventTaskCompleted), string(EventTaskUncompleted), string(EventTaskDeleted),
+		string(EventTaskRestored), string(EventStatusChanged),
+	}
+}
+
+// TaskOperation summarizes one of a user's recent operations
+type TaskOperation struct {
+	ID                 string                 `json:"operation_id"`
+	TaskIDs            []string               `json:"task_ids"`
+	EventTypes         []TaskHistoryEventType `json:"event_types"`
+	CreatedAt          time.Time              `json:"created_at"`
+	UndoneAt           *time.Time             `json:"undone_at,omitempty"`
+	RevertsOperationID *string                `json:"reverts_operation_id,omitempty"` // Set when the operation is itself an undo
+}
+
+// UndoRequest selects the operation to undo; without an ID the most recent operation
+// that is neither undone nor itself an undo is used
+type UndoRequest struct {
+	OperationID *string `json:"operation_id,omitempty" binding:"omitempty,uuid"`
+}
+
+// UndoResult is the outcome of an undo. The undo is an operation of its own,
+// so undoing UndoOperationID redoes the original.
+type UndoResult struct {
+	OperationID     string  `json:"operation_id"`
+	UndoOperationID string  `json:"undo_operation_id"`
+	Tasks           []*Task `json:"tasks"`
+}
+
+// PartialUndoError reports an undo that failed after reverting some of the operation's
+// tasks. The operation is left undoable, and undoing it again reverts the rest; the tasks
+// already reverted are recorded under UndoOperationID.
+type PartialUndoError struct {
+	OperationID     string
+	UndoOperationID string
+	RevertedTaskIDs []string
+	Err             error
+}
+
+func (e *PartialUndoError) Error() string {
+	return fmt.Sprintf("undo stopped after reverting %d task(s); undo the operation again to revert the rest", len(e.RevertedTaskIDs))
+}
+
+func (e *PartialUndoError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeTaskSnapshot parses a task snapshot stored in history; nil if the value is
+// missing or not a task snapshot (e.g. legacy bump counts)
+func DecodeTaskSnapshot(value *string) *Task {
+	if value == nil {
+		return nil
+	}
+	var task Task
+	if err := json.Unmarshal([]byte(*value), &task); err != nil || task.ID == "" {
+		return nil
+	}
+	return &task
+}
+
+// SameTaskState reports whether two copies of a task agree on every field undo restores.
+// Timestamps are compared at database precision, tags as a set, and an empty list
+// equals an unset one.
+func SameTaskState(a, b *Task) bool {
+	return a.Title == b.Title &&
+		equalStringPtr(a.Description, b.Description) &&
+		a.Status == b.Status &&
+		a.StatusKey() == b.StatusKey() &&
+		a.UserPriority == b.UserPriority &&
+		equalTimePtr(a.DueDate, b.DueDate) &&
+		equalEffortPtr(a.EstimatedEffort, b.EstimatedEffort) &&
+		equalStringPtr(a.Category, b.Category) &&
+		equalStringPtr(a.Context, b.Context) &&
+		equalStrings(a.RelatedPeople, b.RelatedPeople) &&
+		equalStrings(sortedCopy(a.Tags), sortedCopy(b.Tags)) &&
+		a.BumpCount == b.BumpCount &&
+		equalTimePtr(a.CompletedAt, b.CompletedAt) &&
+		equalTimePtr(a.DeferUntil, b.DeferUntil) &&
+		(a.DeletedAt == nil) == (b.DeletedAt == nil)
+}
+
+// RestoreTaskState copies the fields undo restores from snapshot onto task,
+// leaving identity, relationships and soft-delete state alone
+func RestoreTaskState(task, snapshot *Task) {
+	task.Title = snapshot.Title
+	task.Description = snapshot.Description
+	task.Status = snapshot.Status
+	// Empty in snapshots taken before workflows; the database then derives it from Status
+	task.WorkflowStatus = snapshot.WorkflowStatus
+	task.UserPriority = snapshot.UserPriority
+	task.DueDate = snapshot.DueDate
+	task.EstimatedEffort = snapshot.EstimatedEffort
+	task.Category = snapshot.Category
+	task.Context = snapshot.Context
+	task.RelatedPeople = snapshot.RelatedPeople
+	// nil would leave the current tags in place
+	task.Tags = append([]string{}, snapshot.Tags...)
+	task.BumpCount = snapshot.BumpCount
+	task.CompletedAt = snapshot.CompletedAt
+	task.DeferUntil = snapshot.DeferUntil
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func equalEffortPtr(a, b *TaskEffort) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func equalTimePtr(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Truncate(time.Microsecond).Equal(b.Truncate(time.Microsecond))
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedCopy(values []string) []string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,232 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// StatusCategory groups workflow statuses by how far along a task is. Every status,
+// built-in or custom, belongs to one, and its category decides the built-in status
+// stored for analytics, at-risk detection and gamification.
+type StatusCategory string
+
+const (
+	StatusCategoryOpen   StatusCategory = "open"   // Not started (todo, on_hold)
+	StatusCategoryActive StatusCategory = "active" // Being worked on (in_progress, blocked)
+	StatusCategoryDone   StatusCategory = "done"   // Finished (done)
+)
+
+// Validate checks if the category is valid
+func (c StatusCategory) Validate() error {
+	switch c {
+	case StatusCategoryOpen, StatusCategoryActive, StatusCategoryDone:
+		return nil
+	default:
+		return fmt.Errorf("invalid status category %q", c)
+	}
+}
+
+// BaseStatus is the built-in status stored for custom statuses in the category
+func (c StatusCategory) BaseStatus() TaskStatus {
+	switch c {
+	case StatusCategoryActive:
+		return TaskStatusInProgress
+	case StatusCategoryDone:
+		return TaskStatusDone
+	default:
+		return TaskStatusTodo
+	}
+}
+
+// Category returns the category of a built-in status
+func (s TaskStatus) Category() StatusCategory {
+	switch s {
+	case TaskStatusInProgress, TaskStatusBlocked:
+		return StatusCategoryActive
+	case TaskStatusDone:
+		return StatusCategoryDone
+	default:
+		return StatusCategoryOpen
+	}
+}
+
+// Workflow limits
+const (
+	MaxWorkflowStatuses      = 20
+	MaxWorkflowStatusNameLen = 50
+)
+
+// workflowStatusKeyPattern matches the keys of custom statuses, e.g. "in_review"
+var workflowStatusKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,29}$`)
+
+// WorkflowStatus is one status of a user's workflow
+type WorkflowStatus struct {
+	Key         TaskStatus     `json:"key" binding:"required"`
+	Name        string         `json:"name" binding:"required"`
+	Category    StatusCategory `json:"category" binding:"required"`
+	Transitions []TaskStatus   `json:"transitions"` // Statuses a task may move to from this one; null allows any
+}
+
+// BaseStatus is the built-in status stored in tasks.status for this status: built-in
+// keys keep their own, custom keys get their category's
+func (s WorkflowStatus) BaseStatus() TaskStatus {
+	if s.Key.Validate() == nil {
+		return s.Key
+	}
+	return s.Category.BaseStatus()
+}
+
+// Workflow is the ordered list of statuses a user's tasks move through. It is also the
+// board's column order.
+type Workflow struct {
+	Statuses []WorkflowStatus `json:"statuses"`
+	Custom   bool             `json:"custom"` // False while the user has the built-in workflow
+}
+
+// DefaultWorkflow returns the built-in statuses. Transitions are only restricted in
+// user-defined workflows, so a task may move between any two built-in statuses.
+func DefaultWorkflow() *Workflow {
+	return &Workflow{Statuses: []WorkflowStatus{
+		{Key: TaskStatusTodo, Name: "To Do", Category: StatusCategoryOpen},
+		{Key: TaskStatusInProgress, Name: "In Progress", Category: StatusCategoryActive},
+		{Key: TaskStatusBlocked, Name: "Blocked", Category: StatusCategoryActive},
+		{Key: TaskStatusOnHold, Name: "On Hold", Category: StatusCategoryOpen},
+		{Key: TaskStatusDone, Name: "Done", Category: StatusCategoryDone},
+	}}
+}
+
+// Status returns the workflow status with the given key
+func (w *Workflow) Status(key TaskStatus) (*WorkflowStatus, bool) {
+	for i := range w.Statuses {
+		if w.Statuses[i].Key == key {
+			return &w.Statuses[i], true
+		}
+	}
+	return nil, false
+}
+
+// CanTransition reports whether a task may move from one status to another.
+// Staying in the same status is always allowed, as is leaving a status that is no
+// longer in the workflow.
+func (w *Workflow) CanTransition(from, to TaskStatus) bool {
+	if from == to {
+		return true
+	}
+	if _, ok := w.Status(to); !ok {
+		return false
+	}
+	status, ok := w.Status(from)
+	if !ok || status.Transitions == nil {
+		return true
+	}
+	for _, allowed := range status.Transitions {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// CompletionStatus returns the first done status a task in status from may move to,
+// which is where completing the task puts it
+func (w *Workflow) CompletionStatus(from TaskStatus) (*WorkflowStatus, bool) {
+	for i := range w.Statuses {
+		if w.Statuses[i].Category == StatusCategoryDone && w.CanTransition(from, w.Statuses[i].Key) {
+			return &w.Statuses[i], true
+		}
+	}
+	return nil, false
+}
+
+// Validate checks the statuses have unique, well-formed keys and names, that built-in
+// keys keep their category, that each category has a status, and that transitions only
+// lead to other statuses of the workflow
+func (w *Workflow) Validate() error {
+	if len(w.Statuses) > MaxWorkflowStatuses {
+		return NewValidationError("statuses", fmt.Sprintf("a workflow can have at most %d statuses", MaxWorkflowStatuses))
+	}
+
+	keys := make(map[TaskStatus]bool, len(w.Statuses))
+	categories := make(map[StatusCategory]bool, 3)
+	for i := range w.Statuses {
+		status := &w.Statuses[i]
+		status.Name = strings.TrimSpace(status.Name)
+
+		if !workflowStatusKeyPattern.MatchString(string(status.Key)) {
+			return NewValidationError("statuses", fmt.Sprintf("key %q must be lowercase letters, digits and underscores, starting with a letter (max 30)", status.Key))
+		}
+		if keys[status.Key] {
+			return NewValidationError("statuses", fmt.Sprintf("duplicate key %q", status.Key))
+		}
+		keys[status.Key] = true
+
+		if status.Name == "" || len(status.Name) > MaxWorkflowStatusNameLen {
+			return NewValidationError("statuses", fmt.Sprintf("name of %s must be 1-%d characters", status.Key, MaxWorkflowStatusNameLen))
+		}
+		if err := status.Category.Validate(); err != nil {
+			return NewValidationError("statuses", err.Error())
+		}
+		if status.Key.Validate() == nil && status.Category != status.Key.Category() {
+			return NewValidationError("statuses", fmt.Sprintf("built-in status %s must stay in the %s category", status.Key, status.Key.Category()))
+		}
+		categories[status.Category] = true
+	}
+
+	for _, category := range []StatusCategory{StatusCategoryOpen, StatusCategoryActive, StatusCategoryDone} {
+		if !categories[category] {
+			return NewValidationError("statuses", fmt.Sprintf("the workflow needs at least one %s status", category))
+		}
+	}
+
+	for _, status := range w.Statuses {
+		for _, to := range status.Transitions {
+			if !keys[to] {
+				return NewValidationError("statuses", fmt.Sprintf("%s transitions to unknown status %q", status.Key, to))
+			}
+			if to == status.Key {
+				return NewValidationError("statuses", fmt.Sprintf("%s cannot transition to itself", status.Key))
+			}
+		}
+	}
+	return nil
+}
+
+// StatusKey returns the task's status in its owner's workflow, falling back to the
+// built-in status for tasks read without it
+func (t *Task) StatusKey() TaskStatus {
+	if t.WorkflowStatus != "" {
+		return t.WorkflowStatus
+	}
+	return t.Status
+}
+
+// UpdateWorkflowDTO replaces a user's workflow.
+// Tasks in removed statuses move to the status given in StatusMapping, which must be
+// in the same category; unmapped ones move to the first status of their category.
+type UpdateWorkflowDTO struct {
+	Statuses      []WorkflowStatus          `json:"statuses" binding:"required,min=1,dive"`
+	StatusMapping map[TaskStatus]TaskStatus `json:"status_mapping,omitempty"`
+}
+
+// ValidateMapping checks every mapping leads from a status removed from current to a
+// status of the new workflow in the same category
+func (dto *UpdateWorkflowDTO) ValidateMapping(current, updated *Workflow) error {
+	for from, to := range dto.StatusMapping {
+		old, ok := current.Status(from)
+		if !ok {
+			return NewValidationError("status_mapping", fmt.Sprintf("%q is not in the current workflow", from))
+		}
+		if _, kept := updated.Status(from); kept {
+			return NewValidationError("status_mapping", fmt.Sprintf("%s is still in the workflow", from))
+		}
+		target, ok := updated.Status(to)
+		if !ok {
+			return NewValidationError("status_mapping", fmt.Sprintf("%q is not in the new workflow", to))
+		}
+		if target.Category != old.Category {
+			return NewValidationError("status_mapping", fmt.Sprintf("%s (%s) can only map to a %s status", from, old.Category, old.Category))
+		}
+	}
+	return nil
+}
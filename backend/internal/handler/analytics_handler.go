@@ -83,6 +83,13 @@ func (h *AnalyticsHandler) GetSummary(c *gin.Context) {
 		return
 	}
 
+	// Get tag breakdown
+	tagStats, err := h.taskRepo.GetTagBreakdown(c.Request.Context(), userID, daysBack)
+	if err != nil {
+		middleware.AbortWithError(c, domain.NewInternalError("failed to fetch tag stats", err))
+		return
+	}
+
 	// Get priority distribution
 	priorityDist, err := h.taskRepo.GetPriorityDistribution(c.Request.Context(), userID)
 	if err != nil {
@@ -90,12 +97,21 @@ func (h *AnalyticsHandler) GetSummary(c *gin.Context) {
 		return
 	}
 
+	// Get tracked time
+	timeTracking, err := h.taskRepo.GetTimeTrackingSummary(c.Request.Context(), userID, daysBack)
+	if err != nil {
+		middleware.AbortWithError(c, domain.NewInternalError("failed to fetch time tracking summary", err))
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"period_days":           daysBack,
 		"completion_stats":      completionStats,
 		"bump_analytics":        bumpAnalytics,
 		"category_breakdown":    categoryStats,
+		"tag_breakdown":         tagStats,
 		"priority_distribution": priorityDist,
+		"time_tracking":         timeTracking,
 	})
 }
 
@@ -158,6 +174,36 @@ func (h *AnalyticsHandler) GetProductivityHeatmap(c *gin.Context) {
 	})
 }
 
+// GetFocusHeatmap returns focused minutes by day of week and hour,
+// the focus-session counterpart of GetProductivityHeatmap
+// GET /api/v1/analytics/focus-heatmap?days=90
+func (h *AnalyticsHandler) GetFocusHeatmap(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	// Parse days parameter (same default and bounds as the productivity heatmap)
+	daysBack := 90
+	if daysStr := c.Query("days"); daysStr != "" {
+		if days, err := strconv.Atoi(daysStr); err == nil && days > 0 && days <= 365 {
+			daysBack = days
+		}
+	}
+
+	heatmap, err := h.taskRepo.GetFocusHeatmap(c.Request.Context(), userID, daysBack)
+	if err != nil {
+		middleware.AbortWithError(c, domain.NewInternalError("failed to fetch focus heatmap", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"period_days": daysBack,
+		"heatmap":     heatmap,
+	})
+}
+
 // GetCategoryTrends returns weekly category breakdown for trend visualization
 // GET /api/v1/analytics/category-trends?days=90
 func (h *AnalyticsHandler) GetCategoryTrends(c *gin.Context) {
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/notkevinvu/taskflow/backend/internal/domain"
@@ -41,13 +42,18 @@ func (m *MockTaskRepository) List(ctx context.Context, userID string, filter *do
 	return args.Get(0).([]*domain.Task), args.Error(1)
 }
 
+func (m *MockTaskRepository) Count(ctx context.Context, userID string, filter *domain.TaskListFilter) (int, error) {
+	args := m.Called(ctx, userID, filter)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockTaskRepository) Update(ctx context.Context, task *domain.Task) error {
 	args := m.Called(ctx, task)
 	return args.Error(0)
 }
 
-func (m *MockTaskRepository) Delete(ctx context.Context, id, userID string) error {
-	args := m.Called(ctx, id, userID)
+func (m *MockTaskRepository) Delete(ctx context.Context, id, userID string, expectedVersion int) error {
+	args := m.Called(ctx, id, userID, expectedVersion)
 	return args.Error(0)
 }
 
@@ -80,14 +86,20 @@ func (m *MockTaskRepository) FindByDateRange(ctx context.Context, userID string,
 	return args.Get(0).([]*domain.Task), args.Error(1)
 }
 
-func (m *MockTaskRepository) RenameCategoryForUser(ctx context.Context, userID, oldName, newName string) (int, error) {
+func (m *MockTaskRepository) RenameCategoryForUser(ctx context.Context, userID, oldName, newName string) ([]*domain.Task, error) {
 	args := m.Called(ctx, userID, oldName, newName)
-	return args.Int(0), args.Error(1)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Task), args.Error(1)
 }
 
-func (m *MockTaskRepository) DeleteCategoryForUser(ctx context.Context, userID, categoryName string) (int, error) {
+func (m *MockTaskRepository) DeleteCategoryForUser(ctx context.Context, userID, categoryName string) ([]*domain.Task, error) {
 	args := m.Called(ctx, userID, categoryName)
-	return args.Int(0), args.Error(1)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Task), args.Error(1)
 }
 
 func (m *MockTaskRepository) GetCompletionStats(ctx context.Context, userID string, daysBack int) (*repository.CompletionStats, error) {
@@ -114,6 +126,22 @@ func (m *MockTaskRepository) GetCategoryBreakdown(ctx context.Context, userID st
 	return args.Get(0).([]repository.CategoryStats), args.Error(1)
 }
 
+func (m *MockTaskRepository) GetTagBreakdown(ctx context.Context, userID string, daysBack int) ([]domain.TagStats, error) {
+	args := m.Called(ctx, userID, daysBack)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.TagStats), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetTimeTrackingSummary(ctx context.Context, userID string, daysBack int) (*domain.TimeTrackingSummary, error) {
+	args := m.Called(ctx, userID, daysBack)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TimeTrackingSummary), args.Error(1)
+}
+
 func (m *MockTaskRepository) GetVelocityMetrics(ctx context.Context, userID string, daysBack int) ([]repository.VelocityMetrics, error) {
 	args := m.Called(ctx, userID, daysBack)
 	if args.Get(0) == nil {
@@ -172,6 +200,14 @@ func (m *MockTaskRepository) GetCompletionTimeStats(ctx context.Context, userID
 	return args.Get(0).(*domain.CompletionTimeStats), args.Error(1)
 }
 
+func (m *MockTaskRepository) GetTrackedTimeStats(ctx context.Context, userID string, category *string, effort *domain.TaskEffort) (*domain.TrackedTimeStats, error) {
+	args := m.Called(ctx, userID, category, effort)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TrackedTimeStats), args.Error(1)
+}
+
 func (m *MockTaskRepository) GetCategoryDistribution(ctx context.Context, userID string) ([]domain.CategoryDistribution, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
@@ -188,6 +224,14 @@ func (m *MockTaskRepository) GetProductivityHeatmap(ctx context.Context, userID
 	return args.Get(0).(*domain.ProductivityHeatmap), args.Error(1)
 }
 
+func (m *MockTaskRepository) GetFocusHeatmap(ctx context.Context, userID string, daysBack int) (*domain.FocusHeatmap, error) {
+	args := m.Called(ctx, userID, daysBack)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FocusHeatmap), args.Error(1)
+}
+
 func (m *MockTaskRepository) GetCategoryTrends(ctx context.Context, userID string, daysBack int) (*domain.CategoryTrends, error) {
 	args := m.Called(ctx, userID, daysBack)
 	if args.Get(0) == nil {
@@ -196,8 +240,8 @@ func (m *MockTaskRepository) GetCategoryTrends(ctx context.Context, userID strin
 	return args.Get(0).(*domain.CategoryTrends), args.Error(1)
 }
 
-func (m *MockTaskRepository) BulkDelete(ctx context.Context, userID string, taskIDs []string) (int, []string, error) {
-	args := m.Called(ctx, userID, taskIDs)
+func (m *MockTaskRepository) BulkUpdateStatus(ctx context.Context, userID string, taskIDs []string, newStatus domain.TaskStatus) (int, []string, error) {
+	args := m.Called(ctx, userID, taskIDs, newStatus)
 	var failedIDs []string
 	if args.Get(1) != nil {
 		failedIDs = args.Get(1).([]string)
@@ -205,8 +249,8 @@ func (m *MockTaskRepository) BulkDelete(ctx context.Context, userID string, task
 	return args.Int(0), failedIDs, args.Error(2)
 }
 
-func (m *MockTaskRepository) BulkUpdateStatus(ctx context.Context, userID string, taskIDs []string, newStatus domain.TaskStatus) (int, []string, error) {
-	args := m.Called(ctx, userID, taskIDs, newStatus)
+func (m *MockTaskRepository) BulkRestore(ctx context.Context, userID string, taskIDs []string) (int, []string, error) {
+	args := m.Called(ctx, userID, taskIDs)
 	var failedIDs []string
 	if args.Get(1) != nil {
 		failedIDs = args.Get(1).([]string)
@@ -214,6 +258,54 @@ func (m *MockTaskRepository) BulkUpdateStatus(ctx context.Context, userID string
 	return args.Int(0), failedIDs, args.Error(2)
 }
 
+func (m *MockTaskRepository) CreateDuplicate(ctx context.Context, dup *domain.TaskDuplicate) error {
+	args := m.Called(ctx, dup)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) Convert(ctx context.Context, conv *domain.TaskConversion) error {
+	args := m.Called(ctx, conv)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) FindTrash(ctx context.Context, userID string, limit, offset int) ([]*domain.Task, int, error) {
+	args := m.Called(ctx, userID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*domain.Task), args.Int(1), args.Error(2)
+}
+
+func (m *MockTaskRepository) PurgeTrash(ctx context.Context, userID string, purge *domain.TrashPurge) (int, error) {
+	args := m.Called(ctx, userID, purge)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) FindUsersWithTrashBefore(ctx context.Context, cutoff time.Time) ([]string, error) {
+	args := m.Called(ctx, cutoff)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// Manual ordering methods
+
+func (m *MockTaskRepository) FindAdjacentRank(ctx context.Context, scope domain.RankScope, rank, excludeTaskID string, after bool) (string, error) {
+	args := m.Called(ctx, scope, rank, excludeTaskID, after)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) UpdateRank(ctx context.Context, task *domain.Task) error {
+	args := m.Called(ctx, task)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) RebalanceRanks(ctx context.Context, scope domain.RankScope) error {
+	args := m.Called(ctx, scope)
+	return args.Error(0)
+}
+
 // Subtask methods
 
 func (m *MockTaskRepository) GetSubtasks(ctx context.Context, parentTaskID string) ([]*domain.Task, error) {
@@ -224,12 +316,25 @@ func (m *MockTaskRepository) GetSubtasks(ctx context.Context, parentTaskID strin
 	return args.Get(0).([]*domain.Task), args.Error(1)
 }
 
-func (m *MockTaskRepository) GetSubtasksBatch(ctx context.Context, parentTaskIDs []string) (map[string][]*domain.Task, error) {
-	args := m.Called(ctx, parentTaskIDs)
+func (m *MockTaskRepository) GetSubtree(ctx context.Context, rootTaskID string) ([]*domain.Task, error) {
+	args := m.Called(ctx, rootTaskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetAncestorIDs(ctx context.Context, taskID string) ([]string, error) {
+	args := m.Called(ctx, taskID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(map[string][]*domain.Task), args.Error(1)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTaskRepository) UpdatePriorityScores(ctx context.Context, scores map[string]int) error {
+	args := m.Called(ctx, scores)
+	return args.Error(0)
 }
 
 func (m *MockTaskRepository) GetSubtaskInfo(ctx context.Context, parentTaskID string) (*domain.SubtaskInfo, error) {
@@ -307,12 +412,24 @@ func TestAnalyticsHandler_GetSummary_Success(t *testing.T) {
 		{Category: "Personal", TotalCount: 30, CompletedCount: 20, CompletionRate: 0.67},
 	}, nil)
 
+	mockRepo.On("GetTagBreakdown", mock.Anything, "user-123", 30).Return([]domain.TagStats{
+		{Tag: "urgent", TotalCount: 12, CompletedCount: 6, CompletionRate: 50},
+	}, nil)
+
 	mockRepo.On("GetPriorityDistribution", mock.Anything, "user-123").Return([]repository.PriorityDistribution{
 		{Range: "high", Count: 20},
 		{Range: "medium", Count: 50},
 		{Range: "low", Count: 30},
 	}, nil)
 
+	mockRepo.On("GetTimeTrackingSummary", mock.Anything, "user-123", 30).Return(&domain.TimeTrackingSummary{
+		TotalMinutes:               540,
+		TrackedTaskCount:           6,
+		CompletedTaskCount:         4,
+		AvgMinutesPerCompletedTask: 75,
+		CategoryBreakdown:          []domain.CategoryTimeStats{{Category: "Work", TotalMinutes: 540, TaskCount: 6}},
+	}, nil)
+
 	req := httptest.NewRequest("GET", "/analytics/summary", nil)
 	w := testutil.NewResponseRecorder()
 	router.ServeHTTP(w, req)
@@ -328,7 +445,11 @@ func TestAnalyticsHandler_GetSummary_Success(t *testing.T) {
 	assert.NotNil(t, response["completion_stats"])
 	assert.NotNil(t, response["bump_analytics"])
 	assert.NotNil(t, response["category_breakdown"])
+	assert.NotNil(t, response["tag_breakdown"])
 	assert.NotNil(t, response["priority_distribution"])
+	timeTracking := response["time_tracking"].(map[string]interface{})
+	assert.Equal(t, float64(540), timeTracking["total_minutes"])
+	assert.Equal(t, float64(75), timeTracking["avg_minutes_per_completed_task"])
 }
 
 func TestAnalyticsHandler_GetSummary_WithCustomDays(t *testing.T) {
@@ -352,7 +473,9 @@ func TestAnalyticsHandler_GetSummary_WithCustomDays(t *testing.T) {
 	}, nil)
 
 	mockRepo.On("GetCategoryBreakdown", mock.Anything, "user-123", 7).Return([]repository.CategoryStats{}, nil)
+	mockRepo.On("GetTagBreakdown", mock.Anything, "user-123", 7).Return([]domain.TagStats{}, nil)
 	mockRepo.On("GetPriorityDistribution", mock.Anything, "user-123").Return([]repository.PriorityDistribution{}, nil)
+	mockRepo.On("GetTimeTrackingSummary", mock.Anything, "user-123", 7).Return(&domain.TimeTrackingSummary{}, nil)
 
 	req := httptest.NewRequest("GET", "/analytics/summary?days=7", nil)
 	w := testutil.NewResponseRecorder()
@@ -379,7 +502,9 @@ func TestAnalyticsHandler_GetSummary_InvalidDaysParameter(t *testing.T) {
 		TasksByBumpCount: map[int]int{},
 	}, nil)
 	mockRepo.On("GetCategoryBreakdown", mock.Anything, "user-123", 30).Return([]repository.CategoryStats{}, nil)
+	mockRepo.On("GetTagBreakdown", mock.Anything, "user-123", 30).Return([]domain.TagStats{}, nil)
 	mockRepo.On("GetPriorityDistribution", mock.Anything, "user-123").Return([]repository.PriorityDistribution{}, nil)
+	mockRepo.On("GetTimeTrackingSummary", mock.Anything, "user-123", 30).Return(&domain.TimeTrackingSummary{}, nil)
 
 	req := httptest.NewRequest("GET", "/analytics/summary?days=invalid", nil)
 	w := testutil.NewResponseRecorder()
@@ -401,7 +526,9 @@ func TestAnalyticsHandler_GetSummary_DaysOutOfRange(t *testing.T) {
 		TasksByBumpCount: map[int]int{},
 	}, nil)
 	mockRepo.On("GetCategoryBreakdown", mock.Anything, "user-123", 30).Return([]repository.CategoryStats{}, nil)
+	mockRepo.On("GetTagBreakdown", mock.Anything, "user-123", 30).Return([]domain.TagStats{}, nil)
 	mockRepo.On("GetPriorityDistribution", mock.Anything, "user-123").Return([]repository.PriorityDistribution{}, nil)
+	mockRepo.On("GetTimeTrackingSummary", mock.Anything, "user-123", 30).Return(&domain.TimeTrackingSummary{}, nil)
 
 	req := httptest.NewRequest("GET", "/analytics/summary?days=500", nil)
 	w := testutil.NewResponseRecorder()
@@ -436,7 +563,9 @@ func TestAnalyticsHandler_GetSummary_DaysBoundaryValues(t *testing.T) {
 				TasksByBumpCount: map[int]int{},
 			}, nil)
 			mockRepo.On("GetCategoryBreakdown", mock.Anything, "user-123", tc.expectedDays).Return([]repository.CategoryStats{}, nil)
+			mockRepo.On("GetTagBreakdown", mock.Anything, "user-123", tc.expectedDays).Return([]domain.TagStats{}, nil)
 			mockRepo.On("GetPriorityDistribution", mock.Anything, "user-123").Return([]repository.PriorityDistribution{}, nil)
+			mockRepo.On("GetTimeTrackingSummary", mock.Anything, "user-123", tc.expectedDays).Return(&domain.TimeTrackingSummary{}, nil)
 
 			req := httptest.NewRequest("GET", "/analytics/summary?days="+tc.daysParam, nil)
 			w := testutil.NewResponseRecorder()
@@ -523,6 +652,28 @@ func TestAnalyticsHandler_GetSummary_CategoryBreakdownError(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestAnalyticsHandler_GetSummary_TagBreakdownError(t *testing.T) {
+	router, mockRepo := setupAnalyticsTest()
+	handler := NewAnalyticsHandler(mockRepo)
+
+	router.GET("/analytics/summary", testutil.WithAuthContext(router, "user-123", handler.GetSummary))
+
+	mockRepo.On("GetCompletionStats", mock.Anything, "user-123", 30).Return(&repository.CompletionStats{}, nil)
+	mockRepo.On("GetBumpAnalytics", mock.Anything, "user-123").Return(&repository.BumpAnalytics{
+		TasksByBumpCount: map[int]int{},
+	}, nil)
+	mockRepo.On("GetCategoryBreakdown", mock.Anything, "user-123", 30).Return([]repository.CategoryStats{}, nil)
+	mockRepo.On("GetTagBreakdown", mock.Anything, "user-123", 30).
+		Return(nil, domain.NewInternalError("database error", nil))
+
+	req := httptest.NewRequest("GET", "/analytics/summary", nil)
+	w := testutil.NewResponseRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestAnalyticsHandler_GetSummary_PriorityDistributionError(t *testing.T) {
 	router, mockRepo := setupAnalyticsTest()
 	handler := NewAnalyticsHandler(mockRepo)
@@ -534,6 +685,7 @@ func TestAnalyticsHandler_GetSummary_PriorityDistributionError(t *testing.T) {
 		TasksByBumpCount: map[int]int{},
 	}, nil)
 	mockRepo.On("GetCategoryBreakdown", mock.Anything, "user-123", 30).Return([]repository.CategoryStats{}, nil)
+	mockRepo.On("GetTagBreakdown", mock.Anything, "user-123", 30).Return([]domain.TagStats{}, nil)
 	mockRepo.On("GetPriorityDistribution", mock.Anything, "user-123").
 		Return(nil, domain.NewInternalError("database error", nil))
 
@@ -545,6 +697,30 @@ func TestAnalyticsHandler_GetSummary_PriorityDistributionError(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestAnalyticsHandler_GetSummary_TimeTrackingError(t *testing.T) {
+	router, mockRepo := setupAnalyticsTest()
+	handler := NewAnalyticsHandler(mockRepo)
+
+	router.GET("/analytics/summary", testutil.WithAuthContext(router, "user-123", handler.GetSummary))
+
+	mockRepo.On("GetCompletionStats", mock.Anything, "user-123", 30).Return(&repository.CompletionStats{}, nil)
+	mockRepo.On("GetBumpAnalytics", mock.Anything, "user-123").Return(&repository.BumpAnalytics{
+		TasksByBumpCount: map[int]int{},
+	}, nil)
+	mockRepo.On("GetCategoryBreakdown", mock.Anything, "user-123", 30).Return([]repository.CategoryStats{}, nil)
+	mockRepo.On("GetTagBreakdown", mock.Anything, "user-123", 30).Return([]domain.TagStats{}, nil)
+	mockRepo.On("GetPriorityDistribution", mock.Anything, "user-123").Return([]repository.PriorityDistribution{}, nil)
+	mockRepo.On("GetTimeTrackingSummary", mock.Anything, "user-123", 30).
+		Return(nil, domain.NewInternalError("database error", nil))
+
+	req := httptest.NewRequest("GET", "/analytics/summary", nil)
+	w := testutil.NewResponseRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestAnalyticsHandler_GetSummary_BumpDistributionMapping(t *testing.T) {
 	router, mockRepo := setupAnalyticsTest()
 	handler := NewAnalyticsHandler(mockRepo)
@@ -568,7 +744,9 @@ func TestAnalyticsHandler_GetSummary_BumpDistributionMapping(t *testing.T) {
 		AtRiskCount: 9,
 	}, nil)
 	mockRepo.On("GetCategoryBreakdown", mock.Anything, "user-123", 30).Return([]repository.CategoryStats{}, nil)
+	mockRepo.On("GetTagBreakdown", mock.Anything, "user-123", 30).Return([]domain.TagStats{}, nil)
 	mockRepo.On("GetPriorityDistribution", mock.Anything, "user-123").Return([]repository.PriorityDistribution{}, nil)
+	mockRepo.On("GetTimeTrackingSummary", mock.Anything, "user-123", 30).Return(&domain.TimeTrackingSummary{}, nil)
 
 	req := httptest.NewRequest("GET", "/analytics/summary", nil)
 	w := testutil.NewResponseRecorder()
@@ -853,6 +1031,56 @@ func TestAnalyticsHandler_GetProductivityHeatmap_Error(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+// =============================================================================
+// GetFocusHeatmap Tests
+// =============================================================================
+
+func TestAnalyticsHandler_GetFocusHeatmap_Success(t *testing.T) {
+	router, mockRepo := setupAnalyticsTest()
+	handler := NewAnalyticsHandler(mockRepo)
+
+	router.GET("/analytics/focus-heatmap", testutil.WithAuthContext(router, "user-123", handler.GetFocusHeatmap))
+
+	mockRepo.On("GetFocusHeatmap", mock.Anything, "user-123", 30).Return(&domain.FocusHeatmap{
+		Cells: []domain.FocusHeatmapCell{
+			{DayOfWeek: 1, Hour: 9, Minutes: 50},
+			{DayOfWeek: 2, Hour: 14, Minutes: 25},
+		},
+		MaxMinutes: 50,
+	}, nil)
+
+	req := httptest.NewRequest("GET", "/analytics/focus-heatmap?days=30", nil)
+	w := testutil.NewResponseRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockRepo.AssertExpectations(t)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(30), response["period_days"])
+	heatmap := response["heatmap"].(map[string]interface{})
+	assert.Equal(t, float64(50), heatmap["max_minutes"])
+	assert.Len(t, heatmap["cells"], 2)
+}
+
+func TestAnalyticsHandler_GetFocusHeatmap_Error(t *testing.T) {
+	router, mockRepo := setupAnalyticsTest()
+	handler := NewAnalyticsHandler(mockRepo)
+
+	router.GET("/analytics/focus-heatmap", testutil.WithAuthContext(router, "user-123", handler.GetFocusHeatmap))
+
+	mockRepo.On("GetFocusHeatmap", mock.Anything, "user-123", 90).
+		Return(nil, domain.NewInternalError("database error", nil))
+
+	req := httptest.NewRequest("GET", "/analytics/focus-heatmap", nil)
+	w := testutil.NewResponseRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
 // =============================================================================
 // GetCategoryTrends Tests
 // =============================================================================
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/middleware"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+)
+
+// multipartOverheadBytes allows for multipart boundaries and part headers on top of the file itself
+const multipartOverheadBytes = 1 << 20
+
+// AttachmentHandler handles HTTP requests for task attachments
+type AttachmentHandler struct {
+	attachmentService ports.AttachmentService
+	maxBytes          int64
+}
+
+// NewAttachmentHandler creates a new attachment handler.
+// maxBytes bounds the request body so oversized uploads are cut off while streaming.
+func NewAttachmentHandler(attachmentService ports.AttachmentService, maxBytes int64) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentService: attachmentService,
+		maxBytes:          maxBytes,
+	}
+}
+
+// UploadAttachment attaches a file to a task (multipart/form-data, field "file")
+// POST /api/v1/tasks/:id/attachments
+func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxBytes+multipartOverheadBytes)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			middleware.AbortWithError(c, domain.ErrAttachmentTooLarge)
+			return
+		}
+		middleware.AbortWithError(c, domain.NewValidationError("file", err.Error()))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		middleware.AbortWithError(c, domain.NewInternalError("failed to read upload", err))
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.attachmentService.Upload(c.Request.Context(), userID, c.Param("id"), &domain.AttachmentUpload{
+		Filename: fileHeader.Filename,
+		Size:     fileHeader.Size,
+		Content:  file,
+	})
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// ListAttachments retrieves a task's attachments and the user's storage usage
+// GET /api/v1/tasks/:id/attachments
+func (h *AttachmentHandler) ListAttachments(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	response, err := h.attachmentService.List(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DownloadAttachment streams an attachment's content
+// GET /api/v1/tasks/:id/attachments/:attachment_id/download
+func (h *AttachmentHandler) DownloadAttachment(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	attachment, reader, err := h.attachmentService.Open(c.Request.Context(), userID, c.Param("id"), c.Param("attachment_id"))
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+	defer reader.Close()
+
+	// Always download rather than render inline, and never let the browser re-sniff
+	c.DataFromReader(http.StatusOK, attachment.SizeBytes, attachment.ContentType, reader, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}),
+		"X-Content-Type-Options": "nosniff",
+		"Cache-Control":          "private, max-age=0",
+	})
+}
+
+// DeleteAttachment removes an attachment and its file
+// DELETE /api/v1/tasks/:id/attachments/:attachment_id
+func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	if err := h.attachmentService.Delete(c.Request.Context(), userID, c.Param("id"), c.Param("attachment_id")); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "attachment deleted",
+	})
+}
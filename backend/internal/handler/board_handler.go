@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/middleware"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+)
+
+// BoardHandler handles HTTP requests for the status board
+type BoardHandler struct {
+	boardService ports.BoardService
+}
+
+// NewBoardHandler creates a new board handler
+func NewBoardHandler(boardService ports.BoardService) *BoardHandler {
+	return &BoardHandler{boardService: boardService}
+}
+
+// GetBoard returns the user's tasks grouped by status.
+// ?limit= sets the page size per column; ?status=&cursor= pages through one column.
+// GET /api/v1/board
+func (h *BoardHandler) GetBoard(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	filter := &domain.BoardFilter{}
+	if statusStr := c.Query("status"); statusStr != "" {
+		status := domain.TaskStatus(statusStr)
+		filter.Status = &status
+	}
+	if cursorStr := c.Query("cursor"); cursorStr != "" {
+		filter.Cursor = &cursorStr
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			middleware.AbortWithError(c, domain.NewValidationError("limit", "must be a positive integer"))
+			return
+		}
+		filter.Limit = limit
+	}
+
+	board, err := h.boardService.GetBoard(c.Request.Context(), userID, filter)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, board)
+}
+
+// GetWIPLimits returns the user's per-column WIP limits
+// GET /api/v1/board/wip-limits
+func (h *BoardHandler) GetWIPLimits(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	limits, err := h.boardService.GetWIPLimits(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"limits": limits})
+}
+
+// UpdateWIPLimits replaces the user's per-column WIP limits
+// PUT /api/v1/board/wip-limits
+func (h *BoardHandler) UpdateWIPLimits(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	var dto domain.UpdateWIPLimitsDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		middleware.AbortWithError(c, domain.NewValidationError("request", err.Error()))
+		return
+	}
+
+	limits, err := h.boardService.UpdateWIPLimits(c.Request.Context(), userID, &dto)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"limits": limits})
+}
+
+// MoveTask moves a task to a column, and optionally between two of its tasks
+// POST /api/v1/board/tasks/:id/move
+func (h *BoardHandler) MoveTask(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	var req domain.MoveBoardTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, domain.NewValidationError("request", err.Error()))
+		return
+	}
+
+	ctx, err := withIfMatch(c)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	task, err := h.boardService.MoveTask(ctx, userID, c.Param("id"), &req)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.Header("ETag", task.ETag())
+	c.JSON(http.StatusOK, task)
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/middleware"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+)
+
+// CommentHandler handles HTTP requests for task comments
+type CommentHandler struct {
+	commentService ports.CommentService
+}
+
+// NewCommentHandler creates a new comment handler
+func NewCommentHandler(commentService ports.CommentService) *CommentHandler {
+	return &CommentHandler{commentService: commentService}
+}
+
+// CreateComment adds a comment to a task
+// POST /api/v1/tasks/:id/comments
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	var dto domain.CreateCommentDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		middleware.AbortWithError(c, domain.NewValidationError("request", err.Error()))
+		return
+	}
+
+	comment, err := h.commentService.Create(c.Request.Context(), userID, c.Param("id"), &dto)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+// ListComments retrieves a task's comments, oldest first
+// GET /api/v1/tasks/:id/comments
+func (h *CommentHandler) ListComments(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	comments, err := h.commentService.List(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.CommentListResponse{
+		Comments:   comments,
+		TotalCount: len(comments),
+	})
+}
+
+// UpdateComment edits a comment's body
+// PUT /api/v1/tasks/:id/comments/:comment_id
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	var dto domain.UpdateCommentDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		middleware.AbortWithError(c, domain.NewValidationError("request", err.Error()))
+		return
+	}
+
+	comment, err := h.commentService.Update(c.Request.Context(), userID, c.Param("id"), c.Param("comment_id"), &dto)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+// DeleteComment soft-deletes a comment
+// DELETE /api/v1/tasks/:id/comments/:comment_id
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	if err := h.commentService.Delete(c.Request.Context(), userID, c.Param("id"), c.Param("comment_id")); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "comment deleted",
+	})
+}
+
+// ListCommentEdits retrieves a comment's edit history
+// GET /api/v1/tasks/:id/comments/:comment_id/edits
+func (h *CommentHandler) ListCommentEdits(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	result, err := h.commentService.ListEdits(c.Request.Context(), userID, c.Param("id"), c.Param("comment_id"))
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
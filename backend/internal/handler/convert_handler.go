@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/middleware"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+)
+
+// ConvertHandler handles HTTP requests for changing a task's type
+type ConvertHandler struct {
+	convertService ports.ConvertService
+}
+
+// NewConvertHandler creates a new convert handler
+func NewConvertHandler(convertService ports.ConvertService) *ConvertHandler {
+	return &ConvertHandler{convertService: convertService}
+}
+
+// Convert changes a task between the regular, subtask and recurring types
+// POST /api/v1/tasks/:id/convert
+func (h *ConvertHandler) Convert(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	var req domain.ConvertTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, domain.NewValidationError("request", err.Error()))
+		return
+	}
+
+	ctx, err := withIfMatch(c)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	task, err := h.convertService.Convert(ctx, userID, c.Param("id"), &req)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.Header("ETag", task.ETag())
+	c.JSON(http.StatusOK, task)
+}
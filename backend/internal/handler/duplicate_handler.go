@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/middleware"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+)
+
+// DuplicateHandler handles HTTP requests for duplicating tasks
+type DuplicateHandler struct {
+	duplicateService ports.DuplicateService
+}
+
+// NewDuplicateHandler creates a new duplicate handler
+func NewDuplicateHandler(duplicateService ports.DuplicateService) *DuplicateHandler {
+	return &DuplicateHandler{duplicateService: duplicateService}
+}
+
+// Duplicate creates a copy of a task
+// POST /api/v1/tasks/:id/duplicate
+func (h *DuplicateHandler) Duplicate(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	// The body is optional: without one the task alone is copied as-is
+	var req domain.DuplicateTaskRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			middleware.AbortWithError(c, domain.NewValidationError("request", err.Error()))
+			return
+		}
+	}
+
+	result, err := h.duplicateService.Duplicate(c.Request.Context(), userID, c.Param("id"), &req)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
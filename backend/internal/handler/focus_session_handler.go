@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/middleware"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+)
+
+// FocusSessionHandler handles HTTP requests for focus sessions
+type FocusSessionHandler struct {
+	focusService ports.FocusSessionService
+}
+
+// NewFocusSessionHandler creates a new focus session handler
+func NewFocusSessionHandler(focusService ports.FocusSessionService) *FocusSessionHandler {
+	return &FocusSessionHandler{focusService: focusService}
+}
+
+// GetSettings returns the user's work and break lengths
+// GET /api/v1/focus/settings
+func (h *FocusSessionHandler) GetSettings(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	settings, err := h.focusService.GetSettings(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateSettings changes the user's work and break lengths
+// PUT /api/v1/focus/settings
+func (h *FocusSessionHandler) UpdateSettings(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	var dto domain.UpdateFocusSettingsDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		middleware.AbortWithError(c, domain.NewValidationError("request", err.Error()))
+		return
+	}
+
+	settings, err := h.focusService.UpdateSettings(c.Request.Context(), userID, &dto)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// StartSession starts a focus session on a task. The body ({"planned_minutes": 50}) is optional.
+// POST /api/v1/tasks/:id/focus-sessions
+func (h *FocusSessionHandler) StartSession(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	var dto domain.StartFocusSessionDTO
+	if err := c.ShouldBindJSON(&dto); err != nil && !errors.Is(err, io.EOF) {
+		middleware.AbortWithError(c, domain.NewValidationError("request", err.Error()))
+		return
+	}
+
+	session, err := h.focusService.Start(c.Request.Context(), userID, c.Param("id"), &dto)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, session)
+}
+
+// ListTaskSessions retrieves a task's focus sessions with session counts
+// GET /api/v1/tasks/:id/focus-sessions
+func (h *FocusSessionHandler) ListTaskSessions(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	response, err := h.focusService.ListForTask(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetActiveSession returns the user's running or paused session, if any
+// GET /api/v1/focus/current
+func (h *FocusSessionHandler) GetActiveSession(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	session, err := h.focusService.GetActive(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"session": session,
+	})
+}
+
+// PauseSession pauses a running session
+// POST /api/v1/focus/sessions/:session_id/pause
+func (h *FocusSessionHandler) PauseSession(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	session, err := h.focusService.Pause(c.Request.Context(), userID, c.Param("session_id"))
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// ResumeSession resumes a paused session
+// POST /api/v1/focus/sessions/:session_id/resume
+func (h *FocusSessionHandler) ResumeSession(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	session, err := h.focusService.Resume(c.Request.Context(), userID, c.Param("session_id"))
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// CompleteSession completes a session and suggests the next break
+// POST /api/v1/focus/sessions/:session_id/complete
+func (h *FocusSessionHandler) CompleteSession(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	completion, err := h.focusService.Complete(c.Request.Context(), userID, c.Param("session_id"))
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, completion)
+}
+
+// InterruptSession abandons a session with a reason
+// POST /api/v1/focus/sessions/:session_id/interrupt
+func (h *FocusSessionHandler) InterruptSession(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	var dto domain.InterruptFocusSessionDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		middleware.AbortWithError(c, domain.NewValidationError("request", err.Error()))
+		return
+	}
+
+	session, err := h.focusService.Interrupt(c.Request.Context(), userID, c.Param("session_id"), &dto)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
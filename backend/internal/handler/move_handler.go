@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/middleware"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+)
+
+// MoveHandler handles HTTP requests for arranging tasks by hand
+type MoveHandler struct {
+	moveService ports.MoveService
+}
+
+// NewMoveHandler creates a new move handler
+func NewMoveHandler(moveService ports.MoveService) *MoveHandler {
+	return &MoveHandler{moveService: moveService}
+}
+
+// Move places a task between two neighbors in its list
+// POST /api/v1/tasks/:id/move
+func (h *MoveHandler) Move(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	var req domain.MoveTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, domain.NewValidationError("request", err.Error()))
+		return
+	}
+
+	ctx, err := withIfMatch(c)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	task, err := h.moveService.Move(ctx, userID, c.Param("id"), &req)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.Header("ETag", task.ETag())
+	c.JSON(http.StatusOK, task)
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/middleware"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+)
+
+// ReminderHandler handles HTTP requests for task reminders
+type ReminderHandler struct {
+	reminderService ports.ReminderService
+}
+
+// NewReminderHandler creates a new reminder handler
+func NewReminderHandler(reminderService ports.ReminderService) *ReminderHandler {
+	return &ReminderHandler{reminderService: reminderService}
+}
+
+// Create adds a reminder to a task, either at a set time or relative to its due date
+// POST /api/v1/tasks/:id/reminders
+func (h *ReminderHandler) Create(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	var dto domain.CreateReminderDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		middleware.AbortWithError(c, domain.NewValidationError("request", err.Error()))
+		return
+	}
+
+	reminder, err := h.reminderService.Create(c.Request.Context(), userID, c.Param("id"), &dto)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, reminder)
+}
+
+// List retrieves a task's reminders, soonest first
+// GET /api/v1/tasks/:id/reminders
+func (h *ReminderHandler) List(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	reminders, err := h.reminderService.List(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reminders": reminders,
+	})
+}
+
+// Delete removes a reminder from a task
+// DELETE /api/v1/tasks/:id/reminders/:reminder_id
+func (h *ReminderHandler) Delete(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	if err := h.reminderService.Delete(c.Request.Context(), userID, c.Param("id"), c.Param("reminder_id")); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "reminder deleted",
+	})
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/middleware"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+)
+
+// SavedViewHandler handles HTTP requests for saved views
+type SavedViewHandler struct {
+	viewService ports.SavedViewService
+}
+
+// NewSavedViewHandler creates a new saved view handler
+func NewSavedViewHandler(viewService ports.SavedViewService) *SavedViewHandler {
+	return &SavedViewHandler{viewService: viewService}
+}
+
+// CreateView creates a new saved view
+// POST /api/v1/views
+func (h *SavedViewHandler) CreateView(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	var dto domain.CreateSavedViewDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		middleware.AbortWithError(c, domain.NewValidationError("request", err.Error()))
+		return
+	}
+
+	view, err := h.viewService.Create(c.Request.Context(), userID, &dto)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, view)
+}
+
+// GetView retrieves a specific saved view by ID
+// GET /api/v1/views/:id
+func (h *SavedViewHandler) GetView(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	view, err := h.viewService.Get(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// ListViews retrieves all saved views for the authenticated user
+// GET /api/v1/views
+func (h *SavedViewHandler) ListViews(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	views, err := h.viewService.List(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SavedViewListResponse{
+		Views:      views,
+		TotalCount: len(views),
+	})
+}
+
+// UpdateView updates an existing saved view
+// PUT /api/v1/views/:id
+func (h *SavedViewHandler) UpdateView(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	var dto domain.UpdateSavedViewDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		middleware.AbortWithError(c, domain.NewValidationError("request", err.Error()))
+		return
+	}
+
+	view, err := h.viewService.Update(c.Request.Context(), userID, c.Param("id"), &dto)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// DeleteView removes a saved view
+// DELETE /api/v1/views/:id
+func (h *SavedViewHandler) DeleteView(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	if err := h.viewService.Delete(c.Request.Context(), userID, c.Param("id")); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "view deleted",
+	})
+}
+
+// ListViewTasks runs a saved view and returns the matching tasks
+// GET /api/v1/views/:id/tasks?limit=&cursor=
+func (h *SavedViewHandler) ListViewTasks(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	limit := DefaultLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 {
+			// Clamp to MaxLimit to prevent memory issues
+			if parsed > MaxLimit {
+				parsed = MaxLimit
+			}
+			limit = parsed
+		}
+	}
+
+	page, err := h.viewService.ListTasks(c.Request.Context(), userID, c.Param("id"), c.Query("cursor"), limit)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
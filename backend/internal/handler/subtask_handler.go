@@ -119,6 +119,24 @@ func (h *SubtaskHandler) GetTaskExpanded(c *gin.Context) {
 	c.JSON(http.StatusOK, taskWithSubtasks)
 }
 
+// GetTree retrieves a task with its whole subtree, nested level by level
+// GET /api/v1/tasks/:id/tree
+func (h *SubtaskHandler) GetTree(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	tree, err := h.subtaskService.GetTree(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tree)
+}
+
 // CompleteSubtask marks a subtask as complete and returns parent completion prompt info
 // POST /api/v1/subtasks/:id/complete
 func (h *SubtaskHandler) CompleteSubtask(c *gin.Context) {
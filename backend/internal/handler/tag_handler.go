@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/middleware"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+)
+
+// TagHandler handles HTTP requests for tags
+type TagHandler struct {
+	tagService ports.TagService
+}
+
+// NewTagHandler creates a new tag handler
+func NewTagHandler(tagService ports.TagService) *TagHandler {
+	return &TagHandler{tagService: tagService}
+}
+
+// CreateTag creates a new tag
+// POST /api/v1/tags
+func (h *TagHandler) CreateTag(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	var dto domain.CreateTagDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		middleware.AbortWithError(c, domain.NewValidationError("request", err.Error()))
+		return
+	}
+
+	tag, err := h.tagService.Create(c.Request.Context(), userID, &dto)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, tag)
+}
+
+// GetTag retrieves a specific tag by ID
+// GET /api/v1/tags/:id
+func (h *TagHandler) GetTag(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	tag, err := h.tagService.Get(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+// ListTags retrieves all tags for the authenticated user
+// GET /api/v1/tags
+func (h *TagHandler) ListTags(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	tags, err := h.tagService.List(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.TagListResponse{
+		Tags:       tags,
+		TotalCount: len(tags),
+	})
+}
+
+// UpdateTag renames or recolors a tag
+// PUT /api/v1/tags/:id
+func (h *TagHandler) UpdateTag(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	var dto domain.UpdateTagDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		middleware.AbortWithError(c, domain.NewValidationError("request", err.Error()))
+		return
+	}
+
+	tag, err := h.tagService.Update(c.Request.Context(), userID, c.Param("id"), &dto)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+// DeleteTag removes a tag from every task and deletes it
+// DELETE /api/v1/tags/:id
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	if err := h.tagService.Delete(c.Request.Context(), userID, c.Param("id")); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "tag deleted",
+	})
+}
+
+// MergeTag merges the tag into another tag, deleting it
+// POST /api/v1/tags/:id/merge
+func (h *TagHandler) MergeTag(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	var dto domain.MergeTagsDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		middleware.AbortWithError(c, domain.NewValidationError("request", err.Error()))
+		return
+	}
+
+	result, err := h.tagService.Merge(c.Request.Context(), userID, c.Param("id"), dto.TargetTagID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/notkevinvu/taskflow/backend/internal/domain"
@@ -67,7 +69,11 @@ func (h *TaskHandler) Create(c *gin.Context) {
 }
 
 // List handles task listing with filters
-// GET /api/v1/tasks?status=&category=&search=&min_priority=&max_priority=&due_date_start=&due_date_end=&limit=&offset=
+// GET /api/v1/tasks?status=&category=&search=&min_priority=&max_priority=&due_date_start=&due_date_end=&include_deferred=&sort=&limit=&offset=&cursor=
+// search accepts the task query language (e.g. search=category:work due:<7d -status:done)
+// sort is a comma-separated list of fields, "-" prefix for descending (e.g. sort=due_date,-priority_score)
+// Prefer cursor over offset for deep pages - the response includes next_cursor when more tasks exist
+// Snoozed tasks (defer_until in the future) are hidden unless include_deferred=true
 func (h *TaskHandler) List(c *gin.Context) {
 	userID, exists := middleware.GetUserID(c)
 	if !exists {
@@ -86,12 +92,30 @@ func (h *TaskHandler) List(c *gin.Context) {
 		filter.Status = &status
 	}
 
+	// A status of the user's workflow, e.g. ?workflow_status=in_review
+	if workflowStatusStr := c.Query("workflow_status"); workflowStatusStr != "" {
+		workflowStatus := domain.TaskStatus(workflowStatusStr)
+		filter.WorkflowStatus = &workflowStatus
+	}
+
 	if category := c.Query("category"); category != "" {
 		filter.Category = &category
 	}
 
+	// Repeatable: ?tag=work&tag=urgent matches tasks carrying both
+	for _, tag := range c.QueryArray("tag") {
+		if tag != "" {
+			filter.Tags = append(filter.Tags, tag)
+		}
+	}
+
 	if search := c.Query("search"); search != "" {
-		filter.Search = &search
+		query, err := domain.ParseTaskQuery(search, time.Now())
+		if err != nil {
+			middleware.AbortWithError(c, err)
+			return
+		}
+		filter.Query = query
 	}
 
 	if limitStr := c.Query("limit"); limitStr != "" {
@@ -110,6 +134,28 @@ func (h *TaskHandler) List(c *gin.Context) {
 		}
 	}
 
+	if sortStr := c.Query("sort"); sortStr != "" {
+		sorts, err := domain.ParseTaskSort(sortStr)
+		if err != nil {
+			middleware.AbortWithError(c, err)
+			return
+		}
+		filter.Sort = sorts
+	}
+
+	if cursorStr := c.Query("cursor"); cursorStr != "" {
+		if filter.Offset > 0 {
+			middleware.AbortWithError(c, domain.NewValidationError("cursor", "cannot be combined with offset"))
+			return
+		}
+		cursor, err := domain.DecodeTaskCursor(cursorStr, filter.SortOrder())
+		if err != nil {
+			middleware.AbortWithError(c, err)
+			return
+		}
+		filter.Cursor = cursor
+	}
+
 	if minPriorityStr := c.Query("min_priority"); minPriorityStr != "" {
 		minPriority, err := strconv.Atoi(minPriorityStr)
 		if err != nil {
@@ -162,21 +208,22 @@ func (h *TaskHandler) List(c *gin.Context) {
 		filter.DueDateEnd = &dueDateEnd
 	}
 
-	tasks, err := h.taskService.List(c.Request.Context(), userID, filter)
+	if includeDeferredStr := c.Query("include_deferred"); includeDeferredStr != "" {
+		includeDeferred, err := strconv.ParseBool(includeDeferredStr)
+		if err != nil {
+			middleware.AbortWithError(c, domain.NewValidationError("include_deferred", "must be true or false"))
+			return
+		}
+		filter.IncludeDeferred = includeDeferred
+	}
+
+	page, err := h.taskService.ListPage(c.Request.Context(), userID, filter)
 	if err != nil {
 		middleware.AbortWithError(c, err)
 		return
 	}
 
-	// Return empty array instead of null if no tasks
-	if tasks == nil {
-		tasks = []*domain.Task{}
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"tasks":       tasks,
-		"total_count": len(tasks),
-	})
+	c.JSON(http.StatusOK, page)
 }
 
 // Get handles fetching a single task
@@ -196,6 +243,7 @@ func (h *TaskHandler) Get(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", task.ETag())
 	c.JSON(http.StatusOK, task)
 }
 
@@ -210,18 +258,58 @@ func (h *TaskHandler) Update(c *gin.Context) {
 
 	taskID := c.Param("id")
 
+	ctx, err := withIfMatch(c)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
 	var dto domain.UpdateTaskDTO
 	if err := c.ShouldBindJSON(&dto); err != nil {
 		middleware.AbortWithError(c, domain.NewValidationError("request_body", "invalid JSON format"))
 		return
 	}
 
-	task, err := h.taskService.Update(c.Request.Context(), userID, taskID, &dto)
+	task, err := h.taskService.Update(ctx, userID, taskID, &dto)
 	if err != nil {
 		middleware.AbortWithError(c, err)
 		return
 	}
 
+	c.Header("ETag", task.ETag())
+	c.JSON(http.StatusOK, task)
+}
+
+// Patch handles JSON merge patch updates; null clears optional fields
+// PATCH /api/v1/tasks/:id
+func (h *TaskHandler) Patch(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	taskID := c.Param("id")
+
+	ctx, err := withIfMatch(c)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	var patch domain.TaskMergePatch
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		middleware.AbortWithError(c, domain.NewValidationError("request_body", "body must be a JSON merge patch object"))
+		return
+	}
+
+	task, err := h.taskService.Patch(ctx, userID, taskID, &patch)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.Header("ETag", task.ETag())
 	c.JSON(http.StatusOK, task)
 }
 
@@ -236,6 +324,12 @@ func (h *TaskHandler) Delete(c *gin.Context) {
 
 	taskID := c.Param("id")
 
+	ctx, err := withIfMatch(c)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
 	// Get task before deletion to record metrics
 	task, getErr := h.taskService.Get(c.Request.Context(), userID, taskID)
 	if getErr != nil {
@@ -247,8 +341,7 @@ func (h *TaskHandler) Delete(c *gin.Context) {
 		)
 	}
 
-	err := h.taskService.Delete(c.Request.Context(), userID, taskID)
-	if err != nil {
+	if err := h.taskService.Delete(ctx, userID, taskID); err != nil {
 		middleware.AbortWithError(c, err)
 		return
 	}
@@ -276,7 +369,13 @@ func (h *TaskHandler) Bump(c *gin.Context) {
 
 	taskID := c.Param("id")
 
-	task, err := h.taskService.Bump(c.Request.Context(), userID, taskID)
+	ctx, err := withIfMatch(c)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	task, err := h.taskService.Bump(ctx, userID, taskID)
 	if err != nil {
 		middleware.AbortWithError(c, err)
 		return
@@ -289,12 +388,85 @@ func (h *TaskHandler) Bump(c *gin.Context) {
 	}
 	metrics.RecordTaskBumped(category)
 
+	c.Header("ETag", task.ETag())
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Task bumped successfully",
 		"task":    task,
 	})
 }
 
+// GetHistory handles listing a task's history with field-level changes, newest first
+// GET /api/v1/tasks/:id/history?event_type=updated,status_changed&limit=&offset=
+// event_type may be repeated or comma-separated; omit it for every event
+func (h *TaskHandler) GetHistory(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	filter := &domain.TaskHistoryFilter{Limit: DefaultLimit}
+	for _, value := range c.QueryArray("event_type") {
+		for _, eventType := range splitAndTrim(value, ",") {
+			filter.EventTypes = append(filter.EventTypes, domain.TaskHistoryEventType(eventType))
+		}
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
+			if limit > MaxLimit {
+				limit = MaxLimit
+			}
+			filter.Limit = limit
+		}
+	}
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+			filter.Offset = offset
+		}
+	}
+
+	page, err := h.taskService.GetHistory(c.Request.Context(), userID, c.Param("id"), filter)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// Snooze handles hiding a task until a start date without bumping it
+// POST /api/v1/tasks/:id/snooze with {"preset": "tonight" | "tomorrow" | "next_week"} or {"until": "<RFC 3339 time>"}
+func (h *TaskHandler) Snooze(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	taskID := c.Param("id")
+
+	var dto domain.SnoozeTaskDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		middleware.AbortWithError(c, domain.NewValidationError("request", err.Error()))
+		return
+	}
+
+	ctx, err := withIfMatch(c)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	task, err := h.taskService.Snooze(ctx, userID, taskID, &dto)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.Header("ETag", task.ETag())
+	c.JSON(http.StatusOK, task)
+}
+
 // Complete handles marking a task as complete
 // POST /api/v1/tasks/:id/complete
 func (h *TaskHandler) Complete(c *gin.Context) {
@@ -306,7 +478,13 @@ func (h *TaskHandler) Complete(c *gin.Context) {
 
 	taskID := c.Param("id")
 
-	task, err := h.taskService.Complete(c.Request.Context(), userID, taskID)
+	ctx, err := withIfMatch(c)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	task, err := h.taskService.Complete(ctx, userID, taskID)
 	if err != nil {
 		middleware.AbortWithError(c, err)
 		return
@@ -323,6 +501,7 @@ func (h *TaskHandler) Complete(c *gin.Context) {
 	}
 	metrics.RecordTaskCompleted(category, effort)
 
+	c.Header("ETag", task.ETag())
 	c.JSON(http.StatusOK, task)
 }
 
@@ -435,6 +614,27 @@ func (h *TaskHandler) GetCalendar(c *gin.Context) {
 	c.JSON(http.StatusOK, calendar)
 }
 
+// withIfMatch returns the request context carrying the If-Match precondition, if one
+// was sent, so the service can reject writes made against a stale copy of the task
+func withIfMatch(c *gin.Context) (context.Context, error) {
+	ctx := c.Request.Context()
+
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		return ctx, nil
+	}
+
+	version, ok, err := domain.ParseIfMatch(header)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return ctx, nil
+	}
+
+	return domain.WithExpectedVersion(ctx, version), nil
+}
+
 // splitAndTrim splits a string by delimiter and trims whitespace from each part.
 // Uses standard library functions for correctness and performance.
 func splitAndTrim(s, delimiter string) []string {
@@ -475,7 +675,7 @@ func (h *TaskHandler) BulkDelete(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-// BulkRestore handles bulk task restoration (done -> todo)
+// BulkRestore handles bulk task restoration (done or trashed -> todo)
 // POST /api/v1/tasks/bulk-restore
 func (h *TaskHandler) BulkRestore(c *gin.Context) {
 	userID, exists := middleware.GetUserID(c)
@@ -498,3 +698,67 @@ func (h *TaskHandler) BulkRestore(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+// BulkUpdate handles applying one patch to many tasks
+// POST /api/v1/tasks/bulk-update
+func (h *TaskHandler) BulkUpdate(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	var req domain.BulkUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, domain.NewValidationError("request_body", "invalid JSON format"))
+		return
+	}
+
+	response, err := h.taskService.BulkUpdate(c.Request.Context(), userID, &req)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// BulkComplete handles completing many tasks in dependency order
+// POST /api/v1/tasks/bulk-complete
+func (h *TaskHandler) BulkComplete(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	var req domain.BulkOperationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, domain.NewValidationError("request_body", "invalid JSON format"))
+		return
+	}
+
+	response, err := h.taskService.BulkComplete(c.Request.Context(), userID, req.TaskIDs)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	// Record metrics for each completed task
+	for _, result := range response.Results {
+		if !result.Success {
+			continue
+		}
+		category := ""
+		if result.CompletedTask.Category != nil {
+			category = *result.CompletedTask.Category
+		}
+		effort := "medium"
+		if result.CompletedTask.EstimatedEffort != nil {
+			effort = string(*result.CompletedTask.EstimatedEffort)
+		}
+		metrics.RecordTaskCompleted(category, effort)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
@@ -45,6 +46,14 @@ func (m *MockTaskService) List(ctx context.Context, userID string, filter *domai
 	return args.Get(0).([]*domain.Task), args.Error(1)
 }
 
+func (m *MockTaskService) ListPage(ctx context.Context, userID string, filter *domain.TaskListFilter) (*domain.TaskListPage, error) {
+	args := m.Called(ctx, userID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TaskListPage), args.Error(1)
+}
+
 func (m *MockTaskService) Update(ctx context.Context, userID, taskID string, dto *domain.UpdateTaskDTO) (*domain.Task, error) {
 	args := m.Called(ctx, userID, taskID, dto)
 	if args.Get(0) == nil {
@@ -53,6 +62,14 @@ func (m *MockTaskService) Update(ctx context.Context, userID, taskID string, dto
 	return args.Get(0).(*domain.Task), args.Error(1)
 }
 
+func (m *MockTaskService) Patch(ctx context.Context, userID, taskID string, patch *domain.TaskMergePatch) (*domain.Task, error) {
+	args := m.Called(ctx, userID, taskID, patch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Task), args.Error(1)
+}
+
 func (m *MockTaskService) Delete(ctx context.Context, userID, taskID string) error {
 	args := m.Called(ctx, userID, taskID)
 	return args.Error(0)
@@ -66,6 +83,22 @@ func (m *MockTaskService) Bump(ctx context.Context, userID, taskID string) (*dom
 	return args.Get(0).(*domain.Task), args.Error(1)
 }
 
+func (m *MockTaskService) GetHistory(ctx context.Context, userID, taskID string, filter *domain.TaskHistoryFilter) (*domain.TaskHistoryPage, error) {
+	args := m.Called(ctx, userID, taskID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TaskHistoryPage), args.Error(1)
+}
+
+func (m *MockTaskService) Snooze(ctx context.Context, userID, taskID string, dto *domain.SnoozeTaskDTO) (*domain.Task, error) {
+	args := m.Called(ctx, userID, taskID, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Task), args.Error(1)
+}
+
 func (m *MockTaskService) Complete(ctx context.Context, userID, taskID string) (*domain.Task, error) {
 	args := m.Called(ctx, userID, taskID)
 	if args.Get(0) == nil {
@@ -124,6 +157,22 @@ func (m *MockTaskService) BulkRestore(ctx context.Context, userID string, taskID
 	return args.Get(0).(*domain.BulkOperationResponse), args.Error(1)
 }
 
+func (m *MockTaskService) BulkUpdate(ctx context.Context, userID string, req *domain.BulkUpdateRequest) (*domain.BulkOperationResponse, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BulkOperationResponse), args.Error(1)
+}
+
+func (m *MockTaskService) BulkComplete(ctx context.Context, userID string, taskIDs []string) (*domain.BulkCompleteResponse, error) {
+	args := m.Called(ctx, userID, taskIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BulkCompleteResponse), args.Error(1)
+}
+
 func (m *MockTaskService) Restore(ctx context.Context, userID, taskID string) (*domain.Task, error) {
 	args := m.Called(ctx, userID, taskID)
 	if args.Get(0) == nil {
@@ -277,9 +326,9 @@ func TestTaskHandler_List_Success(t *testing.T) {
 		testutil.NewTaskBuilder().WithID("task-2").WithTitle("Task 2").Build(),
 	}
 
-	mockService.On("List", mock.Anything, "user-123", mock.MatchedBy(func(filter *domain.TaskListFilter) bool {
-		return filter.Limit == 20 && filter.Offset == 0
-	})).Return(expectedTasks, nil)
+	mockService.On("ListPage", mock.Anything, "user-123", mock.MatchedBy(func(filter *domain.TaskListFilter) bool {
+		return filter.Limit == 20 && filter.Offset == 0 && filter.Cursor == nil
+	})).Return(&domain.TaskListPage{Tasks: expectedTasks, TotalCount: 45}, nil)
 
 	// Create request
 	req := httptest.NewRequest("GET", "/tasks", nil)
@@ -296,7 +345,10 @@ func TestTaskHandler_List_Success(t *testing.T) {
 	var response map[string]interface{}
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Equal(t, float64(2), response["total_count"])
+	assert.Equal(t, float64(45), response["total_count"])
+	assert.Len(t, response["tasks"], 2)
+	assert.Contains(t, response, "next_cursor")
+	assert.Nil(t, response["next_cursor"])
 }
 
 // TestTaskHandler_List_WithFilters tests task listing with filters
@@ -311,11 +363,11 @@ func TestTaskHandler_List_WithFilters(t *testing.T) {
 		testutil.NewTaskBuilder().WithID("task-1").WithStatus(domain.TaskStatusTodo).Build(),
 	}
 
-	mockService.On("List", mock.Anything, "user-123", mock.MatchedBy(func(filter *domain.TaskListFilter) bool {
+	mockService.On("ListPage", mock.Anything, "user-123", mock.MatchedBy(func(filter *domain.TaskListFilter) bool {
 		return filter.Status != nil && *filter.Status == domain.TaskStatusTodo &&
 			filter.Category != nil && *filter.Category == "work" &&
 			filter.Limit == 10 && filter.Offset == 5
-	})).Return(expectedTasks, nil)
+	})).Return(&domain.TaskListPage{Tasks: expectedTasks, TotalCount: 1}, nil)
 
 	// Create request with filters
 	req := httptest.NewRequest("GET", "/tasks?status=todo&category=work&limit=10&offset=5", nil)
@@ -337,8 +389,8 @@ func TestTaskHandler_List_EmptyResult(t *testing.T) {
 	router.GET("/tasks", testutil.WithAuthContext(router, "user-123", handler.List))
 
 	// Mock empty result
-	mockService.On("List", mock.Anything, "user-123", mock.Anything).
-		Return(nil, nil)
+	mockService.On("ListPage", mock.Anything, "user-123", mock.Anything).
+		Return(&domain.TaskListPage{Tasks: []*domain.Task{}}, nil)
 
 	// Create request
 	req := httptest.NewRequest("GET", "/tasks", nil)
@@ -375,7 +427,7 @@ func TestTaskHandler_List_InvalidMinPriority(t *testing.T) {
 
 	// Assert
 	assert.Equal(t, http.StatusBadRequest, w.Code)
-	mockService.AssertNotCalled(t, "List")
+	mockService.AssertNotCalled(t, "ListPage")
 }
 
 // TestTaskHandler_List_InvalidPriorityRange tests validation of priority range
@@ -394,7 +446,228 @@ func TestTaskHandler_List_InvalidPriorityRange(t *testing.T) {
 
 	// Assert
 	assert.Equal(t, http.StatusBadRequest, w.Code)
-	mockService.AssertNotCalled(t, "List")
+	mockService.AssertNotCalled(t, "ListPage")
+}
+
+// TestTaskHandler_List_WithCursor tests that a cursor is decoded into the filter
+func TestTaskHandler_List_WithCursor(t *testing.T) {
+	router, mockService := setupTaskTest()
+	handler := NewTaskHandler(mockService)
+
+	router.GET("/tasks", testutil.WithAuthContext(router, "user-123", handler.List))
+
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	cursor := domain.NewTaskCursor(&domain.Task{
+		ID:            "3f0a6c1e-8d5b-4b7a-9c2e-1a2b3c4d5e6f",
+		PriorityScore: 72,
+		CreatedAt:     createdAt,
+	}, domain.DefaultTaskSort).Encode()
+	nextCursor := "next-page"
+
+	mockService.On("ListPage", mock.Anything, "user-123", mock.MatchedBy(func(filter *domain.TaskListFilter) bool {
+		return filter.Cursor != nil &&
+			filter.Cursor.Values[0] == 72 &&
+			filter.Cursor.Values[1].(time.Time).Equal(createdAt) &&
+			filter.Cursor.ID == "3f0a6c1e-8d5b-4b7a-9c2e-1a2b3c4d5e6f"
+	})).Return(&domain.TaskListPage{
+		Tasks:      []*domain.Task{testutil.NewTaskBuilder().WithID("task-1").Build()},
+		TotalCount: 30,
+		NextCursor: &nextCursor,
+	}, nil)
+
+	req := httptest.NewRequest("GET", "/tasks?cursor="+cursor, nil)
+
+	w := testutil.NewResponseRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "next-page", response["next_cursor"])
+	assert.Equal(t, float64(30), response["total_count"])
+}
+
+// TestTaskHandler_List_InvalidCursor tests validation of the cursor parameter
+func TestTaskHandler_List_InvalidCursor(t *testing.T) {
+	router, mockService := setupTaskTest()
+	handler := NewTaskHandler(mockService)
+
+	router.GET("/tasks", testutil.WithAuthContext(router, "user-123", handler.List))
+
+	req := httptest.NewRequest("GET", "/tasks?cursor=not-a-cursor", nil)
+
+	w := testutil.NewResponseRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "ListPage")
+}
+
+// TestTaskHandler_List_CursorWithOffset tests that cursor and offset cannot be combined
+func TestTaskHandler_List_CursorWithOffset(t *testing.T) {
+	router, mockService := setupTaskTest()
+	handler := NewTaskHandler(mockService)
+
+	router.GET("/tasks", testutil.WithAuthContext(router, "user-123", handler.List))
+
+	cursor := domain.NewTaskCursor(&domain.Task{
+		ID:        "3f0a6c1e-8d5b-4b7a-9c2e-1a2b3c4d5e6f",
+		CreatedAt: time.Now(),
+	}, domain.DefaultTaskSort).Encode()
+	req := httptest.NewRequest("GET", "/tasks?offset=20&cursor="+cursor, nil)
+
+	w := testutil.NewResponseRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "ListPage")
+}
+
+// TestTaskHandler_List_WithSearchQuery tests that the search query language is parsed into the filter
+func TestTaskHandler_List_WithSearchQuery(t *testing.T) {
+	router, mockService := setupTaskTest()
+	handler := NewTaskHandler(mockService)
+
+	router.GET("/tasks", testutil.WithAuthContext(router, "user-123", handler.List))
+
+	mockService.On("ListPage", mock.Anything, "user-123", mock.MatchedBy(func(filter *domain.TaskListFilter) bool {
+		if filter.Query == nil || len(filter.Query.Clauses) != 2 {
+			return false
+		}
+		category := filter.Query.Clauses[0].Terms[0]
+		status := filter.Query.Clauses[1].Terms[0]
+		return category.Field == domain.TaskQueryCategory && category.Value == "work" &&
+			status.Field == domain.TaskQueryStatus && status.Negate
+	})).Return(&domain.TaskListPage{Tasks: []*domain.Task{}}, nil)
+
+	req := httptest.NewRequest("GET", "/tasks?search="+url.QueryEscape("category:work -status:done"), nil)
+
+	w := testutil.NewResponseRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+// TestTaskHandler_List_InvalidSearchQuery tests that search parse errors are returned as validation errors
+func TestTaskHandler_List_InvalidSearchQuery(t *testing.T) {
+	router, mockService := setupTaskTest()
+	handler := NewTaskHandler(mockService)
+
+	router.GET("/tasks", testutil.WithAuthContext(router, "user-123", handler.List))
+
+	req := httptest.NewRequest("GET", "/tasks?search="+url.QueryEscape("priority:>high"), nil)
+
+	w := testutil.NewResponseRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "priority must be a number")
+	mockService.AssertNotCalled(t, "ListPage")
+}
+
+// TestTaskHandler_List_WithSort tests that the sort parameter is parsed into the filter
+func TestTaskHandler_List_WithSort(t *testing.T) {
+	router, mockService := setupTaskTest()
+	handler := NewTaskHandler(mockService)
+
+	router.GET("/tasks", testutil.WithAuthContext(router, "user-123", handler.List))
+
+	mockService.On("ListPage", mock.Anything, "user-123", mock.MatchedBy(func(filter *domain.TaskListFilter) bool {
+		return len(filter.Sort) == 2 &&
+			filter.Sort[0] == domain.TaskSort{Field: domain.TaskSortDueDate} &&
+			filter.Sort[1] == domain.TaskSort{Field: domain.TaskSortPriorityScore, Descending: true}
+	})).Return(&domain.TaskListPage{Tasks: []*domain.Task{}}, nil)
+
+	req := httptest.NewRequest("GET", "/tasks?sort=due_date,-priority_score", nil)
+
+	w := testutil.NewResponseRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+// TestTaskHandler_List_InvalidSort tests validation of the sort parameter
+func TestTaskHandler_List_InvalidSort(t *testing.T) {
+	router, mockService := setupTaskTest()
+	handler := NewTaskHandler(mockService)
+
+	router.GET("/tasks", testutil.WithAuthContext(router, "user-123", handler.List))
+
+	req := httptest.NewRequest("GET", "/tasks?sort=due_date,-password", nil)
+
+	w := testutil.NewResponseRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "ListPage")
+}
+
+// TestTaskHandler_List_IncludeDeferred tests that snoozed tasks can be requested explicitly
+func TestTaskHandler_List_IncludeDeferred(t *testing.T) {
+	router, mockService := setupTaskTest()
+	handler := NewTaskHandler(mockService)
+
+	router.GET("/tasks", testutil.WithAuthContext(router, "user-123", handler.List))
+
+	mockService.On("ListPage", mock.Anything, "user-123", mock.MatchedBy(func(filter *domain.TaskListFilter) bool {
+		return filter.IncludeDeferred
+	})).Return(&domain.TaskListPage{Tasks: []*domain.Task{}}, nil)
+
+	req := httptest.NewRequest("GET", "/tasks?include_deferred=true", nil)
+
+	w := testutil.NewResponseRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+// TestTaskHandler_GetHistory_ParsesFilter tests event type, limit and offset parsing for task history
+func TestTaskHandler_GetHistory_ParsesFilter(t *testing.T) {
+	router, mockService := setupTaskTest()
+	handler := NewTaskHandler(mockService)
+
+	router.GET("/tasks/:id/history", testutil.WithAuthContext(router, "user-123", handler.GetHistory))
+
+	mockService.On("GetHistory", mock.Anything, "user-123", "task-123", &domain.TaskHistoryFilter{
+		EventTypes: []domain.TaskHistoryEventType{domain.EventTaskUpdated, domain.EventStatusChanged, domain.EventTaskBumped},
+		Limit:      MaxLimit,
+		Offset:     40,
+	}).Return(&domain.TaskHistoryPage{History: []*domain.TaskHistoryEntry{}, TotalCount: 41, Limit: MaxLimit, Offset: 40}, nil)
+
+	req := httptest.NewRequest("GET", "/tasks/task-123/history?event_type=updated,status_changed&event_type=bumped&limit=500&offset=40", nil)
+
+	w := testutil.NewResponseRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total_count":41`)
+	mockService.AssertExpectations(t)
+}
+
+// TestTaskHandler_List_CursorSortMismatch tests that a cursor cannot be reused with another sort
+func TestTaskHandler_List_CursorSortMismatch(t *testing.T) {
+	router, mockService := setupTaskTest()
+	handler := NewTaskHandler(mockService)
+
+	router.GET("/tasks", testutil.WithAuthContext(router, "user-123", handler.List))
+
+	cursor := domain.NewTaskCursor(&domain.Task{
+		ID:        "3f0a6c1e-8d5b-4b7a-9c2e-1a2b3c4d5e6f",
+		CreatedAt: time.Now(),
+	}, domain.DefaultTaskSort).Encode()
+	req := httptest.NewRequest("GET", "/tasks?sort=title&cursor="+cursor, nil)
+
+	w := testutil.NewResponseRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "ListPage")
 }
 
 // TestTaskHandler_Get_Success tests successful task retrieval
@@ -516,6 +789,140 @@ func TestTaskHandler_Update_Success(t *testing.T) {
 	assert.Equal(t, "Updated Task", task.Title)
 }
 
+// TestTaskHandler_Get_SetsETag tests that the task version is exposed as an ETag
+func TestTaskHandler_Get_SetsETag(t *testing.T) {
+	router, mockService := setupTaskTest()
+	handler := NewTaskHandler(mockService)
+
+	router.GET("/tasks/:id", testutil.WithAuthContext(router, "user-123", handler.Get))
+
+	mockService.On("Get", mock.Anything, "user-123", "task-123").
+		Return(testutil.NewTaskBuilder().WithID("task-123").WithVersion(7).Build(), nil)
+
+	req := httptest.NewRequest("GET", "/tasks/task-123", nil)
+	w := testutil.NewResponseRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"7"`, w.Header().Get("ETag"))
+}
+
+// TestTaskHandler_Update_IfMatch tests that If-Match is passed to the service as the expected version
+func TestTaskHandler_Update_IfMatch(t *testing.T) {
+	router, mockService := setupTaskTest()
+	handler := NewTaskHandler(mockService)
+
+	router.PUT("/tasks/:id", testutil.WithAuthContext(router, "user-123", handler.Update))
+
+	expectsVersion3 := mock.MatchedBy(func(ctx context.Context) bool {
+		version, ok := domain.ExpectedVersion(ctx)
+		return ok && version == 3
+	})
+	mockService.On("Update", expectsVersion3, "user-123", "task-123", mock.Anything).
+		Return(testutil.NewTaskBuilder().WithID("task-123").WithVersion(4).Build(), nil)
+
+	req := httptest.NewRequest("PUT", "/tasks/task-123", bytes.NewBufferString(`{"title":"Updated"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"3"`)
+
+	w := testutil.NewResponseRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+	mockService.AssertExpectations(t)
+}
+
+// TestTaskHandler_Update_InvalidIfMatch tests that a malformed If-Match is rejected
+func TestTaskHandler_Update_InvalidIfMatch(t *testing.T) {
+	router, mockService := setupTaskTest()
+	handler := NewTaskHandler(mockService)
+
+	router.PUT("/tasks/:id", testutil.WithAuthContext(router, "user-123", handler.Update))
+
+	req := httptest.NewRequest("PUT", "/tasks/task-123", bytes.NewBufferString(`{"title":"Updated"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", "three")
+
+	w := testutil.NewResponseRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestTaskHandler_Update_PreconditionFailed tests the 412 response carries the server copy
+func TestTaskHandler_Update_PreconditionFailed(t *testing.T) {
+	router, mockService := setupTaskTest()
+	handler := NewTaskHandler(mockService)
+
+	router.PUT("/tasks/:id", testutil.WithAuthContext(router, "user-123", handler.Update))
+
+	current := testutil.NewTaskBuilder().WithID("task-123").WithTitle("Edited elsewhere").WithVersion(5).Build()
+	mockService.On("Update", mock.Anything, "user-123", "task-123", mock.Anything).
+		Return(nil, domain.NewPreconditionFailedError("task", current))
+
+	req := httptest.NewRequest("PUT", "/tasks/task-123", bytes.NewBufferString(`{"title":"Updated"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"4"`)
+
+	w := testutil.NewResponseRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	assert.Equal(t, `"5"`, w.Header().Get("ETag"))
+
+	var response struct {
+		Details struct {
+			Current domain.Task `json:"current"`
+		} `json:"details"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "Edited elsewhere", response.Details.Current.Title)
+	assert.Equal(t, 5, response.Details.Current.Version)
+}
+
+// TestTaskHandler_Patch_NullMembers tests that explicit nulls reach the service as clears
+func TestTaskHandler_Patch_NullMembers(t *testing.T) {
+	router, mockService := setupTaskTest()
+	handler := NewTaskHandler(mockService)
+
+	router.PATCH("/tasks/:id", testutil.WithAuthContext(router, "user-123", handler.Patch))
+
+	clearsDueDateOnly := mock.MatchedBy(func(patch *domain.TaskMergePatch) bool {
+		return patch.DueDate.IsNull() && !patch.Title.Set && !patch.Category.Set
+	})
+	mockService.On("Patch", mock.Anything, "user-123", "task-123", clearsDueDateOnly).
+		Return(testutil.NewTaskBuilder().WithID("task-123").WithVersion(2).Build(), nil)
+
+	req := httptest.NewRequest("PATCH", "/tasks/task-123", bytes.NewBufferString(`{"due_date": null}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+
+	w := testutil.NewResponseRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"2"`, w.Header().Get("ETag"))
+	mockService.AssertExpectations(t)
+}
+
+// TestTaskHandler_Patch_NotAnObject tests that non-object patch documents are rejected
+func TestTaskHandler_Patch_NotAnObject(t *testing.T) {
+	router, mockService := setupTaskTest()
+	handler := NewTaskHandler(mockService)
+
+	router.PATCH("/tasks/:id", testutil.WithAuthContext(router, "user-123", handler.Patch))
+
+	req := httptest.NewRequest("PATCH", "/tasks/task-123", bytes.NewBufferString(`["title"]`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+
+	w := testutil.NewResponseRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 // TestTaskHandler_Update_InvalidJSON tests update with invalid JSON
 func TestTaskHandler_Update_InvalidJSON(t *testing.T) {
 	router, mockService := setupTaskTest()
@@ -591,6 +998,31 @@ func TestTaskHandler_Delete_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+// TestTaskHandler_Delete_IfMatchWildcard tests that If-Match: * imposes no version check
+func TestTaskHandler_Delete_IfMatchWildcard(t *testing.T) {
+	router, mockService := setupTaskTest()
+	handler := NewTaskHandler(mockService)
+
+	router.DELETE("/tasks/:id", testutil.WithAuthContext(router, "user-123", handler.Delete))
+
+	noPrecondition := mock.MatchedBy(func(ctx context.Context) bool {
+		_, ok := domain.ExpectedVersion(ctx)
+		return !ok
+	})
+	mockService.On("Get", mock.Anything, "user-123", "task-123").
+		Return(&domain.Task{ID: "task-123"}, nil)
+	mockService.On("Delete", noPrecondition, "user-123", "task-123").Return(nil)
+
+	req := httptest.NewRequest("DELETE", "/tasks/task-123", nil)
+	req.Header.Set("If-Match", "*")
+
+	w := testutil.NewResponseRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockService.AssertExpectations(t)
+}
+
 // TestTaskHandler_Delete_NotFound tests deleting non-existent task
 func TestTaskHandler_Delete_NotFound(t *testing.T) {
 	router, mockService := setupTaskTest()
@@ -694,6 +1126,55 @@ func TestTaskHandler_Bump_NotFound(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+// TestTaskHandler_Snooze_Success tests snoozing a task with a preset
+func TestTaskHandler_Snooze_Success(t *testing.T) {
+	router, mockService := setupTaskTest()
+	handler := NewTaskHandler(mockService)
+
+	router.POST("/tasks/:id/snooze", testutil.WithAuthContext(router, "user-123", handler.Snooze))
+
+	deferUntil := time.Now().Add(24 * time.Hour)
+	snoozedTask := testutil.NewTaskBuilder().
+		WithID("task-123").
+		Build()
+	snoozedTask.DeferUntil = &deferUntil
+
+	mockService.On("Snooze", mock.Anything, "user-123", "task-123", mock.MatchedBy(func(dto *domain.SnoozeTaskDTO) bool {
+		return dto.Preset != nil && *dto.Preset == domain.SnoozeTomorrow && dto.Until == nil
+	})).Return(snoozedTask, nil)
+
+	req := httptest.NewRequest("POST", "/tasks/task-123/snooze", bytes.NewBufferString(`{"preset": "tomorrow"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := testutil.NewResponseRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.NotNil(t, response["defer_until"])
+}
+
+// TestTaskHandler_Snooze_InvalidJSON tests that a malformed body is rejected
+func TestTaskHandler_Snooze_InvalidJSON(t *testing.T) {
+	router, mockService := setupTaskTest()
+	handler := NewTaskHandler(mockService)
+
+	router.POST("/tasks/:id/snooze", testutil.WithAuthContext(router, "user-123", handler.Snooze))
+
+	req := httptest.NewRequest("POST", "/tasks/task-123/snooze", bytes.NewBufferString("invalid json"))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := testutil.NewResponseRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "Snooze")
+}
+
 // TestTaskHandler_Complete_Success tests successful task completion
 func TestTaskHandler_Complete_Success(t *testing.T) {
 	router, mockService := setupTaskTest()
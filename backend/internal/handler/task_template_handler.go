@@ -110,6 +110,32 @@ func (h *TaskTemplateHandler) UpdateTemplate(c *gin.Context) {
 	c.JSON(http.StatusOK, template)
 }
 
+// PatchTemplate applies a JSON merge patch to a template; null clears optional fields
+// PATCH /api/v1/templates/:id
+func (h *TaskTemplateHandler) PatchTemplate(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	templateID := c.Param("id")
+
+	var patch domain.TaskTemplateMergePatch
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		middleware.AbortWithError(c, domain.NewValidationError("request", "body must be a JSON merge patch object"))
+		return
+	}
+
+	template, err := h.templateService.Patch(c.Request.Context(), userID, templateID, &patch)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
 // DeleteTemplate removes a template
 // DELETE /api/v1/templates/:id
 func (h *TaskTemplateHandler) DeleteTemplate(c *gin.Context) {
@@ -86,6 +86,7 @@ func NewTaskBuilder() *TaskBuilder {
 			BumpCount:     0,
 			CreatedAt:     now,
 			UpdatedAt:     now,
+			Version:       1,
 		},
 	}
 }
@@ -145,6 +146,11 @@ func (b *TaskBuilder) WithBumpCount(count int) *TaskBuilder {
 	return b
 }
 
+func (b *TaskBuilder) WithVersion(version int) *TaskBuilder {
+	b.task.Version = version
+	return b
+}
+
 func (b *TaskBuilder) Completed() *TaskBuilder {
 	now := time.Now()
 	b.task.Status = domain.TaskStatusDone
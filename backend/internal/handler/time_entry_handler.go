@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/middleware"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+)
+
+// TimeEntryHandler handles HTTP requests for time tracking
+type TimeEntryHandler struct {
+	timeEntryService ports.TimeEntryService
+}
+
+// NewTimeEntryHandler creates a new time entry handler
+func NewTimeEntryHandler(timeEntryService ports.TimeEntryService) *TimeEntryHandler {
+	return &TimeEntryHandler{timeEntryService: timeEntryService}
+}
+
+// StartTimer starts the user's timer on a task. The body ({"note": "..."}) is optional.
+// POST /api/v1/tasks/:id/timer/start
+func (h *TimeEntryHandler) StartTimer(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	var dto domain.StartTimerDTO
+	if err := c.ShouldBindJSON(&dto); err != nil && !errors.Is(err, io.EOF) {
+		middleware.AbortWithError(c, domain.NewValidationError("request", err.Error()))
+		return
+	}
+
+	entry, err := h.timeEntryService.StartTimer(c.Request.Context(), userID, c.Param("id"), &dto)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// StopTimer stops the user's timer running on a task
+// POST /api/v1/tasks/:id/timer/stop
+func (h *TimeEntryHandler) StopTimer(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	entry, err := h.timeEntryService.StopTimer(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// GetRunningTimer returns the user's running timer, if any
+// GET /api/v1/timer
+func (h *TimeEntryHandler) GetRunningTimer(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	entry, err := h.timeEntryService.GetRunningTimer(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"timer": entry,
+	})
+}
+
+// CreateTimeEntry logs time spent on a task manually
+// POST /api/v1/tasks/:id/time-entries
+func (h *TimeEntryHandler) CreateTimeEntry(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	var dto domain.CreateTimeEntryDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		middleware.AbortWithError(c, domain.NewValidationError("request", err.Error()))
+		return
+	}
+
+	entry, err := h.timeEntryService.CreateEntry(c.Request.Context(), userID, c.Param("id"), &dto)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// ListTimeEntries retrieves a task's time entries, most recent first
+// GET /api/v1/tasks/:id/time-entries
+func (h *TimeEntryHandler) ListTimeEntries(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	response, err := h.timeEntryService.List(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteTimeEntry removes a time entry
+// DELETE /api/v1/tasks/:id/time-entries/:entry_id
+func (h *TimeEntryHandler) DeleteTimeEntry(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	if err := h.timeEntryService.DeleteEntry(c.Request.Context(), userID, c.Param("id"), c.Param("entry_id")); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "time entry deleted",
+	})
+}
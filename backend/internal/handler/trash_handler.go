@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/middleware"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+)
+
+// TrashHandler handles HTTP requests for the trash of deleted tasks
+type TrashHandler struct {
+	trashService ports.TrashService
+}
+
+// NewTrashHandler creates a new trash handler
+func NewTrashHandler(trashService ports.TrashService) *TrashHandler {
+	return &TrashHandler{trashService: trashService}
+}
+
+// List retrieves a page of deleted tasks, most recently deleted first
+// GET /api/v1/tasks/trash?limit=20&offset=0
+func (h *TrashHandler) List(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	limit := DefaultLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 {
+			if parsed > MaxLimit {
+				parsed = MaxLimit
+			}
+			limit = parsed
+		}
+	}
+	offset := 0
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		if parsed, err := strconv.Atoi(offsetStr); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+
+	page, err := h.trashService.List(c.Request.Context(), userID, limit, offset)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// DeletePermanently removes a deleted task and its subtasks for good
+// DELETE /api/v1/tasks/:id/permanent
+func (h *TrashHandler) DeletePermanently(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	if err := h.trashService.DeletePermanently(c.Request.Context(), userID, c.Param("id")); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Empty permanently deletes every task in the trash
+// POST /api/v1/tasks/trash/empty
+func (h *TrashHandler) Empty(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	result, err := h.trashService.Empty(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/middleware"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+)
+
+// UndoHandler handles HTTP requests for undoing task operations
+type UndoHandler struct {
+	undoService ports.UndoService
+}
+
+// NewUndoHandler creates a new undo handler
+func NewUndoHandler(undoService ports.UndoService) *UndoHandler {
+	return &UndoHandler{undoService: undoService}
+}
+
+// ListOperations retrieves the user's recent operations, newest first
+// GET /api/v1/undo
+func (h *UndoHandler) ListOperations(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	operations, err := h.undoService.ListOperations(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"operations": operations,
+	})
+}
+
+// Undo reverts an operation, by default the most recent one
+// POST /api/v1/undo
+func (h *UndoHandler) Undo(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		middleware.AbortWithError(c, domain.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	// The body is optional: without one the latest operation is undone
+	var req domain.UndoRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			middleware.AbortWithError(c, domain.NewValidationError("request", err.Error()))
+			return
+		}
+	}
+
+	result, err := h.undoService.Undo(c.Request.Context(), userID, &req)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -79,6 +79,21 @@ func mapErrorToResponse(c *gin.Context, err error) (int, ErrorResponse) {
 		}
 	}
 
+	var preconditionErr *domain.PreconditionFailedError
+	if errors.As(err, &preconditionErr) {
+		// Send the current entity tag so the client can retry after merging
+		if tagged, ok := preconditionErr.Current.(interface{ ETag() string }); ok {
+			c.Header("ETag", tagged.ETag())
+		}
+		return http.StatusPreconditionFailed, ErrorResponse{
+			Error: preconditionErr.Error(),
+			Details: map[string]interface{}{
+				"resource": preconditionErr.Resource,
+				"current":  preconditionErr.Current,
+			},
+		}
+	}
+
 	// Handle dependency sentinel errors
 	if errors.Is(err, domain.ErrDependencyCycle) ||
 		errors.Is(err, domain.ErrSelfDependency) ||
@@ -106,6 +121,40 @@ func mapErrorToResponse(c *gin.Context, err error) (int, ErrorResponse) {
 		}
 	}
 
+	// Handle subtask sentinel errors
+	if errors.Is(err, domain.ErrSubtaskDepthExceeded) ||
+		errors.Is(err, domain.ErrSubtaskCycle) ||
+		errors.Is(err, domain.ErrCannotCreateSubtask) {
+		return http.StatusBadRequest, ErrorResponse{
+			Error: err.Error(),
+		}
+	}
+
+	if errors.Is(err, domain.ErrParentNotFound) {
+		return http.StatusNotFound, ErrorResponse{
+			Error: err.Error(),
+		}
+	}
+
+	if errors.Is(err, domain.ErrCannotCompleteParent) {
+		return http.StatusUnprocessableEntity, ErrorResponse{
+			Error: err.Error(),
+		}
+	}
+
+	// Handle board sentinel errors
+	if errors.Is(err, domain.ErrInvalidStatusTransition) {
+		return http.StatusUnprocessableEntity, ErrorResponse{
+			Error: err.Error(),
+		}
+	}
+
+	if errors.Is(err, domain.ErrWIPLimitExceeded) {
+		return http.StatusConflict, ErrorResponse{
+			Error: err.Error(),
+		}
+	}
+
 	// Handle template sentinel errors
 	if errors.Is(err, domain.ErrTemplateNotFound) {
 		return http.StatusNotFound, ErrorResponse{
@@ -125,6 +174,133 @@ func mapErrorToResponse(c *gin.Context, err error) (int, ErrorResponse) {
 		}
 	}
 
+	// Handle saved view sentinel errors
+	if errors.Is(err, domain.ErrSavedViewNotFound) {
+		return http.StatusNotFound, ErrorResponse{
+			Error: err.Error(),
+		}
+	}
+
+	if errors.Is(err, domain.ErrSavedViewDuplicateName) {
+		return http.StatusConflict, ErrorResponse{
+			Error: err.Error(),
+		}
+	}
+
+	// Handle tag sentinel errors
+	if errors.Is(err, domain.ErrTagNotFound) {
+		return http.StatusNotFound, ErrorResponse{
+			Error: err.Error(),
+		}
+	}
+
+	if errors.Is(err, domain.ErrTagDuplicateName) {
+		return http.StatusConflict, ErrorResponse{
+			Error: err.Error(),
+		}
+	}
+
+	if errors.Is(err, domain.ErrTagMergeSelf) {
+		return http.StatusBadRequest, ErrorResponse{
+			Error: err.Error(),
+		}
+	}
+
+	// Handle comment sentinel errors
+	if errors.Is(err, domain.ErrCommentNotFound) {
+		return http.StatusNotFound, ErrorResponse{
+			Error: err.Error(),
+		}
+	}
+
+	// Handle time tracking sentinel errors
+	if errors.Is(err, domain.ErrTimeEntryNotFound) {
+		return http.StatusNotFound, ErrorResponse{
+			Error: err.Error(),
+		}
+	}
+
+	if errors.Is(err, domain.ErrTimerAlreadyRunning) || errors.Is(err, domain.ErrTimerNotRunning) {
+		return http.StatusConflict, ErrorResponse{
+			Error: err.Error(),
+		}
+	}
+
+	// Handle focus session sentinel errors
+	if errors.Is(err, domain.ErrFocusSessionNotFound) {
+		return http.StatusNotFound, ErrorResponse{
+			Error: err.Error(),
+		}
+	}
+
+	if errors.Is(err, domain.ErrFocusSessionActive) || errors.Is(err, domain.ErrInvalidFocusSessionChange) {
+		return http.StatusConflict, ErrorResponse{
+			Error: err.Error(),
+		}
+	}
+
+	// Handle attachment sentinel errors
+	if errors.Is(err, domain.ErrAttachmentNotFound) || errors.Is(err, domain.ErrBlobNotFound) {
+		return http.StatusNotFound, ErrorResponse{
+			Error: err.Error(),
+		}
+	}
+
+	if errors.Is(err, domain.ErrAttachmentTooLarge) || errors.Is(err, domain.ErrAttachmentQuotaExceeded) {
+		return http.StatusRequestEntityTooLarge, ErrorResponse{
+			Error: err.Error(),
+		}
+	}
+
+	// Handle reminder sentinel errors
+	if errors.Is(err, domain.ErrReminderNotFound) {
+		return http.StatusNotFound, ErrorResponse{
+			Error: err.Error(),
+		}
+	}
+
+	// Handle undo errors; a partial undo wraps the error that stopped it, so it goes first
+	var partialUndoErr *domain.PartialUndoError
+	if errors.As(err, &partialUndoErr) {
+		status := http.StatusConflict
+		if !errors.Is(err, domain.ErrUndoConflict) {
+			status = http.StatusInternalServerError
+			slog.Error("Undo stopped partway",
+				"operation_id", partialUndoErr.OperationID,
+				"cause", partialUndoErr.Err,
+				"path", c.Request.URL.Path,
+				"method", c.Request.Method,
+			)
+		}
+		return status, ErrorResponse{
+			Error: partialUndoErr.Error(),
+			Details: map[string]interface{}{
+				"operation_id":      partialUndoErr.OperationID,
+				"undo_operation_id": partialUndoErr.UndoOperationID,
+				"reverted_task_ids": partialUndoErr.RevertedTaskIDs,
+			},
+		}
+	}
+
+	if errors.Is(err, domain.ErrOperationNotFound) {
+		return http.StatusNotFound, ErrorResponse{
+			Error: err.Error(),
+		}
+	}
+
+	if errors.Is(err, domain.ErrOperationAlreadyUndone) ||
+		errors.Is(err, domain.ErrUndoConflict) {
+		return http.StatusConflict, ErrorResponse{
+			Error: err.Error(),
+		}
+	}
+
+	if errors.Is(err, domain.ErrOperationNotUndoable) {
+		return http.StatusUnprocessableEntity, ErrorResponse{
+			Error: err.Error(),
+		}
+	}
+
 	var internalErr *domain.InternalError
 	if errors.As(err, &internalErr) {
 		// Log the internal error server-side with full details and request context
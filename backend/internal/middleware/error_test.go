@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -127,6 +128,28 @@ func TestErrorHandler_ForbiddenError(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "forbidden")
 }
 
+func TestErrorHandler_PreconditionFailedError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ErrorHandler())
+	router.GET("/test", func(c *gin.Context) {
+		c.Error(domain.NewPreconditionFailedError("task", &domain.Task{
+			ID:      "task-123",
+			Title:   "Server copy",
+			Version: 6,
+		}))
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	assert.Equal(t, `"6"`, w.Header().Get("ETag"))
+	assert.Contains(t, w.Body.String(), "Server copy")
+	assert.Contains(t, w.Body.String(), `"current"`)
+}
+
 func TestErrorHandler_InternalError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -164,6 +187,55 @@ func TestErrorHandler_UnknownError(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "unexpected error occurred")
 }
 
+func TestErrorHandler_SubtaskErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := map[error]int{
+		domain.ErrSubtaskDepthExceeded: http.StatusBadRequest,
+		domain.ErrSubtaskCycle:         http.StatusBadRequest,
+		domain.ErrCannotCreateSubtask:  http.StatusBadRequest,
+		domain.ErrParentNotFound:       http.StatusNotFound,
+		domain.ErrCannotCompleteParent: http.StatusUnprocessableEntity,
+	}
+	for err, status := range tests {
+		router := gin.New()
+		router.Use(ErrorHandler())
+		router.GET("/test", func(c *gin.Context) {
+			c.Error(err)
+		})
+
+		req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, status, w.Code, err.Error())
+		assert.Contains(t, w.Body.String(), err.Error())
+	}
+}
+
+func TestErrorHandler_BoardErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := map[error]int{
+		fmt.Errorf("%w: done to blocked", domain.ErrInvalidStatusTransition):               http.StatusUnprocessableEntity,
+		fmt.Errorf("%w: in_progress already has 3 of 3 tasks", domain.ErrWIPLimitExceeded): http.StatusConflict,
+	}
+	for err, status := range tests {
+		router := gin.New()
+		router.Use(ErrorHandler())
+		router.GET("/test", func(c *gin.Context) {
+			c.Error(err)
+		})
+
+		req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, status, w.Code, err.Error())
+		assert.Contains(t, w.Body.String(), err.Error())
+	}
+}
+
 func TestErrorHandler_MultipleErrors(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -307,3 +379,32 @@ func TestMapErrorToResponse_ConflictErrorWithResource(t *testing.T) {
 	// Check for resource in details
 	assert.Contains(t, w.Body.String(), "email")
 }
+
+func TestMapErrorToResponse_PartialUndoListsRevertedTasks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := map[error]int{
+		fmt.Errorf("%w: task task-1 was modified", domain.ErrUndoConflict): http.StatusConflict,
+		domain.NewInternalError("failed to update task", nil):              http.StatusInternalServerError,
+	}
+	for cause, status := range tests {
+		router := gin.New()
+		router.Use(ErrorHandler())
+		router.POST("/test", func(c *gin.Context) {
+			c.Error(&domain.PartialUndoError{
+				OperationID:     "op-1",
+				UndoOperationID: "undo-1",
+				RevertedTaskIDs: []string{"task-2"},
+				Err:             cause,
+			})
+		})
+
+		req, _ := http.NewRequest(http.MethodPost, "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, status, w.Code, cause.Error())
+		assert.Contains(t, w.Body.String(), "task-2")
+		assert.Contains(t, w.Body.String(), "undo-1")
+	}
+}
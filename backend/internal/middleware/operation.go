@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+)
+
+// OperationHeader is the response header carrying the ID of the operation a request performed
+const OperationHeader = "X-Operation-ID"
+
+// Operation assigns each mutating request an operation ID. Task history written while
+// handling the request is grouped under it, and it is returned in the X-Operation-ID
+// header so the client can pass it to POST /undo.
+func Operation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		operationID := uuid.New().String()
+		c.Request = c.Request.WithContext(domain.WithOperationID(c.Request.Context(), operationID))
+		c.Header(OperationHeader, operationID)
+		c.Next()
+	}
+}
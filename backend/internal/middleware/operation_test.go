@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// Operation Tests
+// =============================================================================
+
+func TestOperation_AssignsIDToMutatingRequests(t *testing.T) {
+	router := gin.New()
+	router.Use(Operation())
+
+	var seen string
+	router.PATCH("/tasks/:id", func(c *gin.Context) {
+		seen, _ = domain.OperationID(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPatch, "/tasks/task-1", nil))
+
+	require.NotEmpty(t, seen)
+	assert.Equal(t, seen, w.Header().Get(OperationHeader))
+	_, err := uuid.Parse(seen)
+	assert.NoError(t, err)
+}
+
+func TestOperation_SkipsReads(t *testing.T) {
+	router := gin.New()
+	router.Use(Operation())
+
+	hasOperation := true
+	router.GET("/tasks", func(c *gin.Context) {
+		_, hasOperation = domain.OperationID(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tasks", nil))
+
+	assert.False(t, hasOperation)
+	assert.Empty(t, w.Header().Get(OperationHeader))
+}
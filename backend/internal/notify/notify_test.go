@@ -0,0 +1,289 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testNotification(recipient string) *domain.Notification {
+	dueDate := time.Date(2026, 3, 2, 17, 0, 0, 0, time.UTC)
+	return &domain.Notification{
+		ID:        "reminder-1",
+		Event:     domain.NotificationEventTaskReminder,
+		Recipient: recipient,
+		Subject:   "Reminder: Ship the release\r\nBcc: attacker@example.com",
+		Body:      "\"Ship the release\" is due soon.\nDon't forget.",
+		TaskID:    "task-456",
+		TaskTitle: "Ship the release",
+		DueDate:   &dueDate,
+		RemindAt:  dueDate.Add(-time.Hour),
+	}
+}
+
+// =============================================================================
+// WebhookNotifier Tests
+// =============================================================================
+
+func TestWebhookNotifier_Notify_SignsAndPosts(t *testing.T) {
+	var gotHeaders http.Header
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeaders = r.Header.Clone()
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier := newWebhookNotifier("s3cret", nil)
+	require.NoError(t, notifier.Notify(context.Background(), testNotification(server.URL)))
+
+	assert.Equal(t, "application/json", gotHeaders.Get("Content-Type"))
+	assert.Equal(t, domain.NotificationEventTaskReminder, gotHeaders.Get(HeaderEvent))
+	assert.Equal(t, "reminder-1", gotHeaders.Get(HeaderIdempotencyKey))
+	assert.Equal(t, "sha256="+Sign([]byte("s3cret"), gotBody), gotHeaders.Get(HeaderSignature))
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(gotBody, &payload))
+	assert.Equal(t, "task-456", payload["task_id"])
+	assert.Equal(t, "Ship the release", payload["task_title"])
+	// The recipient URL is not echoed back to the receiver
+	assert.NotContains(t, payload, "recipient")
+}
+
+func TestWebhookNotifier_Notify_UnsignedWithoutSecret(t *testing.T) {
+	var gotSignature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get(HeaderSignature)
+	}))
+	defer server.Close()
+
+	require.NoError(t, newWebhookNotifier("", nil).Notify(context.Background(), testNotification(server.URL)))
+	assert.Empty(t, gotSignature)
+}
+
+func TestWebhookNotifier_Notify_Non2xxIsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "receiver is down", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := newWebhookNotifier("", nil).Notify(context.Background(), testNotification(server.URL))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "503")
+	// The response body is not copied into the error, which is stored on the reminder
+	assert.NotContains(t, err.Error(), "receiver is down")
+}
+
+func TestWebhookNotifier_Notify_RejectsNonPublicAddress(t *testing.T) {
+	reached := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+	}))
+	defer server.Close()
+
+	err := NewWebhookNotifier("").Notify(context.Background(), testNotification(server.URL))
+
+	assert.ErrorIs(t, err, ErrNonPublicAddress)
+	assert.False(t, reached)
+}
+
+func TestWebhookNotifier_Notify_DoesNotFollowRedirects(t *testing.T) {
+	redirected := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected = true
+	}))
+	defer target.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+
+	err := newWebhookNotifier("", nil).Notify(context.Background(), testNotification(server.URL))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "307")
+	assert.False(t, redirected)
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr   string
+		public bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::1", false},
+		{"fd00::1", false},
+		{"fe80::1", false},
+		{"::ffff:127.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.public, isPublicAddr(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
+
+// =============================================================================
+// SMTPNotifier Tests
+// =============================================================================
+
+// fakeSMTPServer is a minimal local SMTP stand-in that accepts every message
+// and records the envelope and DATA of each one.
+type fakeSMTPServer struct {
+	listener net.Listener
+	mu       sync.Mutex
+	from     []string
+	rcpt     []string
+	data     []string
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &fakeSMTPServer{listener: listener}
+	go server.serve()
+	t.Cleanup(func() { listener.Close() })
+	return server
+}
+
+func (s *fakeSMTPServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(line)
+
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			s.mu.Lock()
+			s.from = append(s.from, line[len("MAIL FROM:"):])
+			s.mu.Unlock()
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			s.mu.Lock()
+			s.rcpt = append(s.rcpt, line[len("RCPT TO:"):])
+			s.mu.Unlock()
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			s.mu.Lock()
+			s.data = append(s.data, data.String())
+			s.mu.Unlock()
+			reply("250 OK: queued")
+		case command == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestSMTPNotifier_Notify_DeliversToLocalServer(t *testing.T) {
+	server := newFakeSMTPServer(t)
+
+	notifier, err := NewSMTPNotifier(SMTPConfig{
+		Host: "127.0.0.1",
+		Port: server.port(),
+		From: "reminders@taskflow.example",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, notifier.Notify(context.Background(), testNotification("owner@example.com")))
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	assert.Equal(t, []string{"<reminders@taskflow.example>"}, server.from)
+	assert.Equal(t, []string{"<owner@example.com>"}, server.rcpt)
+	require.Len(t, server.data, 1)
+
+	message := server.data[0]
+	assert.Contains(t, message, "To: owner@example.com\r\n")
+	assert.Contains(t, message, "Message-ID: <reminder-1@taskflow.example>\r\n")
+	assert.Contains(t, message, HeaderEvent+": task.reminder\r\n")
+	assert.Contains(t, message, "\r\n\r\n\"Ship the release\" is due soon.\r\nDon't forget.\r\n")
+	// The line break in the subject cannot start a new header
+	assert.NotContains(t, message, "\r\nBcc:")
+}
+
+func TestSMTPNotifier_Notify_ConnectionRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	notifier, err := NewSMTPNotifier(SMTPConfig{Host: "127.0.0.1", Port: port, From: "reminders@taskflow.example"})
+	require.NoError(t, err)
+
+	err = notifier.Notify(context.Background(), testNotification("owner@example.com"))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "smtp dial")
+}
+
+func TestNewSMTPNotifier_RequiresHostAndFrom(t *testing.T) {
+	_, err := NewSMTPNotifier(SMTPConfig{Host: "smtp.example.com"})
+	assert.Error(t, err)
+
+	notifier, err := NewSMTPNotifier(SMTPConfig{Host: "smtp.example.com", From: "reminders@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, 587, notifier.cfg.Port)
+}
@@ -0,0 +1,138 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+)
+
+// SMTPConfig configures an SMTP notifier
+type SMTPConfig struct {
+	Host     string
+	Port     int    // Usually 587 (STARTTLS) or 25
+	Username string // Optional: authenticate with PLAIN auth
+	Password string
+	From     string // Sender address
+}
+
+// SMTPNotifier delivers notifications as plain-text email
+type SMTPNotifier struct {
+	cfg     SMTPConfig
+	timeout time.Duration
+	now     func() time.Time
+}
+
+// NewSMTPNotifier creates an SMTP notifier
+func NewSMTPNotifier(cfg SMTPConfig) (*SMTPNotifier, error) {
+	if cfg.Host == "" || cfg.From == "" {
+		return nil, fmt.Errorf("SMTP host and from address are required")
+	}
+	if cfg.Port == 0 {
+		cfg.Port = 587
+	}
+	return &SMTPNotifier{
+		cfg:     cfg,
+		timeout: 30 * time.Second,
+		now:     time.Now,
+	}, nil
+}
+
+// Notify sends the notification to the recipient address. STARTTLS is used
+// whenever the server offers it; credentials are only sent over TLS or to localhost.
+func (n *SMTPNotifier) Notify(ctx context.Context, notification *domain.Notification) error {
+	addr := net.JoinHostPort(n.cfg.Host, fmt.Sprint(n.cfg.Port))
+
+	deadline := n.now().Add(n.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	dialer := net.Dialer{Deadline: deadline}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("smtp dial: %w", err)
+	}
+	// Bounds the whole conversation, not just the dial
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, n.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp greeting: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.cfg.Host}); err != nil {
+			return fmt.Errorf("smtp starttls: %w", err)
+		}
+	}
+	if n.cfg.Username != "" {
+		// PlainAuth itself refuses to send credentials over an unencrypted non-local connection
+		if err := client.Auth(smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.cfg.Host)); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+
+	if err := client.Mail(n.cfg.From); err != nil {
+		return fmt.Errorf("smtp mail from: %w", err)
+	}
+	if err := client.Rcpt(notification.Recipient); err != nil {
+		return fmt.Errorf("smtp rcpt to: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	if _, err := w.Write(n.buildMessage(notification)); err != nil {
+		return fmt.Errorf("smtp write: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// buildMessage renders the RFC 5322 message. The Message-ID is derived from the
+// notification ID, so a retried delivery carries the same one.
+func (n *SMTPNotifier) buildMessage(notification *domain.Notification) []byte {
+	domainPart := n.cfg.Host
+	if at := strings.LastIndex(n.cfg.From, "@"); at >= 0 {
+		domainPart = n.cfg.From[at+1:]
+	}
+
+	var b strings.Builder
+	writeHeader := func(name, value string) {
+		b.WriteString(name + ": " + headerValue(value) + "\r\n")
+	}
+	writeHeader("From", n.cfg.From)
+	writeHeader("To", notification.Recipient)
+	writeHeader("Subject", mime.QEncoding.Encode("UTF-8", headerValue(notification.Subject)))
+	writeHeader("Date", n.now().Format(time.RFC1123Z))
+	writeHeader("Message-ID", "<"+notification.ID+"@"+domainPart+">")
+	writeHeader(HeaderEvent, notification.Event)
+	writeHeader("MIME-Version", "1.0")
+	writeHeader("Content-Type", "text/plain; charset=UTF-8")
+	writeHeader("Content-Transfer-Encoding", "8bit")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(notification.Body, "\r\n", "\n"), "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
+
+// headerValue strips line breaks so values (e.g. task titles) cannot inject headers
+func headerValue(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
@@ -0,0 +1,127 @@
+// Package notify implements ports.Notifier for the reminder delivery channels.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+)
+
+const (
+	// HeaderEvent names the notification event, e.g. task.reminder
+	HeaderEvent = "X-TaskFlow-Event"
+	// HeaderSignature carries "sha256=<hex HMAC of the body>" when a signing secret is configured
+	HeaderSignature = "X-TaskFlow-Signature"
+	// HeaderIdempotencyKey carries the notification ID, which is the same on every retry
+	HeaderIdempotencyKey = "Idempotency-Key"
+)
+
+// ErrNonPublicAddress is returned when a webhook URL resolves to a loopback,
+// private, link-local or otherwise internal address
+var ErrNonPublicAddress = errors.New("webhook target is not a public address")
+
+// WebhookNotifier delivers notifications as a JSON POST to the recipient URL
+type WebhookNotifier struct {
+	secret []byte
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a webhook notifier. A non-empty secret signs each
+// request body with HMAC-SHA256 so receivers can verify it came from TaskFlow.
+// Recipient URLs are user-supplied, so requests only connect to public addresses
+// and redirects are not followed.
+func NewWebhookNotifier(secret string) *WebhookNotifier {
+	return newWebhookNotifier(secret, rejectNonPublic)
+}
+
+func newWebhookNotifier(secret string, control func(network, address string, conn syscall.RawConn) error) *WebhookNotifier {
+	dialer := &net.Dialer{Timeout: 5 * time.Second, Control: control}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Through a proxy the dial check would only ever see the proxy's address
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &WebhookNotifier{
+		secret: []byte(secret),
+		client: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: transport,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// rejectNonPublic runs after DNS resolution for every connection, so a hostname
+// cannot be pointed at an internal address once the reminder has been created
+func rejectNonPublic(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrNonPublicAddress, address)
+	}
+	if !isPublicAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrNonPublicAddress, addrPort.Addr())
+	}
+	return nil
+}
+
+// isPublicAddr reports whether addr is a globally routable unicast address
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !sharedAddressSpace.Contains(addr)
+}
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), which netip does not treat as private
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// Notify posts the notification; any response other than 2xx is an error
+func (n *WebhookNotifier) Notify(ctx context.Context, notification *domain.Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, notification.Recipient, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "TaskFlow-Webhook/1.0")
+	req.Header.Set(HeaderEvent, notification.Event)
+	req.Header.Set(HeaderIdempotencyKey, notification.ID)
+	if len(n.secret) > 0 {
+		req.Header.Set(HeaderSignature, "sha256="+Sign(n.secret, body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook delivery: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Only the status is reported; the body is the receiver's and ends up in last_error
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the hex HMAC-SHA256 of body, the value of HeaderSignature after "sha256="
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+)
+
+// Notifier delivers notifications over one channel (email, webhook).
+// Delivery may be retried, so implementations should pass notification.ID
+// along for receivers to deduplicate on.
+type Notifier interface {
+	// Notify delivers the notification to notification.Recipient
+	Notify(ctx context.Context, notification *domain.Notification) error
+}
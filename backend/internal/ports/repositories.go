@@ -29,19 +29,24 @@ type TaskRepository interface {
 	FindByID(ctx context.Context, id string) (*domain.Task, error)
 	FindByIDIncludingDeleted(ctx context.Context, id string) (*domain.Task, error)
 	List(ctx context.Context, userID string, filter *domain.TaskListFilter) ([]*domain.Task, error)
+	Count(ctx context.Context, userID string, filter *domain.TaskListFilter) (int, error)
 	Update(ctx context.Context, task *domain.Task) error
-	Delete(ctx context.Context, id, userID string) error
+	// Delete trashes a task and its subtasks; a non-zero expectedVersion guards against concurrent writes
+	Delete(ctx context.Context, id, userID string, expectedVersion int) error
 	Restore(ctx context.Context, id, userID string) error
 	IncrementBumpCount(ctx context.Context, id, userID string) error
 	FindAtRiskTasks(ctx context.Context, userID string) ([]*domain.Task, error)
 	GetCategories(ctx context.Context, userID string) ([]string, error)
 	FindByDateRange(ctx context.Context, userID string, filter *domain.CalendarFilter) ([]*domain.Task, error)
-	RenameCategoryForUser(ctx context.Context, userID, oldName, newName string) (int, error)
-	DeleteCategoryForUser(ctx context.Context, userID, categoryName string) (int, error)
+	// RenameCategoryForUser and DeleteCategoryForUser return the changed tasks as they were before
+	RenameCategoryForUser(ctx context.Context, userID, oldName, newName string) ([]*domain.Task, error)
+	DeleteCategoryForUser(ctx context.Context, userID, categoryName string) ([]*domain.Task, error)
 	// Analytics methods
 	GetCompletionStats(ctx context.Context, userID string, daysBack int) (*repository.CompletionStats, error)
 	GetBumpAnalytics(ctx context.Context, userID string) (*repository.BumpAnalytics, error)
 	GetCategoryBreakdown(ctx context.Context, userID string, daysBack int) ([]repository.CategoryStats, error)
+	GetTagBreakdown(ctx context.Context, userID string, daysBack int) ([]domain.TagStats, error)
+	GetTimeTrackingSummary(ctx context.Context, userID string, daysBack int) (*domain.TimeTrackingSummary, error)
 	GetVelocityMetrics(ctx context.Context, userID string, daysBack int) ([]repository.VelocityMetrics, error)
 	GetPriorityDistribution(ctx context.Context, userID string) ([]repository.PriorityDistribution, error)
 	// Insights analytics methods
@@ -50,24 +55,55 @@ type TaskRepository interface {
 	GetAgingQuickWins(ctx context.Context, userID string, minAgeDays int, limit int) ([]*domain.Task, error)
 	GetDeadlineClusters(ctx context.Context, userID string, windowDays int) ([]domain.DeadlineCluster, error)
 	GetCompletionTimeStats(ctx context.Context, userID string, category *string, effort *domain.TaskEffort) (*domain.CompletionTimeStats, error)
+	GetTrackedTimeStats(ctx context.Context, userID string, category *string, effort *domain.TaskEffort) (*domain.TrackedTimeStats, error)
 	GetCategoryDistribution(ctx context.Context, userID string) ([]domain.CategoryDistribution, error)
 	// Enhanced analytics methods
 	GetProductivityHeatmap(ctx context.Context, userID string, daysBack int) (*domain.ProductivityHeatmap, error)
+	GetFocusHeatmap(ctx context.Context, userID string, daysBack int) (*domain.FocusHeatmap, error)
 	GetCategoryTrends(ctx context.Context, userID string, daysBack int) (*domain.CategoryTrends, error)
 	// Bulk operations
-	BulkDelete(ctx context.Context, userID string, taskIDs []string) (int, []string, error)
 	BulkUpdateStatus(ctx context.Context, userID string, taskIDs []string, newStatus domain.TaskStatus) (int, []string, error)
+	// BulkRestore undeletes the tasks' deletion groups and sets the tasks to todo, atomically
+	BulkRestore(ctx context.Context, userID string, taskIDs []string) (int, []string, error)
+	// CreateDuplicate writes a task copy, its subtasks, series and dependencies in one transaction
+	CreateDuplicate(ctx context.Context, dup *domain.TaskDuplicate) error
+	// Convert writes a task's new type, parent and series in one transaction
+	Convert(ctx context.Context, conv *domain.TaskConversion) error
+	// Trash
+	FindTrash(ctx context.Context, userID string, limit, offset int) ([]*domain.Task, int, error)
+	PurgeTrash(ctx context.Context, userID string, purge *domain.TrashPurge) (int, error)
+	FindUsersWithTrashBefore(ctx context.Context, cutoff time.Time) ([]string, error)
+	// Manual ordering (Create appends tasks without a rank to the end of their list)
+	// FindAdjacentRank returns the next rank after (or before) rank in a list, "" if none
+	FindAdjacentRank(ctx context.Context, scope domain.RankScope, rank, excludeTaskID string, after bool) (string, error)
+	UpdateRank(ctx context.Context, task *domain.Task) error
+	// RebalanceRanks spreads a list's ranks out evenly, keeping its order
+	RebalanceRanks(ctx context.Context, scope domain.RankScope) error
 	// Subtask operations
 	GetSubtasks(ctx context.Context, parentTaskID string) ([]*domain.Task, error)
-	GetSubtasksBatch(ctx context.Context, parentTaskIDs []string) (map[string][]*domain.Task, error)
+	// GetSubtree returns every live subtask below a task, at any depth, parents before their subtasks
+	GetSubtree(ctx context.Context, rootTaskID string) ([]*domain.Task, error)
+	// GetAncestorIDs returns the tasks above a task in its hierarchy, nearest first
+	GetAncestorIDs(ctx context.Context, taskID string) ([]string, error)
 	GetSubtaskInfo(ctx context.Context, parentTaskID string) (*domain.SubtaskInfo, error)
 	CountIncompleteSubtasks(ctx context.Context, parentTaskID string) (int, error)
+	// UpdatePriorityScores sets several tasks' priority scores at once
+	UpdatePriorityScores(ctx context.Context, scores map[string]int) error
 }
 
 // TaskHistoryRepository defines the interface for task history data access
 type TaskHistoryRepository interface {
 	Create(ctx context.Context, history *domain.TaskHistory) error
 	FindByTaskID(ctx context.Context, taskID string) ([]*domain.TaskHistory, error)
+	// ListByTaskID returns a page of the task's history, newest first, and the total matching count
+	ListByTaskID(ctx context.Context, taskID string, filter *domain.TaskHistoryFilter) ([]*domain.TaskHistory, int, error)
+	// ListOperations summarizes the user's most recent task operations, newest first
+	ListOperations(ctx context.Context, userID string, limit int) ([]*domain.TaskOperation, error)
+	// FindByOperationID returns the user's entries written by one operation, oldest first
+	FindByOperationID(ctx context.Context, userID, operationID string) ([]*domain.TaskHistory, error)
+	// MarkOperationUndone claims an operation for undo; false if it was already undone
+	MarkOperationUndone(ctx context.Context, userID, operationID string, undoneAt time.Time) (bool, error)
+	ClearOperationUndone(ctx context.Context, userID, operationID string) error
 }
 
 // TaskSeriesRepository defines the interface for task series data access
@@ -94,6 +130,20 @@ type UserPreferencesRepository interface {
 	DeleteCategoryPreference(ctx context.Context, userID, category string) error
 	DeleteAllCategoryPreferences(ctx context.Context, userID string) error
 	GetAllPreferences(ctx context.Context, userID string) (*domain.AllPreferences, error)
+	GetFocusSettings(ctx context.Context, userID string) (*domain.FocusSettings, error)
+	UpsertFocusSettings(ctx context.Context, userID string, settings *domain.FocusSettings) error
+	GetWIPLimits(ctx context.Context, userID string) (domain.WIPLimits, error)
+	UpsertWIPLimits(ctx context.Context, userID string, limits domain.WIPLimits) error
+}
+
+// WorkflowRepository defines the interface for user-defined workflow data access
+type WorkflowRepository interface {
+	// GetStatuses returns the user's custom statuses in order; empty for the built-in workflow
+	GetStatuses(ctx context.Context, userID string) ([]domain.WorkflowStatus, error)
+	// ReplaceStatuses saves a new workflow and moves tasks out of removed statuses
+	ReplaceStatuses(ctx context.Context, userID string, statuses []domain.WorkflowStatus, mapping map[domain.TaskStatus]domain.TaskStatus) error
+	// DeleteStatuses restores the built-in workflow, moving tasks to their base statuses
+	DeleteStatuses(ctx context.Context, userID string) error
 }
 
 // TaskTemplateRepository defines the interface for task template data access
@@ -107,6 +157,105 @@ type TaskTemplateRepository interface {
 	ExistsByNameExcludingID(ctx context.Context, userID, name, excludeID string) (bool, error)
 }
 
+// SavedViewRepository defines the interface for saved view data access
+type SavedViewRepository interface {
+	Create(ctx context.Context, view *domain.SavedView) error
+	FindByID(ctx context.Context, id string) (*domain.SavedView, error)
+	FindByUserID(ctx context.Context, userID string) ([]*domain.SavedView, error)
+	CountByUserID(ctx context.Context, userID string) (int, error)
+	Update(ctx context.Context, view *domain.SavedView) error
+	Delete(ctx context.Context, id, userID string) error
+	ExistsByName(ctx context.Context, userID, name string) (bool, error)
+	ExistsByNameExcludingID(ctx context.Context, userID, name, excludeID string) (bool, error)
+}
+
+// TagRepository defines the interface for tag data access
+type TagRepository interface {
+	Create(ctx context.Context, tag *domain.Tag) error
+	FindByID(ctx context.Context, id string) (*domain.Tag, error)
+	FindByUserID(ctx context.Context, userID string) ([]*domain.Tag, error)
+	// Update renames/recolors a tag, rewriting templates that reference the old name
+	Update(ctx context.Context, tag *domain.Tag) error
+	Delete(ctx context.Context, id, userID string) error
+	// Merge atomically moves every task from sourceID to targetID and deletes the source.
+	// Returns the number of tasks that carried the source tag.
+	Merge(ctx context.Context, userID, sourceID, targetID string) (int, error)
+}
+
+// CommentRepository defines the interface for task comment data access.
+// Soft-deleted comments are invisible to every read.
+type CommentRepository interface {
+	Create(ctx context.Context, comment *domain.TaskComment) error
+	FindByID(ctx context.Context, id string) (*domain.TaskComment, error)
+	FindByTaskID(ctx context.Context, taskID string) ([]*domain.TaskComment, error)
+	// Update replaces the body, saving the previous body to the edit history
+	Update(ctx context.Context, comment *domain.TaskComment) error
+	FindEdits(ctx context.Context, commentID string) ([]*domain.TaskCommentEdit, error)
+	SoftDelete(ctx context.Context, id, userID string) error
+}
+
+// TimeEntryRepository defines the interface for time entry data access
+type TimeEntryRepository interface {
+	// Create fails with ErrTimerAlreadyRunning if the entry is running and the user already has a running timer
+	Create(ctx context.Context, entry *domain.TimeEntry) error
+	FindByID(ctx context.Context, id string) (*domain.TimeEntry, error)
+	FindRunningByUserID(ctx context.Context, userID string) (*domain.TimeEntry, error)
+	FindByTaskID(ctx context.Context, taskID string) ([]*domain.TimeEntry, error)
+	Stop(ctx context.Context, id, userID string, endedAt time.Time) (*domain.TimeEntry, error)
+	Delete(ctx context.Context, id, userID string) error
+}
+
+// FocusSessionRepository defines the interface for focus session data access
+type FocusSessionRepository interface {
+	// Create fails with ErrFocusSessionActive if the user already has a running or paused session
+	Create(ctx context.Context, session *domain.FocusSession) error
+	FindByID(ctx context.Context, id string) (*domain.FocusSession, error)
+	FindActiveByUserID(ctx context.Context, userID string) (*domain.FocusSession, error)
+	FindByTaskID(ctx context.Context, taskID string) ([]*domain.FocusSession, error)
+	// Update saves a state change, failing with ErrInvalidFocusSessionChange if the
+	// session is no longer in fromStatus (changed concurrently)
+	Update(ctx context.Context, session *domain.FocusSession, fromStatus domain.FocusSessionStatus) error
+	CountCompletedByUserID(ctx context.Context, userID string) (int, error)
+	// CountCompletedToday counts sessions started today in the user's timezone
+	CountCompletedToday(ctx context.Context, userID string) (int, error)
+}
+
+// ReminderRepository defines the interface for task reminder data access
+type ReminderRepository interface {
+	Create(ctx context.Context, reminder *domain.Reminder) error
+	FindByID(ctx context.Context, id string) (*domain.Reminder, error)
+	FindByTaskID(ctx context.Context, taskID string) ([]*domain.Reminder, error)
+	Delete(ctx context.Context, id, userID string) error
+	// ClaimDue leases up to limit due pending reminders of open tasks to the caller.
+	// Concurrent callers never receive the same reminder while its lease lasts.
+	ClaimDue(ctx context.Context, lease time.Duration, limit int) ([]*domain.DueReminder, error)
+	MarkSent(ctx context.Context, id string) error
+	// Release ends the lease on claimed reminders that were not delivered
+	Release(ctx context.Context, ids []string) error
+	// MarkFailed records a failed delivery; a nil retryAt gives up on the reminder
+	MarkFailed(ctx context.Context, id, lastError string, retryAt *time.Time) error
+	// RescheduleForTask moves a task's relative reminders to a new due date,
+	// re-arming sent ones whose new time is still ahead
+	RescheduleForTask(ctx context.Context, taskID string, dueDate *time.Time) error
+	// CopyToTask copies a task's reminders to another task. Relative reminders follow the new
+	// due date; absolute ones are moved by shift, or skipped when shift is nil.
+	CopyToTask(ctx context.Context, fromTaskID, toTaskID string, dueDate *time.Time, shift *time.Duration) error
+}
+
+// AttachmentRepository defines the interface for task attachment metadata access
+type AttachmentRepository interface {
+	// Create inserts the attachment, failing with ErrAttachmentQuotaExceeded if the
+	// user's attachments would exceed quotaBytes
+	Create(ctx context.Context, attachment *domain.Attachment, quotaBytes int64) error
+	FindByID(ctx context.Context, id string) (*domain.Attachment, error)
+	FindByTaskID(ctx context.Context, taskID string) ([]*domain.Attachment, error)
+	SumSizeByUserID(ctx context.Context, userID string) (int64, error)
+	Delete(ctx context.Context, id, userID string) error
+	// Deleted attachment rows (including cascades) queue their blob keys for removal
+	ListPendingBlobDeletions(ctx context.Context, limit int) ([]string, error)
+	RemovePendingBlobDeletions(ctx context.Context, keys []string) error
+}
+
 // DependencyRepository defines the interface for task dependency data access
 type DependencyRepository interface {
 	// Add creates a new dependency (taskID is blocked by blockedByID)
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"io"
 
 	"github.com/notkevinvu/taskflow/backend/internal/domain"
 )
@@ -21,10 +22,15 @@ type TaskService interface {
 	Create(ctx context.Context, userID string, dto *domain.CreateTaskDTO) (*domain.Task, error)
 	Get(ctx context.Context, userID, taskID string) (*domain.Task, error)
 	List(ctx context.Context, userID string, filter *domain.TaskListFilter) ([]*domain.Task, error)
+	ListPage(ctx context.Context, userID string, filter *domain.TaskListFilter) (*domain.TaskListPage, error)
 	Update(ctx context.Context, userID, taskID string, dto *domain.UpdateTaskDTO) (*domain.Task, error)
+	Patch(ctx context.Context, userID, taskID string, patch *domain.TaskMergePatch) (*domain.Task, error)
 	Delete(ctx context.Context, userID, taskID string) error
 	Restore(ctx context.Context, userID, taskID string) (*domain.Task, error)
 	Bump(ctx context.Context, userID, taskID string) (*domain.Task, error)
+	Snooze(ctx context.Context, userID, taskID string, dto *domain.SnoozeTaskDTO) (*domain.Task, error)
+	// GetHistory returns a page of the task's history with field-level changes, newest first
+	GetHistory(ctx context.Context, userID, taskID string, filter *domain.TaskHistoryFilter) (*domain.TaskHistoryPage, error)
 	Complete(ctx context.Context, userID, taskID string) (*domain.Task, error)
 	CompleteWithOptions(ctx context.Context, userID, taskID string, req *domain.TaskCompletionRequest) (*domain.TaskCompletionResponse, error)
 	Uncomplete(ctx context.Context, userID, taskID string) (*domain.Task, error)
@@ -35,6 +41,8 @@ type TaskService interface {
 	// Bulk operations
 	BulkDelete(ctx context.Context, userID string, taskIDs []string) (*domain.BulkOperationResponse, error)
 	BulkRestore(ctx context.Context, userID string, taskIDs []string) (*domain.BulkOperationResponse, error)
+	BulkUpdate(ctx context.Context, userID string, req *domain.BulkUpdateRequest) (*domain.BulkOperationResponse, error)
+	BulkComplete(ctx context.Context, userID string, taskIDs []string) (*domain.BulkCompleteResponse, error)
 }
 
 // InsightsService defines the interface for smart insights and suggestions
@@ -51,6 +59,8 @@ type SubtaskService interface {
 	GetSubtasks(ctx context.Context, userID, parentTaskID string) ([]*domain.Task, error)
 	GetSubtaskInfo(ctx context.Context, userID, parentTaskID string) (*domain.SubtaskInfo, error)
 	GetTaskWithSubtasks(ctx context.Context, userID, taskID string, expandSubtasks bool) (*domain.TaskWithSubtasks, error)
+	// GetTree returns a task with its whole subtree and per-level rollups
+	GetTree(ctx context.Context, userID, taskID string) (*domain.TaskTree, error)
 
 	// Subtask completion with parent prompt
 	CompleteSubtask(ctx context.Context, userID, subtaskID string) (*domain.SubtaskCompletionResponse, error)
@@ -88,6 +98,8 @@ type DependencyService interface {
 	GetDependencyInfo(ctx context.Context, userID, taskID string) (*domain.DependencyInfo, error)
 	// ValidateCompletion checks if a task can be completed (no incomplete blockers)
 	ValidateCompletion(ctx context.Context, taskID string) error
+	// OrderForCompletion orders task IDs so blockers come before the tasks they block
+	OrderForCompletion(ctx context.Context, userID string, taskIDs []string) ([]string, error)
 	// GetBlockerCompletionInfo returns info about tasks unblocked when a blocker completes
 	GetBlockerCompletionInfo(ctx context.Context, blockerTaskID string) (*domain.BlockerCompletionInfo, error)
 }
@@ -102,12 +114,173 @@ type TaskTemplateService interface {
 	List(ctx context.Context, userID string) ([]*domain.TaskTemplate, error)
 	// Update updates an existing template
 	Update(ctx context.Context, userID, templateID string, dto *domain.UpdateTaskTemplateDTO) (*domain.TaskTemplate, error)
+	// Patch applies a JSON merge patch to a template (null clears optional fields)
+	Patch(ctx context.Context, userID, templateID string, patch *domain.TaskTemplateMergePatch) (*domain.TaskTemplate, error)
 	// Delete removes a template
 	Delete(ctx context.Context, userID, templateID string) error
 	// CreateTaskFromTemplate converts a template to a CreateTaskDTO ready for task creation
 	CreateTaskFromTemplate(ctx context.Context, userID, templateID string, overrides *domain.CreateTaskDTO) (*domain.CreateTaskDTO, error)
 }
 
+// SavedViewService defines the interface for saved view business logic
+type SavedViewService interface {
+	// Create creates a new saved view
+	Create(ctx context.Context, userID string, dto *domain.CreateSavedViewDTO) (*domain.SavedView, error)
+	// Get retrieves a saved view by ID (with ownership verification)
+	Get(ctx context.Context, userID, viewID string) (*domain.SavedView, error)
+	// List retrieves all saved views for a user in display order
+	List(ctx context.Context, userID string) ([]*domain.SavedView, error)
+	// Update updates an existing saved view
+	Update(ctx context.Context, userID, viewID string, dto *domain.UpdateSavedViewDTO) (*domain.SavedView, error)
+	// Delete removes a saved view
+	Delete(ctx context.Context, userID, viewID string) error
+	// ListTasks runs the view's filter and returns one page of matching tasks
+	ListTasks(ctx context.Context, userID, viewID, cursor string, limit int) (*domain.TaskListPage, error)
+}
+
+// TagService defines the interface for tag business logic
+type TagService interface {
+	// Create creates a new tag
+	Create(ctx context.Context, userID string, dto *domain.CreateTagDTO) (*domain.Tag, error)
+	// Get retrieves a tag by ID (with ownership verification)
+	Get(ctx context.Context, userID, tagID string) (*domain.Tag, error)
+	// List retrieves all tags for a user with task counts
+	List(ctx context.Context, userID string) ([]*domain.Tag, error)
+	// Update renames or recolors a tag
+	Update(ctx context.Context, userID, tagID string, dto *domain.UpdateTagDTO) (*domain.Tag, error)
+	// Delete removes a tag from every task and deletes it
+	Delete(ctx context.Context, userID, tagID string) error
+	// Merge folds the source tag into the target tag
+	Merge(ctx context.Context, userID, sourceID, targetID string) (*domain.MergeTagsResponse, error)
+}
+
+// CommentService defines the interface for task comment business logic.
+// Every method verifies the caller owns the task.
+type CommentService interface {
+	// Create adds a comment to a task
+	Create(ctx context.Context, userID, taskID string, dto *domain.CreateCommentDTO) (*domain.TaskComment, error)
+	// List retrieves a task's comments, oldest first
+	List(ctx context.Context, userID, taskID string) ([]*domain.TaskComment, error)
+	// Update edits a comment, keeping the previous body in its edit history
+	Update(ctx context.Context, userID, taskID, commentID string, dto *domain.UpdateCommentDTO) (*domain.TaskComment, error)
+	// Delete soft-deletes a comment
+	Delete(ctx context.Context, userID, taskID, commentID string) error
+	// ListEdits retrieves a comment's previous bodies, oldest first
+	ListEdits(ctx context.Context, userID, taskID, commentID string) (*domain.CommentEditListResponse, error)
+}
+
+// TimeEntryService defines the interface for time tracking business logic
+type TimeEntryService interface {
+	// StartTimer starts the user's timer on a task; a user has at most one running timer
+	StartTimer(ctx context.Context, userID, taskID string, dto *domain.StartTimerDTO) (*domain.TimeEntry, error)
+	// StopTimer stops the user's timer if it is running on the task
+	StopTimer(ctx context.Context, userID, taskID string) (*domain.TimeEntry, error)
+	// GetRunningTimer returns the user's running timer, or nil if none is running
+	GetRunningTimer(ctx context.Context, userID string) (*domain.TimeEntry, error)
+	CreateEntry(ctx context.Context, userID, taskID string, dto *domain.CreateTimeEntryDTO) (*domain.TimeEntry, error)
+	List(ctx context.Context, userID, taskID string) (*domain.TimeEntryListResponse, error)
+	DeleteEntry(ctx context.Context, userID, taskID, entryID string) error
+}
+
+// FocusSessionService defines the interface for focus session (Pomodoro) business logic
+type FocusSessionService interface {
+	GetSettings(ctx context.Context, userID string) (*domain.FocusSettings, error)
+	UpdateSettings(ctx context.Context, userID string, dto *domain.UpdateFocusSettingsDTO) (*domain.FocusSettings, error)
+	// Start starts a focus session on a task; a user has at most one running or paused session
+	Start(ctx context.Context, userID, taskID string, dto *domain.StartFocusSessionDTO) (*domain.FocusSession, error)
+	// GetActive returns the user's running or paused session, or nil if there is none
+	GetActive(ctx context.Context, userID string) (*domain.FocusSession, error)
+	Pause(ctx context.Context, userID, sessionID string) (*domain.FocusSession, error)
+	Resume(ctx context.Context, userID, sessionID string) (*domain.FocusSession, error)
+	// Complete ends the session, suggests the next break and awards the focus achievement if earned
+	Complete(ctx context.Context, userID, sessionID string) (*domain.FocusSessionCompletion, error)
+	Interrupt(ctx context.Context, userID, sessionID string, dto *domain.InterruptFocusSessionDTO) (*domain.FocusSession, error)
+	ListForTask(ctx context.Context, userID, taskID string) (*domain.FocusSessionListResponse, error)
+}
+
+// ReminderService defines the interface for task reminders and their delivery
+type ReminderService interface {
+	Create(ctx context.Context, userID, taskID string, dto *domain.CreateReminderDTO) (*domain.Reminder, error)
+	List(ctx context.Context, userID, taskID string) ([]*domain.Reminder, error)
+	Delete(ctx context.Context, userID, taskID, reminderID string) error
+	// RescheduleForTask moves the task's relative reminders after its due date changed
+	RescheduleForTask(ctx context.Context, task *domain.Task) error
+	// CarryOver copies a recurring task's reminders to its next instance
+	CarryOver(ctx context.Context, from, to *domain.Task) error
+	// ProcessDueReminders delivers the reminders that are due
+	ProcessDueReminders(ctx context.Context) (*domain.ReminderRunResult, error)
+}
+
+// UndoService defines the interface for undoing recent task operations
+type UndoService interface {
+	// ListOperations returns the user's most recent operations, newest first
+	ListOperations(ctx context.Context, userID string) ([]*domain.TaskOperation, error)
+	// Undo reverts an operation; the undo is itself an operation that can be undone
+	Undo(ctx context.Context, userID string, req *domain.UndoRequest) (*domain.UndoResult, error)
+}
+
+// TrashService defines the interface for the trash of soft-deleted tasks
+type TrashService interface {
+	// List returns a page of the user's trash, most recently deleted first
+	List(ctx context.Context, userID string, limit, offset int) (*domain.TrashPage, error)
+	// DeletePermanently removes a trashed task and its subtasks for good
+	DeletePermanently(ctx context.Context, userID, taskID string) error
+	Empty(ctx context.Context, userID string) (*domain.EmptyTrashResponse, error)
+}
+
+// DuplicateService defines the interface for copying tasks
+type DuplicateService interface {
+	// Duplicate copies a task, optionally with its subtasks, blockers and a new recurrence
+	Duplicate(ctx context.Context, userID, taskID string, req *domain.DuplicateTaskRequest) (*domain.TaskWithSubtasks, error)
+}
+
+// ConvertService defines the interface for changing a task's type
+type ConvertService interface {
+	// Convert turns a task into a regular task, a subtask of another task, or a recurring task
+	Convert(ctx context.Context, userID, taskID string, req *domain.ConvertTaskRequest) (*domain.Task, error)
+}
+
+// MoveService defines the interface for arranging tasks by hand
+type MoveService interface {
+	// Move places a task between two neighbors of its list (top-level tasks, or subtasks of one parent)
+	Move(ctx context.Context, userID, taskID string, req *domain.MoveTaskRequest) (*domain.Task, error)
+	// Rank picks the rank between the requested neighbors without moving the task
+	Rank(ctx context.Context, userID string, task *domain.Task, req *domain.MoveTaskRequest) (string, error)
+}
+
+// BoardService defines the interface for the status board
+type BoardService interface {
+	// GetBoard returns top-level tasks grouped by workflow status, each column paged and in manual order
+	GetBoard(ctx context.Context, userID string, filter *domain.BoardFilter) (*domain.Board, error)
+	GetWIPLimits(ctx context.Context, userID string) (domain.WIPLimits, error)
+	UpdateWIPLimits(ctx context.Context, userID string, dto *domain.UpdateWIPLimitsDTO) (domain.WIPLimits, error)
+	// MoveTask moves a task to a column within its WIP limit, optionally between two of its tasks
+	MoveTask(ctx context.Context, userID, taskID string, req *domain.MoveBoardTaskRequest) (*domain.Task, error)
+}
+
+// WorkflowService defines the interface for user-defined workflows
+type WorkflowService interface {
+	// GetWorkflow returns the user's workflow, or the built-in one if they have none
+	GetWorkflow(ctx context.Context, userID string) (*domain.Workflow, error)
+	UpdateWorkflow(ctx context.Context, userID string, dto *domain.UpdateWorkflowDTO) (*domain.Workflow, error)
+	// ResetWorkflow restores the built-in workflow
+	ResetWorkflow(ctx context.Context, userID string) (*domain.Workflow, error)
+}
+
+// AttachmentService defines the interface for task attachment business logic
+type AttachmentService interface {
+	// Upload stores a file and attaches it to a task, enforcing size and quota limits
+	Upload(ctx context.Context, userID, taskID string, upload *domain.AttachmentUpload) (*domain.Attachment, error)
+	// List retrieves a task's attachments along with the user's quota usage
+	List(ctx context.Context, userID, taskID string) (*domain.AttachmentListResponse, error)
+	// Open returns an attachment and its content; the caller must close the reader
+	Open(ctx context.Context, userID, taskID, attachmentID string) (*domain.Attachment, io.ReadCloser, error)
+	// Delete removes an attachment and its blob
+	Delete(ctx context.Context, userID, taskID, attachmentID string) error
+	// PurgeDeletedBlobs removes the blobs of deleted attachments from the blob store
+	PurgeDeletedBlobs(ctx context.Context) (int, error)
+}
+
 // GamificationService defines the interface for gamification business logic
 type GamificationService interface {
 	// Dashboard data
@@ -120,12 +293,18 @@ type GamificationService interface {
 	// Allows API to return immediately while gamification processing continues
 	ProcessTaskCompletionAsync(userID string, task *domain.Task)
 
+	// Called once after a batch of tasks is completed - stats and achievements are evaluated once
+	ProcessBulkTaskCompletion(ctx context.Context, userID string, tasks []*domain.Task) (*domain.TaskCompletionGamificationResult, error)
+
 	// Called when a task completion is reversed - decrements stats and revokes invalid achievements
 	ProcessTaskUncompletion(ctx context.Context, userID string, task *domain.Task) error
 
 	// Async version that processes uncompletion in background goroutine
 	ProcessTaskUncompletionAsync(userID string, task *domain.Task)
 
+	// Called when a focus session is completed - awards the focus achievement once enough are completed
+	ProcessFocusSessionCompletion(ctx context.Context, userID string, completedSessions int) *domain.AchievementEarnedEvent
+
 	// Stats computation (can be called to refresh cache)
 	ComputeStats(ctx context.Context, userID string) (*domain.GamificationStats, error)
 
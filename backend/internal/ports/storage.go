@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStore defines the interface for binary object storage (attachment files).
+// Keys are slash-separated paths generated by the application.
+type BlobStore interface {
+	// Put stores size bytes read from r under key, replacing any existing object
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens the object stored under key. Returns domain.ErrBlobNotFound if it does not exist.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object stored under key. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+)
+
+// AttachmentRepository handles database operations for task attachment metadata
+type AttachmentRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewAttachmentRepository creates a new attachment repository
+func NewAttachmentRepository(db *pgxpool.Pool) *AttachmentRepository {
+	return &AttachmentRepository{db: db}
+}
+
+// Create inserts an attachment if it fits in the user's remaining quota.
+// The user's attachments are locked for the check so concurrent uploads cannot
+// both squeeze under the limit.
+func (r *AttachmentRepository) Create(ctx context.Context, attachment *domain.Attachment, quotaBytes int64) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext('attachments:' || $1))", attachment.UserID); err != nil {
+		return err
+	}
+
+	var used int64
+	err = tx.QueryRow(ctx, `
+		SELECT COALESCE(SUM(size_bytes), 0)::bigint FROM task_attachments WHERE user_id = $1
+	`, attachment.UserID).Scan(&used)
+	if err != nil {
+		return err
+	}
+	if used+attachment.SizeBytes > quotaBytes {
+		return domain.ErrAttachmentQuotaExceeded
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO task_attachments (id, task_id, user_id, filename, content_type, size_bytes, storage_key, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`,
+		attachment.ID,
+		attachment.TaskID,
+		attachment.UserID,
+		attachment.Filename,
+		attachment.ContentType,
+		attachment.SizeBytes,
+		attachment.StorageKey,
+		attachment.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// FindByID retrieves an attachment by ID
+func (r *AttachmentRepository) FindByID(ctx context.Context, id string) (*domain.Attachment, error) {
+	var attachment domain.Attachment
+
+	err := r.db.QueryRow(ctx, `
+		SELECT id, task_id, user_id, filename, content_type, size_bytes, storage_key, created_at
+		FROM task_attachments
+		WHERE id = $1
+	`, id).Scan(
+		&attachment.ID,
+		&attachment.TaskID,
+		&attachment.UserID,
+		&attachment.Filename,
+		&attachment.ContentType,
+		&attachment.SizeBytes,
+		&attachment.StorageKey,
+		&attachment.CreatedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+// FindByTaskID retrieves a task's attachments, oldest first
+func (r *AttachmentRepository) FindByTaskID(ctx context.Context, taskID string) ([]*domain.Attachment, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, task_id, user_id, filename, content_type, size_bytes, storage_key, created_at
+		FROM task_attachments
+		WHERE task_id = $1
+		ORDER BY created_at ASC, id ASC
+	`, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := []*domain.Attachment{}
+	for rows.Next() {
+		var attachment domain.Attachment
+		err := rows.Scan(
+			&attachment.ID,
+			&attachment.TaskID,
+			&attachment.UserID,
+			&attachment.Filename,
+			&attachment.ContentType,
+			&attachment.SizeBytes,
+			&attachment.StorageKey,
+			&attachment.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, &attachment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+// SumSizeByUserID returns the total size of a user's attachments in bytes
+func (r *AttachmentRepository) SumSizeByUserID(ctx context.Context, userID string) (int64, error) {
+	var used int64
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE(SUM(size_bytes), 0)::bigint FROM task_attachments WHERE user_id = $1
+	`, userID).Scan(&used)
+	return used, err
+}
+
+// Delete removes an attachment row. The trigger on task_attachments queues its blob for deletion.
+func (r *AttachmentRepository) Delete(ctx context.Context, id, userID string) error {
+	result, err := r.db.Exec(ctx, `
+		DELETE FROM task_attachments WHERE id = $1 AND user_id = $2
+	`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrAttachmentNotFound
+	}
+
+	return nil
+}
+
+// ListPendingBlobDeletions returns up to limit blob keys queued for deletion, oldest first
+func (r *AttachmentRepository) ListPendingBlobDeletions(ctx context.Context, limit int) ([]string, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT storage_key FROM attachment_blob_deletions
+		ORDER BY queued_at ASC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []string{}
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// RemovePendingBlobDeletions dequeues blob keys once their blobs have been deleted
+func (r *AttachmentRepository) RemovePendingBlobDeletions(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	_, err := r.db.Exec(ctx, `
+		DELETE FROM attachment_blob_deletions WHERE storage_key = ANY($1)
+	`, keys)
+	return err
+}
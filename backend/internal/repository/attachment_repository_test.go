@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// AttachmentRepository Integration Tests
+// =============================================================================
+
+func newTestAttachment(userID, taskID string, size int64) *domain.Attachment {
+	id := uuid.New().String()
+	return &domain.Attachment{
+		ID:          id,
+		TaskID:      taskID,
+		UserID:      userID,
+		Filename:    "notes.txt",
+		ContentType: "text/plain; charset=utf-8",
+		SizeBytes:   size,
+		StorageKey:  domain.AttachmentStorageKey(userID, taskID, id),
+		CreatedAt:   time.Now().UTC().Truncate(time.Microsecond),
+	}
+}
+
+func TestAttachmentRepository_QuotaAndBlobDeletionQueue(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool := setupTestDB(t)
+	repo := NewAttachmentRepository(pool)
+	taskRepo := NewTaskRepository(pool)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, pool)
+	task := createTestTask(t, ctx, taskRepo, userID, "Task With Files")
+
+	t.Run("create enforces the quota", func(t *testing.T) {
+		first := newTestAttachment(userID, task.ID, 60)
+		require.NoError(t, repo.Create(ctx, first, 100))
+
+		err := repo.Create(ctx, newTestAttachment(userID, task.ID, 41), 100)
+		assert.ErrorIs(t, err, domain.ErrAttachmentQuotaExceeded)
+
+		used, err := repo.SumSizeByUserID(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(60), used)
+
+		attachments, err := repo.FindByTaskID(ctx, task.ID)
+		require.NoError(t, err)
+		require.Len(t, attachments, 1)
+		assert.Equal(t, first.StorageKey, attachments[0].StorageKey)
+	})
+
+	t.Run("delete queues the blob", func(t *testing.T) {
+		attachment := newTestAttachment(userID, task.ID, 10)
+		require.NoError(t, repo.Create(ctx, attachment, 1000))
+
+		require.NoError(t, repo.Delete(ctx, attachment.ID, userID))
+		_, err := repo.FindByID(ctx, attachment.ID)
+		assert.ErrorIs(t, err, domain.ErrAttachmentNotFound)
+
+		keys, err := repo.ListPendingBlobDeletions(ctx, 100)
+		require.NoError(t, err)
+		assert.Contains(t, keys, attachment.StorageKey)
+
+		require.NoError(t, repo.RemovePendingBlobDeletions(ctx, []string{attachment.StorageKey}))
+		keys, err = repo.ListPendingBlobDeletions(ctx, 100)
+		require.NoError(t, err)
+		assert.NotContains(t, keys, attachment.StorageKey)
+	})
+
+	t.Run("purging a trashed task queues its blobs", func(t *testing.T) {
+		doomed := createTestTask(t, ctx, taskRepo, userID, "Doomed Task")
+		attachment := newTestAttachment(userID, doomed.ID, 10)
+		require.NoError(t, repo.Create(ctx, attachment, 1000))
+
+		require.NoError(t, taskRepo.Delete(ctx, doomed.ID, userID, 0))
+		purged, err := taskRepo.PurgeTrash(ctx, userID, &domain.TrashPurge{
+			Reason: domain.TaskPurgeReasonPermanent,
+			TaskID: &doomed.ID,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, purged)
+
+		keys, err := repo.ListPendingBlobDeletions(ctx, 100)
+		require.NoError(t, err)
+		assert.Contains(t, keys, attachment.StorageKey)
+	})
+
+	t.Run("user delete queues their blobs", func(t *testing.T) {
+		otherUserID := createTestUser(t, ctx, pool)
+		otherTask := createTestTask(t, ctx, taskRepo, otherUserID, "Anonymous Task")
+		attachment := newTestAttachment(otherUserID, otherTask.ID, 10)
+		require.NoError(t, repo.Create(ctx, attachment, 1000))
+
+		require.NoError(t, NewUserRepository(pool).Delete(ctx, otherUserID))
+
+		keys, err := repo.ListPendingBlobDeletions(ctx, 100)
+		require.NoError(t, err)
+		assert.Contains(t, keys, attachment.StorageKey)
+	})
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+)
+
+// CommentRepository handles database operations for task comments
+type CommentRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewCommentRepository creates a new comment repository
+func NewCommentRepository(db *pgxpool.Pool) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+// Create inserts a new comment into the database
+func (r *CommentRepository) Create(ctx context.Context, comment *domain.TaskComment) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO task_comments (id, task_id, user_id, body, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`,
+		comment.ID,
+		comment.TaskID,
+		comment.UserID,
+		comment.Body,
+		comment.CreatedAt,
+		comment.UpdatedAt,
+	)
+	return err
+}
+
+// FindByID retrieves a comment by ID, excluding soft-deleted comments
+func (r *CommentRepository) FindByID(ctx context.Context, id string) (*domain.TaskComment, error) {
+	var comment domain.TaskComment
+
+	err := r.db.QueryRow(ctx, `
+		SELECT id, task_id, user_id, body, edited_at, deleted_at, created_at, updated_at
+		FROM task_comments
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id).Scan(
+		&comment.ID,
+		&comment.TaskID,
+		&comment.UserID,
+		&comment.Body,
+		&comment.EditedAt,
+		&comment.DeletedAt,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrCommentNotFound
+		}
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+// FindByTaskID retrieves a task's comments, oldest first, excluding soft-deleted comments
+func (r *CommentRepository) FindByTaskID(ctx context.Context, taskID string) ([]*domain.TaskComment, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, task_id, user_id, body, edited_at, deleted_at, created_at, updated_at
+		FROM task_comments
+		WHERE task_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at ASC, id ASC
+	`, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := []*domain.TaskComment{}
+	for rows.Next() {
+		var comment domain.TaskComment
+		err := rows.Scan(
+			&comment.ID,
+			&comment.TaskID,
+			&comment.UserID,
+			&comment.Body,
+			&comment.EditedAt,
+			&comment.DeletedAt,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, &comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+// Update replaces a comment's body, recording the previous body in its edit
+// history in the same transaction
+func (r *CommentRepository) Update(ctx context.Context, comment *domain.TaskComment) error {
+	now := time.Now()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+
+	var previousBody string
+	err = tx.QueryRow(ctx, `
+		SELECT body FROM task_comments
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
+		FOR UPDATE
+	`, comment.ID, comment.UserID).Scan(&previousBody)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrCommentNotFound
+		}
+		return err
+	}
+
+	// Saving an unchanged body is not an edit
+	if previousBody == comment.Body {
+		return nil
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO task_comment_edits (comment_id, body, edited_at)
+		VALUES ($1, $2, $3)
+	`, comment.ID, previousBody, now)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE task_comments
+		SET body = $2, edited_at = $3, updated_at = $3
+		WHERE id = $1
+	`, comment.ID, comment.Body, now)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	comment.EditedAt = &now
+	comment.UpdatedAt = now
+	return nil
+}
+
+// FindEdits retrieves the previous bodies of a comment, oldest first
+func (r *CommentRepository) FindEdits(ctx context.Context, commentID string) ([]*domain.TaskCommentEdit, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, comment_id, body, edited_at
+		FROM task_comment_edits
+		WHERE comment_id = $1
+		ORDER BY edited_at ASC, id ASC
+	`, commentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	edits := []*domain.TaskCommentEdit{}
+	for rows.Next() {
+		var edit domain.TaskCommentEdit
+		if err := rows.Scan(&edit.ID, &edit.CommentID, &edit.Body, &edit.EditedAt); err != nil {
+			return nil, err
+		}
+		edits = append(edits, &edit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return edits, nil
+}
+
+// SoftDelete marks a comment as deleted. Its body and edit history are kept.
+func (r *CommentRepository) SoftDelete(ctx context.Context, id, userID string) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE task_comments
+		SET deleted_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
+	`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrCommentNotFound
+	}
+
+	return nil
+}
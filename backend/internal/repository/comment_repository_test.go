@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// CommentRepository Integration Tests
+// =============================================================================
+
+func createTestComment(t *testing.T, ctx context.Context, repo *CommentRepository, userID, taskID, body string) *domain.TaskComment {
+	t.Helper()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	comment := &domain.TaskComment{
+		ID:        uuid.New().String(),
+		TaskID:    taskID,
+		UserID:    userID,
+		Body:      body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	require.NoError(t, repo.Create(ctx, comment))
+	return comment
+}
+
+func TestCommentRepository_EditHistoryAndSoftDelete(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool := setupTestDB(t)
+	repo := NewCommentRepository(pool)
+	taskRepo := NewTaskRepository(pool)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, pool)
+	task := createTestTask(t, ctx, taskRepo, userID, "Commented Task")
+
+	t.Run("edit keeps the previous body", func(t *testing.T) {
+		comment := createTestComment(t, ctx, repo, userID, task.ID, "first draft")
+
+		comment.Body = "second draft"
+		require.NoError(t, repo.Update(ctx, comment))
+		assert.NotNil(t, comment.EditedAt)
+
+		edits, err := repo.FindEdits(ctx, comment.ID)
+		require.NoError(t, err)
+		require.Len(t, edits, 1)
+		assert.Equal(t, "first draft", edits[0].Body)
+
+		found, err := repo.FindByID(ctx, comment.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "second draft", found.Body)
+	})
+
+	t.Run("soft-deleted comments are hidden and not counted", func(t *testing.T) {
+		comment := createTestComment(t, ctx, repo, userID, task.ID, "to be removed")
+
+		require.NoError(t, repo.SoftDelete(ctx, comment.ID, userID))
+
+		_, err := repo.FindByID(ctx, comment.ID)
+		assert.ErrorIs(t, err, domain.ErrCommentNotFound)
+
+		comments, err := repo.FindByTaskID(ctx, task.ID)
+		require.NoError(t, err)
+		found, err := taskRepo.FindByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, len(comments), found.CommentCount)
+
+		assert.ErrorIs(t, repo.SoftDelete(ctx, comment.ID, userID), domain.ErrCommentNotFound)
+	})
+
+	t.Run("deleting the user removes their comments", func(t *testing.T) {
+		otherUserID := createTestUser(t, ctx, pool)
+		otherTask := createTestTask(t, ctx, taskRepo, otherUserID, "Expiring Task")
+		comment := createTestComment(t, ctx, repo, otherUserID, otherTask.ID, "note")
+
+		require.NoError(t, NewUserRepository(pool).Delete(ctx, otherUserID))
+
+		var count int
+		err := pool.QueryRow(ctx, "SELECT COUNT(*) FROM task_comments WHERE id = $1", comment.ID).Scan(&count)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+}
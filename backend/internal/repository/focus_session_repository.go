@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+)
+
+// focusSessionColumns is the select list scanned by scanFocusSession
+const focusSessionColumns = `id, task_id, user_id, status, planned_minutes, started_at, paused_at,
+		paused_seconds, ended_at, focused_seconds, interrupt_reason, created_at, updated_at`
+
+// FocusSessionRepository handles database operations for focus sessions
+type FocusSessionRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewFocusSessionRepository creates a new focus session repository
+func NewFocusSessionRepository(db *pgxpool.Pool) *FocusSessionRepository {
+	return &FocusSessionRepository{db: db}
+}
+
+func scanFocusSession(row pgx.Row) (*domain.FocusSession, error) {
+	var session domain.FocusSession
+	err := row.Scan(
+		&session.ID,
+		&session.TaskID,
+		&session.UserID,
+		&session.Status,
+		&session.PlannedMinutes,
+		&session.StartedAt,
+		&session.PausedAt,
+		&session.PausedSeconds,
+		&session.EndedAt,
+		&session.FocusedSeconds,
+		&session.InterruptReason,
+		&session.CreatedAt,
+		&session.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Create inserts a focus session. Inserting a second active session for a user
+// fails with ErrFocusSessionActive.
+func (r *FocusSessionRepository) Create(ctx context.Context, session *domain.FocusSession) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO focus_sessions (id, task_id, user_id, status, planned_minutes, started_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`,
+		session.ID,
+		session.TaskID,
+		session.UserID,
+		session.Status,
+		session.PlannedMinutes,
+		session.StartedAt,
+		session.CreatedAt,
+		session.UpdatedAt,
+	)
+	if err != nil {
+		if isPgUniqueViolation(err) {
+			return domain.ErrFocusSessionActive
+		}
+		return err
+	}
+	return nil
+}
+
+// FindByID retrieves a focus session by ID
+func (r *FocusSessionRepository) FindByID(ctx context.Context, id string) (*domain.FocusSession, error) {
+	session, err := scanFocusSession(r.db.QueryRow(ctx, `
+		SELECT `+focusSessionColumns+`
+		FROM focus_sessions
+		WHERE id = $1
+	`, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrFocusSessionNotFound
+		}
+		return nil, err
+	}
+	return session, nil
+}
+
+// FindActiveByUserID retrieves the user's running or paused session.
+// Returns ErrFocusSessionNotFound when there is none.
+func (r *FocusSessionRepository) FindActiveByUserID(ctx context.Context, userID string) (*domain.FocusSession, error) {
+	session, err := scanFocusSession(r.db.QueryRow(ctx, `
+		SELECT `+focusSessionColumns+`
+		FROM focus_sessions
+		WHERE user_id = $1 AND status IN ('running', 'paused')
+	`, userID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrFocusSessionNotFound
+		}
+		return nil, err
+	}
+	return session, nil
+}
+
+// FindByTaskID retrieves a task's focus sessions, most recent first
+func (r *FocusSessionRepository) FindByTaskID(ctx context.Context, taskID string) ([]*domain.FocusSession, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+focusSessionColumns+`
+		FROM focus_sessions
+		WHERE task_id = $1
+		ORDER BY started_at DESC, id
+	`, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []*domain.FocusSession{}
+	for rows.Next() {
+		session, err := scanFocusSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// Update saves the session's state fields if it is still in fromStatus
+func (r *FocusSessionRepository) Update(ctx context.Context, session *domain.FocusSession, fromStatus domain.FocusSessionStatus) error {
+	err := r.db.QueryRow(ctx, `
+		UPDATE focus_sessions
+		SET status = $3,
+			paused_at = $4,
+			paused_seconds = $5,
+			ended_at = $6,
+			focused_seconds = $7,
+			interrupt_reason = $8
+		WHERE id = $1 AND user_id = $2 AND status = $9
+		RETURNING updated_at
+	`,
+		session.ID,
+		session.UserID,
+		session.Status,
+		session.PausedAt,
+		session.PausedSeconds,
+		session.EndedAt,
+		session.FocusedSeconds,
+		session.InterruptReason,
+		fromStatus,
+	).Scan(&session.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrInvalidFocusSessionChange
+		}
+		return err
+	}
+	return nil
+}
+
+// CountCompletedByUserID counts the user's completed focus sessions
+func (r *FocusSessionRepository) CountCompletedByUserID(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*)::int
+		FROM focus_sessions
+		WHERE user_id = $1 AND status = 'completed'
+	`, userID).Scan(&count)
+	return count, err
+}
+
+// CountCompletedToday counts the user's completed sessions started since
+// midnight in their timezone (UTC if they have not set one)
+func (r *FocusSessionRepository) CountCompletedToday(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `
+		WITH tz AS (
+			SELECT COALESCE((SELECT timezone FROM user_preferences WHERE user_id = $1), 'UTC') AS name
+		)
+		SELECT COUNT(*)::int
+		FROM focus_sessions fs, tz
+		WHERE fs.user_id = $1
+		  AND fs.status = 'completed'
+		  AND fs.started_at >= (date_trunc('day', NOW() AT TIME ZONE tz.name) AT TIME ZONE tz.name)
+	`, userID).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// FocusSessionRepository Integration Tests
+// =============================================================================
+
+func newTestFocusSession(userID, taskID string, startedAt time.Time) *domain.FocusSession {
+	return &domain.FocusSession{
+		ID:             uuid.New().String(),
+		TaskID:         taskID,
+		UserID:         userID,
+		Status:         domain.FocusSessionRunning,
+		PlannedMinutes: 25,
+		StartedAt:      startedAt,
+		CreatedAt:      startedAt,
+		UpdatedAt:      startedAt,
+	}
+}
+
+func TestFocusSessionRepository_SessionLifecycle(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool := setupTestDB(t)
+	repo := NewFocusSessionRepository(pool)
+	taskRepo := NewTaskRepository(pool)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, pool)
+	task := createTestTask(t, ctx, taskRepo, userID, "Focus Task")
+
+	start := time.Now().UTC().Add(-30 * time.Minute).Truncate(time.Microsecond)
+	session := newTestFocusSession(userID, task.ID, start)
+
+	t.Run("one active session per user", func(t *testing.T) {
+		require.NoError(t, repo.Create(ctx, session))
+
+		err := repo.Create(ctx, newTestFocusSession(userID, task.ID, start))
+		assert.ErrorIs(t, err, domain.ErrFocusSessionActive)
+
+		active, err := repo.FindActiveByUserID(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, session.ID, active.ID)
+	})
+
+	t.Run("update is guarded by the previous status", func(t *testing.T) {
+		require.NoError(t, session.Complete(start.Add(25*time.Minute)))
+		require.NoError(t, repo.Update(ctx, session, domain.FocusSessionRunning))
+
+		stale := *session
+		assert.ErrorIs(t, repo.Update(ctx, &stale, domain.FocusSessionRunning), domain.ErrInvalidFocusSessionChange)
+
+		found, err := repo.FindByID(ctx, session.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.FocusSessionCompleted, found.Status)
+		assert.Equal(t, 25*60, found.FocusedSeconds)
+
+		_, err = repo.FindActiveByUserID(ctx, userID)
+		assert.ErrorIs(t, err, domain.ErrFocusSessionNotFound)
+	})
+
+	t.Run("completed sessions are counted", func(t *testing.T) {
+		total, err := repo.CountCompletedByUserID(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+
+		found, err := taskRepo.FindByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, found.FocusSessionCount)
+	})
+}
+
+func TestUserPreferencesRepository_FocusSettings(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool := setupTestDB(t)
+	repo := NewUserPreferencesRepository(pool)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, pool)
+
+	settings, err := repo.GetFocusSettings(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DefaultFocusSettings(), *settings)
+
+	settings.WorkMinutes = 50
+	settings.CountTowardAchievements = false
+	require.NoError(t, repo.UpsertFocusSettings(ctx, userID, settings))
+
+	saved, err := repo.GetFocusSettings(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 50, saved.WorkMinutes)
+	assert.False(t, saved.CountTowardAchievements)
+}
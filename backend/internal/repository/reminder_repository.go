@@ -0,0 +1,255 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+)
+
+// reminderColumns is the select list scanned by scanReminder
+const reminderColumns = `id, task_id, user_id, offset_minutes, remind_at, channel, target,
+		status, attempts, last_error, sent_at, created_at, updated_at`
+
+// ReminderRepository handles database operations for task reminders
+type ReminderRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewReminderRepository creates a new reminder repository
+func NewReminderRepository(db *pgxpool.Pool) *ReminderRepository {
+	return &ReminderRepository{db: db}
+}
+
+// reminderScanTargets returns the scan destinations matching reminderColumns
+func reminderScanTargets(reminder *domain.Reminder) []any {
+	return []any{
+		&reminder.ID,
+		&reminder.TaskID,
+		&reminder.UserID,
+		&reminder.OffsetMinutes,
+		&reminder.RemindAt,
+		&reminder.Channel,
+		&reminder.Target,
+		&reminder.Status,
+		&reminder.Attempts,
+		&reminder.LastError,
+		&reminder.SentAt,
+		&reminder.CreatedAt,
+		&reminder.UpdatedAt,
+	}
+}
+
+func scanReminder(row pgx.Row) (*domain.Reminder, error) {
+	var reminder domain.Reminder
+	if err := row.Scan(reminderScanTargets(&reminder)...); err != nil {
+		return nil, err
+	}
+	return &reminder, nil
+}
+
+// Create inserts a reminder
+func (r *ReminderRepository) Create(ctx context.Context, reminder *domain.Reminder) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO task_reminders (id, task_id, user_id, offset_minutes, remind_at, channel, target,
+			status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`,
+		reminder.ID,
+		reminder.TaskID,
+		reminder.UserID,
+		reminder.OffsetMinutes,
+		reminder.RemindAt,
+		reminder.Channel,
+		reminder.Target,
+		reminder.Status,
+		reminder.CreatedAt,
+		reminder.UpdatedAt,
+	)
+	return err
+}
+
+// FindByID retrieves a reminder by ID
+func (r *ReminderRepository) FindByID(ctx context.Context, id string) (*domain.Reminder, error) {
+	reminder, err := scanReminder(r.db.QueryRow(ctx, `
+		SELECT `+reminderColumns+`
+		FROM task_reminders
+		WHERE id = $1
+	`, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrReminderNotFound
+		}
+		return nil, err
+	}
+	return reminder, nil
+}
+
+// FindByTaskID retrieves a task's reminders, soonest first (unscheduled last)
+func (r *ReminderRepository) FindByTaskID(ctx context.Context, taskID string) ([]*domain.Reminder, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+reminderColumns+`
+		FROM task_reminders
+		WHERE task_id = $1
+		ORDER BY remind_at ASC NULLS LAST, created_at, id
+	`, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reminders := []*domain.Reminder{}
+	for rows.Next() {
+		reminder, err := scanReminder(rows)
+		if err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, reminder)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+// Delete removes a reminder
+func (r *ReminderRepository) Delete(ctx context.Context, id, userID string) error {
+	result, err := r.db.Exec(ctx, "DELETE FROM task_reminders WHERE id = $1 AND user_id = $2", id, userID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrReminderNotFound
+	}
+
+	return nil
+}
+
+// ClaimDue leases up to limit due pending reminders to the caller and counts the attempt.
+// FOR UPDATE SKIP LOCKED keeps concurrent instances from claiming the same rows, and the
+// lease (locked_until) keeps them from re-claiming a reminder that is being delivered.
+// If the claiming instance dies, the lease runs out and another instance retries it.
+// Reminders of completed or deleted tasks are left alone.
+func (r *ReminderRepository) ClaimDue(ctx context.Context, lease time.Duration, limit int) ([]*domain.DueReminder, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH due AS (
+			SELECT rm.id
+			FROM task_reminders rm
+			JOIN tasks t ON t.id = rm.task_id
+			WHERE rm.status = 'pending'
+			  AND rm.remind_at <= NOW()
+			  AND (rm.locked_until IS NULL OR rm.locked_until <= NOW())
+			  AND t.deleted_at IS NULL
+			  AND t.status != 'done'
+			ORDER BY rm.remind_at
+			LIMIT $2
+			FOR UPDATE OF rm SKIP LOCKED
+		)
+		UPDATE task_reminders rm
+		SET locked_until = NOW() + $1::int * INTERVAL '1 second',
+			attempts = rm.attempts + 1
+		FROM due, tasks t, users u
+		WHERE rm.id = due.id AND t.id = rm.task_id AND u.id = rm.user_id
+		RETURNING rm.id, rm.task_id, rm.user_id, rm.offset_minutes, rm.remind_at, rm.channel, rm.target,
+			rm.status, rm.attempts, rm.last_error, rm.sent_at, rm.created_at, rm.updated_at,
+			t.title, t.due_date, u.email
+	`, int(lease.Seconds()), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var claimed []*domain.DueReminder
+	for rows.Next() {
+		due := &domain.DueReminder{Reminder: &domain.Reminder{}}
+		targets := append(reminderScanTargets(due.Reminder), &due.TaskTitle, &due.TaskDueDate, &due.UserEmail)
+		if err := rows.Scan(targets...); err != nil {
+			return nil, err
+		}
+		claimed = append(claimed, due)
+	}
+
+	return claimed, rows.Err()
+}
+
+// MarkSent records a delivered reminder and releases its lease
+func (r *ReminderRepository) MarkSent(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE task_reminders
+		SET status = 'sent', sent_at = NOW(), last_error = NULL, locked_until = NULL
+		WHERE id = $1 AND status = 'pending'
+	`, id)
+	return err
+}
+
+// Release hands claimed reminders back undelivered: their lease ends now, so any instance
+// may claim them again, and the claim is not counted as an attempt
+func (r *ReminderRepository) Release(ctx context.Context, ids []string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE task_reminders
+		SET locked_until = NULL, attempts = GREATEST(attempts - 1, 0)
+		WHERE id = ANY($1::uuid[]) AND status = 'pending'
+	`, ids)
+	return err
+}
+
+// MarkFailed records a failed delivery. With a retryAt the reminder stays pending and is
+// not claimed again before then; without one it is marked failed.
+func (r *ReminderRepository) MarkFailed(ctx context.Context, id, lastError string, retryAt *time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE task_reminders
+		SET status = CASE WHEN $3::timestamptz IS NULL THEN 'failed' ELSE 'pending' END,
+			last_error = $2,
+			locked_until = $3
+		WHERE id = $1 AND status = 'pending'
+	`, id, lastError, retryAt)
+	return err
+}
+
+// RescheduleForTask moves a task's relative reminders to fire before the new due date.
+// A reminder whose new time is still ahead is re-armed even if it was already sent,
+// so moving a due date later reminds the user again. Absolute reminders are not touched.
+func (r *ReminderRepository) RescheduleForTask(ctx context.Context, taskID string, dueDate *time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE task_reminders rm
+		SET remind_at = n.next_at,
+			status = CASE WHEN n.next_at > NOW() THEN 'pending' ELSE rm.status END,
+			attempts = CASE WHEN n.next_at > NOW() THEN 0 ELSE rm.attempts END,
+			last_error = CASE WHEN n.next_at > NOW() THEN NULL ELSE rm.last_error END,
+			sent_at = CASE WHEN n.next_at > NOW() THEN NULL ELSE rm.sent_at END
+		FROM (
+			SELECT id, $2::timestamptz - offset_minutes * INTERVAL '1 minute' AS next_at
+			FROM task_reminders
+			WHERE task_id = $1 AND offset_minutes IS NOT NULL
+		) n
+		WHERE rm.id = n.id
+	`, taskID, dueDate)
+	return err
+}
+
+// CopyToTask copies a task's reminders to another task as new pending reminders
+func (r *ReminderRepository) CopyToTask(ctx context.Context, fromTaskID, toTaskID string, dueDate *time.Time, shift *time.Duration) error {
+	var shiftSeconds *int64
+	if shift != nil {
+		seconds := int64(shift.Seconds())
+		shiftSeconds = &seconds
+	}
+
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO task_reminders (task_id, user_id, offset_minutes, remind_at, channel, target)
+		SELECT $2, user_id, offset_minutes,
+			CASE
+				WHEN offset_minutes IS NOT NULL THEN $3::timestamptz - offset_minutes * INTERVAL '1 minute'
+				ELSE remind_at + $4::bigint * INTERVAL '1 second'
+			END,
+			channel, target
+		FROM task_reminders
+		WHERE task_id = $1 AND (offset_minutes IS NOT NULL OR $4::bigint IS NOT NULL)
+	`, fromTaskID, toTaskID, dueDate, shiftSeconds)
+	return err
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// ReminderRepository Integration Tests
+// =============================================================================
+
+func newTestReminder(userID, taskID string, remindAt *time.Time, offsetMinutes *int) *domain.Reminder {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	target := "https://hooks.example.com/taskflow"
+	return &domain.Reminder{
+		ID:            uuid.New().String(),
+		TaskID:        taskID,
+		UserID:        userID,
+		OffsetMinutes: offsetMinutes,
+		RemindAt:      remindAt,
+		Channel:       domain.ReminderChannelWebhook,
+		Target:        &target,
+		Status:        domain.ReminderStatusPending,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+func TestReminderRepository_ClaimAndDeliver(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool := setupTestDB(t)
+	repo := NewReminderRepository(pool)
+	taskRepo := NewTaskRepository(pool)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, pool)
+	task := createTestTask(t, ctx, taskRepo, userID, "Task With Reminders")
+
+	t.Run("concurrent claims never share a reminder", func(t *testing.T) {
+		past := time.Now().Add(-time.Minute)
+		for i := 0; i < 10; i++ {
+			require.NoError(t, repo.Create(ctx, newTestReminder(userID, task.ID, &past, nil)))
+		}
+
+		var mu sync.Mutex
+		seen := map[string]int{}
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				claimed, err := repo.ClaimDue(ctx, time.Minute, 100)
+				assert.NoError(t, err)
+				mu.Lock()
+				defer mu.Unlock()
+				for _, due := range claimed {
+					seen[due.Reminder.ID]++
+					assert.Equal(t, "Task With Reminders", due.TaskTitle)
+					assert.Equal(t, 1, due.Reminder.Attempts)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Len(t, seen, 10)
+		for id, count := range seen {
+			assert.Equal(t, 1, count, "reminder %s claimed more than once", id)
+		}
+
+		// Leased reminders are not claimed again
+		claimed, err := repo.ClaimDue(ctx, time.Minute, 100)
+		require.NoError(t, err)
+		assert.Empty(t, claimed)
+
+		for id := range seen {
+			require.NoError(t, repo.MarkSent(ctx, id))
+		}
+	})
+
+	t.Run("failed delivery waits for its retry time", func(t *testing.T) {
+		past := time.Now().Add(-time.Minute)
+		reminder := newTestReminder(userID, task.ID, &past, nil)
+		require.NoError(t, repo.Create(ctx, reminder))
+
+		claimed, err := repo.ClaimDue(ctx, time.Minute, 100)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+
+		retryAt := time.Now().Add(-time.Second)
+		require.NoError(t, repo.MarkFailed(ctx, reminder.ID, "receiver is down", &retryAt))
+
+		claimed, err = repo.ClaimDue(ctx, time.Minute, 100)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		assert.Equal(t, 2, claimed[0].Reminder.Attempts)
+		require.NotNil(t, claimed[0].Reminder.LastError)
+		assert.Equal(t, "receiver is down", *claimed[0].Reminder.LastError)
+
+		require.NoError(t, repo.MarkFailed(ctx, reminder.ID, "receiver is down", nil))
+		found, err := repo.FindByID(ctx, reminder.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ReminderStatusFailed, found.Status)
+	})
+
+	t.Run("released reminders can be claimed again", func(t *testing.T) {
+		past := time.Now().Add(-time.Minute)
+		reminder := newTestReminder(userID, task.ID, &past, nil)
+		require.NoError(t, repo.Create(ctx, reminder))
+
+		claimed, err := repo.ClaimDue(ctx, time.Minute, 100)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+
+		require.NoError(t, repo.Release(ctx, []string{reminder.ID}))
+
+		claimed, err = repo.ClaimDue(ctx, time.Minute, 100)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		// The released claim was not counted as an attempt
+		assert.Equal(t, 1, claimed[0].Reminder.Attempts)
+		require.NoError(t, repo.MarkSent(ctx, reminder.ID))
+	})
+
+	t.Run("due date change moves relative reminders", func(t *testing.T) {
+		movable := createTestTask(t, ctx, taskRepo, userID, "Movable Task")
+		offset := 60
+		relative := newTestReminder(userID, movable.ID, nil, &offset)
+		absoluteAt := time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond)
+		absolute := newTestReminder(userID, movable.ID, &absoluteAt, nil)
+		require.NoError(t, repo.Create(ctx, relative))
+		require.NoError(t, repo.Create(ctx, absolute))
+
+		dueDate := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Microsecond)
+		require.NoError(t, repo.RescheduleForTask(ctx, movable.ID, &dueDate))
+
+		found, err := repo.FindByID(ctx, relative.ID)
+		require.NoError(t, err)
+		require.NotNil(t, found.RemindAt)
+		assert.True(t, found.RemindAt.Equal(dueDate.Add(-time.Hour)))
+
+		found, err = repo.FindByID(ctx, absolute.ID)
+		require.NoError(t, err)
+		assert.True(t, found.RemindAt.Equal(absoluteAt))
+
+		// Recurring carry-over: relative follows the new due date, absolute shifts by a week
+		next := createTestTask(t, ctx, taskRepo, userID, "Next Instance")
+		nextDue := dueDate.AddDate(0, 0, 7)
+		week := 7 * 24 * time.Hour
+		require.NoError(t, repo.CopyToTask(ctx, movable.ID, next.ID, &nextDue, &week))
+
+		copies, err := repo.FindByTaskID(ctx, next.ID)
+		require.NoError(t, err)
+		require.Len(t, copies, 2)
+		assert.True(t, copies[0].RemindAt.Equal(absoluteAt.Add(week)))
+		assert.True(t, copies[1].RemindAt.Equal(nextDue.Add(-time.Hour)))
+	})
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+)
+
+// SavedViewRepository handles database operations for saved views
+type SavedViewRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewSavedViewRepository creates a new saved view repository
+func NewSavedViewRepository(db *pgxpool.Pool) *SavedViewRepository {
+	return &SavedViewRepository{db: db}
+}
+
+// Create inserts a new saved view into the database
+func (r *SavedViewRepository) Create(ctx context.Context, view *domain.SavedView) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO saved_views (
+			id, user_id, name, icon, position, filter, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`,
+		view.ID,
+		view.UserID,
+		view.Name,
+		view.Icon,
+		view.Position,
+		view.Filter,
+		view.CreatedAt,
+		view.UpdatedAt,
+	)
+
+	if err != nil {
+		if isPgUniqueViolation(err) {
+			return domain.ErrSavedViewDuplicateName
+		}
+		return err
+	}
+
+	return nil
+}
+
+// FindByID retrieves a saved view by ID
+func (r *SavedViewRepository) FindByID(ctx context.Context, id string) (*domain.SavedView, error) {
+	var view domain.SavedView
+
+	err := r.db.QueryRow(ctx, `
+		SELECT id, user_id, name, icon, position, filter, created_at, updated_at
+		FROM saved_views
+		WHERE id = $1
+	`, id).Scan(
+		&view.ID,
+		&view.UserID,
+		&view.Name,
+		&view.Icon,
+		&view.Position,
+		&view.Filter,
+		&view.CreatedAt,
+		&view.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrSavedViewNotFound
+		}
+		return nil, err
+	}
+
+	return &view, nil
+}
+
+// FindByUserID retrieves all saved views for a user in display order
+func (r *SavedViewRepository) FindByUserID(ctx context.Context, userID string) ([]*domain.SavedView, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, user_id, name, icon, position, filter, created_at, updated_at
+		FROM saved_views
+		WHERE user_id = $1
+		ORDER BY position ASC, name ASC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	views := []*domain.SavedView{}
+	for rows.Next() {
+		var view domain.SavedView
+		err := rows.Scan(
+			&view.ID,
+			&view.UserID,
+			&view.Name,
+			&view.Icon,
+			&view.Position,
+			&view.Filter,
+			&view.CreatedAt,
+			&view.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, &view)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return views, nil
+}
+
+// CountByUserID returns the number of saved views a user has
+func (r *SavedViewRepository) CountByUserID(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*)::int FROM saved_views WHERE user_id = $1
+	`, userID).Scan(&count)
+	return count, err
+}
+
+// Update updates a saved view
+func (r *SavedViewRepository) Update(ctx context.Context, view *domain.SavedView) error {
+	view.UpdatedAt = time.Now()
+
+	result, err := r.db.Exec(ctx, `
+		UPDATE saved_views
+		SET name = $2, icon = $3, position = $4, filter = $5, updated_at = $6
+		WHERE id = $1 AND user_id = $7
+	`,
+		view.ID,
+		view.Name,
+		view.Icon,
+		view.Position,
+		view.Filter,
+		view.UpdatedAt,
+		view.UserID,
+	)
+
+	if err != nil {
+		if isPgUniqueViolation(err) {
+			return domain.ErrSavedViewDuplicateName
+		}
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrSavedViewNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a saved view
+func (r *SavedViewRepository) Delete(ctx context.Context, id, userID string) error {
+	result, err := r.db.Exec(ctx, `
+		DELETE FROM saved_views
+		WHERE id = $1 AND user_id = $2
+	`, id, userID)
+
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrSavedViewNotFound
+	}
+
+	return nil
+}
+
+// ExistsByName checks if a saved view with the given name exists for the user
+func (r *SavedViewRepository) ExistsByName(ctx context.Context, userID, name string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM saved_views
+			WHERE user_id = $1 AND name = $2
+		)
+	`, userID, name).Scan(&exists)
+	return exists, err
+}
+
+// ExistsByNameExcludingID checks if a saved view with the given name exists for the user,
+// excluding the view with the given ID (for update validation)
+func (r *SavedViewRepository) ExistsByNameExcludingID(ctx context.Context, userID, name, excludeID string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM saved_views
+			WHERE user_id = $1 AND name = $2 AND id != $3
+		)
+	`, userID, name, excludeID).Scan(&exists)
+	return exists, err
+}
@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+)
+
+// TagRepository handles database operations for tags
+type TagRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewTagRepository creates a new tag repository
+func NewTagRepository(db *pgxpool.Pool) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+// tagTaskCountColumn counts the (non-deleted) tasks carrying a tag
+const tagTaskCountColumn = `(
+			SELECT COUNT(*)::int FROM task_tags tt
+			JOIN tasks t ON t.id = tt.task_id
+			WHERE tt.tag_id = tags.id AND t.deleted_at IS NULL
+		) AS task_count`
+
+// renameTemplateTagQuery replaces a tag name ($2, case-insensitive) with $3 in every template
+// of user $1, dropping duplicates that a merge can introduce while keeping the original order
+const renameTemplateTagQuery = `
+	UPDATE task_templates
+	SET tags = ARRAY(
+		SELECT name FROM (
+			SELECT DISTINCT ON (LOWER(name)) name, ord
+			FROM (
+				SELECT CASE WHEN LOWER(t) = LOWER($2) THEN $3 ELSE t END AS name, ord
+				FROM unnest(tags) WITH ORDINALITY AS u(t, ord)
+			) renamed
+			ORDER BY LOWER(name), ord
+		) deduped
+		ORDER BY ord
+	)
+	WHERE user_id = $1 AND EXISTS (SELECT 1 FROM unnest(tags) AS t WHERE LOWER(t) = LOWER($2))
+`
+
+// Create inserts a new tag into the database
+func (r *TagRepository) Create(ctx context.Context, tag *domain.Tag) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO tags (id, user_id, name, color, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`,
+		tag.ID,
+		tag.UserID,
+		tag.Name,
+		tag.Color,
+		tag.CreatedAt,
+		tag.UpdatedAt,
+	)
+
+	if err != nil {
+		if isPgUniqueViolation(err) {
+			return domain.ErrTagDuplicateName
+		}
+		return err
+	}
+
+	return nil
+}
+
+// FindByID retrieves a tag by ID, including its task count
+func (r *TagRepository) FindByID(ctx context.Context, id string) (*domain.Tag, error) {
+	var tag domain.Tag
+
+	err := r.db.QueryRow(ctx, `
+		SELECT id, user_id, name, color, created_at, updated_at, `+tagTaskCountColumn+`
+		FROM tags
+		WHERE id = $1
+	`, id).Scan(
+		&tag.ID,
+		&tag.UserID,
+		&tag.Name,
+		&tag.Color,
+		&tag.CreatedAt,
+		&tag.UpdatedAt,
+		&tag.TaskCount,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrTagNotFound
+		}
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+// FindByUserID retrieves all tags for a user, alphabetically, with task counts
+func (r *TagRepository) FindByUserID(ctx context.Context, userID string) ([]*domain.Tag, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, user_id, name, color, created_at, updated_at, `+tagTaskCountColumn+`
+		FROM tags
+		WHERE user_id = $1
+		ORDER BY LOWER(name) ASC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []*domain.Tag{}
+	for rows.Next() {
+		var tag domain.Tag
+		err := rows.Scan(
+			&tag.ID,
+			&tag.UserID,
+			&tag.Name,
+			&tag.Color,
+			&tag.CreatedAt,
+			&tag.UpdatedAt,
+			&tag.TaskCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, &tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// Update renames and/or recolors a tag.
+// Tasks reference tags by ID, so a rename applies to every task at once; templates store
+// tag names and are rewritten in the same transaction.
+func (r *TagRepository) Update(ctx context.Context, tag *domain.Tag) error {
+	tag.UpdatedAt = time.Now()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+
+	var oldName string
+	err = tx.QueryRow(ctx, `
+		SELECT name FROM tags WHERE id = $1 AND user_id = $2 FOR UPDATE
+	`, tag.ID, tag.UserID).Scan(&oldName)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrTagNotFound
+		}
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE tags
+		SET name = $2, color = $3, updated_at = $4
+		WHERE id = $1
+	`, tag.ID, tag.Name, tag.Color, tag.UpdatedAt)
+	if err != nil {
+		if isPgUniqueViolation(err) {
+			return domain.ErrTagDuplicateName
+		}
+		return err
+	}
+
+	if oldName != tag.Name {
+		if _, err := tx.Exec(ctx, renameTemplateTagQuery, tag.UserID, oldName, tag.Name); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Delete removes a tag from every task and template, then deletes it
+func (r *TagRepository) Delete(ctx context.Context, id, userID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+
+	// task_tags rows are removed by ON DELETE CASCADE
+	var name string
+	err = tx.QueryRow(ctx, `
+		DELETE FROM tags
+		WHERE id = $1 AND user_id = $2
+		RETURNING name
+	`, id, userID).Scan(&name)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrTagNotFound
+		}
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE task_templates
+		SET tags = ARRAY(
+			SELECT t FROM unnest(tags) WITH ORDINALITY AS u(t, ord)
+			WHERE LOWER(t) <> LOWER($2)
+			ORDER BY ord
+		)
+		WHERE user_id = $1 AND EXISTS (SELECT 1 FROM unnest(tags) AS t WHERE LOWER(t) = LOWER($2))
+	`, userID, name)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Merge moves every task and template from the source tag to the target tag and deletes
+// the source, all in one transaction. Returns the number of tasks that carried the source tag.
+func (r *TagRepository) Merge(ctx context.Context, userID, sourceID, targetID string) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+
+	// Lock both tags; either being missing (or another user's) aborts the merge
+	var sourceName, targetName *string
+	err = tx.QueryRow(ctx, `
+		SELECT
+			(SELECT name FROM tags WHERE id = $1 AND user_id = $3 FOR UPDATE),
+			(SELECT name FROM tags WHERE id = $2 AND user_id = $3 FOR UPDATE)
+	`, sourceID, targetID, userID).Scan(&sourceName, &targetName)
+	if err != nil {
+		return 0, err
+	}
+	if sourceName == nil || targetName == nil {
+		return 0, domain.ErrTagNotFound
+	}
+
+	var taskCount int
+	err = tx.QueryRow(ctx, `
+		SELECT COUNT(*)::int FROM task_tags WHERE tag_id = $1
+	`, sourceID).Scan(&taskCount)
+	if err != nil {
+		return 0, err
+	}
+
+	// Tasks already carrying the target keep a single row
+	_, err = tx.Exec(ctx, `
+		INSERT INTO task_tags (task_id, tag_id)
+		SELECT task_id, $2 FROM task_tags WHERE tag_id = $1
+		ON CONFLICT DO NOTHING
+	`, sourceID, targetID)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM tags WHERE id = $1", sourceID); err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.Exec(ctx, renameTemplateTagQuery, userID, *sourceName, *targetName); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return taskCount, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/notkevinvu/taskflow/backend/internal/domain"
@@ -48,7 +49,9 @@ func sqlcHistoryRowToDomain(id, userID, taskID string, eventType sqlc.TaskHistor
 	}
 }
 
-// Create inserts a new task history entry
+// Create inserts a new task history entry. Entries without an operation ID join the
+// operation carried by ctx (see domain.WithOperationID), so every write made while
+// handling one request can be undone together.
 func (r *TaskHistoryRepository) Create(ctx context.Context, history *domain.TaskHistory) error {
 	id, err := stringToPgtypeUUID(history.ID)
 	if err != nil {
@@ -63,14 +66,22 @@ func (r *TaskHistoryRepository) Create(ctx context.Context, history *domain.Task
 		return err
 	}
 
+	if history.OperationID == nil {
+		if operationID, ok := domain.OperationID(ctx); ok {
+			history.OperationID = &operationID
+		}
+	}
+
 	params := sqlc.CreateTaskHistoryParams{
-		ID:        id,
-		UserID:    userID,
-		TaskID:    taskID,
-		EventType: domainEventTypeToSqlc(history.EventType),
-		OldValue:  history.OldValue,
-		NewValue:  history.NewValue,
-		CreatedAt: timeToPgtypeTimestamptz(history.CreatedAt),
+		ID:                 id,
+		UserID:             userID,
+		TaskID:             taskID,
+		EventType:          domainEventTypeToSqlc(history.EventType),
+		OldValue:           history.OldValue,
+		NewValue:           history.NewValue,
+		CreatedAt:          timeToPgtypeTimestamptz(history.CreatedAt),
+		OperationID:        stringPtrToPgtypeUUID(history.OperationID),
+		RevertsOperationID: stringPtrToPgtypeUUID(history.RevertsOperationID),
 	}
 
 	return r.queries.CreateTaskHistory(ctx, params)
@@ -104,3 +115,146 @@ func (r *TaskHistoryRepository) FindByTaskID(ctx context.Context, taskID string)
 
 	return history, nil
 }
+
+// ListByTaskID retrieves a page of a task's history, newest first, with the total
+// number of entries matching the filter
+func (r *TaskHistoryRepository) ListByTaskID(ctx context.Context, taskID string, filter *domain.TaskHistoryFilter) ([]*domain.TaskHistory, int, error) {
+	eventTypes := make([]string, len(filter.EventTypes))
+	for i, eventType := range filter.EventTypes {
+		eventTypes[i] = string(eventType)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, user_id, task_id, event_type::text, old_value, new_value, created_at,
+			operation_id::text, COUNT(*) OVER() AS total_count
+		FROM task_history
+		WHERE task_id = $1
+		  AND (cardinality($2::text[]) = 0 OR event_type::text = ANY($2::text[]))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4
+	`, taskID, eventTypes, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	history := []*domain.TaskHistory{}
+	total := 0
+	for rows.Next() {
+		var h domain.TaskHistory
+		if err := rows.Scan(&h.ID, &h.UserID, &h.TaskID, &h.EventType, &h.OldValue, &h.NewValue, &h.CreatedAt,
+			&h.OperationID, &total); err != nil {
+			return nil, 0, err
+		}
+		history = append(history, &h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	// Past the last page no row carries the window count
+	if len(history) == 0 && filter.Offset > 0 {
+		if err := r.db.QueryRow(ctx, `
+			SELECT COUNT(*)
+			FROM task_history
+			WHERE task_id = $1
+			  AND (cardinality($2::text[]) = 0 OR event_type::text = ANY($2::text[]))
+		`, taskID, eventTypes).Scan(&total); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return history, total, nil
+}
+
+// ListOperations summarizes the user's most recent operations that changed task state,
+// newest first. Operations made up only of comment events are left out.
+func (r *TaskHistoryRepository) ListOperations(ctx context.Context, userID string, limit int) ([]*domain.TaskOperation, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT operation_id::text,
+			array_agg(DISTINCT task_id::text),
+			array_agg(DISTINCT event_type::text),
+			MAX(created_at),
+			MAX(undone_at),
+			(array_agg(reverts_operation_id::text) FILTER (WHERE reverts_operation_id IS NOT NULL))[1]
+		FROM task_history
+		WHERE user_id = $1
+		  AND operation_id IS NOT NULL
+		  AND event_type::text = ANY($2::text[])
+		GROUP BY operation_id
+		ORDER BY MAX(created_at) DESC
+		LIMIT $3
+	`, userID, domain.UndoTargetEventTypes(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	operations := []*domain.TaskOperation{}
+	for rows.Next() {
+		var op domain.TaskOperation
+		var eventTypes []string
+		if err := rows.Scan(&op.ID, &op.TaskIDs, &eventTypes, &op.CreatedAt, &op.UndoneAt, &op.RevertsOperationID); err != nil {
+			return nil, err
+		}
+		op.EventTypes = make([]domain.TaskHistoryEventType, len(eventTypes))
+		for i, eventType := range eventTypes {
+			op.EventTypes[i] = domain.TaskHistoryEventType(eventType)
+		}
+		operations = append(operations, &op)
+	}
+
+	return operations, rows.Err()
+}
+
+// FindByOperationID retrieves the user's history entries written by one operation, oldest first
+func (r *TaskHistoryRepository) FindByOperationID(ctx context.Context, userID, operationID string) ([]*domain.TaskHistory, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, user_id, task_id, event_type::text, old_value, new_value, created_at,
+			operation_id::text, reverts_operation_id::text
+		FROM task_history
+		WHERE user_id = $1 AND operation_id = $2
+		ORDER BY created_at, id
+	`, userID, operationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []*domain.TaskHistory{}
+	for rows.Next() {
+		var h domain.TaskHistory
+		if err := rows.Scan(&h.ID, &h.UserID, &h.TaskID, &h.EventType, &h.OldValue, &h.NewValue, &h.CreatedAt,
+			&h.OperationID, &h.RevertsOperationID); err != nil {
+			return nil, err
+		}
+		history = append(history, &h)
+	}
+
+	return history, rows.Err()
+}
+
+// MarkOperationUndone claims an operation for undo. It reports false if the operation
+// was already undone, so two concurrent undos cannot both revert it.
+func (r *TaskHistoryRepository) MarkOperationUndone(ctx context.Context, userID, operationID string, undoneAt time.Time) (bool, error) {
+	result, err := r.db.Exec(ctx, `
+		UPDATE task_history
+		SET undone_at = $3
+		WHERE user_id = $1 AND operation_id = $2 AND undone_at IS NULL
+	`, userID, operationID, undoneAt)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// ClearOperationUndone marks an operation as not undone, after a failed undo or once
+// the undo itself has been undone
+func (r *TaskHistoryRepository) ClearOperationUndone(ctx context.Context, userID, operationID string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE task_history
+		SET undone_at = NULL
+		WHERE user_id = $1 AND operation_id = $2
+	`, userID, operationID)
+	return err
+}
@@ -143,3 +143,141 @@ func TestTaskHistoryRepository_FindByTaskID(t *testing.T) {
 		}
 	}
 }
+
+func TestTaskHistoryRepository_ListByTaskID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool := setupTestDB(t)
+	repo := NewTaskHistoryRepository(pool)
+	taskRepo := NewTaskRepository(pool)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, pool)
+	task := createTestTask(t, ctx, taskRepo, userID, "Task With History")
+
+	// Five events, one second apart: created, bumped x2, restored, uncompleted
+	base := time.Now().Add(-time.Hour)
+	eventTypes := []domain.TaskHistoryEventType{
+		domain.EventTaskCreated, domain.EventTaskBumped, domain.EventTaskBumped,
+		domain.EventTaskRestored, domain.EventTaskUncompleted,
+	}
+	for i, eventType := range eventTypes {
+		if err := repo.Create(ctx, &domain.TaskHistory{
+			ID:        uuid.New().String(),
+			UserID:    userID,
+			TaskID:    task.ID,
+			EventType: eventType,
+			CreatedAt: base.Add(time.Duration(i) * time.Second),
+		}); err != nil {
+			t.Fatalf("Failed to create %s history: %v", eventType, err)
+		}
+	}
+
+	page, total, err := repo.ListByTaskID(ctx, task.ID, &domain.TaskHistoryFilter{Limit: 2, Offset: 1})
+	if err != nil {
+		t.Fatalf("ListByTaskID failed: %v", err)
+	}
+	if total != 5 || len(page) != 2 {
+		t.Fatalf("Expected 2 of 5 entries, got %d of %d", len(page), total)
+	}
+	if page[0].EventType != domain.EventTaskRestored || page[1].EventType != domain.EventTaskBumped {
+		t.Errorf("Expected newest-first order, got %s, %s", page[0].EventType, page[1].EventType)
+	}
+
+	bumps, total, err := repo.ListByTaskID(ctx, task.ID, &domain.TaskHistoryFilter{
+		EventTypes: []domain.TaskHistoryEventType{domain.EventTaskBumped},
+		Limit:      20,
+	})
+	if err != nil {
+		t.Fatalf("ListByTaskID with event filter failed: %v", err)
+	}
+	if total != 2 || len(bumps) != 2 {
+		t.Errorf("Expected 2 bumped entries, got %d (total %d)", len(bumps), total)
+	}
+
+	// Past the last page the total is still reported
+	empty, total, err := repo.ListByTaskID(ctx, task.ID, &domain.TaskHistoryFilter{Limit: 20, Offset: 10})
+	if err != nil {
+		t.Fatalf("ListByTaskID past the end failed: %v", err)
+	}
+	if total != 5 || len(empty) != 0 {
+		t.Errorf("Expected no entries and total 5, got %d entries and total %d", len(empty), total)
+	}
+}
+
+func TestTaskHistoryRepository_Operations(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool := setupTestDB(t)
+	repo := NewTaskHistoryRepository(pool)
+	taskRepo := NewTaskRepository(pool)
+	userID := createTestUser(t, context.Background(), pool)
+	first := createTestTask(t, context.Background(), taskRepo, userID, "First")
+	second := createTestTask(t, context.Background(), taskRepo, userID, "Second")
+
+	// One bulk operation touching both tasks, then a comment-only operation
+	bulkID := uuid.New().String()
+	bulkCtx := domain.WithOperationID(context.Background(), bulkID)
+	base := time.Now().Add(-time.Minute)
+	for i, task := range []*domain.Task{first, second} {
+		if err := repo.Create(bulkCtx, &domain.TaskHistory{
+			ID:        uuid.New().String(),
+			UserID:    userID,
+			TaskID:    task.ID,
+			EventType: domain.EventTaskUpdated,
+			CreatedAt: base.Add(time.Duration(i) * time.Second),
+		}); err != nil {
+			t.Fatalf("Failed to create history: %v", err)
+		}
+	}
+	commentCtx := domain.WithOperationID(context.Background(), uuid.New().String())
+	if err := repo.Create(commentCtx, &domain.TaskHistory{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		TaskID:    first.ID,
+		EventType: domain.EventCommentAdded,
+		CreatedAt: base.Add(time.Minute),
+	}); err != nil {
+		t.Fatalf("Failed to create comment history: %v", err)
+	}
+
+	operations, err := repo.ListOperations(context.Background(), userID, 10)
+	if err != nil {
+		t.Fatalf("ListOperations failed: %v", err)
+	}
+	if len(operations) != 1 || operations[0].ID != bulkID || len(operations[0].TaskIDs) != 2 {
+		t.Fatalf("Expected only the bulk operation over 2 tasks, got %+v", operations)
+	}
+
+	entries, err := repo.FindByOperationID(context.Background(), userID, bulkID)
+	if err != nil {
+		t.Fatalf("FindByOperationID failed: %v", err)
+	}
+	if len(entries) != 2 || entries[0].TaskID != first.ID || entries[1].TaskID != second.ID {
+		t.Errorf("Expected both entries oldest first, got %d", len(entries))
+	}
+
+	// Only one of two undos can claim the operation
+	claimed, err := repo.MarkOperationUndone(context.Background(), userID, bulkID, time.Now())
+	if err != nil || !claimed {
+		t.Fatalf("Expected first claim to succeed, got %v, %v", claimed, err)
+	}
+	claimed, err = repo.MarkOperationUndone(context.Background(), userID, bulkID, time.Now())
+	if err != nil || claimed {
+		t.Errorf("Expected second claim to fail, got %v, %v", claimed, err)
+	}
+
+	if err := repo.ClearOperationUndone(context.Background(), userID, bulkID); err != nil {
+		t.Fatalf("ClearOperationUndone failed: %v", err)
+	}
+	operations, err = repo.ListOperations(context.Background(), userID, 10)
+	if err != nil {
+		t.Fatalf("ListOperations failed: %v", err)
+	}
+	if len(operations) != 1 || operations[0].UndoneAt != nil {
+		t.Errorf("Expected the operation to be undoable again")
+	}
+}
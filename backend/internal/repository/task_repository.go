@@ -2,11 +2,14 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -160,18 +163,116 @@ func sqlcRowToDomain(id, userID pgtype.UUID, title string, description *string,
 	}
 }
 
-// Create inserts a new task into the database
+// taskTagsColumn selects a task's tag names, sorted case-insensitively, as a TEXT[]
+const taskTagsColumn = `COALESCE((
+			SELECT array_agg(tg.name ORDER BY LOWER(tg.name))
+			FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id
+			WHERE tt.task_id = tasks.id
+		), '{}') AS tags`
+
+// taskCommentCountColumn counts a task's comments, excluding soft-deleted ones
+const taskCommentCountColumn = `(
+			SELECT COUNT(*)::int FROM task_comments tc
+			WHERE tc.task_id = tasks.id AND tc.deleted_at IS NULL
+		) AS comment_count`
+
+// taskTrackedSecondsColumn totals a task's finished time entries; a running timer is not counted
+const taskTrackedSecondsColumn = `(
+			SELECT COALESCE(SUM(EXTRACT(EPOCH FROM (te.ended_at - te.started_at))), 0)::bigint
+			FROM time_entries te
+			WHERE te.task_id = tasks.id AND te.ended_at IS NOT NULL
+		) AS tracked_seconds`
+
+// taskFocusSessionCountColumn counts a task's completed focus sessions
+const taskFocusSessionCountColumn = `(
+			SELECT COUNT(*)::int FROM focus_sessions fs
+			WHERE fs.task_id = tasks.id AND fs.status = 'completed'
+		) AS focus_session_count`
+
+// taskHasTagCondition matches tasks carrying the tag named by the placeholder (case-insensitive)
+const taskHasTagCondition = `EXISTS (
+			SELECT 1 FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id
+			WHERE tt.task_id = tasks.id AND LOWER(tg.name) = LOWER($%d))`
+
+// syncTaskTags replaces a task's tags with the named tags, creating any the user doesn't have yet.
+// Runs inside the caller's transaction so the task and its tags are written atomically.
+func syncTaskTags(ctx context.Context, tx pgx.Tx, userID, taskID string, tags []string) error {
+	if len(tags) > 0 {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO tags (user_id, name)
+			SELECT $1, name FROM unnest($2::text[]) AS name
+			ON CONFLICT (user_id, LOWER(name)) DO NOTHING
+		`, userID, tags); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM task_tags WHERE task_id = $1", taskID); err != nil {
+		return err
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	lowered := make([]string, len(tags))
+	for i, tag := range tags {
+		lowered[i] = strings.ToLower(tag)
+	}
+	_, err := tx.Exec(ctx, `
+		INSERT INTO task_tags (task_id, tag_id)
+		SELECT $1, id FROM tags WHERE user_id = $2 AND LOWER(name) = ANY($3::text[])
+	`, taskID, userID, lowered)
+	return err
+}
+
+// Create inserts a new task (and its tags) into the database
 func (r *TaskRepository) Create(ctx context.Context, task *domain.Task) error {
-	id, err := stringToPgtypeUUID(task.ID)
+	if err := assignRank(ctx, r.db, task); err != nil {
+		return err
+	}
+	params, err := createTaskParams(task)
 	if err != nil {
 		return err
 	}
-	userID, err := stringToPgtypeUUID(task.UserID)
+
+	// The workflow status is set by the database from the task's status
+	if len(task.Tags) == 0 {
+		workflowStatus, err := r.queries.CreateTask(ctx, params)
+		task.WorkflowStatus = domain.TaskStatus(workflowStatus)
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+
+	workflowStatus, err := r.queries.WithTx(tx).CreateTask(ctx, params)
 	if err != nil {
 		return err
 	}
+	task.WorkflowStatus = domain.TaskStatus(workflowStatus)
+	if err := syncTaskTags(ctx, tx, task.UserID, task.ID, task.Tags); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// createTaskParams converts a new task to its insert parameters
+func createTaskParams(task *domain.Task) (sqlc.CreateTaskParams, error) {
+	id, err := stringToPgtypeUUID(task.ID)
+	if err != nil {
+		return sqlc.CreateTaskParams{}, err
+	}
+	userID, err := stringToPgtypeUUID(task.UserID)
+	if err != nil {
+		return sqlc.CreateTaskParams{}, err
+	}
 
-	params := sqlc.CreateTaskParams{
+	return sqlc.CreateTaskParams{
 		ID:              id,
 		UserID:          userID,
 		Title:           task.Title,
@@ -189,19 +290,260 @@ func (r *TaskRepository) Create(ctx context.Context, task *domain.Task) error {
 		UpdatedAt:       timeToPgtypeTimestamptz(task.UpdatedAt),
 		SeriesID:        stringPtrToPgtypeUUID(task.SeriesID),
 		ParentTaskID:    stringPtrToPgtypeUUID(task.ParentTaskID),
+		DeferUntil:      timePtrToPgtypeTimestamptz(task.DeferUntil),
+		Rank:            task.Rank,
+	}, nil
+}
+
+// rowQuerier is satisfied by both the pool and a transaction; Begin on a transaction
+// starts a savepoint
+type rowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// rankScopeCondition selects the live tasks of a manually ordered list, using $1
+func rankScopeCondition(scope domain.RankScope) (string, interface{}) {
+	if scope.ParentTaskID != nil {
+		return "parent_task_id = $1 AND task_type = 'subtask' AND deleted_at IS NULL", *scope.ParentTaskID
+	}
+	return "user_id = $1 AND task_type != 'subtask' AND deleted_at IS NULL", scope.UserID
+}
+
+// assignRank gives a task without a rank the next rank at the end of its list. If moves
+// have left the end of the list with ranks too long to append after, the list's ranks
+// are spread out again first.
+func assignRank(ctx context.Context, db rowQuerier, task *domain.Task) error {
+	if task.Rank != "" {
+		return nil
+	}
+
+	scope := task.RankScope()
+	rank, err := nextRank(ctx, db, scope)
+	if err != nil {
+		return err
+	}
+	if len(rank) > domain.MaxRankLength {
+		if err := rebalanceRanks(ctx, db, scope); err != nil {
+			return err
+		}
+		if rank, err = nextRank(ctx, db, scope); err != nil {
+			return err
+		}
+	}
+	task.Rank = rank
+	return nil
+}
+
+// nextRank returns the rank after the last task of a list
+func nextRank(ctx context.Context, db rowQuerier, scope domain.RankScope) (string, error) {
+	condition, arg := rankScopeCondition(scope)
+	var last string
+	if err := db.QueryRow(ctx, "SELECT COALESCE(MAX(rank), '') FROM tasks WHERE "+condition, arg).Scan(&last); err != nil {
+		return "", err
+	}
+	return domain.RankBetween(last, "")
+}
+
+// CreateDuplicate writes a prepared task copy in a single transaction: the task and its
+// subtasks with their tags, the optional recurrence series, and the blocker relationships
+func (r *TaskRepository) CreateDuplicate(ctx context.Context, dup *domain.TaskDuplicate) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+	q := r.queries.WithTx(tx)
+
+	// The series references its original task, so the task is linked to the series afterwards
+	tasks := append([]*domain.Task{dup.Task}, dup.Subtasks...)
+	for _, task := range tasks {
+		if err := assignRank(ctx, tx, task); err != nil {
+			return err
+		}
+		params, err := createTaskParams(task)
+		if err != nil {
+			return err
+		}
+		workflowStatus, err := q.CreateTask(ctx, params)
+		if err != nil {
+			return err
+		}
+		task.WorkflowStatus = domain.TaskStatus(workflowStatus)
+		if len(task.Tags) > 0 {
+			if err := syncTaskTags(ctx, tx, task.UserID, task.ID, task.Tags); err != nil {
+				return err
+			}
+		}
+	}
+
+	if dup.Series != nil {
+		params, err := createTaskSeriesParams(dup.Series)
+		if err != nil {
+			return err
+		}
+		if err := q.CreateTaskSeries(ctx, params); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `UPDATE tasks SET series_id = $1 WHERE id = $2`, params.ID, params.OriginalTaskID); err != nil {
+			return err
+		}
+	}
+
+	for _, dep := range dup.Dependencies {
+		taskUUID, err := stringToPgtypeUUID(dep.TaskID)
+		if err != nil {
+			return err
+		}
+		blockedByUUID, err := stringToPgtypeUUID(dep.BlockedByID)
+		if err != nil {
+			return err
+		}
+		if err := q.AddDependency(ctx, sqlc.AddDependencyParams{
+			TaskID:      taskUUID,
+			BlockedByID: blockedByUUID,
+			CreatedAt:   timeToPgtypeTimestamptz(dep.CreatedAt),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Convert writes a task's new type, parent and series in one transaction, creating or
+// deactivating a series as the conversion requires. A task with an empty rank is moved
+// to the end of its new list.
+func (r *TaskRepository) Convert(ctx context.Context, conv *domain.TaskConversion) error {
+	task := conv.Task
+	id, err := stringToPgtypeUUID(task.ID)
+	if err != nil {
+		return err
+	}
+	userID, err := stringToPgtypeUUID(task.UserID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+	q := r.queries.WithTx(tx)
+
+	if conv.Series != nil {
+		params, err := createTaskSeriesParams(conv.Series)
+		if err != nil {
+			return err
+		}
+		if err := q.CreateTaskSeries(ctx, params); err != nil {
+			return err
+		}
+	}
+
+	if conv.DeactivateSeriesID != nil {
+		seriesID, err := stringToPgtypeUUID(*conv.DeactivateSeriesID)
+		if err != nil {
+			return err
+		}
+		if err := q.DeactivateTaskSeries(ctx, sqlc.DeactivateTaskSeriesParams{ID: seriesID, UserID: userID}); err != nil {
+			return err
+		}
+	}
+
+	if err := assignRank(ctx, tx, task); err != nil {
+		return err
+	}
+
+	// task_type follows parent_task_id and series_id (see the set_tasks_task_type trigger)
+	query := `
+		UPDATE tasks
+		SET parent_task_id = $1::uuid, series_id = $2::uuid,
+			category = $3, priority_score = $4, updated_at = $5, rank = $9
+		WHERE id = $6 AND user_id = $7 AND deleted_at IS NULL AND ($8 = 0 OR version = $8)
+		RETURNING version
+	`
+	err = tx.QueryRow(ctx, query,
+		task.ParentTaskID,
+		task.SeriesID,
+		task.Category,
+		int32(task.PriorityScore),
+		timeToPgtypeTimestamptz(task.UpdatedAt),
+		id,
+		userID,
+		task.Version,
+		task.Rank,
+	).Scan(&task.Version)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+		var exists bool
+		if err := tx.QueryRow(ctx,
+			"SELECT EXISTS(SELECT 1 FROM tasks WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL)",
+			id, userID).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return domain.ErrTaskVersionConflict
+		}
+		return domain.ErrTaskNotFound
 	}
 
-	return r.queries.CreateTask(ctx, params)
+	return tx.Commit(ctx)
 }
 
 // FindByID retrieves a task by ID
+// Uses a manual query so the row version is included for optimistic concurrency checks.
 func (r *TaskRepository) FindByID(ctx context.Context, id string) (*domain.Task, error) {
 	pguuid, err := stringToPgtypeUUID(id)
 	if err != nil {
 		return nil, err
 	}
 
-	row, err := r.queries.GetTaskByID(ctx, pguuid)
+	query := `
+		SELECT id, user_id, title, description, status, user_priority,
+			   due_date, estimated_effort, category, context, related_people,
+			   priority_score, bump_count, created_at, updated_at, completed_at,
+			   series_id, parent_task_id, defer_until, version, rank, workflow_status, ` + taskTagsColumn + `,
+			   ` + taskCommentCountColumn + `,
+			   ` + taskTrackedSecondsColumn + `,
+			   ` + taskFocusSessionCountColumn + `
+		FROM tasks
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	var task domain.Task
+	var seriesID, parentTaskID pgtype.UUID
+	err = r.db.QueryRow(ctx, query, pguuid).Scan(
+		&task.ID,
+		&task.UserID,
+		&task.Title,
+		&task.Description,
+		&task.Status,
+		&task.UserPriority,
+		&task.DueDate,
+		&task.EstimatedEffort,
+		&task.Category,
+		&task.Context,
+		&task.RelatedPeople,
+		&task.PriorityScore,
+		&task.BumpCount,
+		&task.CreatedAt,
+		&task.UpdatedAt,
+		&task.CompletedAt,
+		&seriesID,
+		&parentTaskID,
+		&task.DeferUntil,
+		&task.Version,
+		&task.Rank,
+		&task.WorkflowStatus,
+		&task.Tags,
+		&task.CommentCount,
+		&task.TrackedSeconds,
+		&task.FocusSessionCount,
+	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrTaskNotFound
@@ -209,73 +551,305 @@ func (r *TaskRepository) FindByID(ctx context.Context, id string) (*domain.Task,
 		return nil, err
 	}
 
-	domainTask := sqlcRowToDomain(row.ID, row.UserID, row.Title, row.Description, row.Status,
-		row.UserPriority, row.DueDate, row.EstimatedEffort, row.Category, row.Context,
-		row.RelatedPeople, row.PriorityScore, row.BumpCount, row.CreatedAt, row.UpdatedAt, row.CompletedAt,
-		row.SeriesID, row.ParentTaskID)
-	return &domainTask, nil
+	task.TaskType = deriveTaskType(seriesID, parentTaskID)
+	task.SeriesID = pgtypeUUIDToStringPtr(seriesID)
+	task.ParentTaskID = pgtypeUUIDToStringPtr(parentTaskID)
+
+	return &task, nil
+}
+
+// buildTaskQueryClause builds the SQL for one OR group of a parsed search query
+func buildTaskQueryClause(clause domain.TaskQueryClause, argNum int) (string, []interface{}, int) {
+	var args []interface{}
+	parts := make([]string, 0, len(clause.Terms))
+
+	for _, term := range clause.Terms {
+		condition, termArgs, nextArgNum := buildTaskQueryTerm(term, argNum)
+		if term.Negate {
+			// COALESCE so negating a comparison on a NULL column still matches the row
+			condition = "NOT COALESCE(" + condition + ", FALSE)"
+		}
+		parts = append(parts, condition)
+		args = append(args, termArgs...)
+		argNum = nextArgNum
+	}
+
+	return "(" + strings.Join(parts, " OR ") + ")", args, argNum
 }
 
-// List retrieves tasks with filters (kept as manual SQL due to dynamic query building)
+// buildTaskQueryTerm builds the SQL for a single search term
+func buildTaskQueryTerm(term domain.TaskQueryTerm, argNum int) (string, []interface{}, int) {
+	switch term.Field {
+	case domain.TaskQueryStatus:
+		return fmt.Sprintf("(status = $%d)", argNum), []interface{}{term.Value}, argNum + 1
+	case domain.TaskQueryCategory:
+		return fmt.Sprintf("(LOWER(category) = LOWER($%d))", argNum), []interface{}{term.Value}, argNum + 1
+	case domain.TaskQueryEffort:
+		return fmt.Sprintf("(estimated_effort = $%d)", argNum), []interface{}{term.Value}, argNum + 1
+	case domain.TaskQueryPerson:
+		return fmt.Sprintf("(EXISTS (SELECT 1 FROM unnest(related_people) AS person WHERE LOWER(person) = LOWER($%d)))", argNum),
+			[]interface{}{term.Value}, argNum + 1
+	case domain.TaskQueryTag:
+		return "(" + fmt.Sprintf(taskHasTagCondition, argNum) + ")", []interface{}{term.Value}, argNum + 1
+	case domain.TaskQueryContext:
+		return fmt.Sprintf("(context ILIKE '%%' || $%d || '%%')", argNum), []interface{}{escapeLikePattern(term.Value)}, argNum + 1
+	case domain.TaskQueryPriority:
+		return fmt.Sprintf("(priority_score %s $%d)", term.Op, argNum), []interface{}{term.Number}, argNum + 1
+	case domain.TaskQueryDue:
+		return buildTaskQueryDateTerm("due_date", term, argNum)
+	case domain.TaskQueryCreated:
+		return buildTaskQueryDateTerm("created_at", term, argNum)
+	default:
+		tsFunc := "plainto_tsquery"
+		if term.Phrase {
+			tsFunc = "phraseto_tsquery"
+		}
+		return fmt.Sprintf("(search_vector @@ %s('english', $%d))", tsFunc, argNum), []interface{}{term.Value}, argNum + 1
+	}
+}
+
+// buildTaskQueryDateTerm compares a timestamp column against a resolved day range [From, To)
+func buildTaskQueryDateTerm(column string, term domain.TaskQueryTerm, argNum int) (string, []interface{}, int) {
+	if term.From == nil || term.To == nil {
+		return fmt.Sprintf("(%s IS NULL)", column), nil, argNum
+	}
+
+	switch term.Op {
+	case domain.TaskQueryLt:
+		return fmt.Sprintf("(%s < $%d)", column, argNum), []interface{}{*term.From}, argNum + 1
+	case domain.TaskQueryLte:
+		return fmt.Sprintf("(%s < $%d)", column, argNum), []interface{}{*term.To}, argNum + 1
+	case domain.TaskQueryGt:
+		return fmt.Sprintf("(%s >= $%d)", column, argNum), []interface{}{*term.To}, argNum + 1
+	case domain.TaskQueryGte:
+		return fmt.Sprintf("(%s >= $%d)", column, argNum), []interface{}{*term.From}, argNum + 1
+	default:
+		return fmt.Sprintf("(%s >= $%d AND %s < $%d)", column, argNum, column, argNum+1),
+			[]interface{}{*term.From, *term.To}, argNum + 2
+	}
+}
+
+// escapeLikePattern escapes LIKE wildcards so user input matches literally
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// buildTaskListConditions builds the WHERE clause shared by List and Count.
+// Returns the clause, its positional args, and the next free placeholder number.
 // Note: Excludes subtasks from main list - they should only appear under their parent
 // Note: Excludes soft-deleted tasks
-func (r *TaskRepository) List(ctx context.Context, userID string, filter *domain.TaskListFilter) ([]*domain.Task, error) {
-	query := `
-		SELECT id, user_id, title, description, status, user_priority,
-			   due_date, estimated_effort, category, context, related_people,
-			   priority_score, bump_count, created_at, updated_at, completed_at,
-			   series_id, parent_task_id
-		FROM tasks
-		WHERE user_id = $1 AND (task_type IS NULL OR task_type != 'subtask') AND deleted_at IS NULL
-	`
+// Note: Excludes deferred tasks (defer_until in the future) unless filter.IncludeDeferred
+func buildTaskListConditions(userID string, filter *domain.TaskListFilter) (string, []interface{}, int) {
+	where := " WHERE user_id = $1 AND (task_type IS NULL OR task_type != 'subtask') AND deleted_at IS NULL"
 	args := []interface{}{userID}
 	argNum := 2
 
-	// Apply filters
+	if filter == nil || !filter.IncludeDeferred {
+		where += " AND (defer_until IS NULL OR defer_until <= NOW())"
+	}
+
+	if filter == nil {
+		return where, args, argNum
+	}
+
 	if filter.Status != nil {
-		query += fmt.Sprintf(" AND status = $%d", argNum)
+		where += fmt.Sprintf(" AND status = $%d", argNum)
 		args = append(args, *filter.Status)
 		argNum++
 	}
 
+	if filter.WorkflowStatus != nil {
+		where += fmt.Sprintf(" AND workflow_status = $%d", argNum)
+		args = append(args, string(*filter.WorkflowStatus))
+		argNum++
+	}
+
 	if filter.Category != nil {
-		query += fmt.Sprintf(" AND category = $%d", argNum)
+		where += fmt.Sprintf(" AND category = $%d", argNum)
 		args = append(args, *filter.Category)
 		argNum++
 	}
 
+	for _, tag := range filter.Tags {
+		where += " AND " + fmt.Sprintf(taskHasTagCondition, argNum)
+		args = append(args, tag)
+		argNum++
+	}
+
 	if filter.Search != nil && *filter.Search != "" {
-		query += fmt.Sprintf(" AND search_vector @@ plainto_tsquery('english', $%d)", argNum)
+		where += fmt.Sprintf(" AND search_vector @@ plainto_tsquery('english', $%d)", argNum)
 		args = append(args, *filter.Search)
 		argNum++
 	}
 
+	if filter.Query != nil {
+		for _, clause := range filter.Query.Clauses {
+			condition, clauseArgs, nextArgNum := buildTaskQueryClause(clause, argNum)
+			where += " AND " + condition
+			args = append(args, clauseArgs...)
+			argNum = nextArgNum
+		}
+	}
+
 	if filter.MinPriority != nil {
-		query += fmt.Sprintf(" AND priority_score >= $%d", argNum)
+		where += fmt.Sprintf(" AND priority_score >= $%d", argNum)
 		args = append(args, *filter.MinPriority)
 		argNum++
 	}
 
 	if filter.MaxPriority != nil {
-		query += fmt.Sprintf(" AND priority_score <= $%d", argNum)
+		where += fmt.Sprintf(" AND priority_score <= $%d", argNum)
 		args = append(args, *filter.MaxPriority)
 		argNum++
 	}
 
 	if filter.DueDateStart != nil {
-		query += fmt.Sprintf(" AND due_date >= $%d", argNum)
+		where += fmt.Sprintf(" AND due_date >= $%d", argNum)
 		args = append(args, *filter.DueDateStart)
 		argNum++
 	}
 
 	if filter.DueDateEnd != nil {
-		query += fmt.Sprintf(" AND due_date <= $%d", argNum)
+		where += fmt.Sprintf(" AND due_date <= $%d", argNum)
 		args = append(args, *filter.DueDateEnd)
 		argNum++
 	}
 
-	// Order by priority score descending
-	query += " ORDER BY priority_score DESC, created_at DESC"
+	return where, args, argNum
+}
+
+// taskSortColumns whitelists the columns that task lists can be ordered by
+var taskSortColumns = map[domain.TaskSortField]string{
+	domain.TaskSortDueDate:       "due_date",
+	domain.TaskSortPriorityScore: "priority_score",
+	domain.TaskSortUserPriority:  "user_priority",
+	domain.TaskSortCreatedAt:     "created_at",
+	domain.TaskSortUpdatedAt:     "updated_at",
+	domain.TaskSortCompletedAt:   "completed_at",
+	domain.TaskSortTitle:         "title",
+	domain.TaskSortBumpCount:     "bump_count",
+	domain.TaskSortManual:        "rank",
+}
+
+// buildTaskOrderBy builds the ORDER BY clause for the given sort keys.
+// Nullable columns always sort NULLs last; id DESC is appended as the final tie-breaker.
+func buildTaskOrderBy(sorts []domain.TaskSort) string {
+	parts := make([]string, 0, len(sorts)+1)
+	for _, s := range sorts {
+		part := taskSortColumns[s.Field]
+		if s.Descending {
+			part += " DESC"
+		} else {
+			part += " ASC"
+		}
+		if s.Field.IsNullable() {
+			part += " NULLS LAST"
+		}
+		parts = append(parts, part)
+	}
+	parts = append(parts, "id DESC")
+
+	return " ORDER BY " + strings.Join(parts, ", ")
+}
+
+// buildTaskCursorCondition builds the keyset condition selecting rows that sort after the cursor.
+// When every key is non-nullable and descending a single row comparison is used (index friendly);
+// otherwise the condition is expanded key by key so mixed directions and NULLs-last work.
+func buildTaskCursorCondition(sorts []domain.TaskSort, cursor *domain.TaskCursor, argNum int) (string, []interface{}, int) {
+	simple := true
+	for _, s := range sorts {
+		if !s.Descending || s.Field.IsNullable() {
+			simple = false
+			break
+		}
+	}
+
+	var args []interface{}
+
+	if simple {
+		columns := make([]string, 0, len(sorts)+1)
+		placeholders := make([]string, 0, len(sorts)+1)
+		for i, s := range sorts {
+			columns = append(columns, taskSortColumns[s.Field])
+			placeholders = append(placeholders, fmt.Sprintf("$%d", argNum))
+			args = append(args, cursor.Values[i])
+			argNum++
+		}
+		columns = append(columns, "id")
+		placeholders = append(placeholders, fmt.Sprintf("$%d", argNum))
+		args = append(args, cursor.ID)
+		argNum++
+
+		return fmt.Sprintf(" AND (%s) < (%s)", strings.Join(columns, ", "), strings.Join(placeholders, ", ")), args, argNum
+	}
+
+	// Expanded form: (k1 after) OR (k1 = AND k2 after) OR ... OR (all equal AND id after)
+	var disjuncts []string
+	var equalities []string
+
+	for i, s := range sorts {
+		column := taskSortColumns[s.Field]
+		value := cursor.Values[i]
+
+		if value == nil {
+			// Nothing sorts after NULL (NULLs last), so only rows tied on NULL can follow
+			equalities = append(equalities, column+" IS NULL")
+			continue
+		}
+
+		op := ">"
+		if s.Descending {
+			op = "<"
+		}
+		after := fmt.Sprintf("%s %s $%d", column, op, argNum)
+		if s.Field.IsNullable() {
+			after = fmt.Sprintf("(%s OR %s IS NULL)", after, column)
+		}
+		disjuncts = append(disjuncts, strings.Join(append(append([]string{}, equalities...), after), " AND "))
+
+		equalities = append(equalities, fmt.Sprintf("%s = $%d", column, argNum))
+		args = append(args, value)
+		argNum++
+	}
+
+	idAfter := fmt.Sprintf("id < $%d", argNum)
+	disjuncts = append(disjuncts, strings.Join(append(equalities, idAfter), " AND "))
+	args = append(args, cursor.ID)
+	argNum++
+
+	return " AND ((" + strings.Join(disjuncts, ") OR (") + "))", args, argNum
+}
+
+// List retrieves tasks with filters (kept as manual SQL due to dynamic query building)
+// Ordering is stable (the requested sort keys, then id descending) so that
+// keyset pagination via filter.Cursor never skips or repeats rows.
+func (r *TaskRepository) List(ctx context.Context, userID string, filter *domain.TaskListFilter) ([]*domain.Task, error) {
+	if filter == nil {
+		filter = &domain.TaskListFilter{}
+	}
+	sorts := filter.SortOrder()
+
+	where, args, argNum := buildTaskListConditions(userID, filter)
+	query := `
+		SELECT id, user_id, title, description, status, user_priority,
+			   due_date, estimated_effort, category, context, related_people,
+			   priority_score, bump_count, created_at, updated_at, completed_at,
+			   series_id, parent_task_id, defer_until, version, rank, workflow_status, ` + taskTagsColumn + `,
+			   ` + taskCommentCountColumn + `,
+			   ` + taskTrackedSecondsColumn + `,
+			   ` + taskFocusSessionCountColumn + `
+		FROM tasks
+	` + where
+
+	// Keyset pagination: only rows that sort after the cursor position
+	if filter.Cursor != nil {
+		condition, cursorArgs, nextArgNum := buildTaskCursorCondition(sorts, filter.Cursor, argNum)
+		query += condition
+		args = append(args, cursorArgs...)
+		argNum = nextArgNum
+	}
+
+	query += buildTaskOrderBy(sorts)
 
 	// Apply limit and offset
 	if filter.Limit > 0 {
@@ -284,7 +858,8 @@ func (r *TaskRepository) List(ctx context.Context, userID string, filter *domain
 		argNum++
 	}
 
-	if filter.Offset > 0 {
+	// Offset is ignored when a cursor is supplied
+	if filter.Offset > 0 && filter.Cursor == nil {
 		query += fmt.Sprintf(" OFFSET $%d", argNum)
 		args = append(args, filter.Offset)
 	}
@@ -318,6 +893,14 @@ func (r *TaskRepository) List(ctx context.Context, userID string, filter *domain
 			&task.CompletedAt,
 			&seriesID,
 			&parentTaskID,
+			&task.DeferUntil,
+			&task.Version,
+			&task.Rank,
+			&task.WorkflowStatus,
+			&task.Tags,
+			&task.CommentCount,
+			&task.TrackedSeconds,
+			&task.FocusSessionCount,
 		)
 		if err != nil {
 			return nil, err
@@ -332,7 +915,22 @@ func (r *TaskRepository) List(ctx context.Context, userID string, filter *domain
 	return tasks, rows.Err()
 }
 
-// Update updates a task in the database
+// Count returns the total number of tasks matching the filter, ignoring pagination
+func (r *TaskRepository) Count(ctx context.Context, userID string, filter *domain.TaskListFilter) (int, error) {
+	where, args, _ := buildTaskListConditions(userID, filter)
+
+	var count int
+	if err := r.db.QueryRow(ctx, "SELECT COUNT(*)::int FROM tasks"+where, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Update updates a task in the database.
+// A non-nil task.Tags replaces the task's tags in the same transaction; nil leaves them unchanged.
+// A board placement carried by ctx (see domain.WithBoardPlacement) also moves the task into
+// its column and rank, after checking the column's WIP limit in the same transaction.
 func (r *TaskRepository) Update(ctx context.Context, task *domain.Task) error {
 	id, err := stringToPgtypeUUID(task.ID)
 	if err != nil {
@@ -343,10 +941,16 @@ func (r *TaskRepository) Update(ctx context.Context, task *domain.Task) error {
 		return err
 	}
 
+	status, workflowStatus, rank := task.Status, task.WorkflowStatus, ""
+	placement, placed := domain.BoardPlacementFor(ctx, task.ID)
+	if placed {
+		status, workflowStatus, rank = placement.Column.BaseStatus(), placement.Column.Key, placement.Rank
+	}
+
 	params := sqlc.UpdateTaskParams{
 		Title:           task.Title,
 		Description:     task.Description,
-		Status:          domainStatusToSqlc(task.Status),
+		Status:          domainStatusToSqlc(status),
 		UserPriority:    int32(task.UserPriority),
 		DueDate:         timePtrToPgtypeTimestamptz(task.DueDate),
 		EstimatedEffort: domainEffortToSqlc(task.EstimatedEffort),
@@ -361,16 +965,35 @@ func (r *TaskRepository) Update(ctx context.Context, task *domain.Task) error {
 		UserID:          userID,
 	}
 
-	// Since sqlc :exec doesn't return rows affected, we need to use manual query to check
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+
+	if placed {
+		if err := checkColumnLimit(ctx, tx, task.UserID, task.ID, placement.Column.Key); err != nil {
+			return err
+		}
+	}
+
+	// Manual query so the version check and the new version can be handled in one round trip.
+	// A zero task.Version (task not loaded from the database) skips the version check.
+	// An empty task.WorkflowStatus keeps the stored one; either way the sync trigger moves
+	// the task to a matching workflow status when only the built-in status changed.
+	// The rank only changes for a board placement between neighbors.
 	query := `
 		UPDATE tasks
 		SET title = $1, description = $2, status = $3, user_priority = $4,
 			due_date = $5, estimated_effort = $6, category = $7, context = $8,
 			related_people = $9, priority_score = $10, bump_count = $11,
-			updated_at = $12, completed_at = $13
-		WHERE id = $14 AND user_id = $15
+			updated_at = $12, completed_at = $13, defer_until = $17,
+			workflow_status = COALESCE(NULLIF($18, ''), workflow_status),
+			rank = COALESCE(NULLIF($19, ''), rank)
+		WHERE id = $14 AND user_id = $15 AND ($16 = 0 OR version = $16)
+		RETURNING version, workflow_status
 	`
-	result, err := r.db.Exec(ctx, query,
+	err = tx.QueryRow(ctx, query,
 		params.Title,
 		params.Description,
 		params.Status,
@@ -386,20 +1009,87 @@ func (r *TaskRepository) Update(ctx context.Context, task *domain.Task) error {
 		params.CompletedAt,
 		params.ID,
 		params.UserID,
-	)
-	if err != nil {
+		task.Version,
+		task.DeferUntil,
+		string(workflowStatus),
+		rank,
+	).Scan(&task.Version, &task.WorkflowStatus)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+		// Distinguish a missing task from one that changed since it was read
+		var exists bool
+		if err := tx.QueryRow(ctx,
+			"SELECT EXISTS(SELECT 1 FROM tasks WHERE id = $1 AND user_id = $2)",
+			params.ID, params.UserID).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return domain.ErrTaskVersionConflict
+		}
+		return domain.ErrTaskNotFound
+	}
+
+	if task.Tags != nil {
+		if err := syncTaskTags(ctx, tx, task.UserID, task.ID, task.Tags); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
 		return err
 	}
+	task.Status = status
+	if rank != "" {
+		task.Rank = rank
+	}
+	return nil
+}
 
-	if result.RowsAffected() == 0 {
-		return domain.ErrTaskNotFound
+// checkColumnLimit refuses to move a task into a board column that is already at its WIP
+// limit. The user's preferences row is locked first, so moves into the same user's columns
+// are counted one at a time.
+func checkColumnLimit(ctx context.Context, tx pgx.Tx, userID, taskID string, column domain.TaskStatus) error {
+	var limitsJSON []byte
+	err := tx.QueryRow(ctx, `SELECT board_wip_limits FROM user_preferences WHERE user_id = $1 FOR UPDATE`, userID).
+		Scan(&limitsJSON)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil // No preferences, so no limits
+	}
+	if err != nil {
+		return err
+	}
+
+	limits := domain.WIPLimits{}
+	if err := json.Unmarshal(limitsJSON, &limits); err != nil {
+		return err
+	}
+	limit := limits.Limit(column)
+	if limit == nil {
+		return nil
 	}
 
+	// Counted the way the board counts the column, without the task being moved
+	where, args, argNum := buildTaskListConditions(userID, &domain.TaskListFilter{WorkflowStatus: &column})
+	where += fmt.Sprintf(" AND id <> $%d", argNum)
+	args = append(args, taskID)
+
+	var count int
+	if err := tx.QueryRow(ctx, "SELECT COUNT(*)::int FROM tasks"+where, args...).Scan(&count); err != nil {
+		return err
+	}
+	if count >= *limit {
+		return fmt.Errorf("%w: %s already has %d of %d tasks", domain.ErrWIPLimitExceeded, column, count, *limit)
+	}
 	return nil
 }
 
-// Delete soft-deletes a task by setting deleted_at timestamp
-func (r *TaskRepository) Delete(ctx context.Context, id, userID string) error {
+// Delete soft-deletes a task and its subtasks by setting deleted_at timestamp.
+// Every task trashed by one delete shares a new deletion group, so Restore brings
+// the tree back together. Subtasks already in the trash keep their own group.
+// A non-zero expectedVersion must match the task's version, or ErrTaskVersionConflict is returned.
+func (r *TaskRepository) Delete(ctx context.Context, id, userID string, expectedVersion int) error {
 	idUUID, err := stringToPgtypeUUID(id)
 	if err != nil {
 		return err
@@ -408,23 +1098,51 @@ func (r *TaskRepository) Delete(ctx context.Context, id, userID string) error {
 	if err != nil {
 		return err
 	}
+	groupUUID, err := stringToPgtypeUUID(uuid.New().String())
+	if err != nil {
+		return err
+	}
 
-	// Soft delete by setting deleted_at timestamp
-	result, err := r.db.Exec(ctx,
-		"UPDATE tasks SET deleted_at = NOW(), updated_at = NOW() WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL",
-		idUUID, userUUID)
+	// Recurring instances also use parent_task_id (for the previous instance) and are left alone
+	query := `
+		WITH RECURSIVE tree AS (
+			SELECT id FROM tasks
+			WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL AND ($4 = 0 OR version = $4)
+			UNION
+			SELECT t.id FROM tasks t
+			JOIN tree ON t.parent_task_id = tree.id
+			WHERE t.series_id IS NULL AND t.deleted_at IS NULL
+		)
+		UPDATE tasks
+		SET deleted_at = NOW(), deletion_group_id = $3, updated_at = NOW()
+		WHERE id IN (SELECT id FROM tree)
+	`
+	result, err := r.db.Exec(ctx, query, idUUID, userUUID, groupUUID, expectedVersion)
 	if err != nil {
 		return err
 	}
 
-	if result.RowsAffected() == 0 {
+	if result.RowsAffected() > 0 {
+		return nil
+	}
+	if expectedVersion == 0 {
 		return domain.ErrTaskNotFound
 	}
 
-	return nil
+	var exists bool
+	if err := r.db.QueryRow(ctx,
+		"SELECT EXISTS(SELECT 1 FROM tasks WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL)",
+		idUUID, userUUID).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrTaskVersionConflict
+	}
+	return domain.ErrTaskNotFound
 }
 
-// Restore undeletes a soft-deleted task by clearing deleted_at timestamp
+// Restore undeletes a soft-deleted task by clearing deleted_at timestamp.
+// The rest of its deletion group (the tree trashed with it) is restored in the same statement.
 func (r *TaskRepository) Restore(ctx context.Context, id, userID string) error {
 	idUUID, err := stringToPgtypeUUID(id)
 	if err != nil {
@@ -435,10 +1153,15 @@ func (r *TaskRepository) Restore(ctx context.Context, id, userID string) error {
 		return err
 	}
 
-	// Restore by clearing deleted_at timestamp
-	result, err := r.db.Exec(ctx,
-		"UPDATE tasks SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL",
-		idUUID, userUUID)
+	query := `
+		UPDATE tasks
+		SET deleted_at = NULL, deletion_group_id = NULL, updated_at = NOW()
+		WHERE user_id = $2 AND deleted_at IS NOT NULL
+		  AND (id = $1 OR deletion_group_id = (
+			SELECT deletion_group_id FROM tasks WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL
+		  ))
+	`
+	result, err := r.db.Exec(ctx, query, idUUID, userUUID)
 	if err != nil {
 		return err
 	}
@@ -461,7 +1184,8 @@ func (r *TaskRepository) FindByIDIncludingDeleted(ctx context.Context, id string
 		SELECT id, user_id, title, description, status, user_priority,
 			   due_date, estimated_effort, category, context, related_people,
 			   priority_score, bump_count, created_at, updated_at, completed_at,
-			   series_id, parent_task_id, deleted_at
+			   series_id, parent_task_id, deleted_at, defer_until, version, rank, workflow_status, ` + taskTagsColumn + `,
+			   deletion_group_id::text
 		FROM tasks
 		WHERE id = $1
 	`
@@ -488,6 +1212,12 @@ func (r *TaskRepository) FindByIDIncludingDeleted(ctx context.Context, id string
 		&seriesID,
 		&parentTaskID,
 		&task.DeletedAt,
+		&task.DeferUntil,
+		&task.Version,
+		&task.Rank,
+		&task.WorkflowStatus,
+		&task.Tags,
+		&task.DeletionGroupID,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -649,42 +1379,94 @@ func (r *TaskRepository) FindByDateRange(ctx context.Context, userID string, fil
 	return tasks, rows.Err()
 }
 
-// RenameCategoryForUser renames a category for all tasks belonging to a user
-func (r *TaskRepository) RenameCategoryForUser(ctx context.Context, userID, oldName, newName string) (int, error) {
-	userUUID, err := stringToPgtypeUUID(userID)
-	if err != nil {
-		return 0, err
-	}
-
-	// Use manual query to get rows affected
-	// Note: Apply to ALL tasks including completed ones - category management should be universal
-	result, err := r.db.Exec(ctx,
-		"UPDATE tasks SET category = $1, updated_at = NOW() WHERE user_id = $2 AND category = $3",
-		newName, userUUID, oldName)
-	if err != nil {
-		return 0, err
-	}
+// RenameCategoryForUser renames a category for all tasks belonging to a user and returns
+// the renamed tasks as they were before, so their history can record the change
+func (r *TaskRepository) RenameCategoryForUser(ctx context.Context, userID, oldName, newName string) ([]*domain.Task, error) {
+	return r.setCategory(ctx, userID, oldName, &newName)
+}
 
-	return int(result.RowsAffected()), nil
+// DeleteCategoryForUser removes a category from all tasks belonging to a user and returns
+// the affected tasks as they were before
+func (r *TaskRepository) DeleteCategoryForUser(ctx context.Context, userID, categoryName string) ([]*domain.Task, error) {
+	return r.setCategory(ctx, userID, categoryName, nil)
 }
 
-// DeleteCategoryForUser removes a category from all tasks belonging to a user
-func (r *TaskRepository) DeleteCategoryForUser(ctx context.Context, userID, categoryName string) (int, error) {
+// setCategory moves every task in category to newCategory (nil clears it). The tasks are
+// locked, read and updated in one statement, so the tasks returned are exactly those changed.
+// Note: Apply to ALL tasks including completed and soft-deleted ones - category management should be universal
+func (r *TaskRepository) setCategory(ctx context.Context, userID, category string, newCategory *string) ([]*domain.Task, error) {
 	userUUID, err := stringToPgtypeUUID(userID)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	// Use manual query to get rows affected
-	// Note: Apply to ALL tasks including completed ones - category management should be universal
-	result, err := r.db.Exec(ctx,
-		"UPDATE tasks SET category = NULL, updated_at = NOW() WHERE user_id = $1 AND category = $2",
-		userUUID, categoryName)
+	query := `
+		WITH old AS (
+			SELECT id, user_id, title, description, status, user_priority,
+				   due_date, estimated_effort, category, context, related_people,
+				   priority_score, bump_count, created_at, updated_at, completed_at,
+				   series_id, parent_task_id, deleted_at, defer_until, version
+			FROM tasks
+			WHERE user_id = $1 AND category = $2
+			FOR UPDATE
+		), renamed AS (
+			UPDATE tasks
+			SET category = $3, updated_at = NOW()
+			WHERE id IN (SELECT id FROM old)
+		)
+		SELECT old.*, COALESCE((
+			SELECT array_agg(tg.name ORDER BY LOWER(tg.name))
+			FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id
+			WHERE tt.task_id = old.id
+		), '{}') AS tags
+		FROM old
+		ORDER BY old.created_at, old.id
+	`
+
+	rows, err := r.db.Query(ctx, query, userUUID, category, newCategory)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := []*domain.Task{}
+	for rows.Next() {
+		var task domain.Task
+		var seriesID, parentTaskID pgtype.UUID
+		err := rows.Scan(
+			&task.ID,
+			&task.UserID,
+			&task.Title,
+			&task.Description,
+			&task.Status,
+			&task.UserPriority,
+			&task.DueDate,
+			&task.EstimatedEffort,
+			&task.Category,
+			&task.Context,
+			&task.RelatedPeople,
+			&task.PriorityScore,
+			&task.BumpCount,
+			&task.CreatedAt,
+			&task.UpdatedAt,
+			&task.CompletedAt,
+			&seriesID,
+			&parentTaskID,
+			&task.DeletedAt,
+			&task.DeferUntil,
+			&task.Version,
+			&task.Tags,
+		)
+		if err != nil {
+			return nil, err
+		}
+		task.TaskType = deriveTaskType(seriesID, parentTaskID)
+		task.SeriesID = pgtypeUUIDToStringPtr(seriesID)
+		task.ParentTaskID = pgtypeUUIDToStringPtr(parentTaskID)
+		tasks = append(tasks, &task)
 	}
 
-	return int(result.RowsAffected()), nil
+	return tasks, rows.Err()
 }
 
 // Analytics-related repository methods
@@ -820,6 +1602,104 @@ func (r *TaskRepository) GetCategoryBreakdown(ctx context.Context, userID string
 	return stats, nil
 }
 
+// GetTagBreakdown retrieves task statistics grouped by tag.
+// A task with several tags counts towards each of them; untagged tasks are omitted.
+func (r *TaskRepository) GetTagBreakdown(ctx context.Context, userID string, daysBack int) ([]domain.TagStats, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT
+			tg.name,
+			tg.color,
+			COUNT(*)::int AS total_count,
+			(COUNT(*) FILTER (WHERE t.status = 'done'))::int AS completed_count
+		FROM task_tags tt
+		JOIN tags tg ON tg.id = tt.tag_id
+		JOIN tasks t ON t.id = tt.task_id
+		WHERE tg.user_id = $1
+		  AND t.created_at >= NOW() - INTERVAL '1 day' * $2
+		GROUP BY tg.id, tg.name, tg.color
+		ORDER BY total_count DESC, LOWER(tg.name)
+	`, userID, daysBack)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := []domain.TagStats{}
+	for rows.Next() {
+		var s domain.TagStats
+		if err := rows.Scan(&s.Tag, &s.Color, &s.TotalCount, &s.CompletedCount); err != nil {
+			return nil, err
+		}
+		if s.TotalCount > 0 {
+			s.CompletionRate = float64(s.CompletedCount) / float64(s.TotalCount) * 100
+		}
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
+
+// GetTimeTrackingSummary retrieves the time tracked by a user over the period.
+// Entries are attributed to the period by their start time; running timers are not counted.
+func (r *TaskRepository) GetTimeTrackingSummary(ctx context.Context, userID string, daysBack int) (*domain.TimeTrackingSummary, error) {
+	summary := &domain.TimeTrackingSummary{CategoryBreakdown: []domain.CategoryTimeStats{}}
+
+	err := r.db.QueryRow(ctx, `
+		WITH per_task AS (
+			SELECT t.id, t.status,
+				SUM(EXTRACT(EPOCH FROM (te.ended_at - te.started_at))) / 60 AS minutes
+			FROM time_entries te
+			JOIN tasks t ON t.id = te.task_id
+			WHERE te.user_id = $1
+			  AND te.ended_at IS NOT NULL
+			  AND te.started_at >= NOW() - INTERVAL '1 day' * $2
+			GROUP BY t.id, t.status
+		)
+		SELECT
+			COALESCE(SUM(minutes), 0)::float,
+			COUNT(*)::int,
+			(COUNT(*) FILTER (WHERE status = 'done'))::int,
+			COALESCE(AVG(minutes) FILTER (WHERE status = 'done'), 0)::float
+		FROM per_task
+	`, userID, daysBack).Scan(
+		&summary.TotalMinutes,
+		&summary.TrackedTaskCount,
+		&summary.CompletedTaskCount,
+		&summary.AvgMinutesPerCompletedTask,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT
+			COALESCE(t.category, 'Uncategorized'),
+			(SUM(EXTRACT(EPOCH FROM (te.ended_at - te.started_at))) / 60)::float AS total_minutes,
+			COUNT(DISTINCT t.id)::int
+		FROM time_entries te
+		JOIN tasks t ON t.id = te.task_id
+		WHERE te.user_id = $1
+		  AND te.ended_at IS NOT NULL
+		  AND te.started_at >= NOW() - INTERVAL '1 day' * $2
+		GROUP BY COALESCE(t.category, 'Uncategorized')
+		ORDER BY total_minutes DESC
+	`, userID, daysBack)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s domain.CategoryTimeStats
+		if err := rows.Scan(&s.Category, &s.TotalMinutes, &s.TaskCount); err != nil {
+			return nil, err
+		}
+		summary.CategoryBreakdown = append(summary.CategoryBreakdown, s)
+	}
+
+	return summary, rows.Err()
+}
+
 // VelocityMetrics represents task completion velocity
 type VelocityMetrics struct {
 	Date           string `json:"date"`
@@ -1055,6 +1935,56 @@ func (r *TaskRepository) GetCompletionTimeStats(ctx context.Context, userID stri
 	return &stats, nil
 }
 
+// GetTrackedTimeStats retrieves statistics on the total tracked time of completed tasks,
+// i.e. the actual effort they took. Completed tasks without tracked time are excluded.
+func (r *TaskRepository) GetTrackedTimeStats(ctx context.Context, userID string, category *string, effort *domain.TaskEffort) (*domain.TrackedTimeStats, error) {
+	query := `
+		WITH per_task AS (
+			SELECT SUM(EXTRACT(EPOCH FROM (te.ended_at - te.started_at))) / 60 AS minutes
+			FROM tasks t
+			JOIN time_entries te ON te.task_id = t.id AND te.ended_at IS NOT NULL
+			WHERE t.user_id = $1
+			  AND t.status = 'done'
+			  AND t.deleted_at IS NULL
+	`
+	args := []interface{}{userID}
+	argNum := 2
+
+	if category != nil {
+		query += fmt.Sprintf(" AND t.category = $%d", argNum)
+		args = append(args, *category)
+		argNum++
+	}
+
+	if effort != nil {
+		query += fmt.Sprintf(" AND t.estimated_effort = $%d", argNum)
+		args = append(args, string(*effort))
+	}
+
+	query += `
+			GROUP BY t.id
+			HAVING SUM(EXTRACT(EPOCH FROM (te.ended_at - te.started_at))) > 0
+		)
+		SELECT
+			COUNT(*)::int,
+			COALESCE(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY minutes), 0)::float,
+			COALESCE(AVG(minutes), 0)::float
+		FROM per_task
+	`
+
+	var stats domain.TrackedTimeStats
+	err := r.db.QueryRow(ctx, query, args...).Scan(
+		&stats.SampleSize,
+		&stats.MedianMinutes,
+		&stats.AvgMinutes,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
 // GetCategoryDistribution retrieves distribution of pending tasks by category
 func (r *TaskRepository) GetCategoryDistribution(ctx context.Context, userID string) ([]domain.CategoryDistribution, error) {
 	userUUID, err := stringToPgtypeUUID(userID)
@@ -1114,6 +2044,50 @@ func (r *TaskRepository) GetProductivityHeatmap(ctx context.Context, userID stri
 	}, nil
 }
 
+// GetFocusHeatmap retrieves focused minutes by day of week and hour.
+// Ended sessions (completed or interrupted) count toward the hour they started in.
+func (r *TaskRepository) GetFocusHeatmap(ctx context.Context, userID string, daysBack int) (*domain.FocusHeatmap, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT
+			EXTRACT(DOW FROM started_at)::int AS day_of_week,
+			EXTRACT(HOUR FROM started_at)::int AS hour,
+			(SUM(focused_seconds) / 60)::int AS minutes
+		FROM focus_sessions
+		WHERE user_id = $1
+		  AND status IN ('completed', 'interrupted')
+		  AND started_at >= NOW() - ($2::int || ' days')::interval
+		GROUP BY day_of_week, hour
+		HAVING SUM(focused_seconds) >= 60
+		ORDER BY day_of_week, hour
+	`, userID, daysBack)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cells := []domain.FocusHeatmapCell{}
+	maxMinutes := 0
+	for rows.Next() {
+		var cell domain.FocusHeatmapCell
+		if err := rows.Scan(&cell.DayOfWeek, &cell.Hour, &cell.Minutes); err != nil {
+			return nil, err
+		}
+		cells = append(cells, cell)
+		if cell.Minutes > maxMinutes {
+			maxMinutes = cell.Minutes
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &domain.FocusHeatmap{
+		Cells:      cells,
+		MaxMinutes: maxMinutes,
+	}, nil
+}
+
 // GetCategoryTrends retrieves weekly category breakdown for trend visualization
 func (r *TaskRepository) GetCategoryTrends(ctx context.Context, userID string, daysBack int) (*domain.CategoryTrends, error) {
 	userUUID, err := stringToPgtypeUUID(userID)
@@ -1169,9 +2143,9 @@ func (r *TaskRepository) GetCategoryTrends(ctx context.Context, userID string, d
 	}, nil
 }
 
-// BulkDelete deletes multiple tasks by their IDs for a user
-// Returns the count of successfully deleted tasks and IDs that failed to delete
-func (r *TaskRepository) BulkDelete(ctx context.Context, userID string, taskIDs []string) (int, []string, error) {
+// BulkUpdateStatus updates the status of multiple tasks for a user
+// Returns the count of successfully updated tasks and IDs that failed to update
+func (r *TaskRepository) BulkUpdateStatus(ctx context.Context, userID string, taskIDs []string, newStatus domain.TaskStatus) (int, []string, error) {
 	if len(taskIDs) == 0 {
 		return 0, nil, nil
 	}
@@ -1193,18 +2167,21 @@ func (r *TaskRepository) BulkDelete(ctx context.Context, userID string, taskIDs
 
 	// Build dynamic IN clause
 	placeholders := ""
-	args := []interface{}{userUUID}
+	args := []interface{}{userUUID, string(newStatus), time.Now()}
+	argNum := 4
 	for i := range taskIDs {
 		if i > 0 {
 			placeholders += ", "
 		}
-		placeholders += fmt.Sprintf("$%d", i+2)
+		placeholders += fmt.Sprintf("$%d", argNum)
 		args = append(args, uuids[i])
+		argNum++
 	}
 
-	// Delete tasks and return the IDs that were actually deleted
+	// Update tasks and return the IDs that were actually updated
 	query := fmt.Sprintf(`
-		DELETE FROM tasks
+		UPDATE tasks
+		SET status = $2, updated_at = $3
 		WHERE user_id = $1 AND id IN (%s)
 		RETURNING id
 	`, placeholders)
@@ -1215,33 +2192,34 @@ func (r *TaskRepository) BulkDelete(ctx context.Context, userID string, taskIDs
 	}
 	defer rows.Close()
 
-	deletedIDs := make(map[string]bool)
+	updatedIDs := make(map[string]bool)
 	for rows.Next() {
 		var id string
 		if err := rows.Scan(&id); err != nil {
 			return 0, taskIDs, err
 		}
-		deletedIDs[id] = true
+		updatedIDs[id] = true
 	}
 
 	if err := rows.Err(); err != nil {
 		return 0, taskIDs, err
 	}
 
-	// Find IDs that were not deleted
+	// Find IDs that were not updated
 	failedIDs := make([]string, 0)
 	for _, id := range taskIDs {
-		if !deletedIDs[id] {
+		if !updatedIDs[id] {
 			failedIDs = append(failedIDs, id)
 		}
 	}
 
-	return len(deletedIDs), failedIDs, nil
+	return len(updatedIDs), failedIDs, nil
 }
 
-// BulkUpdateStatus updates the status of multiple tasks for a user
-// Returns the count of successfully updated tasks and IDs that failed to update
-func (r *TaskRepository) BulkUpdateStatus(ctx context.Context, userID string, taskIDs []string, newStatus domain.TaskStatus) (int, []string, error) {
+// BulkRestore returns tasks to active status in one transaction: trashed tasks come back
+// together with the rest of their deletion group, and every task is set to todo.
+// Returns the count of restored tasks and IDs that were not found or not owned.
+func (r *TaskRepository) BulkRestore(ctx context.Context, userID string, taskIDs []string) (int, []string, error) {
 	if len(taskIDs) == 0 {
 		return 0, nil, nil
 	}
@@ -1251,65 +2229,393 @@ func (r *TaskRepository) BulkUpdateStatus(ctx context.Context, userID string, ta
 		return 0, nil, err
 	}
 
-	// Convert string IDs to UUIDs
-	uuids := make([]interface{}, len(taskIDs))
+	ids := make([]pgtype.UUID, len(taskIDs))
 	for i, id := range taskIDs {
-		uuid, err := stringToPgtypeUUID(id)
+		ids[i], err = stringToPgtypeUUID(id)
 		if err != nil {
 			return 0, taskIDs, fmt.Errorf("invalid task ID %s: %w", id, err)
 		}
-		uuids[i] = uuid
 	}
 
-	// Build dynamic IN clause
-	placeholders := ""
-	args := []interface{}{userUUID, string(newStatus), time.Now()}
-	argNum := 4
-	for i := range taskIDs {
-		if i > 0 {
-			placeholders += ", "
-		}
-		placeholders += fmt.Sprintf("$%d", argNum)
-		args = append(args, uuids[i])
-		argNum++
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, taskIDs, err
 	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
 
-	// Update tasks and return the IDs that were actually updated
-	query := fmt.Sprintf(`
+	undeleteQuery := `
 		UPDATE tasks
-		SET status = $2, updated_at = $3
-		WHERE user_id = $1 AND id IN (%s)
-		RETURNING id
-	`, placeholders)
+		SET deleted_at = NULL, deletion_group_id = NULL, updated_at = NOW()
+		WHERE user_id = $1 AND deleted_at IS NOT NULL
+		  AND (id = ANY($2) OR deletion_group_id IN (
+			SELECT deletion_group_id FROM tasks
+			WHERE user_id = $1 AND id = ANY($2) AND deletion_group_id IS NOT NULL
+		  ))
+	`
+	if _, err := tx.Exec(ctx, undeleteQuery, userUUID, ids); err != nil {
+		return 0, taskIDs, err
+	}
 
-	rows, err := r.db.Query(ctx, query, args...)
+	rows, err := tx.Query(ctx, `
+		UPDATE tasks
+		SET status = $3, updated_at = NOW()
+		WHERE user_id = $1 AND id = ANY($2)
+		RETURNING id
+	`, userUUID, ids, string(domain.TaskStatusTodo))
 	if err != nil {
 		return 0, taskIDs, err
 	}
-	defer rows.Close()
 
-	updatedIDs := make(map[string]bool)
+	restoredIDs := make(map[string]bool)
 	for rows.Next() {
 		var id string
 		if err := rows.Scan(&id); err != nil {
+			rows.Close()
 			return 0, taskIDs, err
 		}
-		updatedIDs[id] = true
+		restoredIDs[id] = true
 	}
-
+	rows.Close()
 	if err := rows.Err(); err != nil {
 		return 0, taskIDs, err
 	}
 
-	// Find IDs that were not updated
+	if err := tx.Commit(ctx); err != nil {
+		return 0, taskIDs, err
+	}
+
+	// Find IDs that were not restored
 	failedIDs := make([]string, 0)
 	for _, id := range taskIDs {
-		if !updatedIDs[id] {
+		if !restoredIDs[id] {
 			failedIDs = append(failedIDs, id)
 		}
 	}
 
-	return len(updatedIDs), failedIDs, nil
+	return len(restoredIDs), failedIDs, nil
+}
+
+// =====================
+// Trash
+// =====================
+
+// trashRootCondition selects the user's trashed tasks, leaving out subtasks that were
+// trashed together with their parent; they come back or go with it
+const trashRootCondition = `
+		user_id = $1 AND deleted_at IS NOT NULL
+		AND NOT EXISTS (
+			SELECT 1 FROM tasks p
+			WHERE p.id = tasks.parent_task_id AND p.deletion_group_id = tasks.deletion_group_id
+		)
+`
+
+// FindTrash returns a page of the user's soft-deleted tasks, most recently deleted first,
+// and the total number of tasks in the trash. Subtasks deleted with their parent are not listed.
+func (r *TaskRepository) FindTrash(ctx context.Context, userID string, limit, offset int) ([]*domain.Task, int, error) {
+	userUUID, err := stringToPgtypeUUID(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT id, user_id, title, description, status, user_priority,
+			   due_date, estimated_effort, category, context, related_people,
+			   priority_score, bump_count, created_at, updated_at, completed_at,
+			   series_id, parent_task_id, deleted_at, defer_until, version, rank, workflow_status, ` + taskTagsColumn + `,
+			   deletion_group_id::text, COUNT(*) OVER() AS total_count
+		FROM tasks
+		WHERE ` + trashRootCondition + `
+		ORDER BY deleted_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, userUUID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	tasks := []*domain.Task{}
+	total := 0
+	for rows.Next() {
+		var task domain.Task
+		var seriesID, parentTaskID pgtype.UUID
+		err := rows.Scan(
+			&task.ID,
+			&task.UserID,
+			&task.Title,
+			&task.Description,
+			&task.Status,
+			&task.UserPriority,
+			&task.DueDate,
+			&task.EstimatedEffort,
+			&task.Category,
+			&task.Context,
+			&task.RelatedPeople,
+			&task.PriorityScore,
+			&task.BumpCount,
+			&task.CreatedAt,
+			&task.UpdatedAt,
+			&task.CompletedAt,
+			&seriesID,
+			&parentTaskID,
+			&task.DeletedAt,
+			&task.DeferUntil,
+			&task.Version,
+			&task.Rank,
+			&task.WorkflowStatus,
+			&task.Tags,
+			&task.DeletionGroupID,
+			&total,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		task.TaskType = deriveTaskType(seriesID, parentTaskID)
+		task.SeriesID = pgtypeUUIDToStringPtr(seriesID)
+		task.ParentTaskID = pgtypeUUIDToStringPtr(parentTaskID)
+		tasks = append(tasks, &task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	// Past the last page no row carries the window count
+	if len(tasks) == 0 && offset > 0 {
+		countQuery := `SELECT COUNT(*) FROM tasks WHERE ` + trashRootCondition
+		if err := r.db.QueryRow(ctx, countQuery, userUUID).Scan(&total); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return tasks, total, nil
+}
+
+// PurgeTrash permanently deletes the user's trashed tasks selected by purge, together with
+// all of their subtasks, and records the purge in task_purges. History, dependencies, tags,
+// comments and other task data are removed by ON DELETE CASCADE. A recurring series whose
+// first task is purged is moved to its earliest remaining task so it survives.
+// Returns the number of tasks deleted, subtasks included.
+func (r *TaskRepository) PurgeTrash(ctx context.Context, userID string, purge *domain.TrashPurge) (int, error) {
+	userUUID, err := stringToPgtypeUUID(userID)
+	if err != nil {
+		return 0, err
+	}
+	taskUUID := stringPtrToPgtypeUUID(purge.TaskID)
+	if purge.TaskID != nil && !taskUUID.Valid {
+		return 0, fmt.Errorf("invalid task ID %s", *purge.TaskID)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+
+	// Subtasks are collected recursively because parent_task_id is ON DELETE SET NULL.
+	// Recurring instances also use parent_task_id (for the previous instance) and are kept.
+	collectQuery := `
+		WITH RECURSIVE purged AS (
+			SELECT id FROM tasks
+			WHERE user_id = $1
+			  AND deleted_at IS NOT NULL
+			  AND ($2::uuid IS NULL OR id = $2)
+			  AND ($3::timestamptz IS NULL OR deleted_at < $3)
+			UNION
+			SELECT t.id FROM tasks t
+			JOIN purged p ON t.parent_task_id = p.id
+			WHERE t.series_id IS NULL
+		)
+		SELECT id FROM purged
+	`
+	rows, err := tx.Query(ctx, collectQuery, userUUID, taskUUID, timePtrToPgtypeTimestamptz(purge.DeletedBefore))
+	if err != nil {
+		return 0, err
+	}
+	ids := []pgtype.UUID{}
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	repointQuery := `
+		UPDATE task_series s
+		SET original_task_id = (
+			SELECT t.id FROM tasks t
+			WHERE t.series_id = s.id AND NOT (t.id = ANY($1))
+			ORDER BY t.created_at, t.id
+			LIMIT 1
+		)
+		WHERE s.original_task_id = ANY($1)
+		  AND EXISTS (SELECT 1 FROM tasks t WHERE t.series_id = s.id AND NOT (t.id = ANY($1)))
+	`
+	if _, err := tx.Exec(ctx, repointQuery, ids); err != nil {
+		return 0, err
+	}
+
+	result, err := tx.Exec(ctx, `DELETE FROM tasks WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return 0, err
+	}
+	deleted := int(result.RowsAffected())
+
+	logQuery := `
+		INSERT INTO task_purges (user_id, task_count, reason, purged_at)
+		VALUES ($1, $2, $3, NOW())
+	`
+	if _, err := tx.Exec(ctx, logQuery, userUUID, deleted, string(purge.Reason)); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
+// FindUsersWithTrashBefore returns the IDs of users who have tasks deleted before cutoff
+func (r *TaskRepository) FindUsersWithTrashBefore(ctx context.Context, cutoff time.Time) ([]string, error) {
+	query := `
+		SELECT DISTINCT user_id::text
+		FROM tasks
+		WHERE deleted_at IS NOT NULL AND deleted_at < $1
+	`
+
+	rows, err := r.db.Query(ctx, query, timeToPgtypeTimestamptz(cutoff))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	userIDs := []string{}
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, rows.Err()
+}
+
+// =====================
+// Manual ordering
+// =====================
+
+// FindAdjacentRank returns the rank of the closest task in a list after (or before) rank,
+// skipping excludeTaskID; "" when there is none
+func (r *TaskRepository) FindAdjacentRank(ctx context.Context, scope domain.RankScope, rank, excludeTaskID string, after bool) (string, error) {
+	condition, arg := rankScopeCondition(scope)
+	query := "SELECT rank FROM tasks WHERE " + condition + " AND id <> $2::uuid"
+	if after {
+		query += " AND rank > $3 ORDER BY rank ASC LIMIT 1"
+	} else {
+		query += " AND rank < $3 ORDER BY rank DESC LIMIT 1"
+	}
+
+	var adjacent string
+	err := r.db.QueryRow(ctx, query, arg, excludeTaskID, rank).Scan(&adjacent)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	return adjacent, err
+}
+
+// UpdateRank moves a task to task.Rank within its list, checking task.Version
+// (0 skips the check) and storing the new version on task
+func (r *TaskRepository) UpdateRank(ctx context.Context, task *domain.Task) error {
+	id, err := stringToPgtypeUUID(task.ID)
+	if err != nil {
+		return err
+	}
+	userID, err := stringToPgtypeUUID(task.UserID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE tasks
+		SET rank = $1, updated_at = $2
+		WHERE id = $3 AND user_id = $4 AND deleted_at IS NULL AND ($5 = 0 OR version = $5)
+		RETURNING version
+	`
+	err = r.db.QueryRow(ctx, query, task.Rank, timeToPgtypeTimestamptz(task.UpdatedAt), id, userID, task.Version).
+		Scan(&task.Version)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+
+	var exists bool
+	if err := r.db.QueryRow(ctx,
+		"SELECT EXISTS(SELECT 1 FROM tasks WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL)",
+		id, userID).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrTaskVersionConflict
+	}
+	return domain.ErrTaskNotFound
+}
+
+// RebalanceRanks spreads the ranks of a list out evenly, keeping its order (ties are
+// broken by id). Used when moves have made ranks too long or left no room between two tasks.
+func (r *TaskRepository) RebalanceRanks(ctx context.Context, scope domain.RankScope) error {
+	return rebalanceRanks(ctx, r.db, scope)
+}
+
+// rebalanceRanks spreads a list's ranks out in one transaction (a savepoint inside one)
+func rebalanceRanks(ctx context.Context, db rowQuerier, scope domain.RankScope) error {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+
+	condition, arg := rankScopeCondition(scope)
+	rows, err := tx.Query(ctx, "SELECT id::text FROM tasks WHERE "+condition+" ORDER BY rank, id FOR UPDATE", arg)
+	if err != nil {
+		return err
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE tasks
+		SET rank = s.rank
+		FROM unnest($1::uuid[], $2::text[]) AS s(id, rank)
+		WHERE tasks.id = s.id AND tasks.rank <> s.rank
+	`
+	if _, err := tx.Exec(ctx, query, ids, domain.SpreadRanks(len(ids))); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 // =====================
@@ -1327,12 +2633,12 @@ func (r *TaskRepository) GetSubtasks(ctx context.Context, parentTaskID string) (
 		SELECT id, user_id, title, description, status, user_priority,
 			   due_date, estimated_effort, category, context, related_people,
 			   priority_score, bump_count, created_at, updated_at, completed_at,
-			   series_id, parent_task_id, task_type
+			   series_id, parent_task_id, task_type, version, rank, workflow_status, ` + taskTagsColumn + `
 		FROM tasks
 		WHERE parent_task_id = $1
 		  AND task_type = 'subtask'
 		  AND deleted_at IS NULL
-		ORDER BY created_at ASC
+		ORDER BY rank, id
 	`
 
 	rows, err := r.db.Query(ctx, query, parentUUID)
@@ -1364,6 +2670,10 @@ func (r *TaskRepository) GetSubtasks(ctx context.Context, parentTaskID string) (
 			&task.SeriesID,
 			&task.ParentTaskID,
 			&task.TaskType,
+			&task.Version,
+			&task.Rank,
+			&task.WorkflowStatus,
+			&task.Tags,
 		)
 		if err != nil {
 			return nil, err
@@ -1374,33 +2684,49 @@ func (r *TaskRepository) GetSubtasks(ctx context.Context, parentTaskID string) (
 	return tasks, rows.Err()
 }
 
-// GetSubtasksBatch retrieves subtasks for multiple parent tasks in a single query.
-// Returns map[parentTaskID] -> [subtasks]
-// Eliminates N+1 queries when loading subtasks for multiple tasks.
-func (r *TaskRepository) GetSubtasksBatch(ctx context.Context, parentTaskIDs []string) (map[string][]*domain.Task, error) {
-	if len(parentTaskIDs) == 0 {
-		return make(map[string][]*domain.Task), nil
+// subtreeCTE walks the live subtasks below $1 at every level. Recurring instances also use
+// parent_task_id (for the previous instance) and are not part of the hierarchy. The path
+// guards against cycles, which the service prevents but a bad row must not turn into an endless loop.
+const subtreeCTE = `
+		WITH RECURSIVE subtree AS (
+			SELECT id, ARRAY[id] AS path, 1 AS depth
+			FROM tasks
+			WHERE parent_task_id = $1 AND series_id IS NULL AND deleted_at IS NULL
+		  UNION ALL
+			SELECT t.id, s.path || t.id, s.depth + 1
+			FROM tasks t
+			JOIN subtree s ON t.parent_task_id = s.id
+			WHERE t.series_id IS NULL AND t.deleted_at IS NULL
+			  AND t.id <> $1 AND t.id <> ALL(s.path)
+		)
+`
+
+// GetSubtree retrieves every live subtask below a task, at any depth, in one query.
+// Tasks are ordered level by level, in manual order within a level, so parents come
+// before their subtasks; use domain.BuildTaskTree to nest them.
+func (r *TaskRepository) GetSubtree(ctx context.Context, rootTaskID string) ([]*domain.Task, error) {
+	rootUUID, err := stringToPgtypeUUID(rootTaskID)
+	if err != nil {
+		return nil, err
 	}
 
-	query := `
-		SELECT id, user_id, title, description, status, user_priority,
+	query := subtreeCTE + `
+		SELECT tasks.id, user_id, title, description, status, user_priority,
 			   due_date, estimated_effort, category, context, related_people,
 			   priority_score, bump_count, created_at, updated_at, completed_at,
-			   series_id, parent_task_id, task_type
-		FROM tasks
-		WHERE parent_task_id = ANY($1)
-		  AND task_type = 'subtask'
-		  AND deleted_at IS NULL
-		ORDER BY parent_task_id, created_at ASC
+			   series_id, parent_task_id, version, rank, workflow_status, ` + taskTagsColumn + `
+		FROM subtree
+		JOIN tasks ON tasks.id = subtree.id
+		ORDER BY subtree.depth, tasks.rank, tasks.id
 	`
 
-	rows, err := r.db.Query(ctx, query, parentTaskIDs)
+	rows, err := r.db.Query(ctx, query, rootUUID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	result := make(map[string][]*domain.Task)
+	tasks := []*domain.Task{}
 	for rows.Next() {
 		var task domain.Task
 		err := rows.Scan(
@@ -1422,62 +2748,95 @@ func (r *TaskRepository) GetSubtasksBatch(ctx context.Context, parentTaskIDs []s
 			&task.CompletedAt,
 			&task.SeriesID,
 			&task.ParentTaskID,
-			&task.TaskType,
+			&task.Version,
+			&task.Rank,
+			&task.WorkflowStatus,
+			&task.Tags,
 		)
 		if err != nil {
 			return nil, err
 		}
+		task.TaskType = domain.TaskTypeSubtask
+		tasks = append(tasks, &task)
+	}
 
-		if task.ParentTaskID != nil {
-			result[*task.ParentTaskID] = append(result[*task.ParentTaskID], &task)
-		}
+	return tasks, rows.Err()
+}
+
+// GetAncestorIDs returns the IDs of the tasks above a task in its hierarchy, nearest first.
+// A top-level task has none.
+func (r *TaskRepository) GetAncestorIDs(ctx context.Context, taskID string) ([]string, error) {
+	taskUUID, err := stringToPgtypeUUID(taskID)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := rows.Err(); err != nil {
+	// Only subtask links count: a recurring instance's parent is its previous instance
+	query := `
+		WITH RECURSIVE ancestors AS (
+			SELECT parent_task_id AS id, ARRAY[id] AS path, 1 AS depth
+			FROM tasks
+			WHERE id = $1 AND parent_task_id IS NOT NULL AND series_id IS NULL
+		  UNION ALL
+			SELECT t.parent_task_id, a.path || t.id, a.depth + 1
+			FROM tasks t
+			JOIN ancestors a ON t.id = a.id
+			WHERE t.parent_task_id IS NOT NULL AND t.series_id IS NULL
+			  AND t.id <> ALL(a.path)
+		)
+		SELECT id::text FROM ancestors ORDER BY depth
+	`
+
+	rows, err := r.db.Query(ctx, query, taskUUID)
+	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	// Ensure all requested IDs have entries (even if empty)
-	for _, id := range parentTaskIDs {
-		if _, exists := result[id]; !exists {
-			result[id] = []*domain.Task{}
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
 		}
+		ids = append(ids, id)
 	}
 
-	return result, nil
+	return ids, rows.Err()
 }
 
-// GetSubtaskInfo returns aggregated subtask statistics for a parent task
-func (r *TaskRepository) GetSubtaskInfo(ctx context.Context, parentTaskID string) (*domain.SubtaskInfo, error) {
-	parentUUID, err := stringToPgtypeUUID(parentTaskID)
-	if err != nil {
-		return nil, err
+// UpdatePriorityScores sets the priority scores of several tasks in one statement,
+// used when a parent's priority is passed down its subtree
+func (r *TaskRepository) UpdatePriorityScores(ctx context.Context, scores map[string]int) error {
+	if len(scores) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(scores))
+	values := make([]int32, 0, len(scores))
+	for id, score := range scores {
+		ids = append(ids, id)
+		values = append(values, int32(score))
 	}
 
 	query := `
-		SELECT
-			COUNT(*)::int as total_count,
-			COUNT(*) FILTER (WHERE status = 'done')::int as completed_count,
-			COUNT(*) FILTER (WHERE status = 'in_progress')::int as in_progress_count,
-			COUNT(*) FILTER (WHERE status = 'todo')::int as todo_count
-		FROM tasks
-		WHERE parent_task_id = $1
-		  AND task_type = 'subtask'
+		UPDATE tasks
+		SET priority_score = s.score
+		FROM unnest($1::uuid[], $2::int[]) AS s(id, score)
+		WHERE tasks.id = s.id AND tasks.priority_score <> s.score
 	`
+	_, err := r.db.Exec(ctx, query, ids, values)
+	return err
+}
 
-	var info domain.SubtaskInfo
-	err = r.db.QueryRow(ctx, query, parentUUID).Scan(
-		&info.TotalCount,
-		&info.CompletedCount,
-		&info.InProgressCount,
-		&info.TodoCount,
-	)
+// GetSubtaskInfo returns aggregated subtask statistics for a parent task, rolled up
+// over its whole subtree
+func (r *TaskRepository) GetSubtaskInfo(ctx context.Context, parentTaskID string) (*domain.SubtaskInfo, error) {
+	descendants, err := r.GetSubtree(ctx, parentTaskID)
 	if err != nil {
 		return nil, err
 	}
-
-	info.CalculateCompletionRate()
-	return &info, nil
+	return domain.NewSubtaskInfo(parentTaskID, descendants), nil
 }
 
 // CountIncompleteSubtasks returns the count of non-completed subtasks for a parent task
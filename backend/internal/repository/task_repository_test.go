@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -348,6 +349,39 @@ func TestTaskRepository_Update(t *testing.T) {
 		err := repo.Update(ctx, task)
 		assert.ErrorIs(t, err, domain.ErrTaskNotFound) // Should fail because user_id doesn't match
 	})
+
+	t.Run("applies a board placement within the column's WIP limit", func(t *testing.T) {
+		boardUserID := createTestUser(t, ctx, pool)
+		require.NoError(t, NewUserPreferencesRepository(pool).UpsertWIPLimits(ctx, boardUserID,
+			domain.WIPLimits{domain.TaskStatusInProgress: 1}))
+		column := &domain.WorkflowStatus{Key: domain.TaskStatusInProgress, Category: domain.StatusCategoryActive}
+
+		first := createTestTask(t, ctx, repo, boardUserID, "First")
+		first.UpdatedAt = time.Now().UTC()
+		placed := domain.WithBoardPlacement(ctx, &domain.BoardPlacement{TaskID: first.ID, Column: column, Rank: "a5"})
+		require.NoError(t, repo.Update(placed, first))
+
+		found, err := repo.FindByID(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.TaskStatusInProgress, found.Status)
+		assert.Equal(t, domain.TaskStatusInProgress, found.WorkflowStatus)
+		assert.Equal(t, "a5", found.Rank)
+
+		// The column is now full, so a second task is refused and left where it was
+		second := createTestTask(t, ctx, repo, boardUserID, "Second")
+		second.UpdatedAt = time.Now().UTC()
+		placed = domain.WithBoardPlacement(ctx, &domain.BoardPlacement{TaskID: second.ID, Column: column})
+		err = repo.Update(placed, second)
+		assert.ErrorIs(t, err, domain.ErrWIPLimitExceeded)
+
+		found, err = repo.FindByID(ctx, second.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.TaskStatusTodo, found.Status)
+
+		// A task already in the column does not count against its own move
+		placed = domain.WithBoardPlacement(ctx, &domain.BoardPlacement{TaskID: first.ID, Column: column})
+		assert.NoError(t, repo.Update(placed, first))
+	})
 }
 
 func TestTaskRepository_Delete(t *testing.T) {
@@ -363,7 +397,7 @@ func TestTaskRepository_Delete(t *testing.T) {
 	t.Run("deletes task successfully", func(t *testing.T) {
 		task := createTestTask(t, ctx, repo, userID, "To Delete")
 
-		err := repo.Delete(ctx, task.ID, userID)
+		err := repo.Delete(ctx, task.ID, userID, 0)
 		require.NoError(t, err)
 
 		found, err := repo.FindByID(ctx, task.ID)
@@ -372,7 +406,7 @@ func TestTaskRepository_Delete(t *testing.T) {
 	})
 
 	t.Run("returns not found for non-existent task", func(t *testing.T) {
-		err := repo.Delete(ctx, uuid.New().String(), userID)
+		err := repo.Delete(ctx, uuid.New().String(), userID, 0)
 		assert.ErrorIs(t, err, domain.ErrTaskNotFound)
 	})
 
@@ -380,7 +414,7 @@ func TestTaskRepository_Delete(t *testing.T) {
 		task := createTestTask(t, ctx, repo, userID, "Protected Task")
 		otherUserID := createTestUser(t, ctx, pool)
 
-		err := repo.Delete(ctx, task.ID, otherUserID)
+		err := repo.Delete(ctx, task.ID, otherUserID, 0)
 		assert.ErrorIs(t, err, domain.ErrTaskNotFound)
 
 		// Verify task still exists
@@ -388,6 +422,17 @@ func TestTaskRepository_Delete(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotNil(t, found)
 	})
+
+	t.Run("rejects a stale expected version", func(t *testing.T) {
+		task := createTestTask(t, ctx, repo, userID, "Edited Elsewhere")
+
+		err := repo.Delete(ctx, task.ID, userID, task.Version+1)
+		assert.ErrorIs(t, err, domain.ErrTaskVersionConflict)
+
+		require.NoError(t, repo.Delete(ctx, task.ID, userID, task.Version))
+		err = repo.Delete(ctx, task.ID, userID, task.Version)
+		assert.ErrorIs(t, err, domain.ErrTaskNotFound)
+	})
 }
 
 func TestTaskRepository_IncrementBumpCount(t *testing.T) {
@@ -468,6 +513,74 @@ func TestTaskRepository_FindAtRiskTasks(t *testing.T) {
 	})
 }
 
+func TestTaskRepository_DeferUntil(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool := setupTestDB(t)
+	repo := NewTaskRepository(pool)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, pool)
+
+	visibleTask := createTestTask(t, ctx, repo, userID, "Visible")
+	deferUntil := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Microsecond)
+	deferredTask := &domain.Task{
+		ID:            uuid.New().String(),
+		UserID:        userID,
+		Title:         "Deferred (At Risk)",
+		Status:        domain.TaskStatusTodo,
+		UserPriority:  5,
+		PriorityScore: 50,
+		BumpCount:     3, // Would be at risk if it weren't deferred
+		DeferUntil:    &deferUntil,
+		CreatedAt:     time.Now().UTC(),
+		UpdatedAt:     time.Now().UTC(),
+	}
+	require.NoError(t, repo.Create(ctx, deferredTask))
+
+	t.Run("round-trips defer_until", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, deferredTask.ID)
+		require.NoError(t, err)
+		require.NotNil(t, found.DeferUntil)
+		assert.True(t, deferUntil.Equal(*found.DeferUntil))
+	})
+
+	t.Run("default list and count exclude deferred tasks", func(t *testing.T) {
+		tasks, err := repo.List(ctx, userID, &domain.TaskListFilter{})
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		assert.Equal(t, visibleTask.ID, tasks[0].ID)
+
+		count, err := repo.Count(ctx, userID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("include_deferred lists deferred tasks", func(t *testing.T) {
+		tasks, err := repo.List(ctx, userID, &domain.TaskListFilter{IncludeDeferred: true})
+		require.NoError(t, err)
+		assert.Len(t, tasks, 2)
+	})
+
+	t.Run("at-risk query skips deferred tasks", func(t *testing.T) {
+		atRisk, err := repo.FindAtRiskTasks(ctx, userID)
+		require.NoError(t, err)
+		assert.Empty(t, atRisk)
+	})
+
+	t.Run("clearing defer_until makes the task visible", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, deferredTask.ID)
+		require.NoError(t, err)
+		found.DeferUntil = nil
+		require.NoError(t, repo.Update(ctx, found))
+
+		tasks, err := repo.List(ctx, userID, nil)
+		require.NoError(t, err)
+		assert.Len(t, tasks, 2)
+	})
+}
+
 func TestTaskRepository_GetCategories(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test in short mode")
@@ -660,9 +773,12 @@ func TestTaskRepository_RenameCategoryForUser(t *testing.T) {
 		require.NoError(t, repo.Create(ctx, task1))
 		require.NoError(t, repo.Create(ctx, task2))
 
-		count, err := repo.RenameCategoryForUser(ctx, userID, oldCat, newCat)
+		renamed, err := repo.RenameCategoryForUser(ctx, userID, oldCat, newCat)
 		require.NoError(t, err)
-		assert.Equal(t, 2, count)
+		require.Len(t, renamed, 2)
+		// The tasks come back as they were before the rename
+		assert.Equal(t, task1.ID, renamed[0].ID)
+		assert.Equal(t, oldCat, *renamed[0].Category)
 
 		// Verify rename
 		categories, err := repo.GetCategories(ctx, userID)
@@ -687,9 +803,9 @@ func TestTaskRepository_RenameCategoryForUser(t *testing.T) {
 		}
 		require.NoError(t, repo.Create(ctx, task))
 
-		count, err := repo.RenameCategoryForUser(ctx, userID, cat, "RenamedDone")
+		renamed, err := repo.RenameCategoryForUser(ctx, userID, cat, "RenamedDone")
 		require.NoError(t, err)
-		assert.Equal(t, 1, count)
+		assert.Len(t, renamed, 1)
 
 		// Verify category was actually renamed
 		found, err := repo.FindByID(ctx, task.ID)
@@ -723,9 +839,9 @@ func TestTaskRepository_DeleteCategoryForUser(t *testing.T) {
 		}
 		require.NoError(t, repo.Create(ctx, task))
 
-		count, err := repo.DeleteCategoryForUser(ctx, userID, cat)
+		removed, err := repo.DeleteCategoryForUser(ctx, userID, cat)
 		require.NoError(t, err)
-		assert.Equal(t, 1, count)
+		assert.Len(t, removed, 1)
 
 		// Verify category is removed
 		found, err := repo.FindByID(ctx, task.ID)
@@ -749,9 +865,9 @@ func TestTaskRepository_DeleteCategoryForUser(t *testing.T) {
 		}
 		require.NoError(t, repo.Create(ctx, task))
 
-		count, err := repo.DeleteCategoryForUser(ctx, userID, cat)
+		removed, err := repo.DeleteCategoryForUser(ctx, userID, cat)
 		require.NoError(t, err)
-		assert.Equal(t, 1, count)
+		assert.Len(t, removed, 1)
 
 		// Verify category is removed from completed task
 		found, err := repo.FindByID(ctx, task.ID)
@@ -1123,6 +1239,77 @@ func TestTaskRepository_List_SearchFilter(t *testing.T) {
 	})
 }
 
+func TestTaskRepository_List_QueryLanguage(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool := setupTestDB(t)
+	repo := NewTaskRepository(pool)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, pool)
+
+	now := time.Now().UTC()
+	soon := now.Add(2 * 24 * time.Hour)
+	work := "Work"
+	home := "home"
+	callContext := "Call mom after lunch"
+
+	tasks := []*domain.Task{
+		{Title: "Quarterly report draft", Category: &work, DueDate: &soon, PriorityScore: 80, Status: domain.TaskStatusTodo, RelatedPeople: []string{"Alice"}},
+		{Title: "Expense report", Category: &work, PriorityScore: 40, Status: domain.TaskStatusDone},
+		{Title: "Groceries", Category: &home, PriorityScore: 20, Status: domain.TaskStatusTodo, Context: &callContext},
+		{Title: "Report the leak", PriorityScore: 60, Status: domain.TaskStatusInProgress, RelatedPeople: []string{"bob"}},
+	}
+	for _, task := range tasks {
+		task.ID = uuid.New().String()
+		task.UserID = userID
+		task.UserPriority = 5
+		task.CreatedAt = now
+		task.UpdatedAt = now
+		require.NoError(t, repo.Create(ctx, task))
+	}
+
+	list := func(t *testing.T, input string) []string {
+		t.Helper()
+		query, err := domain.ParseTaskQuery(input, now)
+		require.NoError(t, err)
+		result, err := repo.List(ctx, userID, &domain.TaskListFilter{Query: query})
+		require.NoError(t, err)
+		titles := make([]string, len(result))
+		for i, task := range result {
+			titles[i] = task.Title
+		}
+		return titles
+	}
+
+	t.Run("combines qualifiers with AND", func(t *testing.T) {
+		assert.Equal(t, []string{"Quarterly report draft"}, list(t, "category:work priority:>50"))
+	})
+
+	t.Run("negation includes rows with NULL values", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{"Groceries", "Report the leak"}, list(t, "-category:work"))
+	})
+
+	t.Run("OR groups", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{"Quarterly report draft", "Report the leak"}, list(t, "person:alice OR person:BOB"))
+	})
+
+	t.Run("relative due dates and none", func(t *testing.T) {
+		assert.Equal(t, []string{"Quarterly report draft"}, list(t, "due:<7d"))
+		assert.ElementsMatch(t, []string{"Expense report", "Groceries", "Report the leak"}, list(t, "due:none"))
+	})
+
+	t.Run("phrases and context matching", func(t *testing.T) {
+		assert.Equal(t, []string{"Quarterly report draft"}, list(t, `"quarterly report"`))
+		assert.Equal(t, []string{"Groceries"}, list(t, `context:"call mom"`))
+	})
+
+	t.Run("free text combined with status", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{"Quarterly report draft", "Report the leak"}, list(t, "report -status:done"))
+	})
+}
+
 func TestTaskRepository_List_DateFilters(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test in short mode")
@@ -1315,7 +1502,637 @@ func TestTaskRepository_List_CombinedFilters(t *testing.T) {
 	})
 }
 
+func TestTaskRepository_List_SortAndCursor(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool := setupTestDB(t)
+	repo := NewTaskRepository(pool)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, pool)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	tomorrow := now.Add(24 * time.Hour)
+	nextWeek := now.Add(7 * 24 * time.Hour)
+
+	// Two tasks share a due date so the secondary key decides their order,
+	// and two have no due date so they must sort last
+	specs := []struct {
+		title    string
+		dueDate  *time.Time
+		priority int
+	}{
+		{"No Due Low", nil, 20},
+		{"Next Week", &nextWeek, 90},
+		{"Tomorrow Low", &tomorrow, 30},
+		{"No Due High", nil, 70},
+		{"Tomorrow High", &tomorrow, 60},
+	}
+	for i, spec := range specs {
+		task := &domain.Task{
+			ID:            uuid.New().String(),
+			UserID:        userID,
+			Title:         spec.title,
+			DueDate:       spec.dueDate,
+			Status:        domain.TaskStatusTodo,
+			UserPriority:  5,
+			PriorityScore: spec.priority,
+			CreatedAt:     now.Add(time.Duration(i) * time.Second),
+			UpdatedAt:     now,
+		}
+		require.NoError(t, repo.Create(ctx, task))
+	}
+
+	sorts, err := domain.ParseTaskSort("due_date,-priority_score")
+	require.NoError(t, err)
+	expected := []string{"Tomorrow High", "Tomorrow Low", "Next Week", "No Due High", "No Due Low"}
+
+	t.Run("sorts by multiple keys with NULLs last", func(t *testing.T) {
+		tasks, err := repo.List(ctx, userID, &domain.TaskListFilter{Sort: sorts})
+		require.NoError(t, err)
+
+		titles := make([]string, len(tasks))
+		for i, task := range tasks {
+			titles[i] = task.Title
+		}
+		assert.Equal(t, expected, titles)
+	})
+
+	t.Run("cursor pages through the sorted list without gaps", func(t *testing.T) {
+		var titles []string
+		filter := &domain.TaskListFilter{Sort: sorts, Limit: 2}
+
+		for page := 0; page < 5; page++ {
+			tasks, err := repo.List(ctx, userID, filter)
+			require.NoError(t, err)
+			if len(tasks) == 0 {
+				break
+			}
+			for _, task := range tasks {
+				titles = append(titles, task.Title)
+			}
+			filter.Cursor = domain.NewTaskCursor(tasks[len(tasks)-1], sorts)
+		}
+
+		assert.Equal(t, expected, titles)
+	})
+
+	t.Run("counts all matching tasks regardless of limit", func(t *testing.T) {
+		count, err := repo.Count(ctx, userID, &domain.TaskListFilter{Sort: sorts, Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, 5, count)
+	})
+}
+
+func TestTaskRepository_Trash(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool := setupTestDB(t)
+	repo := NewTaskRepository(pool)
+	historyRepo := NewTaskHistoryRepository(pool)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, pool)
+
+	live := createTestTask(t, ctx, repo, userID, "Live Task")
+	parent := createTestTask(t, ctx, repo, userID, "Trashed Parent")
+	subtask := &domain.Task{
+		ID:            uuid.New().String(),
+		UserID:        userID,
+		Title:         "Live Subtask",
+		Status:        domain.TaskStatusTodo,
+		UserPriority:  5,
+		PriorityScore: 50,
+		ParentTaskID:  &parent.ID,
+		CreatedAt:     time.Now().UTC(),
+		UpdatedAt:     time.Now().UTC(),
+	}
+	require.NoError(t, repo.Create(ctx, subtask))
+	old := createTestTask(t, ctx, repo, userID, "Old Trash")
+	recent := createTestTask(t, ctx, repo, userID, "Recent Trash")
+
+	require.NoError(t, historyRepo.Create(ctx, &domain.TaskHistory{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		TaskID:    parent.ID,
+		EventType: domain.EventTaskCreated,
+		CreatedAt: time.Now().UTC(),
+	}))
+	for _, task := range []*domain.Task{parent, old, recent} {
+		require.NoError(t, repo.Delete(ctx, task.ID, userID, 0))
+	}
+	_, err := pool.Exec(ctx, `UPDATE tasks SET deleted_at = NOW() - INTERVAL '40 days' WHERE id = $1`, old.ID)
+	require.NoError(t, err)
+
+	t.Run("lists trashed tasks most recently deleted first", func(t *testing.T) {
+		tasks, total, err := repo.FindTrash(ctx, userID, 2, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		require.Len(t, tasks, 2)
+		assert.Equal(t, recent.ID, tasks[0].ID)
+		assert.Equal(t, parent.ID, tasks[1].ID)
+
+		tasks, total, err = repo.FindTrash(ctx, userID, 2, 10)
+		require.NoError(t, err)
+		assert.Empty(t, tasks)
+		assert.Equal(t, 3, total)
+	})
+
+	t.Run("finds users with trash past the cutoff", func(t *testing.T) {
+		userIDs, err := repo.FindUsersWithTrashBefore(ctx, time.Now().AddDate(0, 0, -30))
+		require.NoError(t, err)
+		assert.Contains(t, userIDs, userID)
+	})
+
+	t.Run("retention purge only removes tasks deleted before the cutoff", func(t *testing.T) {
+		cutoff := time.Now().AddDate(0, 0, -30)
+		deleted, err := repo.PurgeTrash(ctx, userID, &domain.TrashPurge{
+			Reason:        domain.TaskPurgeReasonRetention,
+			DeletedBefore: &cutoff,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+
+		_, err = repo.FindByIDIncludingDeleted(ctx, old.ID)
+		assert.ErrorIs(t, err, domain.ErrTaskNotFound)
+	})
+
+	t.Run("permanent delete takes subtasks and history with it", func(t *testing.T) {
+		deleted, err := repo.PurgeTrash(ctx, userID, &domain.TrashPurge{
+			Reason: domain.TaskPurgeReasonPermanent,
+			TaskID: &parent.ID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, deleted)
+
+		_, err = repo.FindByIDIncludingDeleted(ctx, subtask.ID)
+		assert.ErrorIs(t, err, domain.ErrTaskNotFound)
+		history, err := historyRepo.FindByTaskID(ctx, parent.ID)
+		require.NoError(t, err)
+		assert.Empty(t, history)
+	})
+
+	t.Run("emptying the trash leaves live tasks alone and is audited", func(t *testing.T) {
+		deleted, err := repo.PurgeTrash(ctx, userID, &domain.TrashPurge{Reason: domain.TaskPurgeReasonEmptyTrash})
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+
+		found, err := repo.FindByID(ctx, live.ID)
+		require.NoError(t, err)
+		assert.Equal(t, live.ID, found.ID)
+
+		var purges, purgedTasks int
+		err = pool.QueryRow(ctx, `SELECT COUNT(*), COALESCE(SUM(task_count), 0) FROM task_purges WHERE user_id = $1`, userID).
+			Scan(&purges, &purgedTasks)
+		require.NoError(t, err)
+		assert.Equal(t, 3, purges)
+		assert.Equal(t, 4, purgedTasks)
+	})
+}
+
+func TestTaskRepository_DeleteCascade(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool := setupTestDB(t)
+	repo := NewTaskRepository(pool)
+	depRepo := NewDependencyRepository(pool)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, pool)
+
+	createSubtask := func(parentID, title string) *domain.Task {
+		subtask := &domain.Task{
+			ID:            uuid.New().String(),
+			UserID:        userID,
+			Title:         title,
+			Status:        domain.TaskStatusTodo,
+			UserPriority:  5,
+			PriorityScore: 50,
+			ParentTaskID:  &parentID,
+			CreatedAt:     time.Now().UTC(),
+			UpdatedAt:     time.Now().UTC(),
+		}
+		require.NoError(t, repo.Create(ctx, subtask))
+		return subtask
+	}
+
+	parent := createTestTask(t, ctx, repo, userID, "Parent")
+	first := createSubtask(parent.ID, "First Subtask")
+	second := createSubtask(parent.ID, "Second Subtask")
+	blocked := createTestTask(t, ctx, repo, userID, "Blocked Task")
+	_, err := depRepo.Add(ctx, userID, blocked.ID, first.ID)
+	require.NoError(t, err)
+
+	// Deleted on its own first, so it keeps its own group
+	require.NoError(t, repo.Delete(ctx, second.ID, userID, 0))
+
+	t.Run("delete trashes the subtree under one group", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, parent.ID, userID, 0))
+
+		trashedParent, err := repo.FindByIDIncludingDeleted(ctx, parent.ID)
+		require.NoError(t, err)
+		trashedFirst, err := repo.FindByIDIncludingDeleted(ctx, first.ID)
+		require.NoError(t, err)
+		trashedSecond, err := repo.FindByIDIncludingDeleted(ctx, second.ID)
+		require.NoError(t, err)
+
+		require.NotNil(t, trashedFirst.DeletedAt)
+		require.NotNil(t, trashedParent.DeletionGroupID)
+		assert.Equal(t, trashedParent.DeletionGroupID, trashedFirst.DeletionGroupID)
+		assert.NotEqual(t, trashedParent.DeletionGroupID, trashedSecond.DeletionGroupID)
+
+		// Only the roots are listed in the trash
+		tasks, total, err := repo.FindTrash(ctx, userID, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Len(t, tasks, 2)
+	})
+
+	t.Run("trashed blockers are ignored", func(t *testing.T) {
+		count, err := depRepo.CountIncompleteBlockers(ctx, blocked.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+
+		info, err := depRepo.GetDependencyInfo(ctx, blocked.ID)
+		require.NoError(t, err)
+		assert.Empty(t, info.Blockers)
+		assert.True(t, info.CanComplete)
+	})
+
+	t.Run("restoring any member restores the group", func(t *testing.T) {
+		require.NoError(t, repo.Restore(ctx, first.ID, userID))
+
+		for _, id := range []string{parent.ID, first.ID} {
+			task, err := repo.FindByID(ctx, id)
+			require.NoError(t, err)
+			assert.Nil(t, task.DeletedAt)
+		}
+		_, err := repo.FindByID(ctx, second.ID)
+		assert.ErrorIs(t, err, domain.ErrTaskNotFound)
+
+		count, err := depRepo.CountIncompleteBlockers(ctx, blocked.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("bulk restore brings trees back and reopens tasks", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, parent.ID, userID, 0))
+
+		restored, failed, err := repo.BulkRestore(ctx, userID, []string{parent.ID, uuid.New().String()})
+		require.NoError(t, err)
+		assert.Equal(t, 1, restored)
+		assert.Len(t, failed, 1)
+
+		task, err := repo.FindByID(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Nil(t, task.DeletedAt)
+	})
+}
+
+func TestTaskRepository_CreateDuplicate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool := setupTestDB(t)
+	repo := NewTaskRepository(pool)
+	depRepo := NewDependencyRepository(pool)
+	seriesRepo := NewTaskSeriesRepository(pool)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, pool)
+	blocker := createTestTask(t, ctx, repo, userID, "Blocker")
+
+	newTask := func(title string, parentID *string) *domain.Task {
+		now := time.Now().UTC()
+		return &domain.Task{
+			ID:            uuid.New().String(),
+			UserID:        userID,
+			Title:         title,
+			Status:        domain.TaskStatusTodo,
+			UserPriority:  5,
+			PriorityScore: 50,
+			ParentTaskID:  parentID,
+			Tags:          []string{"copied"},
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+	}
+
+	t.Run("writes task, subtasks and dependencies together", func(t *testing.T) {
+		task := newTask("Copy", nil)
+		subtask := newTask("Copy Subtask", &task.ID)
+		require.NoError(t, repo.CreateDuplicate(ctx, &domain.TaskDuplicate{
+			Task:     task,
+			Subtasks: []*domain.Task{subtask},
+			Dependencies: []domain.TaskDependency{
+				{TaskID: task.ID, BlockedByID: blocker.ID, CreatedAt: time.Now()},
+			},
+		}))
+
+		found, err := repo.FindByID(ctx, subtask.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"copied"}, found.Tags)
+
+		subtasks, err := repo.GetSubtasks(ctx, task.ID)
+		require.NoError(t, err)
+		require.Len(t, subtasks, 1)
+
+		blockers, err := depRepo.GetBlockers(ctx, task.ID)
+		require.NoError(t, err)
+		require.Len(t, blockers, 1)
+		assert.Equal(t, blocker.ID, blockers[0].TaskID)
+	})
+
+	t.Run("links a new series to the copy", func(t *testing.T) {
+		task := newTask("Recurring Copy", nil)
+		series := &domain.TaskSeries{
+			ID:                 uuid.New().String(),
+			UserID:             userID,
+			OriginalTaskID:     task.ID,
+			Pattern:            domain.RecurrencePatternWeekly,
+			IntervalValue:      1,
+			DueDateCalculation: domain.DueDateFromOriginal,
+			IsActive:           true,
+			CreatedAt:          time.Now().UTC(),
+			UpdatedAt:          time.Now().UTC(),
+		}
+		require.NoError(t, repo.CreateDuplicate(ctx, &domain.TaskDuplicate{Task: task, Series: series}))
+
+		found, err := repo.FindByID(ctx, task.ID)
+		require.NoError(t, err)
+		require.NotNil(t, found.SeriesID)
+		assert.Equal(t, series.ID, *found.SeriesID)
+
+		_, err = seriesRepo.FindByID(ctx, series.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("rolls back when a dependency is invalid", func(t *testing.T) {
+		task := newTask("Broken Copy", nil)
+		err := repo.CreateDuplicate(ctx, &domain.TaskDuplicate{
+			Task:         task,
+			Dependencies: []domain.TaskDependency{{TaskID: task.ID, BlockedByID: uuid.New().String(), CreatedAt: time.Now()}},
+		})
+		require.Error(t, err)
+
+		_, err = repo.FindByID(ctx, task.ID)
+		assert.ErrorIs(t, err, domain.ErrTaskNotFound)
+	})
+}
+
+func TestTaskRepository_Convert(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool := setupTestDB(t)
+	repo := NewTaskRepository(pool)
+	seriesRepo := NewTaskSeriesRepository(pool)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, pool)
+	parent := createTestTask(t, ctx, repo, userID, "Parent")
+	task := createTestTask(t, ctx, repo, userID, "Converted")
+
+	t.Run("regular to subtask", func(t *testing.T) {
+		converted, err := repo.FindByID(ctx, task.ID)
+		require.NoError(t, err)
+		converted.ParentTaskID = &parent.ID
+		converted.TaskType = domain.TaskTypeSubtask
+		converted.UpdatedAt = time.Now().UTC()
+		require.NoError(t, repo.Convert(ctx, &domain.TaskConversion{Task: converted}))
+
+		subtasks, err := repo.GetSubtasks(ctx, parent.ID)
+		require.NoError(t, err)
+		require.Len(t, subtasks, 1)
+		assert.Equal(t, task.ID, subtasks[0].ID)
+		assert.Equal(t, domain.TaskTypeSubtask, subtasks[0].TaskType)
+	})
+
+	t.Run("subtask to recurring", func(t *testing.T) {
+		converted, err := repo.FindByID(ctx, task.ID)
+		require.NoError(t, err)
+		series := &domain.TaskSeries{
+			ID:                 uuid.New().String(),
+			UserID:             userID,
+			OriginalTaskID:     task.ID,
+			Pattern:            domain.RecurrencePatternDaily,
+			IntervalValue:      1,
+			DueDateCalculation: domain.DueDateFromOriginal,
+			IsActive:           true,
+			CreatedAt:          time.Now().UTC(),
+			UpdatedAt:          time.Now().UTC(),
+		}
+		converted.ParentTaskID = nil
+		converted.SeriesID = &series.ID
+		converted.TaskType = domain.TaskTypeRecurring
+		require.NoError(t, repo.Convert(ctx, &domain.TaskConversion{Task: converted, Series: series}))
+
+		found, err := repo.FindByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.TaskTypeRecurring, found.TaskType)
+		assert.Nil(t, found.ParentTaskID)
+
+		// Leaving the series stops it
+		found.SeriesID = nil
+		found.TaskType = domain.TaskTypeRegular
+		require.NoError(t, repo.Convert(ctx, &domain.TaskConversion{Task: found, DeactivateSeriesID: &series.ID}))
+
+		stored, err := seriesRepo.FindByID(ctx, series.ID)
+		require.NoError(t, err)
+		assert.False(t, stored.IsActive)
+	})
+
+	t.Run("stale version is a conflict", func(t *testing.T) {
+		stale, err := repo.FindByID(ctx, task.ID)
+		require.NoError(t, err)
+		stale.Version--
+		err = repo.Convert(ctx, &domain.TaskConversion{Task: stale})
+		assert.ErrorIs(t, err, domain.ErrTaskVersionConflict)
+	})
+}
+
 // Helper function to create string pointer
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestTaskRepository_Hierarchy(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool := setupTestDB(t)
+	repo := NewTaskRepository(pool)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, pool)
+
+	createChild := func(parentID, title string, status domain.TaskStatus) *domain.Task {
+		t.Helper()
+		task := &domain.Task{
+			ID:            uuid.New().String(),
+			UserID:        userID,
+			Title:         title,
+			Status:        status,
+			UserPriority:  5,
+			PriorityScore: 10,
+			ParentTaskID:  &parentID,
+			CreatedAt:     time.Now().UTC(),
+			UpdatedAt:     time.Now().UTC(),
+		}
+		require.NoError(t, repo.Create(ctx, task))
+		return task
+	}
+
+	epic := createTestTask(t, ctx, repo, userID, "Epic")
+	story := createChild(epic.ID, "Story", domain.TaskStatusInProgress)
+	done := createChild(epic.ID, "Done Story", domain.TaskStatusDone)
+	step := createChild(story.ID, "Step", domain.TaskStatusDone)
+
+	t.Run("subtree is ordered parents first", func(t *testing.T) {
+		descendants, err := repo.GetSubtree(ctx, epic.ID)
+		require.NoError(t, err)
+		require.Len(t, descendants, 3)
+		assert.Equal(t, story.ID, descendants[0].ID)
+		assert.Equal(t, done.ID, descendants[1].ID)
+		assert.Equal(t, step.ID, descendants[2].ID)
+		// The trigger sets the type from parent_task_id
+		assert.Equal(t, domain.TaskTypeSubtask, descendants[2].TaskType)
+	})
+
+	t.Run("ancestors are nearest first", func(t *testing.T) {
+		ancestors, err := repo.GetAncestorIDs(ctx, step.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{story.ID, epic.ID}, ancestors)
+
+		ancestors, err = repo.GetAncestorIDs(ctx, epic.ID)
+		require.NoError(t, err)
+		assert.Empty(t, ancestors)
+	})
+
+	t.Run("subtask info rolls up", func(t *testing.T) {
+		info, err := repo.GetSubtaskInfo(ctx, epic.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, info.TotalCount)
+		assert.Equal(t, 3, info.DescendantCount)
+		assert.Equal(t, 2, info.DescendantCompletedCount)
+		assert.InDelta(t, 1.0, info.Progress, 0.001)
+	})
+
+	t.Run("priority scores update in one statement", func(t *testing.T) {
+		require.NoError(t, repo.UpdatePriorityScores(ctx, map[string]int{story.ID: 42, step.ID: 7}))
+		require.NoError(t, repo.UpdatePriorityScores(ctx, map[string]int{}))
+
+		found, err := repo.FindByID(ctx, story.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 42, found.PriorityScore)
+		found, err = repo.FindByID(ctx, step.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 7, found.PriorityScore)
+	})
+}
+
+func TestTaskRepository_ManualOrder(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool := setupTestDB(t)
+	repo := NewTaskRepository(pool)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, pool)
+
+	first := createTestTask(t, ctx, repo, userID, "First")
+	second := createTestTask(t, ctx, repo, userID, "Second")
+	third := createTestTask(t, ctx, repo, userID, "Third")
+	scope := domain.RankScope{UserID: userID}
+	manual, err := domain.ParseTaskSort("manual")
+	require.NoError(t, err)
+
+	listIDs := func() []string {
+		t.Helper()
+		tasks, err := repo.List(ctx, userID, &domain.TaskListFilter{Sort: manual})
+		require.NoError(t, err)
+		ids := make([]string, len(tasks))
+		for i, task := range tasks {
+			ids[i] = task.ID
+		}
+		return ids
+	}
+
+	t.Run("new tasks are appended", func(t *testing.T) {
+		assert.Less(t, first.Rank, second.Rank)
+		assert.Less(t, second.Rank, third.Rank)
+		assert.Equal(t, []string{first.ID, second.ID, third.ID}, listIDs())
+	})
+
+	t.Run("adjacent ranks skip the moved task", func(t *testing.T) {
+		next, err := repo.FindAdjacentRank(ctx, scope, first.Rank, second.ID, true)
+		require.NoError(t, err)
+		assert.Equal(t, third.Rank, next)
+
+		previous, err := repo.FindAdjacentRank(ctx, scope, first.Rank, third.ID, false)
+		require.NoError(t, err)
+		assert.Empty(t, previous)
+	})
+
+	t.Run("update rank moves one task", func(t *testing.T) {
+		current, err := repo.FindByID(ctx, third.ID)
+		require.NoError(t, err)
+		current.Rank, err = domain.RankBetween("", first.Rank)
+		require.NoError(t, err)
+		require.NoError(t, repo.UpdateRank(ctx, current))
+		assert.Equal(t, []string{third.ID, first.ID, second.ID}, listIDs())
+
+		// The version was bumped, so the stale copy conflicts
+		stale := *current
+		stale.Version--
+		assert.ErrorIs(t, repo.UpdateRank(ctx, &stale), domain.ErrTaskVersionConflict)
+	})
+
+	t.Run("rebalancing keeps the order", func(t *testing.T) {
+		before := listIDs()
+		require.NoError(t, repo.RebalanceRanks(ctx, scope))
+		assert.Equal(t, before, listIDs())
+	})
+
+	t.Run("appending after overlong ranks rebalances the list", func(t *testing.T) {
+		current, err := repo.FindByID(ctx, second.ID)
+		require.NoError(t, err)
+		current.Rank = strings.Repeat("z", domain.MaxRankLength)
+		require.NoError(t, repo.UpdateRank(ctx, current))
+		before := listIDs()
+
+		appended := createTestTask(t, ctx, repo, userID, "Appended")
+		assert.LessOrEqual(t, len(appended.Rank), domain.MaxRankLength)
+		assert.Equal(t, append(before, appended.ID), listIDs())
+	})
+
+	t.Run("subtasks follow their own order", func(t *testing.T) {
+		parentID := first.ID
+		var created []string
+		for _, title := range []string{"Step 1", "Step 2"} {
+			subtask := &domain.Task{
+				ID:            uuid.New().String(),
+				UserID:        userID,
+				Title:         title,
+				Status:        domain.TaskStatusTodo,
+				UserPriority:  5,
+				PriorityScore: 10,
+				ParentTaskID:  &parentID,
+				CreatedAt:     time.Now().UTC(),
+				UpdatedAt:     time.Now().UTC(),
+			}
+			require.NoError(t, repo.Create(ctx, subtask))
+			created = append(created, subtask.ID)
+		}
+
+		subtasks, err := repo.GetSubtasks(ctx, parentID)
+		require.NoError(t, err)
+		require.Len(t, subtasks, 2)
+		assert.Equal(t, created[0], subtasks[0].ID)
+		assert.Equal(t, created[1], subtasks[1].ID)
+	})
+}
@@ -62,20 +62,30 @@ func sqlcTaskSeriesToDomain(s sqlc.TaskSeries) domain.TaskSeries {
 
 // Create inserts a new task series into the database
 func (r *TaskSeriesRepository) Create(ctx context.Context, series *domain.TaskSeries) error {
-	id, err := stringToPgtypeUUID(series.ID)
+	params, err := createTaskSeriesParams(series)
 	if err != nil {
 		return err
 	}
+
+	return r.queries.CreateTaskSeries(ctx, params)
+}
+
+// createTaskSeriesParams converts a new series to its insert parameters
+func createTaskSeriesParams(series *domain.TaskSeries) (sqlc.CreateTaskSeriesParams, error) {
+	id, err := stringToPgtypeUUID(series.ID)
+	if err != nil {
+		return sqlc.CreateTaskSeriesParams{}, err
+	}
 	userID, err := stringToPgtypeUUID(series.UserID)
 	if err != nil {
-		return err
+		return sqlc.CreateTaskSeriesParams{}, err
 	}
 	originalTaskID, err := stringToPgtypeUUID(series.OriginalTaskID)
 	if err != nil {
-		return err
+		return sqlc.CreateTaskSeriesParams{}, err
 	}
 
-	params := sqlc.CreateTaskSeriesParams{
+	return sqlc.CreateTaskSeriesParams{
 		ID:                 id,
 		UserID:             userID,
 		OriginalTaskID:     originalTaskID,
@@ -86,9 +96,7 @@ func (r *TaskSeriesRepository) Create(ctx context.Context, series *domain.TaskSe
 		IsActive:           series.IsActive,
 		CreatedAt:          timeToPgtypeTimestamptz(series.CreatedAt),
 		UpdatedAt:          timeToPgtypeTimestamptz(series.UpdatedAt),
-	}
-
-	return r.queries.CreateTaskSeries(ctx, params)
+	}, nil
 }
 
 // FindByID retrieves a task series by ID
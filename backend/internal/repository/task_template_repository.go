@@ -25,8 +25,8 @@ func (r *TaskTemplateRepository) Create(ctx context.Context, template *domain.Ta
 		INSERT INTO task_templates (
 			id, user_id, name, title, description, category,
 			estimated_effort, user_priority, context, related_people,
-			due_date_offset, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			due_date_offset, created_at, updated_at, tags
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 	`,
 		template.ID,
 		template.UserID,
@@ -41,6 +41,7 @@ func (r *TaskTemplateRepository) Create(ctx context.Context, template *domain.Ta
 		template.DueDateOffset,
 		template.CreatedAt,
 		template.UpdatedAt,
+		template.Tags,
 	)
 
 	if err != nil {
@@ -61,7 +62,7 @@ func (r *TaskTemplateRepository) FindByID(ctx context.Context, id string) (*doma
 	err := r.db.QueryRow(ctx, `
 		SELECT id, user_id, name, title, description, category,
 		       estimated_effort, user_priority, context, related_people,
-		       due_date_offset, created_at, updated_at
+		       due_date_offset, created_at, updated_at, tags
 		FROM task_templates
 		WHERE id = $1
 	`, id).Scan(
@@ -78,6 +79,7 @@ func (r *TaskTemplateRepository) FindByID(ctx context.Context, id string) (*doma
 		&template.DueDateOffset,
 		&template.CreatedAt,
 		&template.UpdatedAt,
+		&template.Tags,
 	)
 
 	if err != nil {
@@ -106,7 +108,7 @@ func (r *TaskTemplateRepository) FindByUserID(ctx context.Context, userID string
 	rows, err := r.db.Query(ctx, `
 		SELECT id, user_id, name, title, description, category,
 		       estimated_effort, user_priority, context, related_people,
-		       due_date_offset, created_at, updated_at
+		       due_date_offset, created_at, updated_at, tags
 		FROM task_templates
 		WHERE user_id = $1
 		ORDER BY name ASC
@@ -135,6 +137,7 @@ func (r *TaskTemplateRepository) FindByUserID(ctx context.Context, userID string
 			&template.DueDateOffset,
 			&template.CreatedAt,
 			&template.UpdatedAt,
+			&template.Tags,
 		)
 		if err != nil {
 			return nil, err
@@ -169,7 +172,8 @@ func (r *TaskTemplateRepository) Update(ctx context.Context, template *domain.Ta
 		UPDATE task_templates
 		SET name = $2, title = $3, description = $4, category = $5,
 		    estimated_effort = $6, user_priority = $7, context = $8,
-		    related_people = $9, due_date_offset = $10, updated_at = $11
+		    related_people = $9, due_date_offset = $10, updated_at = $11,
+		    tags = $13
 		WHERE id = $1 AND user_id = $12
 	`,
 		template.ID,
@@ -184,6 +188,7 @@ func (r *TaskTemplateRepository) Update(ctx context.Context, template *domain.Ta
 		template.DueDateOffset,
 		template.UpdatedAt,
 		template.UserID,
+		template.Tags,
 	)
 
 	if err != nil {
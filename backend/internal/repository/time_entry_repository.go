@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+)
+
+// timeEntryColumns is the select list scanned by scanTimeEntry.
+// duration_seconds counts up to now for a running timer.
+const timeEntryColumns = `id, task_id, user_id, started_at, ended_at,
+		EXTRACT(EPOCH FROM (COALESCE(ended_at, NOW()) - started_at))::bigint AS duration_seconds,
+		source, note, created_at, updated_at`
+
+// TimeEntryRepository handles database operations for time entries
+type TimeEntryRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewTimeEntryRepository creates a new time entry repository
+func NewTimeEntryRepository(db *pgxpool.Pool) *TimeEntryRepository {
+	return &TimeEntryRepository{db: db}
+}
+
+func scanTimeEntry(row pgx.Row) (*domain.TimeEntry, error) {
+	var entry domain.TimeEntry
+	err := row.Scan(
+		&entry.ID,
+		&entry.TaskID,
+		&entry.UserID,
+		&entry.StartedAt,
+		&entry.EndedAt,
+		&entry.DurationSeconds,
+		&entry.Source,
+		&entry.Note,
+		&entry.CreatedAt,
+		&entry.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// Create inserts a time entry. Inserting a second running entry for a user
+// fails with ErrTimerAlreadyRunning.
+func (r *TimeEntryRepository) Create(ctx context.Context, entry *domain.TimeEntry) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO time_entries (id, task_id, user_id, started_at, ended_at, source, note, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`,
+		entry.ID,
+		entry.TaskID,
+		entry.UserID,
+		entry.StartedAt,
+		entry.EndedAt,
+		entry.Source,
+		entry.Note,
+		entry.CreatedAt,
+		entry.UpdatedAt,
+	)
+	if err != nil {
+		if isPgUniqueViolation(err) {
+			return domain.ErrTimerAlreadyRunning
+		}
+		return err
+	}
+	return nil
+}
+
+// FindByID retrieves a time entry by ID
+func (r *TimeEntryRepository) FindByID(ctx context.Context, id string) (*domain.TimeEntry, error) {
+	entry, err := scanTimeEntry(r.db.QueryRow(ctx, `
+		SELECT `+timeEntryColumns+`
+		FROM time_entries
+		WHERE id = $1
+	`, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrTimeEntryNotFound
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+// FindRunningByUserID retrieves the user's running timer.
+// Returns ErrTimeEntryNotFound when no timer is running.
+func (r *TimeEntryRepository) FindRunningByUserID(ctx context.Context, userID string) (*domain.TimeEntry, error) {
+	entry, err := scanTimeEntry(r.db.QueryRow(ctx, `
+		SELECT `+timeEntryColumns+`
+		FROM time_entries
+		WHERE user_id = $1 AND ended_at IS NULL
+	`, userID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrTimeEntryNotFound
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+// FindByTaskID retrieves a task's time entries, most recent first
+func (r *TimeEntryRepository) FindByTaskID(ctx context.Context, taskID string) ([]*domain.TimeEntry, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+timeEntryColumns+`
+		FROM time_entries
+		WHERE task_id = $1
+		ORDER BY started_at DESC, id
+	`, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []*domain.TimeEntry{}
+	for rows.Next() {
+		entry, err := scanTimeEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Stop ends a running timer and returns the finished entry.
+// Returns ErrTimerNotRunning if the entry is not running (e.g. stopped concurrently).
+func (r *TimeEntryRepository) Stop(ctx context.Context, id, userID string, endedAt time.Time) (*domain.TimeEntry, error) {
+	entry, err := scanTimeEntry(r.db.QueryRow(ctx, `
+		UPDATE time_entries
+		SET ended_at = GREATEST($3, started_at)
+		WHERE id = $1 AND user_id = $2 AND ended_at IS NULL
+		RETURNING `+timeEntryColumns, id, userID, endedAt))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrTimerNotRunning
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Delete removes a time entry
+func (r *TimeEntryRepository) Delete(ctx context.Context, id, userID string) error {
+	result, err := r.db.Exec(ctx, `
+		DELETE FROM time_entries WHERE id = $1 AND user_id = $2
+	`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrTimeEntryNotFound
+	}
+
+	return nil
+}
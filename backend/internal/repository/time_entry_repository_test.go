@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// TimeEntryRepository Integration Tests
+// =============================================================================
+
+func newTestTimeEntry(userID, taskID string, startedAt time.Time, endedAt *time.Time) *domain.TimeEntry {
+	source := domain.TimeEntrySourceTimer
+	if endedAt != nil {
+		source = domain.TimeEntrySourceManual
+	}
+	return &domain.TimeEntry{
+		ID:        uuid.New().String(),
+		TaskID:    taskID,
+		UserID:    userID,
+		StartedAt: startedAt,
+		EndedAt:   endedAt,
+		Source:    source,
+		CreatedAt: startedAt,
+		UpdatedAt: startedAt,
+	}
+}
+
+func TestTimeEntryRepository_TimerLifecycle(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool := setupTestDB(t)
+	repo := NewTimeEntryRepository(pool)
+	taskRepo := NewTaskRepository(pool)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, pool)
+	task := createTestTask(t, ctx, taskRepo, userID, "Tracked Task")
+	other := createTestTask(t, ctx, taskRepo, userID, "Other Task")
+
+	start := time.Now().UTC().Add(-time.Hour).Truncate(time.Microsecond)
+	running := newTestTimeEntry(userID, task.ID, start, nil)
+
+	t.Run("one running timer per user", func(t *testing.T) {
+		require.NoError(t, repo.Create(ctx, running))
+
+		err := repo.Create(ctx, newTestTimeEntry(userID, other.ID, start, nil))
+		assert.ErrorIs(t, err, domain.ErrTimerAlreadyRunning)
+
+		found, err := repo.FindRunningByUserID(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, running.ID, found.ID)
+		assert.Nil(t, found.EndedAt)
+	})
+
+	t.Run("stop finishes the entry once", func(t *testing.T) {
+		stopped, err := repo.Stop(ctx, running.ID, userID, start.Add(30*time.Minute))
+		require.NoError(t, err)
+		require.NotNil(t, stopped.EndedAt)
+		assert.Equal(t, int64(30*60), stopped.DurationSeconds)
+
+		_, err = repo.Stop(ctx, running.ID, userID, start.Add(time.Hour))
+		assert.ErrorIs(t, err, domain.ErrTimerNotRunning)
+
+		_, err = repo.FindRunningByUserID(ctx, userID)
+		assert.ErrorIs(t, err, domain.ErrTimeEntryNotFound)
+	})
+
+	t.Run("task tracked seconds sums finished entries", func(t *testing.T) {
+		end := start.Add(15 * time.Minute)
+		require.NoError(t, repo.Create(ctx, newTestTimeEntry(userID, task.ID, start, &end)))
+		require.NoError(t, repo.Create(ctx, newTestTimeEntry(userID, task.ID, time.Now().UTC(), nil)))
+
+		found, err := taskRepo.FindByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(45*60), found.TrackedSeconds)
+
+		entries, err := repo.FindByTaskID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Len(t, entries, 3)
+	})
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 
 	"github.com/google/uuid"
@@ -197,3 +198,103 @@ func (r *UserPreferencesRepository) GetAllPreferences(ctx context.Context, userI
 		CategoryPreferences: categoryPrefs,
 	}, nil
 }
+
+// GetFocusSettings retrieves the user's focus session settings.
+// Returns the defaults if the user has no preferences row yet.
+func (r *UserPreferencesRepository) GetFocusSettings(ctx context.Context, userID string) (*domain.FocusSettings, error) {
+	query := `
+		SELECT focus_work_minutes, focus_short_break_minutes, focus_long_break_minutes,
+		       focus_sessions_until_long_break, focus_counts_toward_achievements
+		FROM user_preferences
+		WHERE user_id = $1
+	`
+
+	var settings domain.FocusSettings
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&settings.WorkMinutes,
+		&settings.ShortBreakMinutes,
+		&settings.LongBreakMinutes,
+		&settings.SessionsUntilLongBreak,
+		&settings.CountTowardAchievements,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			defaults := domain.DefaultFocusSettings()
+			return &defaults, nil
+		}
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// UpsertFocusSettings creates or updates the user's focus session settings
+func (r *UserPreferencesRepository) UpsertFocusSettings(ctx context.Context, userID string, settings *domain.FocusSettings) error {
+	query := `
+		INSERT INTO user_preferences (
+			user_id, focus_work_minutes, focus_short_break_minutes, focus_long_break_minutes,
+			focus_sessions_until_long_break, focus_counts_toward_achievements
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id) DO UPDATE SET
+			focus_work_minutes = EXCLUDED.focus_work_minutes,
+			focus_short_break_minutes = EXCLUDED.focus_short_break_minutes,
+			focus_long_break_minutes = EXCLUDED.focus_long_break_minutes,
+			focus_sessions_until_long_break = EXCLUDED.focus_sessions_until_long_break,
+			focus_counts_toward_achievements = EXCLUDED.focus_counts_toward_achievements,
+			updated_at = NOW()
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		userID,
+		settings.WorkMinutes,
+		settings.ShortBreakMinutes,
+		settings.LongBreakMinutes,
+		settings.SessionsUntilLongBreak,
+		settings.CountTowardAchievements,
+	)
+	return err
+}
+
+// GetWIPLimits retrieves the user's board WIP limits.
+// Returns no limits if the user has no preferences row yet.
+func (r *UserPreferencesRepository) GetWIPLimits(ctx context.Context, userID string) (domain.WIPLimits, error) {
+	query := `SELECT board_wip_limits FROM user_preferences WHERE user_id = $1`
+
+	var limitsJSON []byte
+	err := r.db.QueryRow(ctx, query, userID).Scan(&limitsJSON)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.WIPLimits{}, nil
+		}
+		return nil, err
+	}
+
+	limits := domain.WIPLimits{}
+	if err := json.Unmarshal(limitsJSON, &limits); err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
+
+// UpsertWIPLimits replaces the user's board WIP limits
+func (r *UserPreferencesRepository) UpsertWIPLimits(ctx context.Context, userID string, limits domain.WIPLimits) error {
+	query := `
+		INSERT INTO user_preferences (user_id, board_wip_limits)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET
+			board_wip_limits = EXCLUDED.board_wip_limits,
+			updated_at = NOW()
+	`
+
+	if limits == nil {
+		limits = domain.WIPLimits{}
+	}
+	limitsJSON, err := json.Marshal(limits)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, query, userID, limitsJSON)
+	return err
+}
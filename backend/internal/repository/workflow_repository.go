@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+)
+
+// WorkflowRepository handles database operations for user-defined workflows
+type WorkflowRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewWorkflowRepository creates a new workflow repository
+func NewWorkflowRepository(db *pgxpool.Pool) *WorkflowRepository {
+	return &WorkflowRepository{db: db}
+}
+
+// GetStatuses returns the user's custom statuses in workflow order; empty when the user
+// has the built-in workflow
+func (r *WorkflowRepository) GetStatuses(ctx context.Context, userID string) ([]domain.WorkflowStatus, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT status_key, name, category, transitions
+		FROM workflow_statuses
+		WHERE user_id = $1
+		ORDER BY position
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var statuses []domain.WorkflowStatus
+	for rows.Next() {
+		var status domain.WorkflowStatus
+		var transitions []string
+		if err := rows.Scan(&status.Key, &status.Name, &status.Category, &transitions); err != nil {
+			return nil, err
+		}
+		if transitions != nil {
+			status.Transitions = make([]domain.TaskStatus, len(transitions))
+			for i, key := range transitions {
+				status.Transitions[i] = domain.TaskStatus(key)
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, rows.Err()
+}
+
+// ReplaceStatuses replaces the user's workflow and moves their tasks out of removed
+// statuses, all in one transaction. Tasks in a status listed in mapping move to the
+// mapped status; the rest are moved by the sync trigger to the first status of their
+// category (see migration 000032).
+func (r *WorkflowRepository) ReplaceStatuses(ctx context.Context, userID string, statuses []domain.WorkflowStatus, mapping map[domain.TaskStatus]domain.TaskStatus) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+
+	if _, err := tx.Exec(ctx, "DELETE FROM workflow_statuses WHERE user_id = $1", userID); err != nil {
+		return err
+	}
+
+	keys := make([]string, len(statuses))
+	for i, status := range statuses {
+		keys[i] = string(status.Key)
+		var transitions []string
+		if status.Transitions != nil {
+			transitions = make([]string, len(status.Transitions))
+			for j, key := range status.Transitions {
+				transitions[j] = string(key)
+			}
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO workflow_statuses (user_id, status_key, name, category, base_status, position, transitions)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`, userID, string(status.Key), status.Name, string(status.Category), string(status.BaseStatus()), i, transitions); err != nil {
+			return err
+		}
+	}
+
+	// Trashed tasks move too, so restoring them lands in a status that exists
+	for from, to := range mapping {
+		target := domain.WorkflowStatus{Key: to}
+		for _, status := range statuses {
+			if status.Key == to {
+				target = status
+			}
+		}
+		if _, err := tx.Exec(ctx, `
+			UPDATE tasks
+			SET workflow_status = $3, status = $4, updated_at = NOW()
+			WHERE user_id = $1 AND workflow_status = $2
+		`, userID, string(from), string(to), string(target.BaseStatus())); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE tasks
+		SET workflow_status = NULL, updated_at = NOW()
+		WHERE user_id = $1 AND workflow_status <> ALL($2::text[])
+	`, userID, keys); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// DeleteStatuses switches the user back to the built-in workflow, moving every task to
+// the built-in status it is based on
+func (r *WorkflowRepository) DeleteStatuses(ctx context.Context, userID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+
+	if _, err := tx.Exec(ctx, "DELETE FROM workflow_statuses WHERE user_id = $1", userID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE tasks
+		SET workflow_status = status::text, updated_at = NOW()
+		WHERE user_id = $1 AND workflow_status <> status::text
+	`, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// WorkflowRepository Integration Tests
+// =============================================================================
+
+func TestWorkflowRepository_ReplaceStatuses(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool := setupTestDB(t)
+	repo := NewWorkflowRepository(pool)
+	taskRepo := NewTaskRepository(pool)
+	ctx := context.Background()
+	userID := createTestUser(t, ctx, pool)
+
+	statuses := []domain.WorkflowStatus{
+		{Key: "backlog", Name: "Backlog", Category: domain.StatusCategoryOpen},
+		{Key: domain.TaskStatusInProgress, Name: "In Progress", Category: domain.StatusCategoryActive,
+			Transitions: []domain.TaskStatus{"in_review"}},
+		{Key: "in_review", Name: "In Review", Category: domain.StatusCategoryActive},
+		{Key: "shipped", Name: "Shipped", Category: domain.StatusCategoryDone},
+	}
+
+	t.Run("new tasks start in the built-in workflow", func(t *testing.T) {
+		task := createTestTask(t, ctx, taskRepo, userID, "Before")
+		assert.Equal(t, domain.TaskStatusTodo, task.WorkflowStatus)
+	})
+
+	t.Run("replacing moves tasks out of removed statuses", func(t *testing.T) {
+		onHold := createTestTask(t, ctx, taskRepo, userID, "Paused")
+		onHold.Status = domain.TaskStatusOnHold
+		require.NoError(t, taskRepo.Update(ctx, onHold))
+		todo := createTestTask(t, ctx, taskRepo, userID, "Unmapped")
+
+		require.NoError(t, repo.ReplaceStatuses(ctx, userID, statuses, map[domain.TaskStatus]domain.TaskStatus{
+			domain.TaskStatusOnHold: "backlog",
+		}))
+
+		found, err := repo.GetStatuses(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, found, 4)
+		assert.Equal(t, domain.TaskStatus("backlog"), found[0].Key)
+		assert.Nil(t, found[0].Transitions)
+		assert.Equal(t, []domain.TaskStatus{"in_review"}, found[1].Transitions)
+
+		moved, err := taskRepo.FindByID(ctx, onHold.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.TaskStatus("backlog"), moved.WorkflowStatus)
+		assert.Equal(t, domain.TaskStatusTodo, moved.Status)
+
+		// Unmapped tasks go to the first status of their category
+		moved, err = taskRepo.FindByID(ctx, todo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.TaskStatus("backlog"), moved.WorkflowStatus)
+	})
+
+	t.Run("status-only writes follow the workflow", func(t *testing.T) {
+		task := createTestTask(t, ctx, taskRepo, userID, "Custom")
+		assert.Equal(t, domain.TaskStatus("backlog"), task.WorkflowStatus)
+
+		task.Status = domain.TaskStatusInProgress
+		task.WorkflowStatus = "in_review"
+		require.NoError(t, taskRepo.Update(ctx, task))
+		assert.Equal(t, domain.TaskStatus("in_review"), task.WorkflowStatus)
+
+		// Completing only sets the built-in status
+		task.Status = domain.TaskStatusDone
+		require.NoError(t, taskRepo.Update(ctx, task))
+		assert.Equal(t, domain.TaskStatus("shipped"), task.WorkflowStatus)
+
+		inReview := domain.TaskStatus("in_review")
+		count, err := taskRepo.Count(ctx, userID, &domain.TaskListFilter{WorkflowStatus: &inReview})
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("deleting restores the built-in statuses", func(t *testing.T) {
+		task := createTestTask(t, ctx, taskRepo, userID, "Reset")
+
+		require.NoError(t, repo.DeleteStatuses(ctx, userID))
+
+		found, err := repo.GetStatuses(ctx, userID)
+		require.NoError(t, err)
+		assert.Empty(t, found)
+
+		reset, err := taskRepo.FindByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.TaskStatusTodo, reset.WorkflowStatus)
+	})
+}
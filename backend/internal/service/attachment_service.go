@@ -0,0 +1,233 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+	"github.com/notkevinvu/taskflow/backend/internal/validation"
+)
+
+// sniffLength is how much of a file http.DetectContentType looks at
+const sniffLength = 512
+
+// blobPurgeBatchSize is how many queued blob deletions are processed per batch
+const blobPurgeBatchSize = 100
+
+// AttachmentService handles task attachment business logic
+type AttachmentService struct {
+	attachmentRepo ports.AttachmentRepository
+	blobStore      ports.BlobStore
+	taskService    ports.TaskService
+	maxBytes       int64 // Maximum size of a single file
+	quotaBytes     int64 // Maximum total size of a user's files
+}
+
+// NewAttachmentService creates a new attachment service
+func NewAttachmentService(attachmentRepo ports.AttachmentRepository, blobStore ports.BlobStore, taskService ports.TaskService, maxBytes, quotaBytes int64) *AttachmentService {
+	return &AttachmentService{
+		attachmentRepo: attachmentRepo,
+		blobStore:      blobStore,
+		taskService:    taskService,
+		maxBytes:       maxBytes,
+		quotaBytes:     quotaBytes,
+	}
+}
+
+// Upload stores a file in the blob store and attaches it to a task the user owns.
+// The content type is sniffed from the file's first bytes; the client's claim is ignored.
+func (s *AttachmentService) Upload(ctx context.Context, userID, taskID string, upload *domain.AttachmentUpload) (*domain.Attachment, error) {
+	if _, err := s.taskService.Get(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	filename, err := validation.ValidateFilename(upload.Filename, "file")
+	if err != nil {
+		return nil, err
+	}
+	if upload.Size <= 0 {
+		return nil, domain.NewValidationError("file", "is empty")
+	}
+	if upload.Size > s.maxBytes {
+		return nil, domain.ErrAttachmentTooLarge
+	}
+
+	// Fail fast before transferring the blob; the repository re-checks atomically
+	used, err := s.attachmentRepo.SumSizeByUserID(ctx, userID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to check attachment quota", err)
+	}
+	if used+upload.Size > s.quotaBytes {
+		return nil, domain.ErrAttachmentQuotaExceeded
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(upload.Content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, domain.NewInternalError("failed to read attachment", err)
+	}
+	head = head[:n]
+	content := io.MultiReader(bytes.NewReader(head), upload.Content)
+
+	attachmentID := uuid.New().String()
+	attachment := &domain.Attachment{
+		ID:          attachmentID,
+		TaskID:      taskID,
+		UserID:      userID,
+		Filename:    filename,
+		ContentType: http.DetectContentType(head),
+		SizeBytes:   upload.Size,
+		StorageKey:  domain.AttachmentStorageKey(userID, taskID, attachmentID),
+		CreatedAt:   time.Now(),
+	}
+
+	if err := s.blobStore.Put(ctx, attachment.StorageKey, content, attachment.SizeBytes, attachment.ContentType); err != nil {
+		return nil, domain.NewInternalError("failed to store attachment", err)
+	}
+
+	if err := s.attachmentRepo.Create(ctx, attachment, s.quotaBytes); err != nil {
+		// Without a row nothing references the blob, so remove it now
+		if delErr := s.blobStore.Delete(ctx, attachment.StorageKey); delErr != nil {
+			slog.Warn("Failed to delete orphaned attachment blob",
+				"storage_key", attachment.StorageKey,
+				"error", delErr,
+			)
+		}
+		if err == domain.ErrAttachmentQuotaExceeded {
+			return nil, err
+		}
+		return nil, domain.NewInternalError("failed to create attachment", err)
+	}
+
+	return attachment, nil
+}
+
+// List retrieves a task's attachments along with the user's quota usage
+func (s *AttachmentService) List(ctx context.Context, userID, taskID string) (*domain.AttachmentListResponse, error) {
+	if _, err := s.taskService.Get(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.attachmentRepo.FindByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to list attachments", err)
+	}
+
+	used, err := s.attachmentRepo.SumSizeByUserID(ctx, userID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to check attachment quota", err)
+	}
+
+	return &domain.AttachmentListResponse{
+		Attachments: attachments,
+		TotalCount:  len(attachments),
+		UsedBytes:   used,
+		QuotaBytes:  s.quotaBytes,
+	}, nil
+}
+
+// Open returns an attachment and its content. The caller must close the reader.
+func (s *AttachmentService) Open(ctx context.Context, userID, taskID, attachmentID string) (*domain.Attachment, io.ReadCloser, error) {
+	attachment, err := s.getAttachment(ctx, userID, taskID, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reader, err := s.blobStore.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if err == domain.ErrBlobNotFound {
+			return nil, nil, err
+		}
+		return nil, nil, domain.NewInternalError("failed to read attachment", err)
+	}
+
+	return attachment, reader, nil
+}
+
+// Delete removes an attachment and then its blob
+func (s *AttachmentService) Delete(ctx context.Context, userID, taskID, attachmentID string) error {
+	if _, err := s.getAttachment(ctx, userID, taskID, attachmentID); err != nil {
+		return err
+	}
+
+	if err := s.attachmentRepo.Delete(ctx, attachmentID, userID); err != nil {
+		if err == domain.ErrAttachmentNotFound {
+			return err
+		}
+		return domain.NewInternalError("failed to delete attachment", err)
+	}
+
+	// The row's deletion queued the blob; a failure here leaves it for the next purge
+	if _, err := s.PurgeDeletedBlobs(ctx); err != nil {
+		slog.Warn("Failed to purge deleted attachment blobs", "error", err)
+	}
+
+	return nil
+}
+
+// PurgeDeletedBlobs removes the blobs of deleted attachments from the blob store.
+// Attachment rows queue their blob key when deleted, including when a task or user
+// deletion cascades to them. Keys whose blob cannot be deleted stay queued for a retry.
+func (s *AttachmentService) PurgeDeletedBlobs(ctx context.Context) (int, error) {
+	purged := 0
+	for {
+		keys, err := s.attachmentRepo.ListPendingBlobDeletions(ctx, blobPurgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+		if len(keys) == 0 {
+			return purged, nil
+		}
+
+		deleted := make([]string, 0, len(keys))
+		for _, key := range keys {
+			if err := s.blobStore.Delete(ctx, key); err != nil {
+				slog.Warn("Failed to delete attachment blob", "storage_key", key, "error", err)
+				continue
+			}
+			deleted = append(deleted, key)
+		}
+
+		if err := s.attachmentRepo.RemovePendingBlobDeletions(ctx, deleted); err != nil {
+			return purged, err
+		}
+		purged += len(deleted)
+
+		// Stop once a batch makes no progress or the queue is drained
+		if len(deleted) == 0 || len(keys) < blobPurgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+// getAttachment verifies task ownership and that the attachment belongs to the task
+func (s *AttachmentService) getAttachment(ctx context.Context, userID, taskID, attachmentID string) (*domain.Attachment, error) {
+	if _, err := s.taskService.Get(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	attachment, err := s.attachmentRepo.FindByID(ctx, attachmentID)
+	if err != nil {
+		if err == domain.ErrAttachmentNotFound {
+			return nil, err
+		}
+		return nil, domain.NewInternalError("failed to find attachment", err)
+	}
+
+	// An attachment addressed through another task's URL does not exist there
+	if attachment.TaskID != taskID {
+		return nil, domain.ErrAttachmentNotFound
+	}
+	if attachment.UserID != userID {
+		return nil, domain.NewForbiddenError("attachment", "access")
+	}
+
+	return attachment, nil
+}
@@ -0,0 +1,281 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+const (
+	testAttachmentMaxBytes   = 1024
+	testAttachmentQuotaBytes = 4096
+)
+
+func newAttachmentService() (*AttachmentService, *MockAttachmentRepository, *MockBlobStore, *MockTaskRepository) {
+	mockAttachmentRepo := new(MockAttachmentRepository)
+	mockBlobStore := new(MockBlobStore)
+	mockTaskRepo := new(MockTaskRepository)
+	taskService := NewTaskService(mockTaskRepo, new(MockTaskHistoryRepository))
+	service := NewAttachmentService(mockAttachmentRepo, mockBlobStore, taskService, testAttachmentMaxBytes, testAttachmentQuotaBytes)
+	return service, mockAttachmentRepo, mockBlobStore, mockTaskRepo
+}
+
+func createTestAttachment(userID, taskID, attachmentID string) *domain.Attachment {
+	return &domain.Attachment{
+		ID:          attachmentID,
+		TaskID:      taskID,
+		UserID:      userID,
+		Filename:    "notes.txt",
+		ContentType: "text/plain; charset=utf-8",
+		SizeBytes:   5,
+		StorageKey:  domain.AttachmentStorageKey(userID, taskID, attachmentID),
+	}
+}
+
+func newUpload(filename string, content []byte) *domain.AttachmentUpload {
+	return &domain.AttachmentUpload{
+		Filename: filename,
+		Size:     int64(len(content)),
+		Content:  bytes.NewReader(content),
+	}
+}
+
+// pngContent starts with the PNG signature, whatever the file is called
+var pngContent = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 600)...)
+
+// =============================================================================
+// AttachmentService.Upload Tests
+// =============================================================================
+
+func TestAttachmentService_Upload_SniffsContentType(t *testing.T) {
+	service, mockAttachmentRepo, mockBlobStore, mockTaskRepo := newAttachmentService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	mockAttachmentRepo.On("SumSizeByUserID", mock.Anything, "user-123").Return(int64(0), nil)
+	mockBlobStore.On("Put", mock.Anything, mock.AnythingOfType("string"), int64(len(pngContent)), "image/png").Return(nil)
+	mockAttachmentRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Attachment"), int64(testAttachmentQuotaBytes)).Return(nil)
+
+	// The client claims a text file; the bytes say otherwise
+	attachment, err := service.Upload(context.Background(), "user-123", "task-456", newUpload(`C:\photos\screenshot.txt`, pngContent))
+
+	require.NoError(t, err)
+	assert.Equal(t, "screenshot.txt", attachment.Filename)
+	assert.Equal(t, "image/png", attachment.ContentType)
+	assert.Equal(t, int64(len(pngContent)), attachment.SizeBytes)
+	assert.Equal(t, "attachments/user-123/task-456/"+attachment.ID, attachment.StorageKey)
+	// The sniffed bytes are stitched back onto the stream
+	assert.Equal(t, pngContent, mockBlobStore.Puts[attachment.StorageKey])
+	mockBlobStore.AssertExpectations(t)
+	mockAttachmentRepo.AssertExpectations(t)
+}
+
+func TestAttachmentService_Upload_TooLarge(t *testing.T) {
+	service, mockAttachmentRepo, mockBlobStore, mockTaskRepo := newAttachmentService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+
+	attachment, err := service.Upload(context.Background(), "user-123", "task-456", newUpload("big.bin", make([]byte, testAttachmentMaxBytes+1)))
+
+	assert.Nil(t, attachment)
+	assert.ErrorIs(t, err, domain.ErrAttachmentTooLarge)
+	mockBlobStore.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockAttachmentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAttachmentService_Upload_Empty(t *testing.T) {
+	service, _, mockBlobStore, mockTaskRepo := newAttachmentService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+
+	attachment, err := service.Upload(context.Background(), "user-123", "task-456", newUpload("empty.txt", nil))
+
+	assert.Nil(t, attachment)
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	mockBlobStore.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAttachmentService_Upload_QuotaExceeded(t *testing.T) {
+	service, mockAttachmentRepo, mockBlobStore, mockTaskRepo := newAttachmentService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	mockAttachmentRepo.On("SumSizeByUserID", mock.Anything, "user-123").Return(int64(testAttachmentQuotaBytes-10), nil)
+
+	attachment, err := service.Upload(context.Background(), "user-123", "task-456", newUpload("notes.txt", make([]byte, 11)))
+
+	assert.Nil(t, attachment)
+	assert.ErrorIs(t, err, domain.ErrAttachmentQuotaExceeded)
+	mockBlobStore.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAttachmentService_Upload_QuotaRaceDeletesBlob(t *testing.T) {
+	service, mockAttachmentRepo, mockBlobStore, mockTaskRepo := newAttachmentService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	mockAttachmentRepo.On("SumSizeByUserID", mock.Anything, "user-123").Return(int64(0), nil)
+	mockBlobStore.On("Put", mock.Anything, mock.AnythingOfType("string"), int64(5), "text/plain; charset=utf-8").Return(nil)
+	// A concurrent upload used up the quota between the pre-check and the insert
+	mockAttachmentRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Attachment"), int64(testAttachmentQuotaBytes)).
+		Return(domain.ErrAttachmentQuotaExceeded)
+	mockBlobStore.On("Delete", mock.Anything, mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, "attachments/user-123/task-456/")
+	})).Return(nil)
+
+	attachment, err := service.Upload(context.Background(), "user-123", "task-456", newUpload("notes.txt", []byte("hello")))
+
+	assert.Nil(t, attachment)
+	assert.ErrorIs(t, err, domain.ErrAttachmentQuotaExceeded)
+	mockBlobStore.AssertExpectations(t)
+}
+
+func TestAttachmentService_Upload_OtherUsersTask(t *testing.T) {
+	service, mockAttachmentRepo, mockBlobStore, mockTaskRepo := newAttachmentService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-456", "task-456"), nil)
+
+	attachment, err := service.Upload(context.Background(), "user-123", "task-456", newUpload("notes.txt", []byte("hello")))
+
+	assert.Nil(t, attachment)
+	var forbiddenErr *domain.ForbiddenError
+	assert.ErrorAs(t, err, &forbiddenErr)
+	mockBlobStore.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockAttachmentRepo.AssertNotCalled(t, "SumSizeByUserID", mock.Anything, mock.Anything)
+}
+
+// =============================================================================
+// AttachmentService.List / Open Tests
+// =============================================================================
+
+func TestAttachmentService_List_IncludesQuotaUsage(t *testing.T) {
+	service, mockAttachmentRepo, _, mockTaskRepo := newAttachmentService()
+
+	attachments := []*domain.Attachment{createTestAttachment("user-123", "task-456", "att-1")}
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	mockAttachmentRepo.On("FindByTaskID", mock.Anything, "task-456").Return(attachments, nil)
+	mockAttachmentRepo.On("SumSizeByUserID", mock.Anything, "user-123").Return(int64(2048), nil)
+
+	response, err := service.List(context.Background(), "user-123", "task-456")
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, response.TotalCount)
+	assert.Equal(t, int64(2048), response.UsedBytes)
+	assert.Equal(t, int64(testAttachmentQuotaBytes), response.QuotaBytes)
+}
+
+func TestAttachmentService_Open_Success(t *testing.T) {
+	service, mockAttachmentRepo, mockBlobStore, mockTaskRepo := newAttachmentService()
+
+	existing := createTestAttachment("user-123", "task-456", "att-1")
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	mockAttachmentRepo.On("FindByID", mock.Anything, "att-1").Return(existing, nil)
+	mockBlobStore.On("Get", mock.Anything, existing.StorageKey).Return(io.NopCloser(strings.NewReader("hello")), nil)
+
+	attachment, reader, err := service.Open(context.Background(), "user-123", "task-456", "att-1")
+
+	require.NoError(t, err)
+	defer reader.Close()
+	content, _ := io.ReadAll(reader)
+	assert.Equal(t, "att-1", attachment.ID)
+	assert.Equal(t, "hello", string(content))
+}
+
+func TestAttachmentService_Open_WrongTask(t *testing.T) {
+	service, mockAttachmentRepo, mockBlobStore, mockTaskRepo := newAttachmentService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-789").Return(createTestTask("user-123", "task-789"), nil)
+	mockAttachmentRepo.On("FindByID", mock.Anything, "att-1").Return(createTestAttachment("user-123", "task-456", "att-1"), nil)
+
+	_, _, err := service.Open(context.Background(), "user-123", "task-789", "att-1")
+
+	assert.ErrorIs(t, err, domain.ErrAttachmentNotFound)
+	mockBlobStore.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+}
+
+func TestAttachmentService_Open_MissingBlob(t *testing.T) {
+	service, mockAttachmentRepo, mockBlobStore, mockTaskRepo := newAttachmentService()
+
+	existing := createTestAttachment("user-123", "task-456", "att-1")
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	mockAttachmentRepo.On("FindByID", mock.Anything, "att-1").Return(existing, nil)
+	mockBlobStore.On("Get", mock.Anything, existing.StorageKey).Return(nil, domain.ErrBlobNotFound)
+
+	_, _, err := service.Open(context.Background(), "user-123", "task-456", "att-1")
+
+	assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+}
+
+// =============================================================================
+// AttachmentService.Delete / PurgeDeletedBlobs Tests
+// =============================================================================
+
+func TestAttachmentService_Delete_PurgesBlob(t *testing.T) {
+	service, mockAttachmentRepo, mockBlobStore, mockTaskRepo := newAttachmentService()
+
+	existing := createTestAttachment("user-123", "task-456", "att-1")
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	mockAttachmentRepo.On("FindByID", mock.Anything, "att-1").Return(existing, nil)
+	mockAttachmentRepo.On("Delete", mock.Anything, "att-1", "user-123").Return(nil)
+	mockAttachmentRepo.On("ListPendingBlobDeletions", mock.Anything, blobPurgeBatchSize).Return([]string{existing.StorageKey}, nil)
+	mockBlobStore.On("Delete", mock.Anything, existing.StorageKey).Return(nil)
+	mockAttachmentRepo.On("RemovePendingBlobDeletions", mock.Anything, []string{existing.StorageKey}).Return(nil)
+
+	err := service.Delete(context.Background(), "user-123", "task-456", "att-1")
+
+	require.NoError(t, err)
+	mockAttachmentRepo.AssertExpectations(t)
+	mockBlobStore.AssertExpectations(t)
+}
+
+func TestAttachmentService_Delete_PurgeFailureIsNotAnError(t *testing.T) {
+	service, mockAttachmentRepo, _, mockTaskRepo := newAttachmentService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	mockAttachmentRepo.On("FindByID", mock.Anything, "att-1").Return(createTestAttachment("user-123", "task-456", "att-1"), nil)
+	mockAttachmentRepo.On("Delete", mock.Anything, "att-1", "user-123").Return(nil)
+	mockAttachmentRepo.On("ListPendingBlobDeletions", mock.Anything, blobPurgeBatchSize).Return(nil, errors.New("db down"))
+
+	err := service.Delete(context.Background(), "user-123", "task-456", "att-1")
+
+	assert.NoError(t, err)
+}
+
+func TestAttachmentService_PurgeDeletedBlobs_KeepsFailedKeysQueued(t *testing.T) {
+	service, mockAttachmentRepo, mockBlobStore, _ := newAttachmentService()
+
+	mockAttachmentRepo.On("ListPendingBlobDeletions", mock.Anything, blobPurgeBatchSize).Return([]string{"a/1", "a/2", "a/3"}, nil)
+	mockBlobStore.On("Delete", mock.Anything, "a/1").Return(nil)
+	mockBlobStore.On("Delete", mock.Anything, "a/2").Return(errors.New("store unavailable"))
+	mockBlobStore.On("Delete", mock.Anything, "a/3").Return(nil)
+	mockAttachmentRepo.On("RemovePendingBlobDeletions", mock.Anything, []string{"a/1", "a/3"}).Return(nil)
+
+	purged, err := service.PurgeDeletedBlobs(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, purged)
+	mockAttachmentRepo.AssertExpectations(t)
+}
+
+func TestAttachmentService_PurgeDeletedBlobs_EmptyQueue(t *testing.T) {
+	service, mockAttachmentRepo, mockBlobStore, _ := newAttachmentService()
+
+	mockAttachmentRepo.On("ListPendingBlobDeletions", mock.Anything, blobPurgeBatchSize).Return([]string{}, nil)
+
+	purged, err := service.PurgeDeletedBlobs(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, purged)
+	mockBlobStore.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	mockAttachmentRepo.AssertNotCalled(t, "RemovePendingBlobDeletions", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+)
+
+// boardSort orders every column the way the user arranged the tasks by hand
+var boardSort = []domain.TaskSort{{Field: domain.TaskSortManual}}
+
+// BoardService shows top-level tasks as a board with one column per workflow status, and
+// moves tasks between columns within the user's WIP limits
+type BoardService struct {
+	taskRepo        ports.TaskRepository
+	prefsRepo       ports.UserPreferencesRepository
+	taskService     ports.TaskService
+	moveService     ports.MoveService
+	workflowService ports.WorkflowService
+}
+
+// NewBoardService creates a new board service
+func NewBoardService(
+	taskRepo ports.TaskRepository,
+	prefsRepo ports.UserPreferencesRepository,
+	taskService ports.TaskService,
+	moveService ports.MoveService,
+	workflowService ports.WorkflowService,
+) *BoardService {
+	return &BoardService{
+		taskRepo:        taskRepo,
+		prefsRepo:       prefsRepo,
+		taskService:     taskService,
+		moveService:     moveService,
+		workflowService: workflowService,
+	}
+}
+
+// GetBoard returns a page of each column, or further pages of a single column
+func (s *BoardService) GetBoard(ctx context.Context, userID string, filter *domain.BoardFilter) (*domain.Board, error) {
+	if filter.Status == nil && filter.Cursor != nil {
+		return nil, domain.NewValidationError("cursor", "requires status, since each column pages on its own")
+	}
+
+	workflow, err := s.workflowService.GetWorkflow(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	statuses := workflow.Statuses
+	if filter.Status != nil {
+		status, ok := workflow.Status(*filter.Status)
+		if !ok {
+			return nil, domain.NewValidationError("status", fmt.Sprintf("%q is not a status of your workflow", *filter.Status))
+		}
+		statuses = []domain.WorkflowStatus{*status}
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = domain.DefaultBoardColumnLimit
+	}
+	if limit > domain.MaxBoardColumnLimit {
+		limit = domain.MaxBoardColumnLimit
+	}
+
+	listFilter := domain.TaskListFilter{Sort: boardSort, Limit: limit}
+	if filter.Cursor != nil {
+		cursor, err := domain.DecodeTaskCursor(*filter.Cursor, boardSort)
+		if err != nil {
+			return nil, err
+		}
+		listFilter.Cursor = cursor
+	}
+
+	limits, err := s.prefsRepo.GetWIPLimits(ctx, userID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to get WIP limits", err)
+	}
+
+	board := &domain.Board{Columns: make([]*domain.BoardColumn, 0, len(statuses))}
+	for _, status := range statuses {
+		columnFilter := listFilter
+		columnFilter.WorkflowStatus = &status.Key
+
+		page, err := s.taskService.ListPage(ctx, userID, &columnFilter)
+		if err != nil {
+			return nil, err
+		}
+
+		column := &domain.BoardColumn{
+			Status:     status.Key,
+			Name:       status.Name,
+			Category:   status.Category,
+			Tasks:      page.Tasks,
+			TotalCount: page.TotalCount,
+			NextCursor: page.NextCursor,
+			WIPLimit:   limits.Limit(status.Key),
+		}
+		column.OverLimit = column.WIPLimit != nil && column.TotalCount > *column.WIPLimit
+		board.Columns = append(board.Columns, column)
+	}
+
+	return board, nil
+}
+
+// GetWIPLimits returns the user's WIP limits
+func (s *BoardService) GetWIPLimits(ctx context.Context, userID string) (domain.WIPLimits, error) {
+	limits, err := s.prefsRepo.GetWIPLimits(ctx, userID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to get WIP limits", err)
+	}
+	return limits, nil
+}
+
+// UpdateWIPLimits replaces the user's WIP limits. Columns already over a new limit keep
+// their tasks; only moves into them are refused.
+func (s *BoardService) UpdateWIPLimits(ctx context.Context, userID string, dto *domain.UpdateWIPLimitsDTO) (domain.WIPLimits, error) {
+	workflow, err := s.workflowService.GetWorkflow(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := dto.Limits.Validate(workflow); err != nil {
+		return nil, err
+	}
+
+	limits := dto.Limits
+	if limits == nil {
+		limits = domain.WIPLimits{}
+	}
+	if err := s.prefsRepo.UpsertWIPLimits(ctx, userID, limits); err != nil {
+		return nil, domain.NewInternalError("failed to save WIP limits", err)
+	}
+	return limits, nil
+}
+
+// MoveTask moves a task to another column, if the workflow allows the transition and the
+// column has room, and optionally places it between two of the column's tasks. Moving into
+// a done column completes the task and moving out of one reopens it, as the task endpoints do.
+// The column's WIP limit is checked in the same transaction that writes the new status and
+// rank, so concurrent moves cannot overfill it.
+func (s *BoardService) MoveTask(ctx context.Context, userID, taskID string, req *domain.MoveBoardTaskRequest) (*domain.Task, error) {
+	workflow, err := s.workflowService.GetWorkflow(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	target, ok := workflow.Status(req.Status)
+	if !ok {
+		return nil, domain.NewValidationError("status", fmt.Sprintf("%q is not a status of your workflow", req.Status))
+	}
+
+	task, err := s.taskRepo.FindByID(ctx, taskID)
+	if errors.Is(err, domain.ErrTaskNotFound) {
+		return nil, domain.NewNotFoundError("task", taskID)
+	}
+	if err != nil {
+		return nil, domain.NewInternalError("failed to find task", err)
+	}
+	if task.UserID != userID {
+		return nil, domain.NewForbiddenError("task", "access")
+	}
+	if task.TaskType == domain.TaskTypeSubtask {
+		return nil, domain.NewValidationError("task_id", "subtasks are not on the board; move their parent instead")
+	}
+	if err := checkExpectedVersion(ctx, task); err != nil {
+		return nil, err
+	}
+
+	if !workflow.CanTransition(task.StatusKey(), req.Status) {
+		return nil, fmt.Errorf("%w: %s to %s", domain.ErrInvalidStatusTransition, task.StatusKey(), req.Status)
+	}
+	// Neighbors are checked before anything is written, so a bad placement leaves the task as it was
+	after, err := s.checkNeighbor(ctx, userID, task, req.AfterTaskID, req.Status, "after_task_id")
+	if err != nil {
+		return nil, err
+	}
+	before, err := s.checkNeighbor(ctx, userID, task, req.BeforeTaskID, req.Status, "before_task_id")
+	if err != nil {
+		return nil, err
+	}
+	if after != nil && before != nil && after.Rank > before.Rank {
+		return nil, domain.NewValidationError("after_task_id", "must come before before_task_id in the column")
+	}
+
+	neighbors := &domain.MoveTaskRequest{AfterTaskID: req.AfterTaskID, BeforeTaskID: req.BeforeTaskID}
+	if task.StatusKey() == req.Status {
+		if req.AfterTaskID != nil || req.BeforeTaskID != nil {
+			return s.moveService.Move(ctx, userID, taskID, neighbors)
+		}
+		return task, nil
+	}
+
+	placement := &domain.BoardPlacement{TaskID: taskID, Column: target}
+	if req.AfterTaskID != nil || req.BeforeTaskID != nil {
+		version := task.Version
+		if placement.Rank, err = s.moveService.Rank(ctx, userID, task, neighbors); err != nil {
+			return nil, err
+		}
+		if task.Version != version {
+			// Making room rebalanced the column, which bumped the version the client sent
+			ctx = domain.WithoutExpectedVersion(ctx)
+		}
+	}
+	return s.changeStatus(domain.WithBoardPlacement(ctx, placement), userID, task, target)
+}
+
+// checkNeighbor makes sure a requested neighbor is another of the user's top-level tasks
+// and is already in the target column. Other users' tasks are reported as not found.
+func (s *BoardService) checkNeighbor(ctx context.Context, userID string, task *domain.Task, neighborID *string, status domain.TaskStatus, field string) (*domain.Task, error) {
+	if neighborID == nil {
+		return nil, nil
+	}
+	if *neighborID == task.ID {
+		return nil, domain.NewValidationError(field, "a task cannot be placed next to itself")
+	}
+
+	neighbor, err := s.taskRepo.FindByID(ctx, *neighborID)
+	if errors.Is(err, domain.ErrTaskNotFound) || (err == nil && (neighbor == nil || neighbor.UserID != userID)) {
+		return nil, domain.NewNotFoundError("task", *neighborID)
+	}
+	if err != nil {
+		return nil, domain.NewInternalError("failed to find task", err)
+	}
+	if !neighbor.RankScope().Equal(task.RankScope()) {
+		return nil, domain.NewValidationError(field, "must be a top-level task")
+	}
+	if neighbor.StatusKey() != status {
+		return nil, domain.NewValidationError(field, fmt.Sprintf("must be in the %s column", status))
+	}
+	return neighbor, nil
+}
+
+// changeStatus moves the task to the new status through the task service, so completing
+// and reopening run their usual checks, history and gamification. The board placement in
+// ctx puts the task in the target column itself, rather than the first status of its category.
+func (s *BoardService) changeStatus(ctx context.Context, userID string, task *domain.Task, target *domain.WorkflowStatus) (*domain.Task, error) {
+	switch base := target.BaseStatus(); {
+	case base == domain.TaskStatusDone && task.Status != domain.TaskStatusDone:
+		return s.taskService.Complete(ctx, userID, task.ID)
+	case task.Status == domain.TaskStatusDone && base != domain.TaskStatusDone:
+		return s.taskService.Uncomplete(ctx, userID, task.ID)
+	}
+
+	return s.taskService.Patch(ctx, userID, task.ID, &domain.TaskMergePatch{
+		Status: domain.PatchField[domain.TaskStatus]{Set: true, Value: &target.Key},
+	})
+}
@@ -0,0 +1,361 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newBoardTestService creates a board service for a user with the given custom statuses,
+// or the built-in workflow when nil
+func newBoardTestService(statuses ...domain.WorkflowStatus) (*BoardService, *MockTaskRepository, *MockTaskHistoryRepository, *MockUserPreferencesRepository) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	mockPrefsRepo := new(MockUserPreferencesRepository)
+	mockWorkflowRepo := new(MockWorkflowRepository)
+	mockWorkflowRepo.On("GetStatuses", mock.Anything, "user-123").Return(statuses, nil)
+	workflowService := NewWorkflowService(mockWorkflowRepo)
+	taskService := NewTaskService(mockTaskRepo, mockHistoryRepo)
+	taskService.SetWorkflowService(workflowService)
+	service := NewBoardService(mockTaskRepo, mockPrefsRepo, taskService, NewMoveService(mockTaskRepo), workflowService)
+	return service, mockTaskRepo, mockHistoryRepo, mockPrefsRepo
+}
+
+// reviewWorkflow is a custom workflow with a review step and two done columns
+var reviewWorkflow = []domain.WorkflowStatus{
+	{Key: "backlog", Name: "Backlog", Category: domain.StatusCategoryOpen},
+	{Key: domain.TaskStatusInProgress, Name: "In Progress", Category: domain.StatusCategoryActive},
+	{Key: "in_review", Name: "In Review", Category: domain.StatusCategoryActive,
+		Transitions: []domain.TaskStatus{domain.TaskStatusInProgress, "shipped", "archived"}},
+	{Key: "shipped", Name: "Shipped", Category: domain.StatusCategoryDone},
+	{Key: "archived", Name: "Archived", Category: domain.StatusCategoryDone},
+}
+
+// applyPlacement stands in for the repository moving the task into the column and rank of
+// the board placement carried by an update's context
+func applyPlacement(args mock.Arguments) {
+	task := args.Get(1).(*domain.Task)
+	if placement, ok := domain.BoardPlacementFor(args.Get(0).(context.Context), task.ID); ok {
+		task.Status = placement.Column.BaseStatus()
+		task.WorkflowStatus = placement.Column.Key
+		if placement.Rank != "" {
+			task.Rank = placement.Rank
+		}
+	}
+}
+
+func statusFilter(status domain.TaskStatus) interface{} {
+	return mock.MatchedBy(func(f *domain.TaskListFilter) bool {
+		return f.WorkflowStatus != nil && *f.WorkflowStatus == status
+	})
+}
+
+// =============================================================================
+// BoardService.GetBoard Tests
+// =============================================================================
+
+func TestBoardService_GetBoard_GroupsByStatus(t *testing.T) {
+	service, mockTaskRepo, _, mockPrefsRepo := newBoardTestService()
+
+	inProgress := []*domain.Task{
+		createTestTask("user-123", "11111111-1111-1111-1111-111111111111"),
+		createTestTask("user-123", "22222222-2222-2222-2222-222222222222"),
+		createTestTask("user-123", "33333333-3333-3333-3333-333333333333"),
+	}
+	mockPrefsRepo.On("GetWIPLimits", mock.Anything, "user-123").Return(domain.WIPLimits{domain.TaskStatusInProgress: 2}, nil)
+	mockTaskRepo.On("List", mock.Anything, "user-123", statusFilter(domain.TaskStatusInProgress)).Return(inProgress, nil)
+	mockTaskRepo.On("Count", mock.Anything, "user-123", statusFilter(domain.TaskStatusInProgress)).Return(3, nil)
+	mockTaskRepo.On("List", mock.Anything, "user-123", mock.Anything).Return([]*domain.Task{}, nil)
+	mockTaskRepo.On("Count", mock.Anything, "user-123", mock.Anything).Return(0, nil)
+
+	board, err := service.GetBoard(context.Background(), "user-123", &domain.BoardFilter{Limit: 2})
+
+	require.NoError(t, err)
+	require.Len(t, board.Columns, len(domain.DefaultWorkflow().Statuses))
+	column := board.Columns[1]
+	assert.Equal(t, domain.TaskStatusInProgress, column.Status)
+	assert.Len(t, column.Tasks, 2)
+	assert.Equal(t, 3, column.TotalCount)
+	assert.NotNil(t, column.NextCursor)
+	require.NotNil(t, column.WIPLimit)
+	assert.Equal(t, 2, *column.WIPLimit)
+	assert.True(t, column.OverLimit)
+
+	todo := board.Columns[0]
+	assert.Empty(t, todo.Tasks)
+	assert.Nil(t, todo.WIPLimit)
+	assert.False(t, todo.OverLimit)
+
+	// Columns are listed in manual order, fetching one extra row to detect the next page
+	mockTaskRepo.AssertCalled(t, "List", mock.Anything, "user-123", mock.MatchedBy(func(f *domain.TaskListFilter) bool {
+		return f.Limit == 3 && len(f.Sort) == 1 && f.Sort[0].Field == domain.TaskSortManual
+	}))
+}
+
+func TestBoardService_GetBoard_CustomWorkflow(t *testing.T) {
+	service, mockTaskRepo, _, mockPrefsRepo := newBoardTestService(reviewWorkflow...)
+
+	mockPrefsRepo.On("GetWIPLimits", mock.Anything, "user-123").Return(domain.WIPLimits{}, nil)
+	mockTaskRepo.On("List", mock.Anything, "user-123", mock.Anything).Return([]*domain.Task{}, nil)
+	mockTaskRepo.On("Count", mock.Anything, "user-123", mock.Anything).Return(0, nil)
+
+	board, err := service.GetBoard(context.Background(), "user-123", &domain.BoardFilter{})
+
+	require.NoError(t, err)
+	require.Len(t, board.Columns, len(reviewWorkflow))
+	assert.Equal(t, domain.TaskStatus("in_review"), board.Columns[2].Status)
+	assert.Equal(t, "In Review", board.Columns[2].Name)
+	assert.Equal(t, domain.StatusCategoryActive, board.Columns[2].Category)
+	mockTaskRepo.AssertCalled(t, "List", mock.Anything, "user-123", statusFilter("in_review"))
+}
+
+func TestBoardService_GetBoard_UnknownStatus(t *testing.T) {
+	service, _, _, _ := newBoardTestService(reviewWorkflow...)
+	status := domain.TaskStatusTodo
+
+	_, err := service.GetBoard(context.Background(), "user-123", &domain.BoardFilter{Status: &status})
+
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "status", validationErr.Field)
+}
+
+func TestBoardService_GetBoard_CursorRequiresStatus(t *testing.T) {
+	service, mockTaskRepo, _, _ := newBoardTestService()
+	cursor := "abc"
+
+	_, err := service.GetBoard(context.Background(), "user-123", &domain.BoardFilter{Cursor: &cursor})
+
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "cursor", validationErr.Field)
+	mockTaskRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// =============================================================================
+// BoardService.UpdateWIPLimits Tests
+// =============================================================================
+
+func TestBoardService_UpdateWIPLimits_RejectsDoneColumn(t *testing.T) {
+	service, _, _, mockPrefsRepo := newBoardTestService()
+
+	_, err := service.UpdateWIPLimits(context.Background(), "user-123", &domain.UpdateWIPLimitsDTO{
+		Limits: domain.WIPLimits{domain.TaskStatusDone: 5},
+	})
+
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	mockPrefsRepo.AssertNotCalled(t, "UpsertWIPLimits", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// =============================================================================
+// BoardService.MoveTask Tests
+// =============================================================================
+
+func TestBoardService_MoveTask_ChangesStatus(t *testing.T) {
+	service, mockTaskRepo, mockHistoryRepo, _ := newBoardTestService()
+
+	task := createRegularTestTask("user-123", "task-1")
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(task, nil)
+	mockTaskRepo.On("Update", mock.Anything, mock.Anything).Run(applyPlacement).Return(nil)
+	mockTaskRepo.On("GetSubtree", mock.Anything, "task-1").Return([]*domain.Task{}, nil).Maybe()
+	mockHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+		return h.EventType == domain.EventStatusChanged
+	})).Return(nil)
+
+	moved, err := service.MoveTask(context.Background(), "user-123", "task-1", &domain.MoveBoardTaskRequest{
+		Status: domain.TaskStatusInProgress,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.TaskStatusInProgress, moved.Status)
+	mockHistoryRepo.AssertExpectations(t)
+	// The WIP limit is checked by the update itself, in the same transaction
+	mockTaskRepo.AssertNotCalled(t, "Count", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBoardService_MoveTask_IntoDoneCompletes(t *testing.T) {
+	service, mockTaskRepo, mockHistoryRepo, _ := newBoardTestService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(createRegularTestTask("user-123", "task-1"), nil)
+	mockTaskRepo.On("Update", mock.Anything, mock.Anything).Run(applyPlacement).Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+		return h.EventType == domain.EventTaskCompleted
+	})).Return(nil)
+
+	moved, err := service.MoveTask(context.Background(), "user-123", "task-1", &domain.MoveBoardTaskRequest{
+		Status: domain.TaskStatusDone,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.TaskStatusDone, moved.Status)
+	assert.NotNil(t, moved.CompletedAt)
+	mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestBoardService_MoveTask_IntoCustomDoneColumn(t *testing.T) {
+	service, mockTaskRepo, mockHistoryRepo, _ := newBoardTestService(reviewWorkflow...)
+
+	task := createRegularTestTask("user-123", "task-1")
+	task.Status = domain.TaskStatusInProgress
+	task.WorkflowStatus = "in_review"
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(task, nil)
+	mockTaskRepo.On("Update", mock.Anything, mock.Anything).Run(applyPlacement).Return(nil).Once()
+	// Completing writes the chosen column, so the completion's snapshot (which undo checks against) has it
+	mockHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+		completed := domain.DecodeTaskSnapshot(h.NewValue)
+		return h.EventType == domain.EventTaskCompleted && completed != nil && completed.WorkflowStatus == "archived"
+	})).Return(nil).Once()
+
+	moved, err := service.MoveTask(context.Background(), "user-123", "task-1", &domain.MoveBoardTaskRequest{
+		Status: "archived",
+	})
+
+	require.NoError(t, err)
+	// Completed as usual, straight into the chosen done column
+	assert.Equal(t, domain.TaskStatusDone, moved.Status)
+	assert.Equal(t, domain.TaskStatus("archived"), moved.WorkflowStatus)
+	assert.NotNil(t, moved.CompletedAt)
+	mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestBoardService_MoveTask_ColumnFull(t *testing.T) {
+	service, mockTaskRepo, mockHistoryRepo, _ := newBoardTestService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(createRegularTestTask("user-123", "task-1"), nil)
+	mockTaskRepo.On("Update", mock.Anything, mock.Anything).
+		Return(fmt.Errorf("%w: in_progress already has 3 of 3 tasks", domain.ErrWIPLimitExceeded))
+
+	_, err := service.MoveTask(context.Background(), "user-123", "task-1", &domain.MoveBoardTaskRequest{
+		Status: domain.TaskStatusInProgress,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrWIPLimitExceeded)
+	mockHistoryRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestBoardService_MoveTask_PlacesBetweenNeighborsInOneUpdate(t *testing.T) {
+	service, mockTaskRepo, mockHistoryRepo, _ := newBoardTestService()
+	afterID := "task-2"
+
+	task := createRegularTestTask("user-123", "task-1")
+	task.Rank = "a"
+	neighbor := createRegularTestTask("user-123", "task-2")
+	neighbor.Status = domain.TaskStatusInProgress
+	neighbor.WorkflowStatus = domain.TaskStatusInProgress
+	neighbor.Rank = "m"
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(task, nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-2").Return(neighbor, nil)
+	mockTaskRepo.On("FindAdjacentRank", mock.Anything, mock.Anything, "m", "task-1", true).Return("", nil)
+	mockTaskRepo.On("Update", mock.Anything, mock.MatchedBy(func(t *domain.Task) bool {
+		return t.ID == "task-1"
+	})).Run(applyPlacement).Return(nil).Once()
+	mockTaskRepo.On("GetSubtree", mock.Anything, "task-1").Return([]*domain.Task{}, nil).Maybe()
+	mockHistoryRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	moved, err := service.MoveTask(context.Background(), "user-123", "task-1", &domain.MoveBoardTaskRequest{
+		Status:      domain.TaskStatusInProgress,
+		AfterTaskID: &afterID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.TaskStatusInProgress, moved.Status)
+	assert.Greater(t, moved.Rank, "m")
+	// The status and rank are written together, not as a status change followed by a move
+	mockTaskRepo.AssertExpectations(t)
+	mockTaskRepo.AssertNotCalled(t, "UpdateRank", mock.Anything, mock.Anything)
+}
+
+func TestBoardService_MoveTask_TransitionNotAllowed(t *testing.T) {
+	service, mockTaskRepo, _, _ := newBoardTestService(reviewWorkflow...)
+
+	task := createRegularTestTask("user-123", "task-1")
+	task.Status = domain.TaskStatusInProgress
+	task.WorkflowStatus = "in_review"
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(task, nil)
+
+	_, err := service.MoveTask(context.Background(), "user-123", "task-1", &domain.MoveBoardTaskRequest{
+		Status: "backlog",
+	})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidStatusTransition)
+	mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestBoardService_MoveTask_NeighborInOtherColumn(t *testing.T) {
+	service, mockTaskRepo, _, _ := newBoardTestService()
+	afterID := "task-2"
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(createRegularTestTask("user-123", "task-1"), nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-2").Return(createRegularTestTask("user-123", "task-2"), nil)
+
+	_, err := service.MoveTask(context.Background(), "user-123", "task-1", &domain.MoveBoardTaskRequest{
+		Status:      domain.TaskStatusInProgress,
+		AfterTaskID: &afterID,
+	})
+
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "after_task_id", validationErr.Field)
+}
+
+func TestBoardService_MoveTask_SubtaskRejected(t *testing.T) {
+	service, mockTaskRepo, _, _ := newBoardTestService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "sub-1").Return(createTestSubtask("user-123", "sub-1", "task-1"), nil)
+
+	_, err := service.MoveTask(context.Background(), "user-123", "sub-1", &domain.MoveBoardTaskRequest{
+		Status: domain.TaskStatusInProgress,
+	})
+
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+}
+
+func TestBoardService_MoveTask_OtherUsersNeighborNotFound(t *testing.T) {
+	service, mockTaskRepo, _, _ := newBoardTestService()
+	afterID := "task-2"
+
+	neighbor := createRegularTestTask("other-user", "task-2")
+	neighbor.Status = domain.TaskStatusInProgress
+	neighbor.WorkflowStatus = domain.TaskStatusInProgress
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(createRegularTestTask("user-123", "task-1"), nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-2").Return(neighbor, nil)
+
+	_, err := service.MoveTask(context.Background(), "user-123", "task-1", &domain.MoveBoardTaskRequest{
+		Status:      domain.TaskStatusInProgress,
+		AfterTaskID: &afterID,
+	})
+
+	// Neither the neighbor's column nor its existence is revealed
+	var notFoundErr *domain.NotFoundError
+	require.ErrorAs(t, err, &notFoundErr)
+	mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestBoardService_MoveTask_SubtaskNeighborRejectedBeforeStatusChange(t *testing.T) {
+	service, mockTaskRepo, _, _ := newBoardTestService()
+	beforeID := "sub-1"
+
+	subtask := createTestSubtask("user-123", "sub-1", "task-3")
+	subtask.Status = domain.TaskStatusInProgress
+	subtask.WorkflowStatus = domain.TaskStatusInProgress
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(createRegularTestTask("user-123", "task-1"), nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "sub-1").Return(subtask, nil)
+
+	_, err := service.MoveTask(context.Background(), "user-123", "task-1", &domain.MoveBoardTaskRequest{
+		Status:       domain.TaskStatusInProgress,
+		BeforeTaskID: &beforeID,
+	})
+
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "before_task_id", validationErr.Field)
+	// The status is left alone rather than half-applying the move
+	mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
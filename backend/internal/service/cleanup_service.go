@@ -10,7 +10,8 @@ import (
 
 // CleanupService handles periodic cleanup of expired anonymous users
 type CleanupService struct {
-	userRepo ports.UserRepository
+	userRepo          ports.UserRepository
+	attachmentService ports.AttachmentService // Optional: for attachment blob cleanup
 }
 
 // NewCleanupService creates a new cleanup service
@@ -20,6 +21,11 @@ func NewCleanupService(userRepo ports.UserRepository) *CleanupService {
 	}
 }
 
+// SetAttachmentService sets the optional attachment service so deleted users' attachment blobs are removed
+func (s *CleanupService) SetAttachmentService(attachmentService ports.AttachmentService) {
+	s.attachmentService = attachmentService
+}
+
 // CleanupResult contains the result of a cleanup operation
 type CleanupResult struct {
 	DeletedCount int
@@ -30,7 +36,8 @@ type CleanupResult struct {
 }
 
 // CleanupExpiredAnonymousUsers finds and deletes all expired anonymous users
-// along with their associated data (tasks, etc. via cascade delete).
+// along with their associated data (tasks, comments, attachments, saved views, etc. via cascade delete).
+// Attachment blobs are removed from the blob store afterwards.
 // It logs each deletion for audit purposes.
 func (s *CleanupService) CleanupExpiredAnonymousUsers(ctx context.Context) (*CleanupResult, error) {
 	startTime := time.Now()
@@ -58,6 +65,8 @@ func (s *CleanupService) CleanupExpiredAnonymousUsers(ctx context.Context) (*Cle
 
 	if len(expiredUsers) == 0 {
 		slog.Info("[Cleanup] No expired anonymous users found")
+		// Retry blob deletions left over from earlier failures
+		s.purgeAttachmentBlobs(ctx)
 		result.Duration = time.Since(startTime)
 		return result, nil
 	}
@@ -91,7 +100,7 @@ func (s *CleanupService) CleanupExpiredAnonymousUsers(ctx context.Context) (*Cle
 			continue // Skip deletion - audit is required for compliance
 		}
 
-		// Delete the user (cascades to tasks via FK)
+		// Delete the user (cascades to tasks, comments and attachments via FK)
 		if err := s.userRepo.Delete(ctx, user.ID); err != nil {
 			slog.Error("[Cleanup] Failed to delete user",
 				"user_id", user.ID,
@@ -111,6 +120,9 @@ func (s *CleanupService) CleanupExpiredAnonymousUsers(ctx context.Context) (*Cle
 		)
 	}
 
+	// Deleting users cascaded to their attachments, which queued their blobs
+	s.purgeAttachmentBlobs(ctx)
+
 	result.Duration = time.Since(startTime)
 	slog.Info("[Cleanup] Cleanup completed",
 		"deleted", result.DeletedCount,
@@ -122,6 +134,23 @@ func (s *CleanupService) CleanupExpiredAnonymousUsers(ctx context.Context) (*Cle
 	return result, nil
 }
 
+// purgeAttachmentBlobs removes queued attachment blobs. Failures are logged and
+// the keys stay queued for the next run.
+func (s *CleanupService) purgeAttachmentBlobs(ctx context.Context) {
+	if s.attachmentService == nil {
+		return
+	}
+
+	purged, err := s.attachmentService.PurgeDeletedBlobs(ctx)
+	if err != nil {
+		slog.Error("[Cleanup] Failed to purge attachment blobs", "error", err)
+		return
+	}
+	if purged > 0 {
+		slog.Info("[Cleanup] Purged attachment blobs", "count", purged)
+	}
+}
+
 // RunCleanupLoop starts a background loop that runs cleanup at the specified interval.
 // It blocks until the context is cancelled.
 func (s *CleanupService) RunCleanupLoop(ctx context.Context, interval time.Duration) {
@@ -388,3 +388,52 @@ func TestCleanupExpiredAnonymousUsers_AllUsersFail(t *testing.T) {
 	assert.Len(t, result.Errors, 2)
 	mockUserRepo.AssertExpectations(t)
 }
+
+// =============================================================================
+// CleanupExpiredAnonymousUsers Tests - Attachment Blobs
+// =============================================================================
+
+func TestCleanupExpiredAnonymousUsers_PurgesAttachmentBlobs(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockAttachmentService := new(MockAttachmentService)
+	service := NewCleanupService(mockUserRepo)
+	service.SetAttachmentService(mockAttachmentService)
+
+	expiredUser := createExpiredAnonymousUser("anon-123", 2)
+
+	mockUserRepo.On("FindExpiredAnonymous", mock.Anything).
+		Return([]*domain.User{expiredUser}, nil)
+	mockUserRepo.On("CountTasksByUserID", mock.Anything, "anon-123").
+		Return(2, nil)
+	mockUserRepo.On("LogAnonymousCleanup", mock.Anything, "anon-123", 2, expiredUser.CreatedAt).
+		Return(nil)
+	mockUserRepo.On("Delete", mock.Anything, "anon-123").
+		Return(nil)
+	mockAttachmentService.On("PurgeDeletedBlobs", mock.Anything).
+		Return(4, nil)
+
+	result, err := service.CleanupExpiredAnonymousUsers(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.DeletedCount)
+	mockUserRepo.AssertExpectations(t)
+	mockAttachmentService.AssertExpectations(t)
+}
+
+func TestCleanupExpiredAnonymousUsers_NoExpiredUsers_RetriesBlobPurge(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockAttachmentService := new(MockAttachmentService)
+	service := NewCleanupService(mockUserRepo)
+	service.SetAttachmentService(mockAttachmentService)
+
+	mockUserRepo.On("FindExpiredAnonymous", mock.Anything).
+		Return([]*domain.User{}, nil)
+	mockAttachmentService.On("PurgeDeletedBlobs", mock.Anything).
+		Return(0, errors.New("store unavailable"))
+
+	result, err := service.CleanupExpiredAnonymousUsers(context.Background())
+
+	require.NoError(t, err) // A failed purge does not fail the cleanup
+	assert.Equal(t, 0, result.DeletedCount)
+	mockAttachmentService.AssertExpectations(t)
+}
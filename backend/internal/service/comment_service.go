@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+	"github.com/notkevinvu/taskflow/backend/internal/validation"
+)
+
+// CommentService handles task comment business logic
+type CommentService struct {
+	commentRepo     ports.CommentRepository
+	taskService     ports.TaskService
+	taskHistoryRepo ports.TaskHistoryRepository
+}
+
+// NewCommentService creates a new comment service
+func NewCommentService(commentRepo ports.CommentRepository, taskService ports.TaskService, taskHistoryRepo ports.TaskHistoryRepository) *CommentService {
+	return &CommentService{
+		commentRepo:     commentRepo,
+		taskService:     taskService,
+		taskHistoryRepo: taskHistoryRepo,
+	}
+}
+
+// Create adds a comment to a task the user owns
+func (s *CommentService) Create(ctx context.Context, userID, taskID string, dto *domain.CreateCommentDTO) (*domain.TaskComment, error) {
+	// Ownership is checked through the task, exactly as for GET /tasks/:id
+	if _, err := s.taskService.Get(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	body, err := validation.ValidateMarkdown(dto.Body, domain.MaxCommentLength, "body")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	comment := &domain.TaskComment{
+		ID:        uuid.New().String(),
+		TaskID:    taskID,
+		UserID:    userID,
+		Body:      body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.commentRepo.Create(ctx, comment); err != nil {
+		return nil, domain.NewInternalError("failed to create comment", err)
+	}
+
+	s.logHistory(ctx, userID, taskID, domain.EventCommentAdded, nil, comment)
+
+	return comment, nil
+}
+
+// List retrieves a task's comments, oldest first
+func (s *CommentService) List(ctx context.Context, userID, taskID string) ([]*domain.TaskComment, error) {
+	if _, err := s.taskService.Get(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.commentRepo.FindByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to list comments", err)
+	}
+
+	return comments, nil
+}
+
+// Update edits a comment. The previous body is kept in the comment's edit history.
+func (s *CommentService) Update(ctx context.Context, userID, taskID, commentID string, dto *domain.UpdateCommentDTO) (*domain.TaskComment, error) {
+	comment, err := s.getComment(ctx, userID, taskID, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := validation.ValidateMarkdown(dto.Body, domain.MaxCommentLength, "body")
+	if err != nil {
+		return nil, err
+	}
+	comment.Body = body
+
+	if err := s.commentRepo.Update(ctx, comment); err != nil {
+		if err == domain.ErrCommentNotFound {
+			return nil, err
+		}
+		return nil, domain.NewInternalError("failed to update comment", err)
+	}
+
+	return comment, nil
+}
+
+// Delete soft-deletes a comment
+func (s *CommentService) Delete(ctx context.Context, userID, taskID, commentID string) error {
+	comment, err := s.getComment(ctx, userID, taskID, commentID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.commentRepo.SoftDelete(ctx, commentID, userID); err != nil {
+		if err == domain.ErrCommentNotFound {
+			return err
+		}
+		return domain.NewInternalError("failed to delete comment", err)
+	}
+
+	s.logHistory(ctx, userID, taskID, domain.EventCommentRemoved, comment, nil)
+
+	return nil
+}
+
+// ListEdits retrieves a comment together with its previous bodies
+func (s *CommentService) ListEdits(ctx context.Context, userID, taskID, commentID string) (*domain.CommentEditListResponse, error) {
+	comment, err := s.getComment(ctx, userID, taskID, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	edits, err := s.commentRepo.FindEdits(ctx, commentID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to list comment edits", err)
+	}
+
+	return &domain.CommentEditListResponse{
+		Comment: comment,
+		Edits:   edits,
+	}, nil
+}
+
+// getComment verifies task ownership and that the comment belongs to the task
+func (s *CommentService) getComment(ctx context.Context, userID, taskID, commentID string) (*domain.TaskComment, error) {
+	if _, err := s.taskService.Get(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.commentRepo.FindByID(ctx, commentID)
+	if err != nil {
+		if err == domain.ErrCommentNotFound {
+			return nil, err
+		}
+		return nil, domain.NewInternalError("failed to find comment", err)
+	}
+
+	// A comment addressed through another task's URL does not exist there
+	if comment.TaskID != taskID {
+		return nil, domain.ErrCommentNotFound
+	}
+	if comment.UserID != userID {
+		return nil, domain.NewForbiddenError("comment", "access")
+	}
+
+	return comment, nil
+}
+
+// logHistory records a comment event on the task. Failures are logged, not returned,
+// so a history write never loses the user's comment.
+func (s *CommentService) logHistory(ctx context.Context, userID, taskID string, eventType domain.TaskHistoryEventType, oldComment, newComment *domain.TaskComment) {
+	var oldValue, newValue *string
+
+	if oldComment != nil {
+		data, _ := json.Marshal(oldComment)
+		str := string(data)
+		oldValue = &str
+	}
+
+	if newComment != nil {
+		data, _ := json.Marshal(newComment)
+		str := string(data)
+		newValue = &str
+	}
+
+	history := &domain.TaskHistory{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		TaskID:    taskID,
+		EventType: eventType,
+		OldValue:  oldValue,
+		NewValue:  newValue,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.taskHistoryRepo.Create(ctx, history); err != nil {
+		slog.Warn("Failed to log comment history",
+			"task_id", taskID,
+			"event_type", eventType,
+			"error", err,
+		)
+	}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+func newCommentService() (*CommentService, *MockCommentRepository, *MockTaskRepository, *MockTaskHistoryRepository) {
+	mockCommentRepo := new(MockCommentRepository)
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	taskService := NewTaskService(mockTaskRepo, mockHistoryRepo)
+	return NewCommentService(mockCommentRepo, taskService, mockHistoryRepo), mockCommentRepo, mockTaskRepo, mockHistoryRepo
+}
+
+func createTestComment(userID, taskID, commentID string) *domain.TaskComment {
+	return &domain.TaskComment{
+		ID:     commentID,
+		TaskID: taskID,
+		UserID: userID,
+		Body:   "Drafted the outline",
+	}
+}
+
+// =============================================================================
+// CommentService.Create Tests
+// =============================================================================
+
+func TestCommentService_Create_Success(t *testing.T) {
+	service, mockCommentRepo, mockTaskRepo, mockHistoryRepo := newCommentService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	mockCommentRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TaskComment")).Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+		return h.EventType == domain.EventCommentAdded && h.TaskID == "task-456" &&
+			h.OldValue == nil && h.NewValue != nil
+	})).Return(nil)
+
+	comment, err := service.Create(context.Background(), "user-123", "task-456", &domain.CreateCommentDTO{
+		Body: "  **Done:** outline\n- next: review  ",
+	})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, comment.ID)
+	assert.Equal(t, "task-456", comment.TaskID)
+	assert.Equal(t, "**Done:** outline\n- next: review", comment.Body)
+	mockCommentRepo.AssertExpectations(t)
+	mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestCommentService_Create_OtherUsersTask(t *testing.T) {
+	service, mockCommentRepo, mockTaskRepo, _ := newCommentService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-456", "task-456"), nil)
+
+	comment, err := service.Create(context.Background(), "user-123", "task-456", &domain.CreateCommentDTO{Body: "hi"})
+
+	assert.Nil(t, comment)
+	var forbiddenErr *domain.ForbiddenError
+	assert.ErrorAs(t, err, &forbiddenErr)
+	mockCommentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCommentService_Create_EmptyBody(t *testing.T) {
+	service, mockCommentRepo, mockTaskRepo, _ := newCommentService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+
+	comment, err := service.Create(context.Background(), "user-123", "task-456", &domain.CreateCommentDTO{Body: " \n "})
+
+	assert.Nil(t, comment)
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "body", validationErr.Field)
+	mockCommentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+// =============================================================================
+// CommentService.Update / Delete Tests
+// =============================================================================
+
+func TestCommentService_Update_Success(t *testing.T) {
+	service, mockCommentRepo, mockTaskRepo, _ := newCommentService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	mockCommentRepo.On("FindByID", mock.Anything, "comment-1").Return(createTestComment("user-123", "task-456", "comment-1"), nil)
+	mockCommentRepo.On("Update", mock.Anything, mock.MatchedBy(func(c *domain.TaskComment) bool {
+		return c.Body == "Drafted and reviewed the outline"
+	})).Return(nil)
+
+	comment, err := service.Update(context.Background(), "user-123", "task-456", "comment-1", &domain.UpdateCommentDTO{
+		Body: "Drafted and reviewed the outline",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Drafted and reviewed the outline", comment.Body)
+	mockCommentRepo.AssertExpectations(t)
+}
+
+func TestCommentService_Update_CommentOnDifferentTask(t *testing.T) {
+	service, mockCommentRepo, mockTaskRepo, _ := newCommentService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	mockCommentRepo.On("FindByID", mock.Anything, "comment-1").Return(createTestComment("user-123", "task-789", "comment-1"), nil)
+
+	comment, err := service.Update(context.Background(), "user-123", "task-456", "comment-1", &domain.UpdateCommentDTO{Body: "x"})
+
+	assert.Nil(t, comment)
+	assert.ErrorIs(t, err, domain.ErrCommentNotFound)
+	mockCommentRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestCommentService_Delete_LogsHistory(t *testing.T) {
+	service, mockCommentRepo, mockTaskRepo, mockHistoryRepo := newCommentService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	mockCommentRepo.On("FindByID", mock.Anything, "comment-1").Return(createTestComment("user-123", "task-456", "comment-1"), nil)
+	mockCommentRepo.On("SoftDelete", mock.Anything, "comment-1", "user-123").Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+		return h.EventType == domain.EventCommentRemoved && h.OldValue != nil && h.NewValue == nil
+	})).Return(nil)
+
+	err := service.Delete(context.Background(), "user-123", "task-456", "comment-1")
+
+	require.NoError(t, err)
+	mockCommentRepo.AssertExpectations(t)
+	mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestCommentService_ListEdits(t *testing.T) {
+	service, mockCommentRepo, mockTaskRepo, _ := newCommentService()
+
+	edits := []*domain.TaskCommentEdit{{ID: "edit-1", CommentID: "comment-1", Body: "Draftd the outline"}}
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	mockCommentRepo.On("FindByID", mock.Anything, "comment-1").Return(createTestComment("user-123", "task-456", "comment-1"), nil)
+	mockCommentRepo.On("FindEdits", mock.Anything, "comment-1").Return(edits, nil)
+
+	result, err := service.ListEdits(context.Background(), "user-123", "task-456", "comment-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "comment-1", result.Comment.ID)
+	assert.Len(t, result.Edits, 1)
+}
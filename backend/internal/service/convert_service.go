@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/domain/priority"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+)
+
+// ConvertService changes tasks between the regular, subtask and recurring types,
+// enforcing the same rules as creating a task of the target type
+type ConvertService struct {
+	taskRepo        ports.TaskRepository
+	taskHistoryRepo ports.TaskHistoryRepository
+	dependencyRepo  ports.DependencyRepository
+	priorityCalc    *priority.Calculator
+	maxDepth        int
+}
+
+// NewConvertService creates a new convert service
+func NewConvertService(taskRepo ports.TaskRepository, taskHistoryRepo ports.TaskHistoryRepository, dependencyRepo ports.DependencyRepository) *ConvertService {
+	return &ConvertService{
+		taskRepo:        taskRepo,
+		taskHistoryRepo: taskHistoryRepo,
+		dependencyRepo:  dependencyRepo,
+		priorityCalc:    priority.NewCalculator(),
+		maxDepth:        domain.DefaultMaxTaskDepth,
+	}
+}
+
+// SetMaxDepth sets how many levels a task hierarchy may have, counting the top-level task.
+// Values below 2 still allow one level of subtasks.
+func (s *ConvertService) SetMaxDepth(maxDepth int) {
+	s.maxDepth = max(maxDepth, 2)
+}
+
+// Convert changes a task's type:
+//   - to subtask: moves the task, with its own subtasks, under a parent (or to another parent)
+//   - to regular: promotes a subtask, or detaches an instance from its recurring series
+//   - to recurring: starts a new series with the task as its first instance
+//
+// A recurring task leaving its series stops the series when it was the pending instance.
+func (s *ConvertService) Convert(ctx context.Context, userID, taskID string, req *domain.ConvertTaskRequest) (*domain.Task, error) {
+	task, err := s.taskRepo.FindByID(ctx, taskID)
+	if errors.Is(err, domain.ErrTaskNotFound) {
+		return nil, domain.NewNotFoundError("task", taskID)
+	}
+	if err != nil {
+		return nil, domain.NewInternalError("failed to find task", err)
+	}
+	if task.UserID != userID {
+		return nil, domain.NewForbiddenError("task", "access")
+	}
+	if err := checkExpectedVersion(ctx, task); err != nil {
+		return nil, err
+	}
+
+	if err := req.To.Validate(); err != nil {
+		return nil, domain.NewValidationError("to", "must be regular, subtask or recurring")
+	}
+	if req.To != domain.TaskTypeSubtask && req.ParentTaskID != nil {
+		return nil, domain.NewValidationError("parent_task_id", "is only used when converting to a subtask")
+	}
+	if req.To != domain.TaskTypeRecurring && req.Recurrence != nil {
+		return nil, domain.NewValidationError("recurrence", "is only used when converting to a recurring task")
+	}
+	if req.To == task.TaskType && req.To != domain.TaskTypeSubtask {
+		return nil, domain.NewValidationError("to", "task is already "+string(req.To))
+	}
+
+	oldTask := *task
+	converted := *task
+	conv := &domain.TaskConversion{Task: &converted}
+	now := time.Now()
+
+	// Leaving a series: only the pending instance generates the next one, so the series stops with it
+	if task.TaskType == domain.TaskTypeRecurring && task.SeriesID != nil && task.Status != domain.TaskStatusDone {
+		conv.DeactivateSeriesID = task.SeriesID
+	}
+	converted.ParentTaskID = nil
+	converted.SeriesID = nil
+	converted.TaskType = req.To
+	converted.UpdatedAt = now
+
+	// Only regular tasks can have dependencies
+	if task.TaskType == domain.TaskTypeRegular && req.To != domain.TaskTypeRegular {
+		if err := s.checkNoDependencies(ctx, task.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	// The task's own subtasks move with it
+	var descendants []*domain.Task
+	if task.CanHaveSubtasks() {
+		descendants, err = s.taskRepo.GetSubtree(ctx, task.ID)
+		if err != nil {
+			return nil, domain.NewInternalError("failed to retrieve subtasks", err)
+		}
+	}
+
+	switch req.To {
+	case domain.TaskTypeSubtask:
+		parent, err := s.findParent(ctx, userID, task, req.ParentTaskID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkSubtaskPlacement(ctx, s.taskRepo, s.maxDepth, parent, task.ID, domain.SubtreeHeight(task.ID, descendants)); err != nil {
+			return nil, err
+		}
+		converted.ParentTaskID = &parent.ID
+		// Subtasks inherit the parent's category, as in SubtaskService.Create
+		converted.Category = parent.Category
+		converted.PriorityScore = s.priorityCalc.CalculateForSubtask(&converted, parent.PriorityScore)
+
+	case domain.TaskTypeRecurring:
+		if len(descendants) > 0 {
+			return nil, domain.NewValidationError("to", "a task with subtasks cannot recur; move or delete its subtasks first")
+		}
+		rule := req.Recurrence
+		if rule == nil || !rule.Pattern.IsRecurring() {
+			return nil, domain.NewValidationError("recurrence", "is required when converting to a recurring task")
+		}
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+		conv.Series = &domain.TaskSeries{
+			ID:                 uuid.New().String(),
+			UserID:             userID,
+			OriginalTaskID:     task.ID,
+			Pattern:            rule.Pattern,
+			IntervalValue:      rule.IntervalValue,
+			EndDate:            rule.EndDate,
+			DueDateCalculation: rule.DueDateCalculation,
+			IsActive:           true,
+			CreatedAt:          now,
+			UpdatedAt:          now,
+		}
+		converted.SeriesID = &conv.Series.ID
+		converted.PriorityScore = s.priorityCalc.Calculate(&converted)
+
+	default:
+		converted.PriorityScore = s.priorityCalc.Calculate(&converted)
+	}
+
+	// A task that changes lists goes to the end of its new one
+	if !converted.RankScope().Equal(task.RankScope()) {
+		converted.Rank = ""
+	}
+
+	if err := s.taskRepo.Convert(ctx, conv); err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			return nil, domain.NewNotFoundError("task", taskID)
+		}
+		if errors.Is(err, domain.ErrTaskVersionConflict) {
+			// The task changed since it was read; hand back the current copy, as updates do
+			current, err := s.taskRepo.FindByID(ctx, taskID)
+			if err != nil {
+				return nil, domain.NewInternalError("failed to find task", err)
+			}
+			return nil, domain.NewPreconditionFailedError("task", current)
+		}
+		return nil, domain.NewInternalError("failed to convert task", err)
+	}
+
+	result, err := s.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to fetch converted task", err)
+	}
+
+	s.logHistory(ctx, userID, &oldTask, result)
+
+	// The moved subtree inherits priority from its new position
+	if len(descendants) > 0 {
+		if err := propagatePriority(ctx, s.taskRepo, s.priorityCalc, result); err != nil {
+			slog.Warn("Failed to update subtask priorities", "task_id", result.ID, "error", err)
+		}
+	}
+
+	return result, nil
+}
+
+// checkNoDependencies rejects making a regular task a subtask or recurring task while it
+// still blocks or waits on other tasks, since only regular tasks may have dependencies
+func (s *ConvertService) checkNoDependencies(ctx context.Context, taskID string) error {
+	blockers, err := s.dependencyRepo.GetBlockers(ctx, taskID)
+	if err != nil {
+		return domain.NewInternalError("failed to retrieve blockers", err)
+	}
+	blocking, err := s.dependencyRepo.GetBlocking(ctx, taskID)
+	if err != nil {
+		return domain.NewInternalError("failed to retrieve blocked tasks", err)
+	}
+	if len(blockers) > 0 || len(blocking) > 0 {
+		return domain.ErrInvalidDependencyType
+	}
+
+	return nil
+}
+
+// findParent loads and checks the new parent of a task becoming a subtask
+func (s *ConvertService) findParent(ctx context.Context, userID string, task *domain.Task, parentID *string) (*domain.Task, error) {
+	if parentID == nil {
+		return nil, domain.NewValidationError("parent_task_id", "is required when converting to a subtask")
+	}
+	if *parentID == task.ID {
+		return nil, domain.ErrSubtaskCycle
+	}
+	if task.ParentTaskID != nil && *task.ParentTaskID == *parentID && task.TaskType == domain.TaskTypeSubtask {
+		return nil, domain.NewValidationError("parent_task_id", "task is already a subtask of this parent")
+	}
+
+	parent, err := s.taskRepo.FindByID(ctx, *parentID)
+	if errors.Is(err, domain.ErrTaskNotFound) {
+		return nil, domain.ErrParentNotFound
+	}
+	if err != nil {
+		return nil, domain.NewInternalError("failed to find parent task", err)
+	}
+	if parent.UserID != userID {
+		return nil, domain.NewForbiddenError("parent task", "access")
+	}
+
+	return parent, nil
+}
+
+// logHistory records the conversion with before and after snapshots, so the diff shows
+// the changed type, parent, series and category
+func (s *ConvertService) logHistory(ctx context.Context, userID string, oldTask, newTask *domain.Task) {
+	oldData, _ := json.Marshal(oldTask)
+	newData, _ := json.Marshal(newTask)
+	oldValue, newValue := string(oldData), string(newData)
+
+	if err := s.taskHistoryRepo.Create(ctx, &domain.TaskHistory{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		TaskID:    newTask.ID,
+		EventType: domain.EventTaskConverted,
+		OldValue:  &oldValue,
+		NewValue:  &newValue,
+		CreatedAt: time.Now(),
+	}); err != nil {
+		slog.Warn("Failed to log task conversion history",
+			"user_id", userID, "task_id", newTask.ID, "error", err)
+	}
+}
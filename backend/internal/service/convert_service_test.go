@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newConvertTestService() (*ConvertService, *MockTaskRepository, *MockTaskHistoryRepository, *MockDependencyRepository) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	mockDependencyRepo := new(MockDependencyRepository)
+	return NewConvertService(mockTaskRepo, mockHistoryRepo, mockDependencyRepo), mockTaskRepo, mockHistoryRepo, mockDependencyRepo
+}
+
+func createRegularTestTask(userID, taskID string) *domain.Task {
+	task := createTestTask(userID, taskID)
+	task.TaskType = domain.TaskTypeRegular
+	return task
+}
+
+func expectConversionHistory(mockHistoryRepo *MockTaskHistoryRepository) {
+	mockHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+		return h.EventType == domain.EventTaskConverted && h.OldValue != nil && h.NewValue != nil
+	})).Return(nil).Once()
+}
+
+// =============================================================================
+// ConvertService.Convert Tests
+// =============================================================================
+
+func TestConvertService_Convert_RegularToSubtask(t *testing.T) {
+	service, mockTaskRepo, mockHistoryRepo, mockDependencyRepo := newConvertTestService()
+
+	task := createRegularTestTask("user-123", "task-1")
+	parent := createRegularTestTask("user-123", "parent-1")
+	category := "Work"
+	parent.Category = &category
+	parentID := "parent-1"
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(task, nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "parent-1").Return(parent, nil)
+	mockTaskRepo.On("GetSubtree", mock.Anything, "task-1").Return([]*domain.Task{}, nil)
+	mockTaskRepo.On("GetAncestorIDs", mock.Anything, "parent-1").Return([]string{}, nil)
+	mockDependencyRepo.On("GetBlockers", mock.Anything, "task-1").Return([]*domain.DependencyWithTask{}, nil)
+	mockDependencyRepo.On("GetBlocking", mock.Anything, "task-1").Return([]*domain.DependencyWithTask{}, nil)
+	mockTaskRepo.On("Convert", mock.Anything, mock.MatchedBy(func(conv *domain.TaskConversion) bool {
+		return conv.Task.TaskType == domain.TaskTypeSubtask &&
+			*conv.Task.ParentTaskID == "parent-1" &&
+			*conv.Task.Category == "Work" &&
+			conv.Task.SeriesID == nil && conv.Series == nil && conv.DeactivateSeriesID == nil
+	})).Return(nil)
+	expectConversionHistory(mockHistoryRepo)
+
+	_, err := service.Convert(context.Background(), "user-123", "task-1", &domain.ConvertTaskRequest{
+		To:           domain.TaskTypeSubtask,
+		ParentTaskID: &parentID,
+	})
+
+	require.NoError(t, err)
+	// The loaded task is not modified in place
+	assert.Nil(t, task.ParentTaskID)
+	mockTaskRepo.AssertExpectations(t)
+	mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestConvertService_Convert_MovesSubtreeWithTask(t *testing.T) {
+	service, mockTaskRepo, mockHistoryRepo, mockDependencyRepo := newConvertTestService()
+	parentID := "parent-1"
+
+	task := createRegularTestTask("user-123", "task-1")
+	child := createTestSubtask("user-123", "child-1", "task-1")
+	child.PriorityScore = 0
+	parent := createRegularTestTask("user-123", "parent-1")
+	parent.PriorityScore = 80
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(task, nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "parent-1").Return(parent, nil)
+	mockDependencyRepo.On("GetBlockers", mock.Anything, "task-1").Return([]*domain.DependencyWithTask{}, nil)
+	mockDependencyRepo.On("GetBlocking", mock.Anything, "task-1").Return([]*domain.DependencyWithTask{}, nil)
+	mockTaskRepo.On("GetSubtree", mock.Anything, "task-1").Return([]*domain.Task{child}, nil)
+	mockTaskRepo.On("GetAncestorIDs", mock.Anything, "parent-1").Return([]string{}, nil)
+	mockTaskRepo.On("Convert", mock.Anything, mock.Anything).Return(nil)
+	expectConversionHistory(mockHistoryRepo)
+	mockTaskRepo.On("UpdatePriorityScores", mock.Anything, mock.MatchedBy(func(scores map[string]int) bool {
+		return scores["child-1"] > 0
+	})).Return(nil)
+
+	_, err := service.Convert(context.Background(), "user-123", "task-1", &domain.ConvertTaskRequest{
+		To:           domain.TaskTypeSubtask,
+		ParentTaskID: &parentID,
+	})
+
+	require.NoError(t, err)
+	mockTaskRepo.AssertExpectations(t)
+}
+
+func TestConvertService_Convert_RejectsTooDeepSubtree(t *testing.T) {
+	service, mockTaskRepo, _, mockDependencyRepo := newConvertTestService()
+	service.SetMaxDepth(3)
+	parentID := "parent-1"
+
+	child := createTestSubtask("user-123", "child-1", "task-1")
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(createRegularTestTask("user-123", "task-1"), nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "parent-1").Return(createTestSubtask("user-123", "parent-1", "root-1"), nil)
+	mockDependencyRepo.On("GetBlockers", mock.Anything, "task-1").Return([]*domain.DependencyWithTask{}, nil)
+	mockDependencyRepo.On("GetBlocking", mock.Anything, "task-1").Return([]*domain.DependencyWithTask{}, nil)
+	mockTaskRepo.On("GetSubtree", mock.Anything, "task-1").Return([]*domain.Task{child}, nil)
+	mockTaskRepo.On("GetAncestorIDs", mock.Anything, "parent-1").Return([]string{"root-1"}, nil)
+
+	// root -> parent -> task -> child would be 4 levels
+	_, err := service.Convert(context.Background(), "user-123", "task-1", &domain.ConvertTaskRequest{
+		To:           domain.TaskTypeSubtask,
+		ParentTaskID: &parentID,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrSubtaskDepthExceeded)
+	mockTaskRepo.AssertNotCalled(t, "Convert", mock.Anything, mock.Anything)
+}
+
+func TestConvertService_Convert_RejectsTaskWithSubtasksBecomingRecurring(t *testing.T) {
+	service, mockTaskRepo, _, mockDependencyRepo := newConvertTestService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(createRegularTestTask("user-123", "task-1"), nil)
+	mockDependencyRepo.On("GetBlockers", mock.Anything, "task-1").Return([]*domain.DependencyWithTask{}, nil)
+	mockDependencyRepo.On("GetBlocking", mock.Anything, "task-1").Return([]*domain.DependencyWithTask{}, nil)
+	mockTaskRepo.On("GetSubtree", mock.Anything, "task-1").
+		Return([]*domain.Task{createTestSubtask("user-123", "child-1", "task-1")}, nil)
+
+	_, err := service.Convert(context.Background(), "user-123", "task-1", &domain.ConvertTaskRequest{
+		To: domain.TaskTypeRecurring,
+		Recurrence: &domain.RecurrenceRule{
+			Pattern:            domain.RecurrencePatternWeekly,
+			IntervalValue:      1,
+			DueDateCalculation: domain.DueDateFromOriginal,
+		},
+	})
+
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	mockTaskRepo.AssertNotCalled(t, "Convert", mock.Anything, mock.Anything)
+}
+
+func TestConvertService_Convert_RejectsTaskWithDependencies(t *testing.T) {
+	service, mockTaskRepo, _, mockDependencyRepo := newConvertTestService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(createRegularTestTask("user-123", "task-1"), nil)
+	mockDependencyRepo.On("GetBlockers", mock.Anything, "task-1").Return([]*domain.DependencyWithTask{}, nil)
+	mockDependencyRepo.On("GetBlocking", mock.Anything, "task-1").
+		Return([]*domain.DependencyWithTask{{TaskID: "task-2"}}, nil)
+
+	_, err := service.Convert(context.Background(), "user-123", "task-1", &domain.ConvertTaskRequest{
+		To: domain.TaskTypeRecurring,
+		Recurrence: &domain.RecurrenceRule{
+			Pattern:            domain.RecurrencePatternWeekly,
+			IntervalValue:      1,
+			DueDateCalculation: domain.DueDateFromOriginal,
+		},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidDependencyType)
+	mockTaskRepo.AssertNotCalled(t, "Convert", mock.Anything, mock.Anything)
+}
+
+func TestConvertService_Convert_RejectsMoveUnderOwnSubtask(t *testing.T) {
+	service, mockTaskRepo, _, _ := newConvertTestService()
+	parentID := "grandchild-1"
+
+	subtask := createTestSubtask("user-123", "task-1", "root-1")
+	child := createTestSubtask("user-123", "child-1", "task-1")
+	grandchild := createTestSubtask("user-123", "grandchild-1", "child-1")
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(subtask, nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "grandchild-1").Return(grandchild, nil)
+	mockTaskRepo.On("GetSubtree", mock.Anything, "task-1").Return([]*domain.Task{child, grandchild}, nil)
+	mockTaskRepo.On("GetAncestorIDs", mock.Anything, "grandchild-1").Return([]string{"child-1", "task-1", "root-1"}, nil)
+
+	_, err := service.Convert(context.Background(), "user-123", "task-1", &domain.ConvertTaskRequest{
+		To:           domain.TaskTypeSubtask,
+		ParentTaskID: &parentID,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrSubtaskCycle)
+	mockTaskRepo.AssertNotCalled(t, "Convert", mock.Anything, mock.Anything)
+}
+
+func TestConvertService_Convert_RegularToRecurringStartsSeries(t *testing.T) {
+	service, mockTaskRepo, mockHistoryRepo, mockDependencyRepo := newConvertTestService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(createRegularTestTask("user-123", "task-1"), nil)
+	mockDependencyRepo.On("GetBlockers", mock.Anything, "task-1").Return([]*domain.DependencyWithTask{}, nil)
+	mockDependencyRepo.On("GetBlocking", mock.Anything, "task-1").Return([]*domain.DependencyWithTask{}, nil)
+	mockTaskRepo.On("GetSubtree", mock.Anything, "task-1").Return([]*domain.Task{}, nil)
+	mockTaskRepo.On("Convert", mock.Anything, mock.MatchedBy(func(conv *domain.TaskConversion) bool {
+		return conv.Series != nil &&
+			conv.Series.OriginalTaskID == "task-1" &&
+			conv.Series.IsActive &&
+			*conv.Task.SeriesID == conv.Series.ID &&
+			conv.Task.TaskType == domain.TaskTypeRecurring
+	})).Return(nil)
+	expectConversionHistory(mockHistoryRepo)
+
+	_, err := service.Convert(context.Background(), "user-123", "task-1", &domain.ConvertTaskRequest{
+		To: domain.TaskTypeRecurring,
+		Recurrence: &domain.RecurrenceRule{
+			Pattern:            domain.RecurrencePatternDaily,
+			IntervalValue:      2,
+			DueDateCalculation: domain.DueDateFromCompletion,
+		},
+	})
+
+	require.NoError(t, err)
+	mockTaskRepo.AssertExpectations(t)
+}
+
+func TestConvertService_Convert_RecurringToRegularStopsSeries(t *testing.T) {
+	service, mockTaskRepo, mockHistoryRepo, _ := newConvertTestService()
+
+	task := createTestTask("user-123", "task-1")
+	task.TaskType = domain.TaskTypeRecurring
+	seriesID := "series-1"
+	previousID := "task-0"
+	task.SeriesID = &seriesID
+	task.ParentTaskID = &previousID
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(task, nil)
+	mockTaskRepo.On("Convert", mock.Anything, mock.MatchedBy(func(conv *domain.TaskConversion) bool {
+		return conv.Task.TaskType == domain.TaskTypeRegular &&
+			conv.Task.SeriesID == nil && conv.Task.ParentTaskID == nil &&
+			conv.DeactivateSeriesID != nil && *conv.DeactivateSeriesID == "series-1"
+	})).Return(nil)
+	expectConversionHistory(mockHistoryRepo)
+
+	_, err := service.Convert(context.Background(), "user-123", "task-1", &domain.ConvertTaskRequest{To: domain.TaskTypeRegular})
+
+	require.NoError(t, err)
+	mockTaskRepo.AssertExpectations(t)
+}
+
+func TestConvertService_Convert_AlreadyTargetType(t *testing.T) {
+	service, mockTaskRepo, _, _ := newConvertTestService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(createRegularTestTask("user-123", "task-1"), nil)
+
+	_, err := service.Convert(context.Background(), "user-123", "task-1", &domain.ConvertTaskRequest{To: domain.TaskTypeRegular})
+
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "to", validationErr.Field)
+}
+
+func TestConvertService_Convert_OtherUsersTask(t *testing.T) {
+	service, mockTaskRepo, _, _ := newConvertTestService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(createRegularTestTask("user-456", "task-1"), nil)
+
+	_, err := service.Convert(context.Background(), "user-123", "task-1", &domain.ConvertTaskRequest{To: domain.TaskTypeSubtask})
+
+	var forbiddenErr *domain.ForbiddenError
+	require.ErrorAs(t, err, &forbiddenErr)
+}
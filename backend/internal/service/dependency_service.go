@@ -172,6 +172,17 @@ func (s *DependencyService) ValidateCompletion(ctx context.Context, taskID strin
 	return nil
 }
 
+// OrderForCompletion orders task IDs so that blockers come before the tasks they block,
+// letting a batch complete a blocker and its dependents in one pass
+func (s *DependencyService) OrderForCompletion(ctx context.Context, userID string, taskIDs []string) ([]string, error) {
+	dependencyGraph, err := s.dependencyRepo.GetDependencyGraph(ctx, userID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to get dependency graph", err)
+	}
+
+	return graph.DependencyOrder(taskIDs, dependencyGraph), nil
+}
+
 // GetBlockerCompletionInfo returns info about tasks unblocked when a blocker completes
 func (s *DependencyService) GetBlockerCompletionInfo(ctx context.Context, blockerTaskID string) (*domain.BlockerCompletionInfo, error) {
 	// Get tasks that were blocked by this task
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/domain/priority"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+	"github.com/notkevinvu/taskflow/backend/internal/validation"
+)
+
+// DuplicateService copies tasks, optionally with their subtasks, blockers and a new recurrence
+type DuplicateService struct {
+	taskRepo        ports.TaskRepository
+	taskHistoryRepo ports.TaskHistoryRepository
+	dependencyRepo  ports.DependencyRepository
+	priorityCalc    *priority.Calculator
+}
+
+// NewDuplicateService creates a new duplicate service
+func NewDuplicateService(taskRepo ports.TaskRepository, taskHistoryRepo ports.TaskHistoryRepository, dependencyRepo ports.DependencyRepository) *DuplicateService {
+	return &DuplicateService{
+		taskRepo:        taskRepo,
+		taskHistoryRepo: taskHistoryRepo,
+		dependencyRepo:  dependencyRepo,
+		priorityCalc:    priority.NewCalculator(),
+	}
+}
+
+// Duplicate creates a copy of a task as a new todo task. A subtask is copied as a new
+// subtask of the same parent. Everything the copy needs is written in one transaction.
+func (s *DuplicateService) Duplicate(ctx context.Context, userID, taskID string, req *domain.DuplicateTaskRequest) (*domain.TaskWithSubtasks, error) {
+	source, err := s.taskRepo.FindByID(ctx, taskID)
+	if errors.Is(err, domain.ErrTaskNotFound) {
+		return nil, domain.NewNotFoundError("task", taskID)
+	}
+	if err != nil {
+		return nil, domain.NewInternalError("failed to find task", err)
+	}
+	if source.UserID != userID {
+		return nil, domain.NewForbiddenError("task", "access")
+	}
+
+	title := source.Title
+	if req.Title != nil {
+		if title, err = validation.ValidateRequiredText(*req.Title, 200, "title"); err != nil {
+			return nil, err
+		}
+	}
+
+	isSubtask := source.TaskType == domain.TaskTypeSubtask
+	if req.Recurrence != nil {
+		if isSubtask {
+			return nil, domain.NewValidationError("recurrence", "subtasks cannot recur")
+		}
+		// Only regular tasks can have subtasks
+		if req.IncludeSubtasks {
+			return nil, domain.NewValidationError("recurrence", "a recurring task cannot have subtasks; drop include_subtasks or recurrence")
+		}
+		if err := req.Recurrence.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Only regular tasks can have dependencies, so the copy must stay regular to keep them
+	if req.IncludeDependencies && (isSubtask || req.Recurrence != nil) {
+		return nil, domain.ErrInvalidDependencyType
+	}
+
+	now := time.Now()
+	shiftDays := 0
+	if req.ShiftDates {
+		shiftDays = domain.DaysBetween(source.CreatedAt, now)
+	}
+
+	copyTask := s.copyOf(source, title, shiftDays, now)
+	dup := &domain.TaskDuplicate{Task: copyTask}
+
+	// A copied subtask keeps its parent, so its priority gets the same parent boost
+	if isSubtask && source.ParentTaskID != nil {
+		parent, err := s.taskRepo.FindByID(ctx, *source.ParentTaskID)
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			return nil, domain.ErrParentNotFound
+		}
+		if err != nil {
+			return nil, domain.NewInternalError("failed to find parent task", err)
+		}
+		if !parent.CanHaveSubtasks() {
+			return nil, domain.ErrCannotCreateSubtask
+		}
+		copyTask.ParentTaskID = source.ParentTaskID
+		copyTask.TaskType = domain.TaskTypeSubtask
+		copyTask.PriorityScore = s.priorityCalc.CalculateForSubtask(copyTask, parent.PriorityScore)
+	} else {
+		copyTask.PriorityScore = s.priorityCalc.Calculate(copyTask)
+	}
+
+	if req.IncludeSubtasks && source.CanHaveSubtasks() {
+		descendants, err := s.taskRepo.GetSubtree(ctx, source.ID)
+		if err != nil {
+			return nil, domain.NewInternalError("failed to retrieve subtasks", err)
+		}
+		// Parents come before their subtasks, so each copy's parent is already copied
+		copies := map[string]*domain.Task{source.ID: copyTask}
+		for _, subtask := range descendants {
+			parentCopy := copies[*subtask.ParentTaskID]
+			subtaskCopy := s.copyOf(subtask, subtask.Title, shiftDays, now)
+			subtaskCopy.TaskType = domain.TaskTypeSubtask
+			// Copies keep their order under the new parent
+			subtaskCopy.Rank = subtask.Rank
+			subtaskCopy.ParentTaskID = &parentCopy.ID
+			// Subtasks inherit the parent's category, as in SubtaskService.Create
+			subtaskCopy.Category = parentCopy.Category
+			subtaskCopy.PriorityScore = s.priorityCalc.CalculateForSubtask(subtaskCopy, parentCopy.PriorityScore)
+			copies[subtask.ID] = subtaskCopy
+			dup.Subtasks = append(dup.Subtasks, subtaskCopy)
+		}
+	}
+
+	// The copy waits on the same blockers as the source
+	if req.IncludeDependencies {
+		blockers, err := s.dependencyRepo.GetBlockers(ctx, source.ID)
+		if err != nil {
+			return nil, domain.NewInternalError("failed to retrieve blockers", err)
+		}
+		for _, blocker := range blockers {
+			dup.Dependencies = append(dup.Dependencies, domain.TaskDependency{
+				TaskID:      copyTask.ID,
+				BlockedByID: blocker.TaskID,
+				CreatedAt:   now,
+			})
+		}
+	}
+
+	if req.Recurrence != nil {
+		copyTask.TaskType = domain.TaskTypeRecurring
+		dup.Series = &domain.TaskSeries{
+			ID:                 uuid.New().String(),
+			UserID:             userID,
+			OriginalTaskID:     copyTask.ID,
+			Pattern:            req.Recurrence.Pattern,
+			IntervalValue:      req.Recurrence.IntervalValue,
+			EndDate:            req.Recurrence.EndDate,
+			DueDateCalculation: req.Recurrence.DueDateCalculation,
+			IsActive:           true,
+			CreatedAt:          now,
+			UpdatedAt:          now,
+		}
+	}
+
+	if err := s.taskRepo.CreateDuplicate(ctx, dup); err != nil {
+		return nil, domain.NewInternalError("failed to duplicate task", err)
+	}
+
+	result, err := s.load(ctx, copyTask.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logCreated(ctx, userID, result.Task)
+	for _, subtask := range dup.Subtasks {
+		s.logCreated(ctx, userID, subtask)
+	}
+
+	return result, nil
+}
+
+// copyOf returns a fresh todo copy of task, with its dates moved by shiftDays
+func (s *DuplicateService) copyOf(task *domain.Task, title string, shiftDays int, now time.Time) *domain.Task {
+	return &domain.Task{
+		ID:              uuid.New().String(),
+		UserID:          task.UserID,
+		Title:           title,
+		Description:     task.Description,
+		Status:          domain.TaskStatusTodo,
+		TaskType:        domain.TaskTypeRegular,
+		UserPriority:    task.UserPriority,
+		DueDate:         shiftDate(task.DueDate, shiftDays),
+		EstimatedEffort: task.EstimatedEffort,
+		Category:        task.Category,
+		Context:         task.Context,
+		RelatedPeople:   append([]string{}, task.RelatedPeople...),
+		Tags:            append([]string{}, task.Tags...),
+		DeferUntil:      shiftDate(task.DeferUntil, shiftDays),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+}
+
+func shiftDate(date *time.Time, days int) *time.Time {
+	if date == nil {
+		return nil
+	}
+	shifted := date.AddDate(0, 0, days)
+	return &shifted
+}
+
+// load reads the new copy back with its subtasks
+func (s *DuplicateService) load(ctx context.Context, taskID string) (*domain.TaskWithSubtasks, error) {
+	task, err := s.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to fetch duplicated task", err)
+	}
+
+	result := &domain.TaskWithSubtasks{Task: task}
+	if task.CanHaveSubtasks() {
+		info, err := s.taskRepo.GetSubtaskInfo(ctx, taskID)
+		if err != nil {
+			return nil, domain.NewInternalError("failed to retrieve subtask info", err)
+		}
+		result.SubtaskInfo = info
+		if info.TotalCount > 0 {
+			subtasks, err := s.taskRepo.GetSubtasks(ctx, taskID)
+			if err != nil {
+				return nil, domain.NewInternalError("failed to retrieve subtasks", err)
+			}
+			result.Subtasks = subtasks
+		}
+	}
+
+	return result, nil
+}
+
+// logCreated records a created event for a copy so it can be undone like any new task
+func (s *DuplicateService) logCreated(ctx context.Context, userID string, task *domain.Task) {
+	data, _ := json.Marshal(task)
+	newValue := string(data)
+	if err := s.taskHistoryRepo.Create(ctx, &domain.TaskHistory{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		TaskID:    task.ID,
+		EventType: domain.EventTaskCreated,
+		NewValue:  &newValue,
+		CreatedAt: time.Now(),
+	}); err != nil {
+		slog.Warn("Failed to log duplicated task history",
+			"user_id", userID, "task_id", task.ID, "error", err)
+	}
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newDuplicateTestService() (*DuplicateService, *MockTaskRepository, *MockTaskHistoryRepository, *MockDependencyRepository) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	mockDependencyRepo := new(MockDependencyRepository)
+	return NewDuplicateService(mockTaskRepo, mockHistoryRepo, mockDependencyRepo), mockTaskRepo, mockHistoryRepo, mockDependencyRepo
+}
+
+func createTestSubtask(userID, taskID, parentID string) *domain.Task {
+	subtask := createTestTask(userID, taskID)
+	subtask.TaskType = domain.TaskTypeSubtask
+	subtask.ParentTaskID = &parentID
+	return subtask
+}
+
+// =============================================================================
+// DuplicateService.Duplicate Tests
+// =============================================================================
+
+func TestDuplicateService_Duplicate_CopiesSubtasksAndDependencies(t *testing.T) {
+	service, mockTaskRepo, mockHistoryRepo, mockDependencyRepo := newDuplicateTestService()
+
+	source := createTestTask("user-123", "task-1")
+	source.TaskType = domain.TaskTypeRegular
+	source.Status = domain.TaskStatusDone
+	source.BumpCount = 3
+	source.Tags = []string{"work"}
+	dueDate := source.CreatedAt.Add(48 * time.Hour)
+	source.DueDate = &dueDate
+	subtask := createTestSubtask("user-123", "sub-1", "task-1")
+	nested := createTestSubtask("user-123", "sub-2", "sub-1")
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(source, nil)
+	mockTaskRepo.On("GetSubtree", mock.Anything, "task-1").Return([]*domain.Task{subtask, nested}, nil)
+	mockDependencyRepo.On("GetBlockers", mock.Anything, "task-1").
+		Return([]*domain.DependencyWithTask{{TaskID: "blocker-1"}}, nil)
+
+	var written *domain.TaskDuplicate
+	mockTaskRepo.On("CreateDuplicate", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { written = args.Get(1).(*domain.TaskDuplicate) }).
+		Return(nil)
+
+	reloaded := createTestTask("user-123", "copy-1")
+	reloaded.TaskType = domain.TaskTypeRegular
+	reloadedSubtask := createTestSubtask("user-123", "copy-sub-1", "copy-1")
+	mockTaskRepo.On("FindByID", mock.Anything, mock.Anything).Return(reloaded, nil)
+	mockTaskRepo.On("GetSubtaskInfo", mock.Anything, mock.Anything).Return(&domain.SubtaskInfo{TotalCount: 1}, nil)
+	mockTaskRepo.On("GetSubtasks", mock.Anything, mock.Anything).Return([]*domain.Task{reloadedSubtask}, nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+		return h.EventType == domain.EventTaskCreated && h.OldValue == nil && h.NewValue != nil
+	})).Return(nil).Times(3)
+
+	result, err := service.Duplicate(context.Background(), "user-123", "task-1", &domain.DuplicateTaskRequest{
+		IncludeSubtasks:     true,
+		IncludeDependencies: true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, reloaded, result.Task)
+	assert.Len(t, result.Subtasks, 1)
+
+	require.NotNil(t, written)
+	copyTask := written.Task
+	assert.NotEqual(t, "task-1", copyTask.ID)
+	assert.Equal(t, "Test Task", copyTask.Title)
+	assert.Equal(t, domain.TaskStatusTodo, copyTask.Status)
+	assert.Zero(t, copyTask.BumpCount)
+	assert.Equal(t, []string{"work"}, copyTask.Tags)
+	assert.True(t, copyTask.DueDate.Equal(dueDate), "dates are kept unless shift_dates is set")
+	assert.Nil(t, written.Series)
+
+	require.Len(t, written.Subtasks, 2)
+	subtaskCopy := written.Subtasks[0]
+	require.NotNil(t, subtaskCopy.ParentTaskID)
+	assert.Equal(t, copyTask.ID, *subtaskCopy.ParentTaskID)
+	// Nested subtasks hang under the copy of their own parent
+	require.NotNil(t, written.Subtasks[1].ParentTaskID)
+	assert.Equal(t, subtaskCopy.ID, *written.Subtasks[1].ParentTaskID)
+
+	require.Len(t, written.Dependencies, 1)
+	assert.Equal(t, copyTask.ID, written.Dependencies[0].TaskID)
+	assert.Equal(t, "blocker-1", written.Dependencies[0].BlockedByID)
+	mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestDuplicateService_Duplicate_ShiftDatesAndRecurrence(t *testing.T) {
+	service, mockTaskRepo, mockHistoryRepo, _ := newDuplicateTestService()
+
+	source := createTestTask("user-123", "task-1")
+	source.TaskType = domain.TaskTypeRegular
+	source.CreatedAt = time.Now().AddDate(0, 0, -10)
+	dueDate := source.CreatedAt.AddDate(0, 0, 3)
+	source.DueDate = &dueDate
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(source, nil)
+	var written *domain.TaskDuplicate
+	mockTaskRepo.On("CreateDuplicate", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { written = args.Get(1).(*domain.TaskDuplicate) }).
+		Return(nil)
+	reloaded := createTestTask("user-123", "copy-1")
+	reloaded.TaskType = domain.TaskTypeRecurring
+	mockTaskRepo.On("FindByID", mock.Anything, mock.Anything).Return(reloaded, nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	title := "  Weekly review  "
+	_, err := service.Duplicate(context.Background(), "user-123", "task-1", &domain.DuplicateTaskRequest{
+		Title:      &title,
+		ShiftDates: true,
+		Recurrence: &domain.RecurrenceRule{
+			Pattern:            domain.RecurrencePatternWeekly,
+			IntervalValue:      1,
+			DueDateCalculation: domain.DueDateFromOriginal,
+		},
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, written)
+	assert.Equal(t, "Weekly review", written.Task.Title)
+	assert.Equal(t, domain.TaskTypeRecurring, written.Task.TaskType)
+	assert.True(t, written.Task.DueDate.Equal(dueDate.AddDate(0, 0, 10)))
+	require.NotNil(t, written.Series)
+	assert.Equal(t, written.Task.ID, written.Series.OriginalTaskID)
+	assert.True(t, written.Series.IsActive)
+	mockTaskRepo.AssertNotCalled(t, "GetSubtaskInfo", mock.Anything, mock.Anything)
+}
+
+func TestDuplicateService_Duplicate_SubtaskStaysUnderParent(t *testing.T) {
+	service, mockTaskRepo, mockHistoryRepo, _ := newDuplicateTestService()
+
+	parent := createTestTask("user-123", "parent-1")
+	parent.TaskType = domain.TaskTypeRegular
+	parent.PriorityScore = 80
+	source := createTestSubtask("user-123", "sub-1", "parent-1")
+
+	mockTaskRepo.On("FindByID", mock.Anything, "sub-1").Return(source, nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "parent-1").Return(parent, nil)
+	var written *domain.TaskDuplicate
+	mockTaskRepo.On("CreateDuplicate", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { written = args.Get(1).(*domain.TaskDuplicate) }).
+		Return(nil)
+	mockTaskRepo.On("FindByID", mock.Anything, mock.Anything).Return(createTestSubtask("user-123", "copy-1", "parent-1"), nil)
+	mockTaskRepo.On("GetSubtaskInfo", mock.Anything, mock.Anything).Return(&domain.SubtaskInfo{}, nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	result, err := service.Duplicate(context.Background(), "user-123", "sub-1", &domain.DuplicateTaskRequest{})
+
+	require.NoError(t, err)
+	require.NotNil(t, result.SubtaskInfo)
+	assert.Zero(t, result.SubtaskInfo.TotalCount)
+	require.NotNil(t, written.Task.ParentTaskID)
+	assert.Equal(t, "parent-1", *written.Task.ParentTaskID)
+	assert.Equal(t, domain.TaskTypeSubtask, written.Task.TaskType)
+}
+
+func TestDuplicateService_Duplicate_RejectsRecurringTaskWithSubtasks(t *testing.T) {
+	service, mockTaskRepo, _, _ := newDuplicateTestService()
+
+	source := createTestTask("user-123", "task-1")
+	source.TaskType = domain.TaskTypeRegular
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(source, nil)
+
+	_, err := service.Duplicate(context.Background(), "user-123", "task-1", &domain.DuplicateTaskRequest{
+		IncludeSubtasks: true,
+		Recurrence: &domain.RecurrenceRule{
+			Pattern:            domain.RecurrencePatternDaily,
+			IntervalValue:      1,
+			DueDateCalculation: domain.DueDateFromOriginal,
+		},
+	})
+
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "recurrence", validationErr.Field)
+	mockTaskRepo.AssertNotCalled(t, "CreateDuplicate", mock.Anything, mock.Anything)
+}
+
+func TestDuplicateService_Duplicate_RejectsDependenciesOnSubtaskCopy(t *testing.T) {
+	service, mockTaskRepo, _, mockDependencyRepo := newDuplicateTestService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "sub-1").Return(createTestSubtask("user-123", "sub-1", "parent-1"), nil)
+
+	_, err := service.Duplicate(context.Background(), "user-123", "sub-1", &domain.DuplicateTaskRequest{IncludeDependencies: true})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidDependencyType)
+	mockDependencyRepo.AssertNotCalled(t, "GetBlockers", mock.Anything, mock.Anything)
+}
+
+func TestDuplicateService_Duplicate_OtherUsersTask(t *testing.T) {
+	service, mockTaskRepo, _, _ := newDuplicateTestService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(createTestTask("user-456", "task-1"), nil)
+
+	_, err := service.Duplicate(context.Background(), "user-123", "task-1", &domain.DuplicateTaskRequest{})
+
+	var forbiddenErr *domain.ForbiddenError
+	require.ErrorAs(t, err, &forbiddenErr)
+	mockTaskRepo.AssertNotCalled(t, "CreateDuplicate", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+	"github.com/notkevinvu/taskflow/backend/internal/validation"
+)
+
+// FocusSessionService handles focus session (Pomodoro) business logic
+type FocusSessionService struct {
+	focusRepo           ports.FocusSessionRepository
+	prefsRepo           ports.UserPreferencesRepository
+	taskService         ports.TaskService
+	gamificationService ports.GamificationService // Optional: for the focus achievement
+	now                 func() time.Time
+}
+
+// NewFocusSessionService creates a new focus session service
+func NewFocusSessionService(
+	focusRepo ports.FocusSessionRepository,
+	prefsRepo ports.UserPreferencesRepository,
+	taskService ports.TaskService,
+) *FocusSessionService {
+	return &FocusSessionService{
+		focusRepo:   focusRepo,
+		prefsRepo:   prefsRepo,
+		taskService: taskService,
+		now:         time.Now,
+	}
+}
+
+// SetGamificationService sets the optional gamification service so completed sessions can earn the focus achievement
+func (s *FocusSessionService) SetGamificationService(gamificationService ports.GamificationService) {
+	s.gamificationService = gamificationService
+}
+
+// GetSettings retrieves the user's focus settings, or the defaults if never changed
+func (s *FocusSessionService) GetSettings(ctx context.Context, userID string) (*domain.FocusSettings, error) {
+	settings, err := s.prefsRepo.GetFocusSettings(ctx, userID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to get focus settings", err)
+	}
+	return settings, nil
+}
+
+// UpdateSettings changes the settings present in the DTO
+func (s *FocusSessionService) UpdateSettings(ctx context.Context, userID string, dto *domain.UpdateFocusSettingsDTO) (*domain.FocusSettings, error) {
+	settings, err := s.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dto.Apply(settings)
+
+	if err := s.prefsRepo.UpsertFocusSettings(ctx, userID, settings); err != nil {
+		return nil, domain.NewInternalError("failed to update focus settings", err)
+	}
+
+	return settings, nil
+}
+
+// Start starts a focus session on a task the user owns.
+// Fails with ErrFocusSessionActive if the user already has a running or paused session.
+func (s *FocusSessionService) Start(ctx context.Context, userID, taskID string, dto *domain.StartFocusSessionDTO) (*domain.FocusSession, error) {
+	if _, err := s.taskService.Get(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	plannedMinutes := 0
+	if dto.PlannedMinutes != nil {
+		plannedMinutes = *dto.PlannedMinutes
+	} else {
+		settings, err := s.GetSettings(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		plannedMinutes = settings.WorkMinutes
+	}
+
+	now := s.now()
+	session := &domain.FocusSession{
+		ID:             uuid.New().String(),
+		TaskID:         taskID,
+		UserID:         userID,
+		Status:         domain.FocusSessionRunning,
+		PlannedMinutes: plannedMinutes,
+		StartedAt:      now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	// The database allows one active session per user, so concurrent starts cannot both win
+	if err := s.focusRepo.Create(ctx, session); err != nil {
+		if err == domain.ErrFocusSessionActive {
+			return nil, err
+		}
+		return nil, domain.NewInternalError("failed to start focus session", err)
+	}
+
+	return session, nil
+}
+
+// GetActive returns the user's running or paused session, or nil if there is none
+func (s *FocusSessionService) GetActive(ctx context.Context, userID string) (*domain.FocusSession, error) {
+	session, err := s.focusRepo.FindActiveByUserID(ctx, userID)
+	if err != nil {
+		if err == domain.ErrFocusSessionNotFound {
+			return nil, nil
+		}
+		return nil, domain.NewInternalError("failed to find active focus session", err)
+	}
+
+	session.FocusedSeconds = session.ElapsedFocusSeconds(s.now())
+	return session, nil
+}
+
+// Pause pauses a running session
+func (s *FocusSessionService) Pause(ctx context.Context, userID, sessionID string) (*domain.FocusSession, error) {
+	return s.change(ctx, userID, sessionID, func(session *domain.FocusSession, now time.Time) error {
+		return session.Pause(now)
+	})
+}
+
+// Resume resumes a paused session
+func (s *FocusSessionService) Resume(ctx context.Context, userID, sessionID string) (*domain.FocusSession, error) {
+	return s.change(ctx, userID, sessionID, func(session *domain.FocusSession, now time.Time) error {
+		return session.Resume(now)
+	})
+}
+
+// Interrupt abandons an active session, recording why
+func (s *FocusSessionService) Interrupt(ctx context.Context, userID, sessionID string, dto *domain.InterruptFocusSessionDTO) (*domain.FocusSession, error) {
+	reason, err := validation.ValidateRequiredText(dto.Reason, domain.MaxFocusInterruptReasonLength, "reason")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.change(ctx, userID, sessionID, func(session *domain.FocusSession, now time.Time) error {
+		return session.Interrupt(now, reason)
+	})
+}
+
+// Complete ends an active session as completed. The response suggests the next break
+// and carries the focus achievement if this session earned it.
+func (s *FocusSessionService) Complete(ctx context.Context, userID, sessionID string) (*domain.FocusSessionCompletion, error) {
+	session, err := s.change(ctx, userID, sessionID, func(session *domain.FocusSession, now time.Time) error {
+		return session.Complete(now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The session is already saved, so a failed count only costs the long-break suggestion
+	completedToday, err := s.focusRepo.CountCompletedToday(ctx, userID)
+	if err != nil {
+		slog.Warn("Failed to count today's focus sessions",
+			"user_id", userID, "error", err)
+		completedToday = 0
+	}
+
+	completion := &domain.FocusSessionCompletion{
+		Session:        session,
+		CompletedToday: completedToday,
+		NextBreak:      domain.NextFocusBreak(*settings, completedToday),
+	}
+
+	if settings.CountTowardAchievements && s.gamificationService != nil {
+		completion.NewAchievement = s.awardFocusAchievement(ctx, userID)
+	}
+
+	return completion, nil
+}
+
+// awardFocusAchievement checks the focus achievement; failures are logged, not returned
+func (s *FocusSessionService) awardFocusAchievement(ctx context.Context, userID string) *domain.AchievementEarnedEvent {
+	total, err := s.focusRepo.CountCompletedByUserID(ctx, userID)
+	if err != nil {
+		slog.Warn("Failed to count focus sessions for achievement check",
+			"user_id", userID, "error", err)
+		return nil
+	}
+	return s.gamificationService.ProcessFocusSessionCompletion(ctx, userID, total)
+}
+
+// ListForTask retrieves a task's focus sessions, most recent first, with session counts
+func (s *FocusSessionService) ListForTask(ctx context.Context, userID, taskID string) (*domain.FocusSessionListResponse, error) {
+	if _, err := s.taskService.Get(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.focusRepo.FindByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to list focus sessions", err)
+	}
+
+	now := s.now()
+	response := &domain.FocusSessionListResponse{Sessions: sessions}
+	for _, session := range sessions {
+		switch session.Status {
+		case domain.FocusSessionCompleted:
+			response.CompletedCount++
+			response.FocusedSeconds += session.FocusedSeconds
+		case domain.FocusSessionInterrupted:
+			response.InterruptedCount++
+			response.FocusedSeconds += session.FocusedSeconds
+		default:
+			session.FocusedSeconds = session.ElapsedFocusSeconds(now)
+		}
+	}
+
+	return response, nil
+}
+
+// change loads the user's session, applies a state change and saves it.
+// A change that raced with another one fails with ErrInvalidFocusSessionChange.
+func (s *FocusSessionService) change(
+	ctx context.Context,
+	userID, sessionID string,
+	apply func(session *domain.FocusSession, now time.Time) error,
+) (*domain.FocusSession, error) {
+	session, err := s.focusRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		if err == domain.ErrFocusSessionNotFound {
+			return nil, err
+		}
+		return nil, domain.NewInternalError("failed to find focus session", err)
+	}
+	if session.UserID != userID {
+		return nil, domain.NewForbiddenError("focus session", "access")
+	}
+
+	now := s.now()
+	fromStatus := session.Status
+	if err := apply(session, now); err != nil {
+		return nil, err
+	}
+
+	if err := s.focusRepo.Update(ctx, session, fromStatus); err != nil {
+		if err == domain.ErrInvalidFocusSessionChange {
+			return nil, err
+		}
+		return nil, domain.NewInternalError("failed to update focus session", err)
+	}
+
+	if session.IsActive() {
+		session.FocusedSeconds = session.ElapsedFocusSeconds(now)
+	}
+	return session, nil
+}
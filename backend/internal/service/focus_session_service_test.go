@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+var testFocusNow = time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC)
+
+type focusSessionTestDeps struct {
+	focusRepo *MockFocusSessionRepository
+	prefsRepo *MockUserPreferencesRepository
+	taskRepo  *MockTaskRepository
+}
+
+func newFocusSessionService() (*FocusSessionService, focusSessionTestDeps) {
+	deps := focusSessionTestDeps{
+		focusRepo: new(MockFocusSessionRepository),
+		prefsRepo: new(MockUserPreferencesRepository),
+		taskRepo:  new(MockTaskRepository),
+	}
+	taskService := NewTaskService(deps.taskRepo, new(MockTaskHistoryRepository))
+	service := NewFocusSessionService(deps.focusRepo, deps.prefsRepo, taskService)
+	service.now = func() time.Time { return testFocusNow }
+	return service, deps
+}
+
+func createRunningFocusSession(userID, sessionID string, startedAgo time.Duration) *domain.FocusSession {
+	return &domain.FocusSession{
+		ID:             sessionID,
+		TaskID:         "task-456",
+		UserID:         userID,
+		Status:         domain.FocusSessionRunning,
+		PlannedMinutes: 25,
+		StartedAt:      testFocusNow.Add(-startedAgo),
+	}
+}
+
+func defaultFocusSettings() *domain.FocusSettings {
+	settings := domain.DefaultFocusSettings()
+	return &settings
+}
+
+// =============================================================================
+// FocusSessionService Settings Tests
+// =============================================================================
+
+func TestFocusSessionService_UpdateSettings_MergesIntoCurrent(t *testing.T) {
+	service, deps := newFocusSessionService()
+
+	current := defaultFocusSettings()
+	current.LongBreakMinutes = 20
+	longBreak := 30
+	deps.prefsRepo.On("GetFocusSettings", mock.Anything, "user-123").Return(current, nil)
+	deps.prefsRepo.On("UpsertFocusSettings", mock.Anything, "user-123", mock.MatchedBy(func(s *domain.FocusSettings) bool {
+		return s.LongBreakMinutes == 30 && s.WorkMinutes == 25
+	})).Return(nil)
+
+	settings, err := service.UpdateSettings(context.Background(), "user-123", &domain.UpdateFocusSettingsDTO{LongBreakMinutes: &longBreak})
+
+	require.NoError(t, err)
+	assert.Equal(t, 30, settings.LongBreakMinutes)
+	deps.prefsRepo.AssertExpectations(t)
+}
+
+// =============================================================================
+// FocusSessionService.Start Tests
+// =============================================================================
+
+func TestFocusSessionService_Start_UsesWorkMinutesSetting(t *testing.T) {
+	service, deps := newFocusSessionService()
+
+	settings := defaultFocusSettings()
+	settings.WorkMinutes = 50
+	deps.taskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	deps.prefsRepo.On("GetFocusSettings", mock.Anything, "user-123").Return(settings, nil)
+	deps.focusRepo.On("Create", mock.Anything, mock.MatchedBy(func(s *domain.FocusSession) bool {
+		return s.PlannedMinutes == 50 && s.Status == domain.FocusSessionRunning && s.StartedAt.Equal(testFocusNow)
+	})).Return(nil)
+
+	session, err := service.Start(context.Background(), "user-123", "task-456", &domain.StartFocusSessionDTO{})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, session.ID)
+	deps.focusRepo.AssertExpectations(t)
+}
+
+func TestFocusSessionService_Start_PlannedMinutesOverride(t *testing.T) {
+	service, deps := newFocusSessionService()
+
+	minutes := 15
+	deps.taskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	deps.focusRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.FocusSession")).Return(nil)
+
+	session, err := service.Start(context.Background(), "user-123", "task-456", &domain.StartFocusSessionDTO{PlannedMinutes: &minutes})
+
+	require.NoError(t, err)
+	assert.Equal(t, 15, session.PlannedMinutes)
+	deps.prefsRepo.AssertNotCalled(t, "GetFocusSettings", mock.Anything, mock.Anything)
+}
+
+func TestFocusSessionService_Start_AlreadyActive(t *testing.T) {
+	service, deps := newFocusSessionService()
+
+	minutes := 25
+	deps.taskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	deps.focusRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.FocusSession")).Return(domain.ErrFocusSessionActive)
+
+	session, err := service.Start(context.Background(), "user-123", "task-456", &domain.StartFocusSessionDTO{PlannedMinutes: &minutes})
+
+	assert.Nil(t, session)
+	assert.ErrorIs(t, err, domain.ErrFocusSessionActive)
+}
+
+// =============================================================================
+// FocusSessionService State Change Tests
+// =============================================================================
+
+func TestFocusSessionService_Pause_Success(t *testing.T) {
+	service, deps := newFocusSessionService()
+
+	deps.focusRepo.On("FindByID", mock.Anything, "session-1").Return(createRunningFocusSession("user-123", "session-1", 10*time.Minute), nil)
+	deps.focusRepo.On("Update", mock.Anything, mock.MatchedBy(func(s *domain.FocusSession) bool {
+		return s.Status == domain.FocusSessionPaused && s.PausedAt != nil
+	}), domain.FocusSessionRunning).Return(nil)
+
+	session, err := service.Pause(context.Background(), "user-123", "session-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.FocusSessionPaused, session.Status)
+	assert.Equal(t, 10*60, session.FocusedSeconds)
+}
+
+func TestFocusSessionService_Resume_NotPaused(t *testing.T) {
+	service, deps := newFocusSessionService()
+
+	deps.focusRepo.On("FindByID", mock.Anything, "session-1").Return(createRunningFocusSession("user-123", "session-1", 10*time.Minute), nil)
+
+	session, err := service.Resume(context.Background(), "user-123", "session-1")
+
+	assert.Nil(t, session)
+	assert.ErrorIs(t, err, domain.ErrInvalidFocusSessionChange)
+	deps.focusRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFocusSessionService_Pause_OtherUsersSession(t *testing.T) {
+	service, deps := newFocusSessionService()
+
+	deps.focusRepo.On("FindByID", mock.Anything, "session-1").Return(createRunningFocusSession("user-456", "session-1", time.Minute), nil)
+
+	_, err := service.Pause(context.Background(), "user-123", "session-1")
+
+	var forbiddenErr *domain.ForbiddenError
+	assert.ErrorAs(t, err, &forbiddenErr)
+}
+
+func TestFocusSessionService_Pause_ConcurrentChange(t *testing.T) {
+	service, deps := newFocusSessionService()
+
+	deps.focusRepo.On("FindByID", mock.Anything, "session-1").Return(createRunningFocusSession("user-123", "session-1", time.Minute), nil)
+	deps.focusRepo.On("Update", mock.Anything, mock.Anything, domain.FocusSessionRunning).Return(domain.ErrInvalidFocusSessionChange)
+
+	_, err := service.Pause(context.Background(), "user-123", "session-1")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidFocusSessionChange)
+}
+
+func TestFocusSessionService_Interrupt_RequiresReason(t *testing.T) {
+	service, deps := newFocusSessionService()
+
+	_, err := service.Interrupt(context.Background(), "user-123", "session-1", &domain.InterruptFocusSessionDTO{Reason: "   "})
+
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "reason", validationErr.Field)
+	deps.focusRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func TestFocusSessionService_Interrupt_Success(t *testing.T) {
+	service, deps := newFocusSessionService()
+
+	deps.focusRepo.On("FindByID", mock.Anything, "session-1").Return(createRunningFocusSession("user-123", "session-1", 12*time.Minute), nil)
+	deps.focusRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.FocusSession"), domain.FocusSessionRunning).Return(nil)
+
+	session, err := service.Interrupt(context.Background(), "user-123", "session-1", &domain.InterruptFocusSessionDTO{Reason: "urgent call"})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.FocusSessionInterrupted, session.Status)
+	assert.Equal(t, 12*60, session.FocusedSeconds)
+	require.NotNil(t, session.InterruptReason)
+	assert.Equal(t, "urgent call", *session.InterruptReason)
+}
+
+// =============================================================================
+// FocusSessionService.Complete Tests
+// =============================================================================
+
+func TestFocusSessionService_Complete_SuggestsLongBreakAndAwardsAchievement(t *testing.T) {
+	service, deps := newFocusSessionService()
+	gamification := new(MockGamificationService)
+	service.SetGamificationService(gamification)
+
+	earned := &domain.AchievementEarnedEvent{
+		Achievement: &domain.UserAchievement{UserID: "user-123", AchievementType: domain.AchievementFocusMaster},
+	}
+	deps.focusRepo.On("FindByID", mock.Anything, "session-1").Return(createRunningFocusSession("user-123", "session-1", 25*time.Minute), nil)
+	deps.focusRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.FocusSession"), domain.FocusSessionRunning).Return(nil)
+	deps.prefsRepo.On("GetFocusSettings", mock.Anything, "user-123").Return(defaultFocusSettings(), nil)
+	deps.focusRepo.On("CountCompletedToday", mock.Anything, "user-123").Return(4, nil)
+	deps.focusRepo.On("CountCompletedByUserID", mock.Anything, "user-123").Return(25, nil)
+	gamification.On("ProcessFocusSessionCompletion", mock.Anything, "user-123", 25).Return(earned)
+
+	completion, err := service.Complete(context.Background(), "user-123", "session-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.FocusSessionCompleted, completion.Session.Status)
+	assert.Equal(t, 25*60, completion.Session.FocusedSeconds)
+	assert.Equal(t, 4, completion.CompletedToday)
+	assert.Equal(t, domain.FocusBreak{Type: domain.FocusBreakLong, Minutes: 15}, completion.NextBreak)
+	assert.Equal(t, earned, completion.NewAchievement)
+	gamification.AssertExpectations(t)
+}
+
+func TestFocusSessionService_Complete_OptedOutOfAchievements(t *testing.T) {
+	service, deps := newFocusSessionService()
+	gamification := new(MockGamificationService)
+	service.SetGamificationService(gamification)
+
+	settings := defaultFocusSettings()
+	settings.CountTowardAchievements = false
+	deps.focusRepo.On("FindByID", mock.Anything, "session-1").Return(createRunningFocusSession("user-123", "session-1", 25*time.Minute), nil)
+	deps.focusRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.FocusSession"), domain.FocusSessionRunning).Return(nil)
+	deps.prefsRepo.On("GetFocusSettings", mock.Anything, "user-123").Return(settings, nil)
+	deps.focusRepo.On("CountCompletedToday", mock.Anything, "user-123").Return(0, errors.New("db down"))
+
+	completion, err := service.Complete(context.Background(), "user-123", "session-1")
+
+	require.NoError(t, err, "a failed count does not undo the completion")
+	assert.Equal(t, domain.FocusBreakShort, completion.NextBreak.Type)
+	assert.Nil(t, completion.NewAchievement)
+	gamification.AssertNotCalled(t, "ProcessFocusSessionCompletion", mock.Anything, mock.Anything, mock.Anything)
+	deps.focusRepo.AssertNotCalled(t, "CountCompletedByUserID", mock.Anything, mock.Anything)
+}
+
+// =============================================================================
+// FocusSessionService Query Tests
+// =============================================================================
+
+func TestFocusSessionService_GetActive_None(t *testing.T) {
+	service, deps := newFocusSessionService()
+
+	deps.focusRepo.On("FindActiveByUserID", mock.Anything, "user-123").Return(nil, domain.ErrFocusSessionNotFound)
+
+	session, err := service.GetActive(context.Background(), "user-123")
+
+	require.NoError(t, err)
+	assert.Nil(t, session)
+}
+
+func TestFocusSessionService_ListForTask_Counts(t *testing.T) {
+	service, deps := newFocusSessionService()
+
+	ended := testFocusNow.Add(-time.Hour)
+	sessions := []*domain.FocusSession{
+		createRunningFocusSession("user-123", "session-active", 5*time.Minute),
+		{ID: "session-1", TaskID: "task-456", UserID: "user-123", Status: domain.FocusSessionCompleted, EndedAt: &ended, FocusedSeconds: 1500},
+		{ID: "session-2", TaskID: "task-456", UserID: "user-123", Status: domain.FocusSessionCompleted, EndedAt: &ended, FocusedSeconds: 1400},
+		{ID: "session-3", TaskID: "task-456", UserID: "user-123", Status: domain.FocusSessionInterrupted, EndedAt: &ended, FocusedSeconds: 300},
+	}
+	deps.taskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	deps.focusRepo.On("FindByTaskID", mock.Anything, "task-456").Return(sessions, nil)
+
+	response, err := service.ListForTask(context.Background(), "user-123", "task-456")
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, response.CompletedCount)
+	assert.Equal(t, 1, response.InterruptedCount)
+	assert.Equal(t, 3200, response.FocusedSeconds)
+	assert.Equal(t, 5*60, response.Sessions[0].FocusedSeconds, "active session shows time so far")
+}
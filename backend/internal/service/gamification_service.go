@@ -173,6 +173,78 @@ func (s *GamificationService) ProcessTaskCompletionAsync(userID string, task *do
 	}()
 }
 
+// ProcessBulkTaskCompletion processes gamification for a batch of completed tasks at once.
+// Category mastery is incremented per task, but stats are computed, achievements checked
+// and stats persisted once, so the result aggregates the whole batch.
+func (s *GamificationService) ProcessBulkTaskCompletion(
+	ctx context.Context,
+	userID string,
+	tasks []*domain.Task,
+) (*domain.TaskCompletionGamificationResult, error) {
+	// Get previous stats for streak comparison
+	previousStats, err := s.gamificationRepo.GetStats(ctx, userID)
+	if err != nil && !errors.Is(err, domain.ErrGamificationStatsNotFound) {
+		slog.Warn("Failed to get previous stats for streak comparison",
+			"user_id", userID, "error", err)
+	}
+	previousStreak := 0
+	if previousStats != nil {
+		previousStreak = previousStats.CurrentStreak
+	}
+
+	// Update category mastery for every task, remembering one task per category
+	// so category achievements are checked once per category
+	representatives := make([]*domain.Task, 0, len(tasks))
+	seenCategories := make(map[string]bool)
+	for _, task := range tasks {
+		category := ""
+		if task.Category != nil {
+			category = *task.Category
+		}
+		if category != "" {
+			if _, err := s.gamificationRepo.IncrementCategoryMastery(ctx, userID, category); err != nil {
+				slog.Warn("Failed to increment category mastery",
+					"user_id", userID, "category", category, "error", err)
+			}
+		}
+		if !seenCategories[category] {
+			seenCategories[category] = true
+			representatives = append(representatives, task)
+		}
+	}
+
+	// Compute updated stats
+	stats, err := s.ComputeStats(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for new achievements (awarding is idempotent, so repeated
+	// milestone checks across categories never double-award)
+	newAchievements := []*domain.AchievementEarnedEvent{}
+	for _, task := range representatives {
+		earned, err := s.CheckAndAwardAchievements(ctx, userID, task, stats)
+		if err != nil {
+			slog.Warn("Failed to check achievements", "user_id", userID, "error", err)
+			continue
+		}
+		newAchievements = append(newAchievements, earned...)
+	}
+
+	// Persist updated stats
+	if err := s.gamificationRepo.UpsertStats(ctx, stats); err != nil {
+		slog.Error("Failed to persist gamification stats - progress may be lost",
+			"user_id", userID, "error", err)
+	}
+
+	return &domain.TaskCompletionGamificationResult{
+		UpdatedStats:    stats,
+		NewAchievements: newAchievements,
+		StreakExtended:  stats.CurrentStreak > previousStreak,
+		PreviousStreak:  previousStreak,
+	}, nil
+}
+
 // ComputeStats calculates all gamification stats from scratch.
 // Uses parallel queries via errgroup for improved performance.
 func (s *GamificationService) ComputeStats(ctx context.Context, userID string) (*domain.GamificationStats, error) {
@@ -593,6 +665,19 @@ func (s *GamificationService) CheckAndAwardAchievements(
 	return newAchievements, nil
 }
 
+// ProcessFocusSessionCompletion awards the focus achievement once the user has
+// completed enough focus sessions. Returns nil if nothing new was earned.
+func (s *GamificationService) ProcessFocusSessionCompletion(
+	ctx context.Context,
+	userID string,
+	completedSessions int,
+) *domain.AchievementEarnedEvent {
+	if completedSessions < domain.FocusMasterThreshold {
+		return nil
+	}
+	return s.awardAchievementIfNew(ctx, userID, domain.AchievementFocusMaster, nil)
+}
+
 // awardAchievementIfNew creates achievement if not already earned
 func (s *GamificationService) awardAchievementIfNew(
 	ctx context.Context,
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/domain/priority"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+)
+
+// checkSubtaskPlacement verifies that a task can be placed under parent. taskID is the
+// task being moved ("" for a new one), which must not be the parent or one of its
+// ancestors; subtreeHeight is how many levels of subtasks it brings along (0 for none).
+// The deepest level must stay within maxDepth, counting the top-level task as 1.
+func checkSubtaskPlacement(ctx context.Context, taskRepo ports.TaskRepository, maxDepth int, parent *domain.Task, taskID string, subtreeHeight int) error {
+	if !parent.CanHaveSubtasks() {
+		return domain.ErrCannotCreateSubtask
+	}
+
+	ancestors, err := taskRepo.GetAncestorIDs(ctx, parent.ID)
+	if err != nil {
+		return domain.NewInternalError("failed to load parent hierarchy", err)
+	}
+	if taskID != "" && (parent.ID == taskID || slices.Contains(ancestors, taskID)) {
+		return domain.ErrSubtaskCycle
+	}
+
+	// The parent sits at level len(ancestors)+1, the task one below it
+	if len(ancestors)+2+subtreeHeight > maxDepth {
+		return domain.ErrSubtaskDepthExceeded
+	}
+
+	return nil
+}
+
+// propagatePriority passes a task's priority score down its subtree: every subtask is
+// rescored with CalculateForSubtask against its parent's new score, level by level
+func propagatePriority(ctx context.Context, taskRepo ports.TaskRepository, calc *priority.Calculator, root *domain.Task) error {
+	descendants, err := taskRepo.GetSubtree(ctx, root.ID)
+	if err != nil {
+		return err
+	}
+	if len(descendants) == 0 {
+		return nil
+	}
+
+	// Parents come before their subtasks, so each parent's score is known when needed
+	scores := map[string]int{root.ID: root.PriorityScore}
+	changed := make(map[string]int)
+	for _, task := range descendants {
+		score := calc.CalculateForSubtask(task, scores[*task.ParentTaskID])
+		scores[task.ID] = score
+		if score != task.PriorityScore {
+			changed[task.ID] = score
+		}
+	}
+
+	return taskRepo.UpdatePriorityScores(ctx, changed)
+}
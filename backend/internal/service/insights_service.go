@@ -17,7 +17,8 @@ import (
 
 // InsightsService provides smart suggestions based on user behavior patterns
 type InsightsService struct {
-	taskRepo ports.TaskRepository
+	taskRepo  ports.TaskRepository
+	prefsRepo ports.UserPreferencesRepository // Optional: for WIP limit warnings
 }
 
 // NewInsightsService creates a new insights service
@@ -25,8 +26,14 @@ func NewInsightsService(taskRepo ports.TaskRepository) *InsightsService {
 	return &InsightsService{taskRepo: taskRepo}
 }
 
+// SetUserPreferencesRepository sets the optional preferences repository so board columns
+// over their WIP limits are reported
+func (s *InsightsService) SetUserPreferencesRepository(prefsRepo ports.UserPreferencesRepository) {
+	s.prefsRepo = prefsRepo
+}
+
 // GetInsights generates all applicable insights for a user.
-// All 6 insight checks run in parallel for better performance.
+// All 7 insight checks run in parallel for better performance.
 func (s *InsightsService) GetInsights(ctx context.Context, userID string) (*domain.InsightResponse, error) {
 	var insights []domain.Insight
 	var mu sync.Mutex
@@ -42,6 +49,7 @@ func (s *InsightsService) GetInsights(ctx context.Context, userID string) (*doma
 		s.checkDeadlineClustering,
 		s.checkAtRiskTasks,
 		s.checkCategoryOverload,
+		s.checkWIPLimits,
 	}
 
 	// Launch all checks in parallel
@@ -292,7 +300,75 @@ func (s *InsightsService) checkCategoryOverload(ctx context.Context, userID stri
 	}
 }
 
-// EstimateCompletionTime estimates how long a task will take based on historical data
+// checkWIPLimits warns about board columns holding more tasks than their WIP limit,
+// which happens when tasks change status outside the board or a limit is lowered
+func (s *InsightsService) checkWIPLimits(ctx context.Context, userID string) *domain.Insight {
+	if s.prefsRepo == nil {
+		return nil
+	}
+	limits, err := s.prefsRepo.GetWIPLimits(ctx, userID)
+	if err != nil {
+		slog.Warn("Failed to get WIP limits for insight",
+			"user_id", userID, "error", err)
+		return nil
+	}
+
+	var over []map[string]interface{}
+	var names []string
+	for _, status := range limits.Statuses() {
+		limit := limits.Limit(status)
+		count, err := s.taskRepo.Count(ctx, userID, &domain.TaskListFilter{WorkflowStatus: &status})
+		if err != nil {
+			slog.Warn("Failed to count tasks for WIP limit insight",
+				"user_id", userID, "status", status, "error", err)
+			return nil
+		}
+		if count > *limit {
+			over = append(over, map[string]interface{}{
+				"status":     status,
+				"task_count": count,
+				"wip_limit":  *limit,
+			})
+			names = append(names, fmt.Sprintf("%s (%d/%d)", status, count, *limit))
+		}
+	}
+	if len(over) == 0 {
+		return nil
+	}
+
+	actionURL := "/board"
+	return &domain.Insight{
+		Type:      domain.InsightWIPLimitExceeded,
+		Title:     "WIP Limit Exceeded",
+		Message:   fmt.Sprintf("Too much in flight: %s. Finish or move tasks before starting new ones.", strings.Join(names, ", ")),
+		Priority:  domain.InsightPriorityHigh,
+		ActionURL: &actionURL,
+		Data: map[string]interface{}{
+			"columns": over,
+		},
+		GeneratedAt: time.Now(),
+	}
+}
+
+// minTrackedSamples is how many completed tasks with tracked time are needed
+// before estimates are based on tracked effort instead of wall time
+const minTrackedSamples = 3
+
+// workdayMinutes converts tracked effort into days of focused work
+const workdayMinutes = 8 * 60
+
+// effortFactors scale an estimate by the task's effort bucket when the user
+// has no tracked history for that bucket
+var effortFactors = map[domain.TaskEffort]float64{
+	domain.TaskEffortSmall:  0.5,
+	domain.TaskEffortMedium: 1.0,
+	domain.TaskEffortLarge:  2.0,
+	domain.TaskEffortXLarge: 3.5,
+}
+
+// EstimateCompletionTime estimates how long a task will take based on historical data.
+// Once enough completed tasks have tracked time, the estimate is based on that actual
+// effort; otherwise it falls back to how long tasks took from creation to completion.
 func (s *InsightsService) EstimateCompletionTime(ctx context.Context, userID string, task *domain.Task) (*domain.TimeEstimate, error) {
 	// Get base statistics
 	baseStats, err := s.taskRepo.GetCompletionTimeStats(ctx, userID, nil, nil)
@@ -300,6 +376,14 @@ func (s *InsightsService) EstimateCompletionTime(ctx context.Context, userID str
 		return nil, err
 	}
 
+	trackedStats, err := s.taskRepo.GetTrackedTimeStats(ctx, userID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if trackedStats.SampleSize >= minTrackedSamples {
+		return s.estimateFromTrackedTime(ctx, userID, task, trackedStats), nil
+	}
+
 	baseEstimate := baseStats.MedianDays
 	if baseEstimate == 0 {
 		baseEstimate = 3.0 // Default fallback
@@ -315,12 +399,6 @@ func (s *InsightsService) EstimateCompletionTime(ctx context.Context, userID str
 	}
 
 	// Effort factor based on task effort level
-	effortFactors := map[domain.TaskEffort]float64{
-		domain.TaskEffortSmall:  0.5,
-		domain.TaskEffortMedium: 1.0,
-		domain.TaskEffortLarge:  2.0,
-		domain.TaskEffortXLarge: 3.5,
-	}
 	effortFactor := 1.0
 	if task.EstimatedEffort != nil {
 		if factor, ok := effortFactors[*task.EstimatedEffort]; ok {
@@ -329,22 +407,15 @@ func (s *InsightsService) EstimateCompletionTime(ctx context.Context, userID str
 	}
 
 	// Bump factor: each bump adds 20% to estimate
-	bumpFactor := 1.0 + (0.2 * float64(task.BumpCount))
+	bumpFactor := bumpEstimateFactor(task)
 
 	estimatedDays := baseEstimate * categoryFactor * effortFactor * bumpFactor
 
-	// Determine confidence level
-	confidence := "medium"
-	if baseStats.SampleSize < 5 {
-		confidence = "low"
-	} else if baseStats.SampleSize >= 20 {
-		confidence = "high"
-	}
-
 	return &domain.TimeEstimate{
 		EstimatedDays:   estimatedDays,
-		ConfidenceLevel: confidence,
+		ConfidenceLevel: estimateConfidence(baseStats.SampleSize),
 		BasedOn:         baseStats.SampleSize,
+		Basis:           domain.EstimateBasisWallTime,
 		Factors: domain.TimeEstimateFactor{
 			BaseEstimate:   baseEstimate,
 			CategoryFactor: categoryFactor,
@@ -354,6 +425,65 @@ func (s *InsightsService) EstimateCompletionTime(ctx context.Context, userID str
 	}, nil
 }
 
+// estimateFromTrackedTime estimates a task's effort from the time tracked on completed tasks.
+// Category and effort factors compare the tracked effort of matching tasks to the overall
+// median when there is enough history; bumps only stretch the estimate in days.
+func (s *InsightsService) estimateFromTrackedTime(ctx context.Context, userID string, task *domain.Task, trackedStats *domain.TrackedTimeStats) *domain.TimeEstimate {
+	baseMinutes := trackedStats.MedianMinutes
+
+	categoryFactor := 1.0
+	if task.Category != nil {
+		catStats, err := s.taskRepo.GetTrackedTimeStats(ctx, userID, task.Category, nil)
+		if err == nil && catStats.SampleSize >= minTrackedSamples && catStats.MedianMinutes > 0 {
+			categoryFactor = catStats.MedianMinutes / baseMinutes
+		}
+	}
+
+	effortFactor := 1.0
+	if task.EstimatedEffort != nil {
+		effortStats, err := s.taskRepo.GetTrackedTimeStats(ctx, userID, nil, task.EstimatedEffort)
+		if err == nil && effortStats.SampleSize >= minTrackedSamples && effortStats.MedianMinutes > 0 {
+			effortFactor = effortStats.MedianMinutes / baseMinutes
+		} else if factor, ok := effortFactors[*task.EstimatedEffort]; ok {
+			effortFactor = factor
+		}
+	}
+
+	bumpFactor := bumpEstimateFactor(task)
+	estimatedMinutes := baseMinutes * categoryFactor * effortFactor
+
+	return &domain.TimeEstimate{
+		EstimatedDays:    estimatedMinutes / workdayMinutes * bumpFactor,
+		EstimatedMinutes: estimatedMinutes,
+		ConfidenceLevel:  estimateConfidence(trackedStats.SampleSize),
+		BasedOn:          trackedStats.SampleSize,
+		Basis:            domain.EstimateBasisTrackedTime,
+		Factors: domain.TimeEstimateFactor{
+			BaseEstimate:   baseMinutes / workdayMinutes,
+			BaseMinutes:    baseMinutes,
+			CategoryFactor: categoryFactor,
+			EffortFactor:   effortFactor,
+			BumpFactor:     bumpFactor,
+		},
+	}
+}
+
+// bumpEstimateFactor adds 20% to an estimate for each time the task was bumped
+func bumpEstimateFactor(task *domain.Task) float64 {
+	return 1.0 + (0.2 * float64(task.BumpCount))
+}
+
+// estimateConfidence determines the confidence level from the number of samples
+func estimateConfidence(sampleSize int) string {
+	confidence := "medium"
+	if sampleSize < 5 {
+		confidence = "low"
+	} else if sampleSize >= 20 {
+		confidence = "high"
+	}
+	return confidence
+}
+
 // Category patterns for auto-categorization hints
 var categoryPatterns = map[string][]string{
 	"Code Review":    {"review", "pr", "pull request", "code review", "cr"},
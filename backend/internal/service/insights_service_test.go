@@ -461,6 +461,58 @@ func TestInsightsService_CheckCategoryOverload_RequiresThresholds(t *testing.T)
 	}
 }
 
+// =============================================================================
+// checkWIPLimits Tests
+// =============================================================================
+
+func TestInsightsService_CheckWIPLimits_FlagsColumnsOverLimit(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockPrefsRepo := new(MockUserPreferencesRepository)
+	service := newInsightsService(mockRepo)
+	service.SetUserPreferencesRepository(mockPrefsRepo)
+
+	mockRepo.On("GetCategoryBumpStats", mock.Anything, "user-123").Return([]domain.CategoryBumpStats{}, nil)
+	mockRepo.On("GetCompletionByDayOfWeek", mock.Anything, "user-123", 90).Return([]domain.DayOfWeekStats{}, nil)
+	mockRepo.On("GetAgingQuickWins", mock.Anything, "user-123", 5, 10).Return([]*domain.Task{}, nil)
+	mockRepo.On("GetDeadlineClusters", mock.Anything, "user-123", 14).Return([]domain.DeadlineCluster{}, nil)
+	mockRepo.On("FindAtRiskTasks", mock.Anything, "user-123").Return([]*domain.Task{}, nil)
+	mockRepo.On("GetCategoryDistribution", mock.Anything, "user-123").Return([]domain.CategoryDistribution{}, nil)
+	mockPrefsRepo.On("GetWIPLimits", mock.Anything, "user-123").Return(domain.WIPLimits{
+		domain.TaskStatusInProgress: 3,
+		domain.TaskStatusBlocked:    2,
+	}, nil)
+	mockRepo.On("Count", mock.Anything, "user-123", mock.MatchedBy(func(f *domain.TaskListFilter) bool {
+		return *f.WorkflowStatus == domain.TaskStatusInProgress
+	})).Return(5, nil)
+	mockRepo.On("Count", mock.Anything, "user-123", mock.MatchedBy(func(f *domain.TaskListFilter) bool {
+		return *f.WorkflowStatus == domain.TaskStatusBlocked
+	})).Return(2, nil)
+
+	response, err := service.GetInsights(context.Background(), "user-123")
+
+	require.NoError(t, err)
+	require.Len(t, response.Insights, 1)
+	insight := response.Insights[0]
+	assert.Equal(t, domain.InsightWIPLimitExceeded, insight.Type)
+	assert.Contains(t, insight.Message, "in_progress (5/3)")
+	assert.NotContains(t, insight.Message, "blocked")
+	assert.Len(t, insight.Data["columns"], 1)
+}
+
+func TestInsightsService_CheckWIPLimits_SkippedWithoutLimits(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockPrefsRepo := new(MockUserPreferencesRepository)
+	service := newInsightsService(mockRepo)
+	service.SetUserPreferencesRepository(mockPrefsRepo)
+
+	mockPrefsRepo.On("GetWIPLimits", mock.Anything, "user-123").Return(domain.WIPLimits{}, nil)
+
+	insight := service.checkWIPLimits(context.Background(), "user-123")
+
+	assert.Nil(t, insight)
+	mockRepo.AssertNotCalled(t, "Count", mock.Anything, mock.Anything, mock.Anything)
+}
+
 // =============================================================================
 // EstimateCompletionTime Tests
 // =============================================================================
@@ -482,6 +534,8 @@ func TestInsightsService_EstimateCompletionTime_CalculatesCorrectEstimate(t *tes
 	// Base stats: median 5 days
 	mockRepo.On("GetCompletionTimeStats", mock.Anything, "user-123", (*string)(nil), (*domain.TaskEffort)(nil)).
 		Return(&domain.CompletionTimeStats{MedianDays: 5.0, AvgDays: 5.5, SampleSize: 25}, nil)
+	mockRepo.On("GetTrackedTimeStats", mock.Anything, "user-123", (*string)(nil), (*domain.TaskEffort)(nil)).
+		Return(&domain.TrackedTimeStats{}, nil) // No tracked time yet
 
 	// Category stats: median 4 days (faster category)
 	mockRepo.On("GetCompletionTimeStats", mock.Anything, "user-123", &category, (*domain.TaskEffort)(nil)).
@@ -523,6 +577,8 @@ func TestInsightsService_EstimateCompletionTime_AppliesEffortFactor(t *testing.T
 
 			mockRepo.On("GetCompletionTimeStats", mock.Anything, "user-123", (*string)(nil), (*domain.TaskEffort)(nil)).
 				Return(&domain.CompletionTimeStats{MedianDays: 4.0, SampleSize: 10}, nil)
+			mockRepo.On("GetTrackedTimeStats", mock.Anything, "user-123", (*string)(nil), (*domain.TaskEffort)(nil)).
+				Return(&domain.TrackedTimeStats{}, nil) // No tracked time yet
 
 			estimate, err := service.EstimateCompletionTime(context.Background(), "user-123", task)
 
@@ -547,6 +603,8 @@ func TestInsightsService_EstimateCompletionTime_AppliesBumpFactor(t *testing.T)
 
 	mockRepo.On("GetCompletionTimeStats", mock.Anything, "user-123", (*string)(nil), (*domain.TaskEffort)(nil)).
 		Return(&domain.CompletionTimeStats{MedianDays: 5.0, SampleSize: 10}, nil)
+	mockRepo.On("GetTrackedTimeStats", mock.Anything, "user-123", (*string)(nil), (*domain.TaskEffort)(nil)).
+		Return(&domain.TrackedTimeStats{}, nil) // No tracked time yet
 
 	estimate, err := service.EstimateCompletionTime(context.Background(), "user-123", task)
 
@@ -568,6 +626,8 @@ func TestInsightsService_EstimateCompletionTime_UsesDefaultWhenNoData(t *testing
 
 	mockRepo.On("GetCompletionTimeStats", mock.Anything, "user-123", (*string)(nil), (*domain.TaskEffort)(nil)).
 		Return(&domain.CompletionTimeStats{MedianDays: 0, SampleSize: 0}, nil)
+	mockRepo.On("GetTrackedTimeStats", mock.Anything, "user-123", (*string)(nil), (*domain.TaskEffort)(nil)).
+		Return(&domain.TrackedTimeStats{}, nil) // No tracked time yet
 
 	estimate, err := service.EstimateCompletionTime(context.Background(), "user-123", task)
 
@@ -599,6 +659,8 @@ func TestInsightsService_EstimateCompletionTime_DeterminesConfidenceLevel(t *tes
 
 			mockRepo.On("GetCompletionTimeStats", mock.Anything, "user-123", (*string)(nil), (*domain.TaskEffort)(nil)).
 				Return(&domain.CompletionTimeStats{MedianDays: 5.0, SampleSize: tt.sampleSize}, nil)
+			mockRepo.On("GetTrackedTimeStats", mock.Anything, "user-123", (*string)(nil), (*domain.TaskEffort)(nil)).
+				Return(&domain.TrackedTimeStats{}, nil) // No tracked time yet
 
 			estimate, err := service.EstimateCompletionTime(context.Background(), "user-123", task)
 
@@ -608,6 +670,87 @@ func TestInsightsService_EstimateCompletionTime_DeterminesConfidenceLevel(t *tes
 	}
 }
 
+func TestInsightsService_EstimateCompletionTime_UsesTrackedTime(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := newInsightsService(mockRepo)
+
+	category := "Work"
+	effort := domain.TaskEffortLarge
+	task := &domain.Task{
+		ID:              "task-1",
+		UserID:          "user-123",
+		Category:        &category,
+		EstimatedEffort: &effort,
+		BumpCount:       1,
+	}
+
+	// Wall time says tasks sit for 10 days; tracked time says they take an hour of work
+	mockRepo.On("GetCompletionTimeStats", mock.Anything, "user-123", (*string)(nil), (*domain.TaskEffort)(nil)).
+		Return(&domain.CompletionTimeStats{MedianDays: 10.0, SampleSize: 30}, nil)
+	mockRepo.On("GetTrackedTimeStats", mock.Anything, "user-123", (*string)(nil), (*domain.TaskEffort)(nil)).
+		Return(&domain.TrackedTimeStats{MedianMinutes: 60, AvgMinutes: 70, SampleSize: 8}, nil)
+	mockRepo.On("GetTrackedTimeStats", mock.Anything, "user-123", &category, (*domain.TaskEffort)(nil)).
+		Return(&domain.TrackedTimeStats{MedianMinutes: 90, SampleSize: 4}, nil)
+	mockRepo.On("GetTrackedTimeStats", mock.Anything, "user-123", (*string)(nil), &effort).
+		Return(&domain.TrackedTimeStats{MedianMinutes: 120, SampleSize: 3}, nil)
+
+	estimate, err := service.EstimateCompletionTime(context.Background(), "user-123", task)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.EstimateBasisTrackedTime, estimate.Basis)
+	// Base=60min, CategoryFactor=90/60=1.5, EffortFactor=120/60=2.0 -> 180 minutes of work
+	assert.InDelta(t, 180.0, estimate.EstimatedMinutes, 0.01)
+	assert.InDelta(t, 1.5, estimate.Factors.CategoryFactor, 0.01)
+	assert.InDelta(t, 2.0, estimate.Factors.EffortFactor, 0.01)
+	// 180 minutes of an 8 hour day, stretched by one bump (1.2)
+	assert.InDelta(t, 180.0/480.0*1.2, estimate.EstimatedDays, 0.001)
+	assert.Equal(t, 8, estimate.BasedOn)
+	assert.Equal(t, "medium", estimate.ConfidenceLevel)
+}
+
+func TestInsightsService_EstimateCompletionTime_TrackedTimeFallsBackToEffortBuckets(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := newInsightsService(mockRepo)
+
+	effort := domain.TaskEffortSmall
+	task := &domain.Task{ID: "task-1", UserID: "user-123", EstimatedEffort: &effort}
+
+	mockRepo.On("GetCompletionTimeStats", mock.Anything, "user-123", (*string)(nil), (*domain.TaskEffort)(nil)).
+		Return(&domain.CompletionTimeStats{MedianDays: 4.0, SampleSize: 10}, nil)
+	mockRepo.On("GetTrackedTimeStats", mock.Anything, "user-123", (*string)(nil), (*domain.TaskEffort)(nil)).
+		Return(&domain.TrackedTimeStats{MedianMinutes: 60, SampleSize: 3}, nil)
+	// Too few small tasks with tracked time to derive their own factor
+	mockRepo.On("GetTrackedTimeStats", mock.Anything, "user-123", (*string)(nil), &effort).
+		Return(&domain.TrackedTimeStats{MedianMinutes: 10, SampleSize: 1}, nil)
+
+	estimate, err := service.EstimateCompletionTime(context.Background(), "user-123", task)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.EstimateBasisTrackedTime, estimate.Basis)
+	assert.InDelta(t, 0.5, estimate.Factors.EffortFactor, 0.01)
+	assert.InDelta(t, 30.0, estimate.EstimatedMinutes, 0.01)
+	assert.Equal(t, "low", estimate.ConfidenceLevel)
+}
+
+func TestInsightsService_EstimateCompletionTime_TooLittleTrackedTimeUsesWallTime(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := newInsightsService(mockRepo)
+
+	task := &domain.Task{ID: "task-1", UserID: "user-123"}
+
+	mockRepo.On("GetCompletionTimeStats", mock.Anything, "user-123", (*string)(nil), (*domain.TaskEffort)(nil)).
+		Return(&domain.CompletionTimeStats{MedianDays: 5.0, SampleSize: 10}, nil)
+	mockRepo.On("GetTrackedTimeStats", mock.Anything, "user-123", (*string)(nil), (*domain.TaskEffort)(nil)).
+		Return(&domain.TrackedTimeStats{MedianMinutes: 60, SampleSize: 2}, nil)
+
+	estimate, err := service.EstimateCompletionTime(context.Background(), "user-123", task)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.EstimateBasisWallTime, estimate.Basis)
+	assert.InDelta(t, 5.0, estimate.EstimatedDays, 0.01)
+	assert.Zero(t, estimate.EstimatedMinutes)
+}
+
 func TestInsightsService_EstimateCompletionTime_ReturnsErrorOnRepoFailure(t *testing.T) {
 	mockRepo := new(MockTaskRepository)
 	service := newInsightsService(mockRepo)
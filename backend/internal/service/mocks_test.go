@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/notkevinvu/taskflow/backend/internal/domain"
@@ -35,13 +36,18 @@ func (m *MockTaskRepository) List(ctx context.Context, userID string, filter *do
 	return args.Get(0).([]*domain.Task), args.Error(1)
 }
 
+func (m *MockTaskRepository) Count(ctx context.Context, userID string, filter *domain.TaskListFilter) (int, error) {
+	args := m.Called(ctx, userID, filter)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockTaskRepository) Update(ctx context.Context, task *domain.Task) error {
 	args := m.Called(ctx, task)
 	return args.Error(0)
 }
 
-func (m *MockTaskRepository) Delete(ctx context.Context, id, userID string) error {
-	args := m.Called(ctx, id, userID)
+func (m *MockTaskRepository) Delete(ctx context.Context, id, userID string, expectedVersion int) error {
+	args := m.Called(ctx, id, userID, expectedVersion)
 	return args.Error(0)
 }
 
@@ -74,14 +80,20 @@ func (m *MockTaskRepository) FindByDateRange(ctx context.Context, userID string,
 	return args.Get(0).([]*domain.Task), args.Error(1)
 }
 
-func (m *MockTaskRepository) RenameCategoryForUser(ctx context.Context, userID, oldName, newName string) (int, error) {
+func (m *MockTaskRepository) RenameCategoryForUser(ctx context.Context, userID, oldName, newName string) ([]*domain.Task, error) {
 	args := m.Called(ctx, userID, oldName, newName)
-	return args.Int(0), args.Error(1)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Task), args.Error(1)
 }
 
-func (m *MockTaskRepository) DeleteCategoryForUser(ctx context.Context, userID, categoryName string) (int, error) {
+func (m *MockTaskRepository) DeleteCategoryForUser(ctx context.Context, userID, categoryName string) ([]*domain.Task, error) {
 	args := m.Called(ctx, userID, categoryName)
-	return args.Int(0), args.Error(1)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Task), args.Error(1)
 }
 
 func (m *MockTaskRepository) GetCompletionStats(ctx context.Context, userID string, daysBack int) (*repository.CompletionStats, error) {
@@ -108,6 +120,22 @@ func (m *MockTaskRepository) GetCategoryBreakdown(ctx context.Context, userID st
 	return args.Get(0).([]repository.CategoryStats), args.Error(1)
 }
 
+func (m *MockTaskRepository) GetTagBreakdown(ctx context.Context, userID string, daysBack int) ([]domain.TagStats, error) {
+	args := m.Called(ctx, userID, daysBack)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.TagStats), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetTimeTrackingSummary(ctx context.Context, userID string, daysBack int) (*domain.TimeTrackingSummary, error) {
+	args := m.Called(ctx, userID, daysBack)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TimeTrackingSummary), args.Error(1)
+}
+
 func (m *MockTaskRepository) GetVelocityMetrics(ctx context.Context, userID string, daysBack int) ([]repository.VelocityMetrics, error) {
 	args := m.Called(ctx, userID, daysBack)
 	if args.Get(0) == nil {
@@ -166,6 +194,14 @@ func (m *MockTaskRepository) GetCompletionTimeStats(ctx context.Context, userID
 	return args.Get(0).(*domain.CompletionTimeStats), args.Error(1)
 }
 
+func (m *MockTaskRepository) GetTrackedTimeStats(ctx context.Context, userID string, category *string, effort *domain.TaskEffort) (*domain.TrackedTimeStats, error) {
+	args := m.Called(ctx, userID, category, effort)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TrackedTimeStats), args.Error(1)
+}
+
 func (m *MockTaskRepository) GetCategoryDistribution(ctx context.Context, userID string) ([]domain.CategoryDistribution, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
@@ -182,6 +218,14 @@ func (m *MockTaskRepository) GetProductivityHeatmap(ctx context.Context, userID
 	return args.Get(0).(*domain.ProductivityHeatmap), args.Error(1)
 }
 
+func (m *MockTaskRepository) GetFocusHeatmap(ctx context.Context, userID string, daysBack int) (*domain.FocusHeatmap, error) {
+	args := m.Called(ctx, userID, daysBack)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FocusHeatmap), args.Error(1)
+}
+
 func (m *MockTaskRepository) GetCategoryTrends(ctx context.Context, userID string, daysBack int) (*domain.CategoryTrends, error) {
 	args := m.Called(ctx, userID, daysBack)
 	if args.Get(0) == nil {
@@ -190,8 +234,8 @@ func (m *MockTaskRepository) GetCategoryTrends(ctx context.Context, userID strin
 	return args.Get(0).(*domain.CategoryTrends), args.Error(1)
 }
 
-func (m *MockTaskRepository) BulkDelete(ctx context.Context, userID string, taskIDs []string) (int, []string, error) {
-	args := m.Called(ctx, userID, taskIDs)
+func (m *MockTaskRepository) BulkUpdateStatus(ctx context.Context, userID string, taskIDs []string, newStatus domain.TaskStatus) (int, []string, error) {
+	args := m.Called(ctx, userID, taskIDs, newStatus)
 	var failedIDs []string
 	if args.Get(1) != nil {
 		failedIDs = args.Get(1).([]string)
@@ -199,8 +243,8 @@ func (m *MockTaskRepository) BulkDelete(ctx context.Context, userID string, task
 	return args.Int(0), failedIDs, args.Error(2)
 }
 
-func (m *MockTaskRepository) BulkUpdateStatus(ctx context.Context, userID string, taskIDs []string, newStatus domain.TaskStatus) (int, []string, error) {
-	args := m.Called(ctx, userID, taskIDs, newStatus)
+func (m *MockTaskRepository) BulkRestore(ctx context.Context, userID string, taskIDs []string) (int, []string, error) {
+	args := m.Called(ctx, userID, taskIDs)
 	var failedIDs []string
 	if args.Get(1) != nil {
 		failedIDs = args.Get(1).([]string)
@@ -208,6 +252,54 @@ func (m *MockTaskRepository) BulkUpdateStatus(ctx context.Context, userID string
 	return args.Int(0), failedIDs, args.Error(2)
 }
 
+func (m *MockTaskRepository) CreateDuplicate(ctx context.Context, dup *domain.TaskDuplicate) error {
+	args := m.Called(ctx, dup)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) Convert(ctx context.Context, conv *domain.TaskConversion) error {
+	args := m.Called(ctx, conv)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) FindTrash(ctx context.Context, userID string, limit, offset int) ([]*domain.Task, int, error) {
+	args := m.Called(ctx, userID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*domain.Task), args.Int(1), args.Error(2)
+}
+
+func (m *MockTaskRepository) PurgeTrash(ctx context.Context, userID string, purge *domain.TrashPurge) (int, error) {
+	args := m.Called(ctx, userID, purge)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) FindUsersWithTrashBefore(ctx context.Context, cutoff time.Time) ([]string, error) {
+	args := m.Called(ctx, cutoff)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// Manual ordering methods
+
+func (m *MockTaskRepository) FindAdjacentRank(ctx context.Context, scope domain.RankScope, rank, excludeTaskID string, after bool) (string, error) {
+	args := m.Called(ctx, scope, rank, excludeTaskID, after)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) UpdateRank(ctx context.Context, task *domain.Task) error {
+	args := m.Called(ctx, task)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) RebalanceRanks(ctx context.Context, scope domain.RankScope) error {
+	args := m.Called(ctx, scope)
+	return args.Error(0)
+}
+
 // Subtask methods
 
 func (m *MockTaskRepository) GetSubtasks(ctx context.Context, parentTaskID string) ([]*domain.Task, error) {
@@ -218,12 +310,25 @@ func (m *MockTaskRepository) GetSubtasks(ctx context.Context, parentTaskID strin
 	return args.Get(0).([]*domain.Task), args.Error(1)
 }
 
-func (m *MockTaskRepository) GetSubtasksBatch(ctx context.Context, parentTaskIDs []string) (map[string][]*domain.Task, error) {
-	args := m.Called(ctx, parentTaskIDs)
+func (m *MockTaskRepository) GetSubtree(ctx context.Context, rootTaskID string) ([]*domain.Task, error) {
+	args := m.Called(ctx, rootTaskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetAncestorIDs(ctx context.Context, taskID string) ([]string, error) {
+	args := m.Called(ctx, taskID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(map[string][]*domain.Task), args.Error(1)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTaskRepository) UpdatePriorityScores(ctx context.Context, scores map[string]int) error {
+	args := m.Called(ctx, scores)
+	return args.Error(0)
 }
 
 func (m *MockTaskRepository) GetSubtaskInfo(ctx context.Context, parentTaskID string) (*domain.SubtaskInfo, error) {
@@ -272,6 +377,40 @@ func (m *MockTaskHistoryRepository) FindByTaskID(ctx context.Context, taskID str
 	return args.Get(0).([]*domain.TaskHistory), args.Error(1)
 }
 
+func (m *MockTaskHistoryRepository) ListByTaskID(ctx context.Context, taskID string, filter *domain.TaskHistoryFilter) ([]*domain.TaskHistory, int, error) {
+	args := m.Called(ctx, taskID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*domain.TaskHistory), args.Int(1), args.Error(2)
+}
+
+func (m *MockTaskHistoryRepository) ListOperations(ctx context.Context, userID string, limit int) ([]*domain.TaskOperation, error) {
+	args := m.Called(ctx, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.TaskOperation), args.Error(1)
+}
+
+func (m *MockTaskHistoryRepository) FindByOperationID(ctx context.Context, userID, operationID string) ([]*domain.TaskHistory, error) {
+	args := m.Called(ctx, userID, operationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.TaskHistory), args.Error(1)
+}
+
+func (m *MockTaskHistoryRepository) MarkOperationUndone(ctx context.Context, userID, operationID string, undoneAt time.Time) (bool, error) {
+	args := m.Called(ctx, userID, operationID, undoneAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTaskHistoryRepository) ClearOperationUndone(ctx context.Context, userID, operationID string) error {
+	args := m.Called(ctx, userID, operationID)
+	return args.Error(0)
+}
+
 // MockUserRepository is a mock implementation of ports.UserRepository
 type MockUserRepository struct {
 	mock.Mock
@@ -335,3 +474,705 @@ func (m *MockUserRepository) LogAnonymousCleanup(ctx context.Context, userID str
 	args := m.Called(ctx, userID, taskCount, userCreatedAt)
 	return args.Error(0)
 }
+
+// MockSavedViewRepository is a mock implementation of ports.SavedViewRepository
+type MockSavedViewRepository struct {
+	mock.Mock
+}
+
+func (m *MockSavedViewRepository) Create(ctx context.Context, view *domain.SavedView) error {
+	args := m.Called(ctx, view)
+	return args.Error(0)
+}
+
+func (m *MockSavedViewRepository) FindByID(ctx context.Context, id string) (*domain.SavedView, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SavedView), args.Error(1)
+}
+
+func (m *MockSavedViewRepository) FindByUserID(ctx context.Context, userID string) ([]*domain.SavedView, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.SavedView), args.Error(1)
+}
+
+func (m *MockSavedViewRepository) CountByUserID(ctx context.Context, userID string) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockSavedViewRepository) Update(ctx context.Context, view *domain.SavedView) error {
+	args := m.Called(ctx, view)
+	return args.Error(0)
+}
+
+func (m *MockSavedViewRepository) Delete(ctx context.Context, id, userID string) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+func (m *MockSavedViewRepository) ExistsByName(ctx context.Context, userID, name string) (bool, error) {
+	args := m.Called(ctx, userID, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSavedViewRepository) ExistsByNameExcludingID(ctx context.Context, userID, name, excludeID string) (bool, error) {
+	args := m.Called(ctx, userID, name, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
+// MockTagRepository is a mock implementation of ports.TagRepository
+type MockTagRepository struct {
+	mock.Mock
+}
+
+func (m *MockTagRepository) Create(ctx context.Context, tag *domain.Tag) error {
+	args := m.Called(ctx, tag)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) FindByID(ctx context.Context, id string) (*domain.Tag, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindByUserID(ctx context.Context, userID string) ([]*domain.Tag, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) Update(ctx context.Context, tag *domain.Tag) error {
+	args := m.Called(ctx, tag)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) Delete(ctx context.Context, id, userID string) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) Merge(ctx context.Context, userID, sourceID, targetID string) (int, error) {
+	args := m.Called(ctx, userID, sourceID, targetID)
+	return args.Int(0), args.Error(1)
+}
+
+// MockCommentRepository is a mock implementation of ports.CommentRepository
+type MockCommentRepository struct {
+	mock.Mock
+}
+
+func (m *MockCommentRepository) Create(ctx context.Context, comment *domain.TaskComment) error {
+	args := m.Called(ctx, comment)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) FindByID(ctx context.Context, id string) (*domain.TaskComment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TaskComment), args.Error(1)
+}
+
+func (m *MockCommentRepository) FindByTaskID(ctx context.Context, taskID string) ([]*domain.TaskComment, error) {
+	args := m.Called(ctx, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.TaskComment), args.Error(1)
+}
+
+func (m *MockCommentRepository) Update(ctx context.Context, comment *domain.TaskComment) error {
+	args := m.Called(ctx, comment)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) FindEdits(ctx context.Context, commentID string) ([]*domain.TaskCommentEdit, error) {
+	args := m.Called(ctx, commentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.TaskCommentEdit), args.Error(1)
+}
+
+func (m *MockCommentRepository) SoftDelete(ctx context.Context, id, userID string) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+// MockTimeEntryRepository is a mock implementation of ports.TimeEntryRepository
+type MockTimeEntryRepository struct {
+	mock.Mock
+}
+
+func (m *MockTimeEntryRepository) Create(ctx context.Context, entry *domain.TimeEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockTimeEntryRepository) FindByID(ctx context.Context, id string) (*domain.TimeEntry, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TimeEntry), args.Error(1)
+}
+
+func (m *MockTimeEntryRepository) FindRunningByUserID(ctx context.Context, userID string) (*domain.TimeEntry, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TimeEntry), args.Error(1)
+}
+
+func (m *MockTimeEntryRepository) FindByTaskID(ctx context.Context, taskID string) ([]*domain.TimeEntry, error) {
+	args := m.Called(ctx, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.TimeEntry), args.Error(1)
+}
+
+func (m *MockTimeEntryRepository) Stop(ctx context.Context, id, userID string, endedAt time.Time) (*domain.TimeEntry, error) {
+	args := m.Called(ctx, id, userID, endedAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TimeEntry), args.Error(1)
+}
+
+func (m *MockTimeEntryRepository) Delete(ctx context.Context, id, userID string) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+// MockFocusSessionRepository is a mock implementation of ports.FocusSessionRepository
+type MockFocusSessionRepository struct {
+	mock.Mock
+}
+
+func (m *MockFocusSessionRepository) Create(ctx context.Context, session *domain.FocusSession) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
+func (m *MockFocusSessionRepository) FindByID(ctx context.Context, id string) (*domain.FocusSession, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FocusSession), args.Error(1)
+}
+
+func (m *MockFocusSessionRepository) FindActiveByUserID(ctx context.Context, userID string) (*domain.FocusSession, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FocusSession), args.Error(1)
+}
+
+func (m *MockFocusSessionRepository) FindByTaskID(ctx context.Context, taskID string) ([]*domain.FocusSession, error) {
+	args := m.Called(ctx, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.FocusSession), args.Error(1)
+}
+
+func (m *MockFocusSessionRepository) Update(ctx context.Context, session *domain.FocusSession, fromStatus domain.FocusSessionStatus) error {
+	args := m.Called(ctx, session, fromStatus)
+	return args.Error(0)
+}
+
+func (m *MockFocusSessionRepository) CountCompletedByUserID(ctx context.Context, userID string) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockFocusSessionRepository) CountCompletedToday(ctx context.Context, userID string) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+// MockGamificationService is a mock implementation of ports.GamificationService
+type MockGamificationService struct {
+	mock.Mock
+}
+
+func (m *MockGamificationService) GetDashboard(ctx context.Context, userID string) (*domain.GamificationDashboard, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GamificationDashboard), args.Error(1)
+}
+
+func (m *MockGamificationService) ProcessTaskCompletion(ctx context.Context, userID string, task *domain.Task) (*domain.TaskCompletionGamificationResult, error) {
+	args := m.Called(ctx, userID, task)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TaskCompletionGamificationResult), args.Error(1)
+}
+
+func (m *MockGamificationService) ProcessTaskCompletionAsync(userID string, task *domain.Task) {
+	m.Called(userID, task)
+}
+
+func (m *MockGamificationService) ProcessBulkTaskCompletion(ctx context.Context, userID string, tasks []*domain.Task) (*domain.TaskCompletionGamificationResult, error) {
+	args := m.Called(ctx, userID, tasks)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TaskCompletionGamificationResult), args.Error(1)
+}
+
+func (m *MockGamificationService) ProcessTaskUncompletion(ctx context.Context, userID string, task *domain.Task) error {
+	args := m.Called(ctx, userID, task)
+	return args.Error(0)
+}
+
+func (m *MockGamificationService) ProcessTaskUncompletionAsync(userID string, task *domain.Task) {
+	m.Called(userID, task)
+}
+
+func (m *MockGamificationService) ProcessFocusSessionCompletion(ctx context.Context, userID string, completedSessions int) *domain.AchievementEarnedEvent {
+	args := m.Called(ctx, userID, completedSessions)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(*domain.AchievementEarnedEvent)
+}
+
+func (m *MockGamificationService) ComputeStats(ctx context.Context, userID string) (*domain.GamificationStats, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GamificationStats), args.Error(1)
+}
+
+func (m *MockGamificationService) CheckAndAwardAchievements(ctx context.Context, userID string, task *domain.Task, stats *domain.GamificationStats) ([]*domain.AchievementEarnedEvent, error) {
+	args := m.Called(ctx, userID, task, stats)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.AchievementEarnedEvent), args.Error(1)
+}
+
+func (m *MockGamificationService) SetUserTimezone(ctx context.Context, userID, timezone string) error {
+	args := m.Called(ctx, userID, timezone)
+	return args.Error(0)
+}
+
+func (m *MockGamificationService) GetUserTimezone(ctx context.Context, userID string) (string, error) {
+	args := m.Called(ctx, userID)
+	return args.String(0), args.Error(1)
+}
+
+// MockAttachmentRepository is a mock implementation of ports.AttachmentRepository
+type MockAttachmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentRepository) Create(ctx context.Context, attachment *domain.Attachment, quotaBytes int64) error {
+	args := m.Called(ctx, attachment, quotaBytes)
+	return args.Error(0)
+}
+
+func (m *MockAttachmentRepository) FindByID(ctx context.Context, id string) (*domain.Attachment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) FindByTaskID(ctx context.Context, taskID string) ([]*domain.Attachment, error) {
+	args := m.Called(ctx, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) SumSizeByUserID(ctx context.Context, userID string) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) Delete(ctx context.Context, id, userID string) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+func (m *MockAttachmentRepository) ListPendingBlobDeletions(ctx context.Context, limit int) ([]string, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) RemovePendingBlobDeletions(ctx context.Context, keys []string) error {
+	args := m.Called(ctx, keys)
+	return args.Error(0)
+}
+
+// MockBlobStore is a mock implementation of ports.BlobStore.
+// Put drains the reader into Puts so tests can inspect what was stored.
+type MockBlobStore struct {
+	mock.Mock
+	Puts map[string][]byte
+}
+
+func (m *MockBlobStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if m.Puts == nil {
+		m.Puts = map[string][]byte{}
+	}
+	m.Puts[key] = data
+	args := m.Called(ctx, key, size, contentType)
+	return args.Error(0)
+}
+
+func (m *MockBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *MockBlobStore) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+// MockAttachmentService is a mock implementation of ports.AttachmentService
+type MockAttachmentService struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentService) Upload(ctx context.Context, userID, taskID string, upload *domain.AttachmentUpload) (*domain.Attachment, error) {
+	args := m.Called(ctx, userID, taskID, upload)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentService) List(ctx context.Context, userID, taskID string) (*domain.AttachmentListResponse, error) {
+	args := m.Called(ctx, userID, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AttachmentListResponse), args.Error(1)
+}
+
+func (m *MockAttachmentService) Open(ctx context.Context, userID, taskID, attachmentID string) (*domain.Attachment, io.ReadCloser, error) {
+	args := m.Called(ctx, userID, taskID, attachmentID)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*domain.Attachment), args.Get(1).(io.ReadCloser), args.Error(2)
+}
+
+func (m *MockAttachmentService) Delete(ctx context.Context, userID, taskID, attachmentID string) error {
+	args := m.Called(ctx, userID, taskID, attachmentID)
+	return args.Error(0)
+}
+
+func (m *MockAttachmentService) PurgeDeletedBlobs(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+// MockTaskTemplateRepository is a mock implementation of ports.TaskTemplateRepository
+type MockTaskTemplateRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskTemplateRepository) Create(ctx context.Context, template *domain.TaskTemplate) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+func (m *MockTaskTemplateRepository) FindByID(ctx context.Context, id string) (*domain.TaskTemplate, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTaskTemplateRepository) FindByUserID(ctx context.Context, userID string) ([]*domain.TaskTemplate, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTaskTemplateRepository) Update(ctx context.Context, template *domain.TaskTemplate) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+func (m *MockTaskTemplateRepository) Delete(ctx context.Context, id, userID string) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+func (m *MockTaskTemplateRepository) ExistsByName(ctx context.Context, userID, name string) (bool, error) {
+	args := m.Called(ctx, userID, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTaskTemplateRepository) ExistsByNameExcludingID(ctx context.Context, userID, name, excludeID string) (bool, error) {
+	args := m.Called(ctx, userID, name, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
+// MockDependencyRepository is a mock implementation of ports.DependencyRepository
+type MockDependencyRepository struct {
+	mock.Mock
+}
+
+func (m *MockDependencyRepository) Add(ctx context.Context, userID, taskID, blockedByID string) (*domain.TaskDependency, error) {
+	args := m.Called(ctx, userID, taskID, blockedByID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TaskDependency), args.Error(1)
+}
+
+func (m *MockDependencyRepository) Remove(ctx context.Context, userID, taskID, blockedByID string) error {
+	args := m.Called(ctx, userID, taskID, blockedByID)
+	return args.Error(0)
+}
+
+func (m *MockDependencyRepository) Exists(ctx context.Context, taskID, blockedByID string) (bool, error) {
+	args := m.Called(ctx, taskID, blockedByID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockDependencyRepository) GetBlockers(ctx context.Context, taskID string) ([]*domain.DependencyWithTask, error) {
+	args := m.Called(ctx, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DependencyWithTask), args.Error(1)
+}
+
+func (m *MockDependencyRepository) GetBlocking(ctx context.Context, taskID string) ([]*domain.DependencyWithTask, error) {
+	args := m.Called(ctx, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DependencyWithTask), args.Error(1)
+}
+
+func (m *MockDependencyRepository) GetDependencyInfo(ctx context.Context, taskID string) (*domain.DependencyInfo, error) {
+	args := m.Called(ctx, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DependencyInfo), args.Error(1)
+}
+
+func (m *MockDependencyRepository) GetAllBlockerIDs(ctx context.Context, taskID string) ([]string, error) {
+	args := m.Called(ctx, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockDependencyRepository) GetDependencyGraph(ctx context.Context, userID string) (map[string][]string, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string][]string), args.Error(1)
+}
+
+func (m *MockDependencyRepository) CountIncompleteBlockers(ctx context.Context, taskID string) (int, error) {
+	args := m.Called(ctx, taskID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockDependencyRepository) CountIncompleteBlockersBatch(ctx context.Context, taskIDs []string) (map[string]int, error) {
+	args := m.Called(ctx, taskIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockDependencyRepository) GetTasksBlockedBy(ctx context.Context, blockerTaskID string) ([]string, error) {
+	args := m.Called(ctx, blockerTaskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// MockReminderRepository is a mock implementation of ports.ReminderRepository
+type MockReminderRepository struct {
+	mock.Mock
+}
+
+func (m *MockReminderRepository) Create(ctx context.Context, reminder *domain.Reminder) error {
+	args := m.Called(ctx, reminder)
+	return args.Error(0)
+}
+
+func (m *MockReminderRepository) FindByID(ctx context.Context, id string) (*domain.Reminder, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Reminder), args.Error(1)
+}
+
+func (m *MockReminderRepository) FindByTaskID(ctx context.Context, taskID string) ([]*domain.Reminder, error) {
+	args := m.Called(ctx, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Reminder), args.Error(1)
+}
+
+func (m *MockReminderRepository) Delete(ctx context.Context, id, userID string) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+func (m *MockReminderRepository) ClaimDue(ctx context.Context, lease time.Duration, limit int) ([]*domain.DueReminder, error) {
+	args := m.Called(ctx, lease, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DueReminder), args.Error(1)
+}
+
+func (m *MockReminderRepository) MarkSent(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockReminderRepository) Release(ctx context.Context, ids []string) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+func (m *MockReminderRepository) MarkFailed(ctx context.Context, id, lastError string, retryAt *time.Time) error {
+	args := m.Called(ctx, id, lastError, retryAt)
+	return args.Error(0)
+}
+
+func (m *MockReminderRepository) RescheduleForTask(ctx context.Context, taskID string, dueDate *time.Time) error {
+	args := m.Called(ctx, taskID, dueDate)
+	return args.Error(0)
+}
+
+func (m *MockReminderRepository) CopyToTask(ctx context.Context, fromTaskID, toTaskID string, dueDate *time.Time, shift *time.Duration) error {
+	args := m.Called(ctx, fromTaskID, toTaskID, dueDate, shift)
+	return args.Error(0)
+}
+
+// MockNotifier is a mock implementation of ports.Notifier that records what it was asked to send
+type MockNotifier struct {
+	mock.Mock
+	Sent []*domain.Notification
+}
+
+func (m *MockNotifier) Notify(ctx context.Context, notification *domain.Notification) error {
+	m.Sent = append(m.Sent, notification)
+	args := m.Called(ctx, notification)
+	return args.Error(0)
+}
+
+// MockReminderService is a mock implementation of ports.ReminderService
+type MockReminderService struct {
+	mock.Mock
+}
+
+func (m *MockReminderService) Create(ctx context.Context, userID, taskID string, dto *domain.CreateReminderDTO) (*domain.Reminder, error) {
+	args := m.Called(ctx, userID, taskID, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Reminder), args.Error(1)
+}
+
+func (m *MockReminderService) List(ctx context.Context, userID, taskID string) ([]*domain.Reminder, error) {
+	args := m.Called(ctx, userID, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Reminder), args.Error(1)
+}
+
+func (m *MockReminderService) Delete(ctx context.Context, userID, taskID, reminderID string) error {
+	args := m.Called(ctx, userID, taskID, reminderID)
+	return args.Error(0)
+}
+
+func (m *MockReminderService) RescheduleForTask(ctx context.Context, task *domain.Task) error {
+	args := m.Called(ctx, task)
+	return args.Error(0)
+}
+
+func (m *MockReminderService) CarryOver(ctx context.Context, from, to *domain.Task) error {
+	args := m.Called(ctx, from, to)
+	return args.Error(0)
+}
+
+func (m *MockReminderService) ProcessDueReminders(ctx context.Context) (*domain.ReminderRunResult, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReminderRunResult), args.Error(1)
+}
+
+// MockWorkflowRepository is a mock implementation of ports.WorkflowRepository
+type MockWorkflowRepository struct {
+	mock.Mock
+}
+
+func (m *MockWorkflowRepository) GetStatuses(ctx context.Context, userID string) ([]domain.WorkflowStatus, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.WorkflowStatus), args.Error(1)
+}
+
+func (m *MockWorkflowRepository) ReplaceStatuses(ctx context.Context, userID string, statuses []domain.WorkflowStatus, mapping map[domain.TaskStatus]domain.TaskStatus) error {
+	args := m.Called(ctx, userID, statuses, mapping)
+	return args.Error(0)
+}
+
+func (m *MockWorkflowRepository) DeleteStatuses(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+)
+
+// MoveService arranges tasks by hand: top-level tasks within a user's list, and
+// subtasks within their parent
+type MoveService struct {
+	taskRepo ports.TaskRepository
+}
+
+// NewMoveService creates a new move service
+func NewMoveService(taskRepo ports.TaskRepository) *MoveService {
+	return &MoveService{taskRepo: taskRepo}
+}
+
+// Move places a task between two neighbors of its own list. Only the task's rank is
+// rewritten, unless the list has run out of room there and is rebalanced first.
+func (s *MoveService) Move(ctx context.Context, userID, taskID string, req *domain.MoveTaskRequest) (*domain.Task, error) {
+	if req.AfterTaskID == nil && req.BeforeTaskID == nil {
+		return nil, domain.NewValidationError("after_task_id", "after_task_id or before_task_id is required")
+	}
+
+	task, err := s.findOwned(ctx, userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkExpectedVersion(ctx, task); err != nil {
+		return nil, err
+	}
+
+	rank, err := s.Rank(ctx, userID, task, req)
+	if err != nil {
+		return nil, err
+	}
+
+	moved := *task
+	moved.Rank = rank
+	moved.UpdatedAt = time.Now()
+	if err := s.taskRepo.UpdateRank(ctx, &moved); err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			return nil, domain.NewNotFoundError("task", taskID)
+		}
+		if errors.Is(err, domain.ErrTaskVersionConflict) {
+			// The task changed since it was read; hand back the current copy, as updates do
+			current, err := s.taskRepo.FindByID(ctx, taskID)
+			if err != nil {
+				return nil, domain.NewInternalError("failed to find task", err)
+			}
+			return nil, domain.NewPreconditionFailedError("task", current)
+		}
+		return nil, domain.NewInternalError("failed to move task", err)
+	}
+
+	return &moved, nil
+}
+
+// Rank picks the rank that places a task between the requested neighbors of its list,
+// without moving it. If the list has run out of room there it is rebalanced first, which
+// bumps every version in the list, so task is read again.
+func (s *MoveService) Rank(ctx context.Context, userID string, task *domain.Task, req *domain.MoveTaskRequest) (string, error) {
+	rank, err := s.rankFor(ctx, userID, task, req)
+	if errors.Is(err, domain.ErrRankConflict) || (err == nil && len(rank) > domain.MaxRankLength) {
+		// No short rank fits between the neighbors: spread the list out and try again
+		if err := s.taskRepo.RebalanceRanks(ctx, task.RankScope()); err != nil {
+			return "", domain.NewInternalError("failed to rebalance task order", err)
+		}
+		current, findErr := s.findOwned(ctx, userID, task.ID)
+		if findErr != nil {
+			return "", findErr
+		}
+		*task = *current
+		rank, err = s.rankFor(ctx, userID, task, req)
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrRankConflict) || errors.Is(err, domain.ErrInvalidRank) {
+			return "", domain.NewInternalError("failed to find a position for the task", err)
+		}
+		return "", err
+	}
+	return rank, nil
+}
+
+// rankFor picks the task's new rank between the requested neighbors. A missing neighbor
+// is the task next to the given one (or the end of the list).
+func (s *MoveService) rankFor(ctx context.Context, userID string, task *domain.Task, req *domain.MoveTaskRequest) (string, error) {
+	scope := task.RankScope()
+	after, err := s.findNeighbor(ctx, userID, task, scope, req.AfterTaskID, "after_task_id")
+	if err != nil {
+		return "", err
+	}
+	before, err := s.findNeighbor(ctx, userID, task, scope, req.BeforeTaskID, "before_task_id")
+	if err != nil {
+		return "", err
+	}
+
+	var lower, upper string
+	switch {
+	case after != nil && before != nil:
+		if after.Rank > before.Rank {
+			return "", domain.NewValidationError("after_task_id", "must come before before_task_id in the list")
+		}
+		lower, upper = after.Rank, before.Rank
+	case after != nil:
+		lower = after.Rank
+		if upper, err = s.taskRepo.FindAdjacentRank(ctx, scope, after.Rank, task.ID, true); err != nil {
+			return "", domain.NewInternalError("failed to read task order", err)
+		}
+	default:
+		upper = before.Rank
+		if lower, err = s.taskRepo.FindAdjacentRank(ctx, scope, before.Rank, task.ID, false); err != nil {
+			return "", domain.NewInternalError("failed to read task order", err)
+		}
+	}
+
+	return domain.RankBetween(lower, upper)
+}
+
+// findNeighbor loads a requested neighbor, which must be another task of the same list
+func (s *MoveService) findNeighbor(ctx context.Context, userID string, task *domain.Task, scope domain.RankScope, neighborID *string, field string) (*domain.Task, error) {
+	if neighborID == nil {
+		return nil, nil
+	}
+	if *neighborID == task.ID {
+		return nil, domain.NewValidationError(field, "a task cannot be placed next to itself")
+	}
+
+	neighbor, err := s.findOwned(ctx, userID, *neighborID)
+	if err != nil {
+		return nil, err
+	}
+	if !neighbor.RankScope().Equal(scope) {
+		return nil, domain.NewValidationError(field, "must be in the same list as the task (top-level tasks, or subtasks of the same parent)")
+	}
+
+	return neighbor, nil
+}
+
+func (s *MoveService) findOwned(ctx context.Context, userID, taskID string) (*domain.Task, error) {
+	task, err := s.taskRepo.FindByID(ctx, taskID)
+	if errors.Is(err, domain.ErrTaskNotFound) {
+		return nil, domain.NewNotFoundError("task", taskID)
+	}
+	if err != nil {
+		return nil, domain.NewInternalError("failed to find task", err)
+	}
+	if task.UserID != userID {
+		return nil, domain.NewForbiddenError("task", "access")
+	}
+	return task, nil
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createRankedTestTask(userID, taskID, rank string) *domain.Task {
+	task := createRegularTestTask(userID, taskID)
+	task.Rank = rank
+	return task
+}
+
+// =============================================================================
+// MoveService.Move Tests
+// =============================================================================
+
+func TestMoveService_Move_AfterNeighbor(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewMoveService(mockTaskRepo)
+	afterID := "task-2"
+
+	task := createRankedTestTask("user-123", "task-1", "V")
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(task, nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-2").Return(createRankedTestTask("user-123", "task-2", "a"), nil)
+	mockTaskRepo.On("FindAdjacentRank", mock.Anything, task.RankScope(), "a", "task-1", true).Return("c", nil)
+	mockTaskRepo.On("UpdateRank", mock.Anything, mock.MatchedBy(func(moved *domain.Task) bool {
+		return moved.ID == "task-1" && moved.Rank > "a" && moved.Rank < "c"
+	})).Return(nil)
+
+	moved, err := service.Move(context.Background(), "user-123", "task-1", &domain.MoveTaskRequest{AfterTaskID: &afterID})
+
+	require.NoError(t, err)
+	assert.Equal(t, "b", moved.Rank)
+	// The loaded task is not modified in place
+	assert.Equal(t, "V", task.Rank)
+	mockTaskRepo.AssertExpectations(t)
+}
+
+func TestMoveService_Move_BeforeFirstTask(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewMoveService(mockTaskRepo)
+	beforeID := "task-2"
+
+	task := createRankedTestTask("user-123", "task-1", "V")
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(task, nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-2").Return(createRankedTestTask("user-123", "task-2", "1"), nil)
+	mockTaskRepo.On("FindAdjacentRank", mock.Anything, task.RankScope(), "1", "task-1", false).Return("", nil)
+	mockTaskRepo.On("UpdateRank", mock.Anything, mock.Anything).Return(nil)
+
+	moved, err := service.Move(context.Background(), "user-123", "task-1", &domain.MoveTaskRequest{BeforeTaskID: &beforeID})
+
+	require.NoError(t, err)
+	assert.Less(t, moved.Rank, "1")
+}
+
+func TestMoveService_Move_RebalancesWhenNoRoom(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewMoveService(mockTaskRepo)
+	afterID, beforeID := "task-2", "task-3"
+
+	// The neighbors share a rank until the list is rebalanced
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(createRankedTestTask("user-123", "task-1", "V"), nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-2").Return(createRankedTestTask("user-123", "task-2", "a"), nil).Once()
+	mockTaskRepo.On("FindByID", mock.Anything, "task-3").Return(createRankedTestTask("user-123", "task-3", "a"), nil).Once()
+	mockTaskRepo.On("RebalanceRanks", mock.Anything, domain.RankScope{UserID: "user-123"}).Return(nil).Once()
+	mockTaskRepo.On("FindByID", mock.Anything, "task-2").Return(createRankedTestTask("user-123", "task-2", "F"), nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-3").Return(createRankedTestTask("user-123", "task-3", "R"), nil)
+	mockTaskRepo.On("UpdateRank", mock.Anything, mock.Anything).Return(nil)
+
+	moved, err := service.Move(context.Background(), "user-123", "task-1", &domain.MoveTaskRequest{
+		AfterTaskID:  &afterID,
+		BeforeTaskID: &beforeID,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, moved.Rank > "F" && moved.Rank < "R")
+	mockTaskRepo.AssertExpectations(t)
+}
+
+func TestMoveService_Move_NeighborInOtherList(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewMoveService(mockTaskRepo)
+	afterID := "sub-1"
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(createRankedTestTask("user-123", "task-1", "V"), nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "sub-1").Return(createTestSubtask("user-123", "sub-1", "task-9"), nil)
+
+	_, err := service.Move(context.Background(), "user-123", "task-1", &domain.MoveTaskRequest{AfterTaskID: &afterID})
+
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "after_task_id", validationErr.Field)
+	mockTaskRepo.AssertNotCalled(t, "UpdateRank", mock.Anything, mock.Anything)
+}
+
+func TestMoveService_Move_NeighborsOutOfOrder(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewMoveService(mockTaskRepo)
+	afterID, beforeID := "task-2", "task-3"
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(createRankedTestTask("user-123", "task-1", "V"), nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-2").Return(createRankedTestTask("user-123", "task-2", "b"), nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-3").Return(createRankedTestTask("user-123", "task-3", "a"), nil)
+
+	_, err := service.Move(context.Background(), "user-123", "task-1", &domain.MoveTaskRequest{
+		AfterTaskID:  &afterID,
+		BeforeTaskID: &beforeID,
+	})
+
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+}
+
+func TestMoveService_Move_RequiresNeighbor(t *testing.T) {
+	service := NewMoveService(new(MockTaskRepository))
+
+	_, err := service.Move(context.Background(), "user-123", "task-1", &domain.MoveTaskRequest{})
+
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+}
+
+func TestMoveService_Move_VersionConflict(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewMoveService(mockTaskRepo)
+	afterID := "task-2"
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(createRankedTestTask("user-123", "task-1", "V"), nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-2").Return(createRankedTestTask("user-123", "task-2", "a"), nil)
+	mockTaskRepo.On("FindAdjacentRank", mock.Anything, mock.Anything, "a", "task-1", true).Return("", nil)
+	mockTaskRepo.On("UpdateRank", mock.Anything, mock.Anything).Return(domain.ErrTaskVersionConflict)
+
+	_, err := service.Move(context.Background(), "user-123", "task-1", &domain.MoveTaskRequest{AfterTaskID: &afterID})
+
+	var preconditionErr *domain.PreconditionFailedError
+	require.ErrorAs(t, err, &preconditionErr)
+}
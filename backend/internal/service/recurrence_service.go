@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -78,6 +79,13 @@ func (s *RecurrenceService) CreateTaskWithRecurrence(
 
 	// Update the task in the database
 	if err := s.taskRepo.Update(ctx, task); err != nil {
+		if errors.Is(err, domain.ErrTaskVersionConflict) {
+			current, err := s.taskRepo.FindByID(ctx, task.ID)
+			if err != nil {
+				return nil, nil, domain.NewInternalError("failed to find task", err)
+			}
+			return nil, nil, domain.NewPreconditionFailedError("task", current)
+		}
 		return nil, nil, domain.NewInternalError("failed to update task with series", err)
 	}
 
@@ -176,6 +184,7 @@ func (s *RecurrenceService) GenerateNextTask(
 		Category:        completedTask.Category,
 		Context:         completedTask.Context,
 		RelatedPeople:   completedTask.RelatedPeople,
+		Tags:            completedTask.Tags,
 		BumpCount:       0, // Reset bump count for new instance
 		SeriesID:        completedTask.SeriesID,
 		ParentTaskID:    &completedTask.ID,
@@ -191,13 +200,14 @@ func (s *RecurrenceService) GenerateNextTask(
 		return nil, domain.NewInternalError("failed to create next recurring task", err)
 	}
 
-	// Log task creation in history
+	// Log task creation in history; the snapshot lets undoing the completion remove it again
+	snapshot, _ := json.Marshal(nextTask)
 	s.taskHistoryRepo.Create(ctx, &domain.TaskHistory{
 		ID:        uuid.New().String(),
 		UserID:    nextTask.UserID,
 		TaskID:    nextTask.ID,
 		EventType: domain.EventTaskCreated,
-		NewValue:  strPtr("Auto-generated from recurring series"),
+		NewValue:  strPtr(string(snapshot)),
 		CreatedAt: now,
 	})
 
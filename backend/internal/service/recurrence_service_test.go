@@ -138,6 +138,32 @@ func (m *MockUserPreferencesRepository) GetAllPreferences(ctx context.Context, u
 	return args.Get(0).(*domain.AllPreferences), args.Error(1)
 }
 
+func (m *MockUserPreferencesRepository) GetFocusSettings(ctx context.Context, userID string) (*domain.FocusSettings, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FocusSettings), args.Error(1)
+}
+
+func (m *MockUserPreferencesRepository) UpsertFocusSettings(ctx context.Context, userID string, settings *domain.FocusSettings) error {
+	args := m.Called(ctx, userID, settings)
+	return args.Error(0)
+}
+
+func (m *MockUserPreferencesRepository) GetWIPLimits(ctx context.Context, userID string) (domain.WIPLimits, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domain.WIPLimits), args.Error(1)
+}
+
+func (m *MockUserPreferencesRepository) UpsertWIPLimits(ctx context.Context, userID string, limits domain.WIPLimits) error {
+	args := m.Called(ctx, userID, limits)
+	return args.Error(0)
+}
+
 // =============================================================================
 // Test Helpers
 // =============================================================================
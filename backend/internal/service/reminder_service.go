@@ -0,0 +1,345 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+)
+
+const (
+	// reminderLease is how long a claimed reminder is held before another instance may retry it
+	reminderLease = 2 * time.Minute
+	// reminderDeliveryTimeout bounds one delivery; no delivery starts unless it can finish
+	// within the batch's lease
+	reminderDeliveryTimeout = 30 * time.Second
+	// reminderBatchSize bounds how many reminders one scheduler pass claims
+	reminderBatchSize = 100
+)
+
+// ReminderService handles task reminders and their delivery
+type ReminderService struct {
+	reminderRepo ports.ReminderRepository
+	userRepo     ports.UserRepository
+	taskService  ports.TaskService
+	notifiers    map[domain.ReminderChannel]ports.Notifier
+	now          func() time.Time
+}
+
+// NewReminderService creates a new reminder service. Channels are enabled with SetNotifier.
+func NewReminderService(
+	reminderRepo ports.ReminderRepository,
+	userRepo ports.UserRepository,
+	taskService ports.TaskService,
+) *ReminderService {
+	return &ReminderService{
+		reminderRepo: reminderRepo,
+		userRepo:     userRepo,
+		taskService:  taskService,
+		notifiers:    make(map[domain.ReminderChannel]ports.Notifier),
+		now:          time.Now,
+	}
+}
+
+// SetNotifier enables a delivery channel; reminders can only be created for enabled channels
+func (s *ReminderService) SetNotifier(channel domain.ReminderChannel, notifier ports.Notifier) {
+	s.notifiers[channel] = notifier
+}
+
+// Create adds a reminder to a task the user owns
+func (s *ReminderService) Create(ctx context.Context, userID, taskID string, dto *domain.CreateReminderDTO) (*domain.Reminder, error) {
+	task, err := s.taskService.Get(ctx, userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	reminder := &domain.Reminder{
+		ID:            uuid.New().String(),
+		TaskID:        taskID,
+		UserID:        userID,
+		OffsetMinutes: dto.OffsetMinutes,
+		Channel:       dto.Channel,
+		Status:        domain.ReminderStatusPending,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	switch {
+	case (dto.RemindAt == nil) == (dto.OffsetMinutes == nil):
+		return nil, domain.NewValidationError("reminder", "exactly one of remind_at or offset_minutes is required")
+	case dto.RemindAt != nil:
+		if !dto.RemindAt.After(now) {
+			return nil, domain.NewValidationError("remind_at", "must be in the future")
+		}
+		reminder.RemindAt = dto.RemindAt
+	default:
+		if *dto.OffsetMinutes < 0 || *dto.OffsetMinutes > domain.MaxReminderOffsetMinutes {
+			return nil, domain.NewValidationError("offset_minutes", "must be between 0 and 525600")
+		}
+		// Stays unscheduled until the task gets a due date
+		reminder.RemindAt = reminder.RemindAtForDueDate(task.DueDate)
+	}
+
+	target, err := s.validateTarget(ctx, userID, dto.Channel, dto.Target)
+	if err != nil {
+		return nil, err
+	}
+	reminder.Target = target
+
+	if err := s.reminderRepo.Create(ctx, reminder); err != nil {
+		return nil, domain.NewInternalError("failed to create reminder", err)
+	}
+
+	return reminder, nil
+}
+
+// validateTarget checks the channel is enabled and the target suits it.
+// An email reminder without a target goes to the account email, which anonymous users lack.
+func (s *ReminderService) validateTarget(ctx context.Context, userID string, channel domain.ReminderChannel, target *string) (*string, error) {
+	if err := channel.Validate(); err != nil {
+		return nil, domain.NewValidationError("channel", err.Error())
+	}
+	if _, ok := s.notifiers[channel]; !ok {
+		return nil, domain.NewValidationError("channel", string(channel)+" notifications are not enabled on this server")
+	}
+
+	if target != nil {
+		trimmed := strings.TrimSpace(*target)
+		if trimmed == "" {
+			target = nil
+		} else {
+			if len(trimmed) > domain.MaxReminderTargetLength {
+				return nil, domain.NewValidationError("target", "is too long")
+			}
+			target = &trimmed
+		}
+	}
+
+	switch channel {
+	case domain.ReminderChannelWebhook:
+		if target == nil {
+			return nil, domain.NewValidationError("target", "is required for webhook reminders")
+		}
+		parsed, err := url.Parse(*target)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, domain.NewValidationError("target", "must be an http or https URL")
+		}
+	case domain.ReminderChannelEmail:
+		if target != nil {
+			address, err := mail.ParseAddress(*target)
+			if err != nil {
+				return nil, domain.NewValidationError("target", "must be an email address")
+			}
+			target = &address.Address
+			break
+		}
+		user, err := s.userRepo.FindByID(ctx, userID)
+		if err != nil {
+			return nil, domain.NewInternalError("failed to find user", err)
+		}
+		if user == nil || user.Email == nil {
+			return nil, domain.NewValidationError("target", "is required: the account has no email address")
+		}
+	}
+
+	return target, nil
+}
+
+// List retrieves a task's reminders, soonest first
+func (s *ReminderService) List(ctx context.Context, userID, taskID string) ([]*domain.Reminder, error) {
+	if _, err := s.taskService.Get(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	reminders, err := s.reminderRepo.FindByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to list reminders", err)
+	}
+	return reminders, nil
+}
+
+// Delete removes a reminder from a task
+func (s *ReminderService) Delete(ctx context.Context, userID, taskID, reminderID string) error {
+	reminder, err := s.reminderRepo.FindByID(ctx, reminderID)
+	if err != nil {
+		if err == domain.ErrReminderNotFound {
+			return err
+		}
+		return domain.NewInternalError("failed to find reminder", err)
+	}
+	if reminder.TaskID != taskID {
+		return domain.ErrReminderNotFound
+	}
+	if reminder.UserID != userID {
+		return domain.NewForbiddenError("reminder", "delete")
+	}
+
+	if err := s.reminderRepo.Delete(ctx, reminderID, userID); err != nil {
+		if err == domain.ErrReminderNotFound {
+			return err
+		}
+		return domain.NewInternalError("failed to delete reminder", err)
+	}
+	return nil
+}
+
+// RescheduleForTask moves the task's relative reminders to its current due date
+func (s *ReminderService) RescheduleForTask(ctx context.Context, task *domain.Task) error {
+	return s.reminderRepo.RescheduleForTask(ctx, task.ID, task.DueDate)
+}
+
+// CarryOver copies a recurring task's reminders to its next instance. Relative reminders
+// follow the new due date; absolute ones move by as much as the due date did, and are
+// dropped when either instance has no due date.
+func (s *ReminderService) CarryOver(ctx context.Context, from, to *domain.Task) error {
+	var shift *time.Duration
+	if from.DueDate != nil && to.DueDate != nil {
+		delta := to.DueDate.Sub(*from.DueDate)
+		shift = &delta
+	}
+	return s.reminderRepo.CopyToTask(ctx, from.ID, to.ID, to.DueDate, shift)
+}
+
+// ProcessDueReminders claims the reminders that are due and delivers them.
+// A failed delivery is retried with backoff until MaxReminderAttempts. Deliveries only
+// start while they can finish within the lease; the rest of the batch is released
+// for the next pass, so no other instance sends them while this one still might.
+func (s *ReminderService) ProcessDueReminders(ctx context.Context) (*domain.ReminderRunResult, error) {
+	leaseEnd := s.now().Add(reminderLease)
+	claimed, err := s.reminderRepo.ClaimDue(ctx, reminderLease, reminderBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.ReminderRunResult{Claimed: len(claimed)}
+	for i, due := range claimed {
+		if s.now().Add(reminderDeliveryTimeout).After(leaseEnd) {
+			s.release(ctx, claimed[i:])
+			result.Released = len(claimed) - i
+			break
+		}
+
+		if err := s.deliver(ctx, due); err != nil {
+			if s.recordFailure(ctx, due.Reminder, err) {
+				result.Retried++
+			} else {
+				result.Failed++
+			}
+			continue
+		}
+
+		if err := s.reminderRepo.MarkSent(ctx, due.Reminder.ID); err != nil {
+			// The lease expires and the reminder is delivered again; receivers can deduplicate on its ID
+			slog.Error("[Reminders] Failed to mark reminder sent",
+				"reminder_id", due.Reminder.ID, "error", err)
+			continue
+		}
+		result.Sent++
+	}
+
+	return result, nil
+}
+
+// release hands undelivered reminders back; if that fails their lease runs out instead
+func (s *ReminderService) release(ctx context.Context, remaining []*domain.DueReminder) {
+	ids := make([]string, len(remaining))
+	for i, due := range remaining {
+		ids[i] = due.Reminder.ID
+	}
+	if err := s.reminderRepo.Release(ctx, ids); err != nil {
+		slog.Error("[Reminders] Failed to release reminders", "count", len(ids), "error", err)
+	}
+}
+
+// deliver sends one claimed reminder over its channel
+func (s *ReminderService) deliver(ctx context.Context, due *domain.DueReminder) error {
+	notifier, ok := s.notifiers[due.Reminder.Channel]
+	if !ok {
+		return domain.NewValidationError("channel", string(due.Reminder.Channel)+" notifications are not enabled on this server")
+	}
+
+	recipient := ""
+	switch {
+	case due.Reminder.Target != nil:
+		recipient = *due.Reminder.Target
+	case due.Reminder.Channel == domain.ReminderChannelEmail && due.UserEmail != nil:
+		recipient = *due.UserEmail
+	default:
+		return domain.NewValidationError("target", "reminder has no recipient")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reminderDeliveryTimeout)
+	defer cancel()
+	return notifier.Notify(ctx, domain.NewReminderNotification(due, recipient))
+}
+
+// recordFailure stores a failed delivery and reports whether it will be retried
+func (s *ReminderService) recordFailure(ctx context.Context, reminder *domain.Reminder, deliveryErr error) bool {
+	var retryAt *time.Time
+	if reminder.Attempts < domain.MaxReminderAttempts {
+		// 1, 4, 9, 16 minutes
+		next := s.now().Add(time.Duration(reminder.Attempts*reminder.Attempts) * time.Minute)
+		retryAt = &next
+	}
+
+	slog.Warn("[Reminders] Reminder delivery failed",
+		"reminder_id", reminder.ID,
+		"channel", reminder.Channel,
+		"attempt", reminder.Attempts,
+		"will_retry", retryAt != nil,
+		"error", deliveryErr,
+	)
+
+	if err := s.reminderRepo.MarkFailed(ctx, reminder.ID, deliveryErr.Error(), retryAt); err != nil {
+		slog.Error("[Reminders] Failed to record reminder failure",
+			"reminder_id", reminder.ID, "error", err)
+	}
+	return retryAt != nil
+}
+
+// RunReminderLoop starts a background loop that delivers due reminders at the specified interval.
+// Any number of instances can run it; each due reminder is claimed by only one of them.
+// It blocks until the context is cancelled.
+func (s *ReminderService) RunReminderLoop(ctx context.Context, interval time.Duration) {
+	slog.Info("[Reminders] Starting reminder loop", "interval", interval)
+
+	// Run immediately on start
+	s.runOnce(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("[Reminders] Reminder loop stopped")
+			return
+		case <-ticker.C:
+			s.runOnce(ctx)
+		}
+	}
+}
+
+// runOnce runs one scheduler pass and logs its outcome
+func (s *ReminderService) runOnce(ctx context.Context) {
+	result, err := s.ProcessDueReminders(ctx)
+	if err != nil {
+		slog.Error("[Reminders] Scheduled delivery failed", "error", err)
+		return
+	}
+	if result.Claimed > 0 {
+		slog.Info("[Reminders] Delivered reminders",
+			"sent", result.Sent,
+			"retried", result.Retried,
+			"failed", result.Failed,
+			"released", result.Released,
+		)
+	}
+}
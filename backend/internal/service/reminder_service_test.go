@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+type reminderTestDeps struct {
+	reminderRepo    *MockReminderRepository
+	userRepo        *MockUserRepository
+	taskRepo        *MockTaskRepository
+	emailNotifier   *MockNotifier
+	webhookNotifier *MockNotifier
+}
+
+func newReminderService() (*ReminderService, *reminderTestDeps) {
+	deps := &reminderTestDeps{
+		reminderRepo:    new(MockReminderRepository),
+		userRepo:        new(MockUserRepository),
+		taskRepo:        new(MockTaskRepository),
+		emailNotifier:   new(MockNotifier),
+		webhookNotifier: new(MockNotifier),
+	}
+	taskService := NewTaskService(deps.taskRepo, new(MockTaskHistoryRepository))
+	service := NewReminderService(deps.reminderRepo, deps.userRepo, taskService)
+	service.SetNotifier(domain.ReminderChannelEmail, deps.emailNotifier)
+	service.SetNotifier(domain.ReminderChannelWebhook, deps.webhookNotifier)
+	return service, deps
+}
+
+func createTestDueReminder(reminderID string, channel domain.ReminderChannel, target *string, attempts int) *domain.DueReminder {
+	remindAt := time.Now().Add(-time.Minute)
+	email := "owner@example.com"
+	return &domain.DueReminder{
+		Reminder: &domain.Reminder{
+			ID:       reminderID,
+			TaskID:   "task-456",
+			UserID:   "user-123",
+			RemindAt: &remindAt,
+			Channel:  channel,
+			Target:   target,
+			Status:   domain.ReminderStatusPending,
+			Attempts: attempts,
+		},
+		TaskTitle: "Test Task",
+		UserEmail: &email,
+	}
+}
+
+// =============================================================================
+// ReminderService.Create Tests
+// =============================================================================
+
+func TestReminderService_Create_RelativeResolvesFromDueDate(t *testing.T) {
+	service, deps := newReminderService()
+
+	task := createTestTask("user-123", "task-456")
+	dueDate := time.Now().Add(48 * time.Hour)
+	task.DueDate = &dueDate
+	deps.taskRepo.On("FindByID", mock.Anything, "task-456").Return(task, nil)
+	deps.reminderRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Reminder")).Return(nil)
+
+	offset := 60
+	target := "https://hooks.example.com/taskflow"
+	reminder, err := service.Create(context.Background(), "user-123", "task-456", &domain.CreateReminderDTO{
+		OffsetMinutes: &offset,
+		Channel:       domain.ReminderChannelWebhook,
+		Target:        &target,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, reminder.RemindAt)
+	assert.True(t, reminder.RemindAt.Equal(dueDate.Add(-time.Hour)))
+	assert.Equal(t, domain.ReminderStatusPending, reminder.Status)
+	assert.True(t, reminder.IsRelative())
+	deps.reminderRepo.AssertExpectations(t)
+}
+
+func TestReminderService_Create_EmailDefaultsToAccountEmail(t *testing.T) {
+	service, deps := newReminderService()
+
+	email := "owner@example.com"
+	remindAt := time.Now().Add(time.Hour)
+	deps.taskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	deps.userRepo.On("FindByID", mock.Anything, "user-123").Return(&domain.User{ID: "user-123", Email: &email}, nil)
+	deps.reminderRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Reminder")).Return(nil)
+
+	reminder, err := service.Create(context.Background(), "user-123", "task-456", &domain.CreateReminderDTO{
+		RemindAt: &remindAt,
+		Channel:  domain.ReminderChannelEmail,
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, reminder.Target)
+	assert.False(t, reminder.IsRelative())
+}
+
+func TestReminderService_Create_Validation(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	offset := 30
+	badURL := "ftp://example.com/hook"
+	badEmail := "not-an-address"
+
+	tests := []struct {
+		name  string
+		dto   *domain.CreateReminderDTO
+		field string
+	}{
+		{"neither time nor offset", &domain.CreateReminderDTO{Channel: domain.ReminderChannelEmail}, "reminder"},
+		{"both time and offset", &domain.CreateReminderDTO{RemindAt: &future, OffsetMinutes: &offset, Channel: domain.ReminderChannelEmail}, "reminder"},
+		{"time in the past", &domain.CreateReminderDTO{RemindAt: &past, Channel: domain.ReminderChannelEmail}, "remind_at"},
+		{"unknown channel", &domain.CreateReminderDTO{RemindAt: &future, Channel: "sms"}, "channel"},
+		{"webhook without target", &domain.CreateReminderDTO{RemindAt: &future, Channel: domain.ReminderChannelWebhook}, "target"},
+		{"webhook with non-http target", &domain.CreateReminderDTO{RemindAt: &future, Channel: domain.ReminderChannelWebhook, Target: &badURL}, "target"},
+		{"email with invalid target", &domain.CreateReminderDTO{RemindAt: &future, Channel: domain.ReminderChannelEmail, Target: &badEmail}, "target"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, deps := newReminderService()
+			deps.taskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+
+			_, err := service.Create(context.Background(), "user-123", "task-456", tt.dto)
+
+			var validationErr *domain.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+			deps.reminderRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestReminderService_Create_ChannelNotEnabled(t *testing.T) {
+	mockReminderRepo := new(MockReminderRepository)
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewReminderService(mockReminderRepo, new(MockUserRepository), NewTaskService(mockTaskRepo, new(MockTaskHistoryRepository)))
+	service.SetNotifier(domain.ReminderChannelWebhook, new(MockNotifier))
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	remindAt := time.Now().Add(time.Hour)
+	target := "owner@example.com"
+
+	_, err := service.Create(context.Background(), "user-123", "task-456", &domain.CreateReminderDTO{
+		RemindAt: &remindAt,
+		Channel:  domain.ReminderChannelEmail,
+		Target:   &target,
+	})
+
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "channel", validationErr.Field)
+}
+
+// =============================================================================
+// ReminderService.Delete Tests
+// =============================================================================
+
+func TestReminderService_Delete_WrongTask(t *testing.T) {
+	service, deps := newReminderService()
+
+	deps.reminderRepo.On("FindByID", mock.Anything, "reminder-1").Return(&domain.Reminder{
+		ID: "reminder-1", TaskID: "other-task", UserID: "user-123",
+	}, nil)
+
+	err := service.Delete(context.Background(), "user-123", "task-456", "reminder-1")
+
+	assert.ErrorIs(t, err, domain.ErrReminderNotFound)
+	deps.reminderRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// =============================================================================
+// ReminderService.CarryOver Tests
+// =============================================================================
+
+func TestReminderService_CarryOver_ShiftsByDueDateDelta(t *testing.T) {
+	service, deps := newReminderService()
+
+	fromDue := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	toDue := fromDue.AddDate(0, 0, 7)
+	from := createTestTask("user-123", "task-456")
+	from.DueDate = &fromDue
+	to := createTestTask("user-123", "task-789")
+	to.DueDate = &toDue
+
+	week := 7 * 24 * time.Hour
+	deps.reminderRepo.On("CopyToTask", mock.Anything, "task-456", "task-789", &toDue, &week).Return(nil)
+
+	require.NoError(t, service.CarryOver(context.Background(), from, to))
+	deps.reminderRepo.AssertExpectations(t)
+}
+
+func TestReminderService_CarryOver_NoDueDateSkipsAbsolute(t *testing.T) {
+	service, deps := newReminderService()
+
+	from := createTestTask("user-123", "task-456")
+	to := createTestTask("user-123", "task-789")
+	deps.reminderRepo.On("CopyToTask", mock.Anything, "task-456", "task-789", (*time.Time)(nil), (*time.Duration)(nil)).Return(nil)
+
+	require.NoError(t, service.CarryOver(context.Background(), from, to))
+	deps.reminderRepo.AssertExpectations(t)
+}
+
+// =============================================================================
+// ReminderService.ProcessDueReminders Tests
+// =============================================================================
+
+func TestReminderService_ProcessDueReminders_DeliversAndMarksSent(t *testing.T) {
+	service, deps := newReminderService()
+
+	hook := "https://hooks.example.com/taskflow"
+	emailReminder := createTestDueReminder("reminder-1", domain.ReminderChannelEmail, nil, 1)
+	webhookReminder := createTestDueReminder("reminder-2", domain.ReminderChannelWebhook, &hook, 1)
+
+	deps.reminderRepo.On("ClaimDue", mock.Anything, reminderLease, reminderBatchSize).
+		Return([]*domain.DueReminder{emailReminder, webhookReminder}, nil)
+	deps.emailNotifier.On("Notify", mock.Anything, mock.Anything).Return(nil)
+	deps.webhookNotifier.On("Notify", mock.Anything, mock.Anything).Return(nil)
+	deps.reminderRepo.On("MarkSent", mock.Anything, "reminder-1").Return(nil)
+	deps.reminderRepo.On("MarkSent", mock.Anything, "reminder-2").Return(nil)
+
+	result, err := service.ProcessDueReminders(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, &domain.ReminderRunResult{Claimed: 2, Sent: 2}, result)
+
+	// Email without a target goes to the account email
+	require.Len(t, deps.emailNotifier.Sent, 1)
+	assert.Equal(t, "owner@example.com", deps.emailNotifier.Sent[0].Recipient)
+	assert.Equal(t, "reminder-1", deps.emailNotifier.Sent[0].ID)
+	require.Len(t, deps.webhookNotifier.Sent, 1)
+	assert.Equal(t, hook, deps.webhookNotifier.Sent[0].Recipient)
+	deps.reminderRepo.AssertExpectations(t)
+}
+
+func TestReminderService_ProcessDueReminders_RetriesWithBackoff(t *testing.T) {
+	service, deps := newReminderService()
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	due := createTestDueReminder("reminder-1", domain.ReminderChannelEmail, nil, 3)
+	deps.reminderRepo.On("ClaimDue", mock.Anything, reminderLease, reminderBatchSize).Return([]*domain.DueReminder{due}, nil)
+	deps.emailNotifier.On("Notify", mock.Anything, mock.Anything).Return(errors.New("connection refused"))
+	retryAt := now.Add(9 * time.Minute)
+	deps.reminderRepo.On("MarkFailed", mock.Anything, "reminder-1", "connection refused", &retryAt).Return(nil)
+
+	result, err := service.ProcessDueReminders(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, &domain.ReminderRunResult{Claimed: 1, Retried: 1}, result)
+	deps.reminderRepo.AssertNotCalled(t, "MarkSent", mock.Anything, mock.Anything)
+	deps.reminderRepo.AssertExpectations(t)
+}
+
+func TestReminderService_ProcessDueReminders_GivesUpAfterMaxAttempts(t *testing.T) {
+	service, deps := newReminderService()
+
+	due := createTestDueReminder("reminder-1", domain.ReminderChannelEmail, nil, domain.MaxReminderAttempts)
+	deps.reminderRepo.On("ClaimDue", mock.Anything, reminderLease, reminderBatchSize).Return([]*domain.DueReminder{due}, nil)
+	deps.emailNotifier.On("Notify", mock.Anything, mock.Anything).Return(errors.New("mailbox unavailable"))
+	deps.reminderRepo.On("MarkFailed", mock.Anything, "reminder-1", "mailbox unavailable", (*time.Time)(nil)).Return(nil)
+
+	result, err := service.ProcessDueReminders(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, &domain.ReminderRunResult{Claimed: 1, Failed: 1}, result)
+	deps.reminderRepo.AssertExpectations(t)
+}
+
+func TestReminderService_ProcessDueReminders_ReleasesRowsNearLeaseEnd(t *testing.T) {
+	service, deps := newReminderService()
+	// Every delivery appears to take a minute, so only the first one fits in the lease
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time {
+		current := now
+		now = now.Add(time.Minute)
+		return current
+	}
+
+	hook := "https://hooks.example.com/taskflow"
+	claimed := []*domain.DueReminder{
+		createTestDueReminder("reminder-1", domain.ReminderChannelWebhook, &hook, 1),
+		createTestDueReminder("reminder-2", domain.ReminderChannelWebhook, &hook, 1),
+		createTestDueReminder("reminder-3", domain.ReminderChannelWebhook, &hook, 1),
+	}
+	deps.reminderRepo.On("ClaimDue", mock.Anything, reminderLease, reminderBatchSize).Return(claimed, nil)
+	deps.webhookNotifier.On("Notify", mock.Anything, mock.Anything).Return(nil)
+	deps.reminderRepo.On("MarkSent", mock.Anything, "reminder-1").Return(nil)
+	deps.reminderRepo.On("Release", mock.Anything, []string{"reminder-2", "reminder-3"}).Return(nil)
+
+	result, err := service.ProcessDueReminders(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, &domain.ReminderRunResult{Claimed: 3, Sent: 1, Released: 2}, result)
+	require.Len(t, deps.webhookNotifier.Sent, 1)
+	assert.Equal(t, "reminder-1", deps.webhookNotifier.Sent[0].ID)
+	deps.reminderRepo.AssertExpectations(t)
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+)
+
+// SavedViewService handles saved view business logic
+type SavedViewService struct {
+	viewRepo    ports.SavedViewRepository
+	taskService ports.TaskService
+}
+
+// NewSavedViewService creates a new saved view service
+func NewSavedViewService(viewRepo ports.SavedViewRepository, taskService ports.TaskService) *SavedViewService {
+	return &SavedViewService{
+		viewRepo:    viewRepo,
+		taskService: taskService,
+	}
+}
+
+// Create creates a new saved view for the user
+func (s *SavedViewService) Create(ctx context.Context, userID string, dto *domain.CreateSavedViewDTO) (*domain.SavedView, error) {
+	// Check for duplicate name
+	exists, err := s.viewRepo.ExistsByName(ctx, userID, dto.Name)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to check view name", err)
+	}
+	if exists {
+		return nil, domain.ErrSavedViewDuplicateName
+	}
+
+	// New views go to the end of the list unless a position is given
+	position := 0
+	if dto.Position != nil {
+		position = *dto.Position
+	} else {
+		count, err := s.viewRepo.CountByUserID(ctx, userID)
+		if err != nil {
+			return nil, domain.NewInternalError("failed to count views", err)
+		}
+		position = count
+	}
+
+	now := time.Now()
+	view := &domain.SavedView{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Name:      dto.Name,
+		Icon:      dto.Icon,
+		Position:  position,
+		Filter:    dto.Filter,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	// Validate view (including that the filter's search and sort parse)
+	if err := view.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.viewRepo.Create(ctx, view); err != nil {
+		if err == domain.ErrSavedViewDuplicateName {
+			return nil, err
+		}
+		return nil, domain.NewInternalError("failed to create view", err)
+	}
+
+	return view, nil
+}
+
+// Get retrieves a specific saved view by ID, verifying ownership
+func (s *SavedViewService) Get(ctx context.Context, userID, viewID string) (*domain.SavedView, error) {
+	view, err := s.viewRepo.FindByID(ctx, viewID)
+	if err != nil {
+		if err == domain.ErrSavedViewNotFound {
+			return nil, err
+		}
+		return nil, domain.NewInternalError("failed to find view", err)
+	}
+
+	// Verify ownership
+	if view.UserID != userID {
+		return nil, domain.NewForbiddenError("view", "access")
+	}
+
+	return view, nil
+}
+
+// List retrieves all saved views for a user
+func (s *SavedViewService) List(ctx context.Context, userID string) ([]*domain.SavedView, error) {
+	views, err := s.viewRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to list views", err)
+	}
+
+	return views, nil
+}
+
+// Update updates an existing saved view
+func (s *SavedViewService) Update(ctx context.Context, userID, viewID string, dto *domain.UpdateSavedViewDTO) (*domain.SavedView, error) {
+	view, err := s.Get(ctx, userID, viewID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for duplicate name if name is being changed
+	if dto.Name != nil && *dto.Name != view.Name {
+		exists, err := s.viewRepo.ExistsByNameExcludingID(ctx, userID, *dto.Name, viewID)
+		if err != nil {
+			return nil, domain.NewInternalError("failed to check view name", err)
+		}
+		if exists {
+			return nil, domain.ErrSavedViewDuplicateName
+		}
+		view.Name = *dto.Name
+	}
+
+	// Apply updates (only update fields that are provided)
+	if dto.Icon != nil {
+		view.Icon = dto.Icon
+	}
+	if dto.Position != nil {
+		view.Position = *dto.Position
+	}
+	if dto.Filter != nil {
+		view.Filter = *dto.Filter
+	}
+
+	if err := view.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.viewRepo.Update(ctx, view); err != nil {
+		if err == domain.ErrSavedViewDuplicateName || err == domain.ErrSavedViewNotFound {
+			return nil, err
+		}
+		return nil, domain.NewInternalError("failed to update view", err)
+	}
+
+	return view, nil
+}
+
+// Delete removes a saved view
+func (s *SavedViewService) Delete(ctx context.Context, userID, viewID string) error {
+	// Verify ownership by attempting to get the view
+	if _, err := s.Get(ctx, userID, viewID); err != nil {
+		return err
+	}
+
+	if err := s.viewRepo.Delete(ctx, viewID, userID); err != nil {
+		if err == domain.ErrSavedViewNotFound {
+			return err
+		}
+		return domain.NewInternalError("failed to delete view", err)
+	}
+
+	return nil
+}
+
+// ListTasks executes the view's filter through the task service.
+// Relative dates in the view's search are resolved at call time.
+func (s *SavedViewService) ListTasks(ctx context.Context, userID, viewID, cursor string, limit int) (*domain.TaskListPage, error) {
+	view, err := s.Get(ctx, userID, viewID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := view.Filter.ToTaskListFilter(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	filter.Limit = limit
+
+	if cursor != "" {
+		taskCursor, err := domain.DecodeTaskCursor(cursor, filter.SortOrder())
+		if err != nil {
+			return nil, err
+		}
+		filter.Cursor = taskCursor
+	}
+
+	return s.taskService.ListPage(ctx, userID, filter)
+}
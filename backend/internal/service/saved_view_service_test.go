@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+func newSavedViewService() (*SavedViewService, *MockSavedViewRepository, *MockTaskRepository) {
+	mockViewRepo := new(MockSavedViewRepository)
+	mockTaskRepo := new(MockTaskRepository)
+	taskService := NewTaskService(mockTaskRepo, new(MockTaskHistoryRepository))
+	return NewSavedViewService(mockViewRepo, taskService), mockViewRepo, mockTaskRepo
+}
+
+// =============================================================================
+// SavedViewService.Create Tests
+// =============================================================================
+
+func TestSavedViewService_Create_AppendsToEnd(t *testing.T) {
+	service, mockViewRepo, _ := newSavedViewService()
+
+	dto := &domain.CreateSavedViewDTO{
+		Name: "Work this week",
+		Filter: domain.SavedViewFilter{
+			Search: stringPtr("category:work due:<7d -status:done"),
+			Sort:   stringPtr("due_date,-priority_score"),
+		},
+	}
+
+	mockViewRepo.On("ExistsByName", mock.Anything, "user-123", "Work this week").Return(false, nil)
+	mockViewRepo.On("CountByUserID", mock.Anything, "user-123").Return(3, nil)
+	mockViewRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.SavedView")).Return(nil)
+
+	view, err := service.Create(context.Background(), "user-123", dto)
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, view.ID)
+	assert.Equal(t, "user-123", view.UserID)
+	assert.Equal(t, 3, view.Position)
+	assert.Equal(t, "category:work due:<7d -status:done", *view.Filter.Search)
+	mockViewRepo.AssertExpectations(t)
+}
+
+func TestSavedViewService_Create_DuplicateName(t *testing.T) {
+	service, mockViewRepo, _ := newSavedViewService()
+
+	mockViewRepo.On("ExistsByName", mock.Anything, "user-123", "Inbox").Return(true, nil)
+
+	view, err := service.Create(context.Background(), "user-123", &domain.CreateSavedViewDTO{Name: "Inbox"})
+
+	assert.Nil(t, view)
+	assert.ErrorIs(t, err, domain.ErrSavedViewDuplicateName)
+	mockViewRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestSavedViewService_Create_InvalidFilter(t *testing.T) {
+	service, mockViewRepo, _ := newSavedViewService()
+	position := 0
+
+	mockViewRepo.On("ExistsByName", mock.Anything, "user-123", "Broken").Return(false, nil)
+
+	view, err := service.Create(context.Background(), "user-123", &domain.CreateSavedViewDTO{
+		Name:     "Broken",
+		Position: &position,
+		Filter:   domain.SavedViewFilter{Sort: stringPtr("-colour")},
+	})
+
+	assert.Nil(t, view)
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	mockViewRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+// =============================================================================
+// SavedViewService.Get / Update / Delete Tests
+// =============================================================================
+
+func TestSavedViewService_Get_Forbidden(t *testing.T) {
+	service, mockViewRepo, _ := newSavedViewService()
+
+	mockViewRepo.On("FindByID", mock.Anything, "view-1").
+		Return(&domain.SavedView{ID: "view-1", UserID: "other-user", Name: "Theirs"}, nil)
+
+	view, err := service.Get(context.Background(), "user-123", "view-1")
+
+	assert.Nil(t, view)
+	var forbiddenErr *domain.ForbiddenError
+	assert.ErrorAs(t, err, &forbiddenErr)
+}
+
+func TestSavedViewService_Get_RepoError(t *testing.T) {
+	service, mockViewRepo, _ := newSavedViewService()
+
+	mockViewRepo.On("FindByID", mock.Anything, "view-1").Return(nil, errors.New("database error"))
+
+	view, err := service.Get(context.Background(), "user-123", "view-1")
+
+	assert.Nil(t, view)
+	var internalErr *domain.InternalError
+	assert.ErrorAs(t, err, &internalErr)
+}
+
+func TestSavedViewService_Update_ReplacesFilter(t *testing.T) {
+	service, mockViewRepo, _ := newSavedViewService()
+
+	existing := &domain.SavedView{
+		ID:     "view-1",
+		UserID: "user-123",
+		Name:   "Work",
+		Filter: domain.SavedViewFilter{Category: stringPtr("work")},
+	}
+	status := domain.TaskStatusInProgress
+
+	mockViewRepo.On("FindByID", mock.Anything, "view-1").Return(existing, nil)
+	mockViewRepo.On("Update", mock.Anything, existing).Return(nil)
+
+	view, err := service.Update(context.Background(), "user-123", "view-1", &domain.UpdateSavedViewDTO{
+		Icon:   stringPtr("briefcase"),
+		Filter: &domain.SavedViewFilter{Status: &status},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "briefcase", *view.Icon)
+	assert.Nil(t, view.Filter.Category)
+	assert.Equal(t, domain.TaskStatusInProgress, *view.Filter.Status)
+	mockViewRepo.AssertExpectations(t)
+}
+
+func TestSavedViewService_Delete_NotFound(t *testing.T) {
+	service, mockViewRepo, _ := newSavedViewService()
+
+	mockViewRepo.On("FindByID", mock.Anything, "missing").Return(nil, domain.ErrSavedViewNotFound)
+
+	err := service.Delete(context.Background(), "user-123", "missing")
+
+	assert.ErrorIs(t, err, domain.ErrSavedViewNotFound)
+	mockViewRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// =============================================================================
+// SavedViewService.ListTasks Tests
+// =============================================================================
+
+func TestSavedViewService_ListTasks_ExecutesFilter(t *testing.T) {
+	service, mockViewRepo, mockTaskRepo := newSavedViewService()
+
+	mockViewRepo.On("FindByID", mock.Anything, "view-1").Return(&domain.SavedView{
+		ID:     "view-1",
+		UserID: "user-123",
+		Name:   "Urgent work",
+		Filter: domain.SavedViewFilter{
+			Category: stringPtr("work"),
+			Search:   stringPtr("priority:>=70"),
+			Sort:     stringPtr("due_date"),
+		},
+	}, nil)
+
+	matchesView := mock.MatchedBy(func(filter *domain.TaskListFilter) bool {
+		return filter.Category != nil && *filter.Category == "work" &&
+			filter.Query != nil && filter.Query.Clauses[0].Terms[0].Number == 70 &&
+			len(filter.Sort) == 1 && filter.Sort[0].Field == domain.TaskSortDueDate
+	})
+	mockTaskRepo.On("List", mock.Anything, "user-123", matchesView).
+		Return([]*domain.Task{{ID: "task-1", UserID: "user-123"}}, nil)
+	mockTaskRepo.On("Count", mock.Anything, "user-123", matchesView).Return(1, nil)
+
+	page, err := service.ListTasks(context.Background(), "user-123", "view-1", "", 20)
+
+	require.NoError(t, err)
+	assert.Len(t, page.Tasks, 1)
+	assert.Equal(t, 1, page.TotalCount)
+	mockTaskRepo.AssertExpectations(t)
+}
+
+func TestSavedViewService_ListTasks_CursorFromOtherSort(t *testing.T) {
+	service, mockViewRepo, mockTaskRepo := newSavedViewService()
+
+	mockViewRepo.On("FindByID", mock.Anything, "view-1").Return(&domain.SavedView{
+		ID:     "view-1",
+		UserID: "user-123",
+		Name:   "By title",
+		Filter: domain.SavedViewFilter{Sort: stringPtr("title")},
+	}, nil)
+
+	// Cursor issued for the default ordering cannot be used with this view's sort
+	cursor := domain.NewTaskCursor(&domain.Task{ID: "3f0a6c1e-8d5b-4b7a-9c2e-1a2b3c4d5e6f"}, domain.DefaultTaskSort).Encode()
+
+	page, err := service.ListTasks(context.Background(), "user-123", "view-1", cursor, 20)
+
+	assert.Nil(t, page)
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	mockTaskRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything)
+}
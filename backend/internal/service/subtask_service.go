@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,6 +18,7 @@ type SubtaskService struct {
 	taskRepo        ports.TaskRepository
 	taskHistoryRepo ports.TaskHistoryRepository
 	priorityCalc    *priority.Calculator
+	maxDepth        int
 }
 
 // NewSubtaskService creates a new subtask service
@@ -24,14 +27,24 @@ func NewSubtaskService(taskRepo ports.TaskRepository, taskHistoryRepo ports.Task
 		taskRepo:        taskRepo,
 		taskHistoryRepo: taskHistoryRepo,
 		priorityCalc:    priority.NewCalculator(),
+		maxDepth:        domain.DefaultMaxTaskDepth,
 	}
 }
 
+// SetMaxDepth sets how many levels a task hierarchy may have, counting the top-level task.
+// Values below 2 still allow one level of subtasks.
+func (s *SubtaskService) SetMaxDepth(maxDepth int) {
+	s.maxDepth = max(maxDepth, 2)
+}
+
 // Create creates a new subtask under a parent task
-// Validates single-level nesting and inherits parent's category
+// Validates the hierarchy depth and inherits parent's category
 func (s *SubtaskService) Create(ctx context.Context, userID string, dto *domain.CreateSubtaskDTO) (*domain.Task, error) {
 	// Validate parent task exists and user owns it
 	parentTask, err := s.taskRepo.FindByID(ctx, dto.ParentTaskID)
+	if errors.Is(err, domain.ErrTaskNotFound) {
+		return nil, domain.ErrParentNotFound
+	}
 	if err != nil {
 		return nil, domain.NewInternalError("failed to find parent task", err)
 	}
@@ -42,9 +55,9 @@ func (s *SubtaskService) Create(ctx context.Context, userID string, dto *domain.
 		return nil, domain.NewForbiddenError("parent task", "access")
 	}
 
-	// Enforce single-level nesting: parent cannot be a subtask
-	if !parentTask.CanHaveSubtasks() {
-		return nil, domain.ErrSubtaskDepthExceeded
+	// Recurring tasks cannot have subtasks, and the hierarchy cannot grow past the max depth
+	if err := checkSubtaskPlacement(ctx, s.taskRepo, s.maxDepth, parentTask, "", 0); err != nil {
+		return nil, err
 	}
 
 	// Validate title
@@ -105,7 +118,8 @@ func (s *SubtaskService) Create(ctx context.Context, userID string, dto *domain.
 		UpdatedAt:       now,
 	}
 
-	// Calculate priority with parent boost (15% of parent's priority score)
+	// Calculate priority with parent boost (15% of parent's priority score, which
+	// carries its own parent's boost, so priority is inherited down the tree)
 	subtask.PriorityScore = s.priorityCalc.CalculateForSubtask(subtask, parentTask.PriorityScore)
 
 	// Save to database
@@ -114,7 +128,7 @@ func (s *SubtaskService) Create(ctx context.Context, userID string, dto *domain.
 	}
 
 	// Log subtask creation in history
-	if err := s.logHistorySimple(ctx, userID, subtask.ID, domain.EventTaskCreated, nil, nil); err != nil {
+	if err := s.logHistory(ctx, userID, subtask.ID, domain.EventTaskCreated, nil, subtask); err != nil {
 		// Log error but don't fail the request
 	}
 
@@ -183,8 +197,8 @@ func (s *SubtaskService) GetTaskWithSubtasks(ctx context.Context, userID, taskID
 		Task: task,
 	}
 
-	// Only regular tasks can have subtasks
-	if task.TaskType == domain.TaskTypeRegular {
+	// Recurring tasks cannot have subtasks
+	if task.CanHaveSubtasks() {
 		// Get subtask info
 		info, err := s.taskRepo.GetSubtaskInfo(ctx, taskID)
 		if err != nil {
@@ -205,6 +219,31 @@ func (s *SubtaskService) GetTaskWithSubtasks(ctx context.Context, userID, taskID
 	return result, nil
 }
 
+// GetTree retrieves a task with its whole subtree, loaded in one query, each level
+// carrying its own rolled-up subtask info
+func (s *SubtaskService) GetTree(ctx context.Context, userID, taskID string) (*domain.TaskTree, error) {
+	task, err := s.taskRepo.FindByID(ctx, taskID)
+	if errors.Is(err, domain.ErrTaskNotFound) {
+		return nil, domain.NewNotFoundError("task", taskID)
+	}
+	if err != nil {
+		return nil, domain.NewInternalError("failed to find task", err)
+	}
+	if task.UserID != userID {
+		return nil, domain.NewForbiddenError("task", "access")
+	}
+
+	var descendants []*domain.Task
+	if task.CanHaveSubtasks() {
+		descendants, err = s.taskRepo.GetSubtree(ctx, taskID)
+		if err != nil {
+			return nil, domain.NewInternalError("failed to retrieve subtask tree", err)
+		}
+	}
+
+	return domain.BuildTaskTree(task, descendants), nil
+}
+
 // CompleteSubtask marks a subtask as complete and checks if all subtasks are done
 // Returns special response to prompt user for parent completion if this was the last subtask
 func (s *SubtaskService) CompleteSubtask(ctx context.Context, userID, subtaskID string) (*domain.SubtaskCompletionResponse, error) {
@@ -225,18 +264,35 @@ func (s *SubtaskService) CompleteSubtask(ctx context.Context, userID, subtaskID
 		return nil, domain.NewValidationError("task", "is not a subtask")
 	}
 
+	// A subtask with subtasks of its own completes only once they are done
+	incompleteChildren, err := s.taskRepo.CountIncompleteSubtasks(ctx, subtaskID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to count incomplete subtasks", err)
+	}
+	if incompleteChildren > 0 {
+		return nil, domain.ErrCannotCompleteParent
+	}
+
 	// Complete the subtask
+	oldSubtask := *subtask
 	subtask.Status = domain.TaskStatusDone
 	now := time.Now()
 	subtask.CompletedAt = &now
 	subtask.UpdatedAt = now
 
 	if err := s.taskRepo.Update(ctx, subtask); err != nil {
+		if errors.Is(err, domain.ErrTaskVersionConflict) {
+			current, err := s.taskRepo.FindByID(ctx, subtaskID)
+			if err != nil {
+				return nil, domain.NewInternalError("failed to find task", err)
+			}
+			return nil, domain.NewPreconditionFailedError("task", current)
+		}
 		return nil, domain.NewInternalError("failed to update subtask", err)
 	}
 
 	// Log completion
-	if err := s.logHistorySimple(ctx, userID, subtaskID, domain.EventTaskCompleted, nil, nil); err != nil {
+	if err := s.logHistory(ctx, userID, subtaskID, domain.EventTaskCompleted, &oldSubtask, subtask); err != nil {
 		// Log error but don't fail the request
 	}
 
@@ -300,8 +356,8 @@ func (s *SubtaskService) ValidateParentCompletion(ctx context.Context, taskID st
 		return nil // Not found is OK - TaskService handles this
 	}
 
-	// Only check regular tasks (they can have subtasks)
-	if task.TaskType != domain.TaskTypeRegular {
+	// Recurring tasks have no subtasks to check
+	if !task.CanHaveSubtasks() {
 		return nil
 	}
 
@@ -318,6 +374,25 @@ func (s *SubtaskService) ValidateParentCompletion(ctx context.Context, taskID st
 	return nil
 }
 
+// logHistory creates a history entry with before/after snapshots of the task, as TaskService does
+func (s *SubtaskService) logHistory(ctx context.Context, userID, taskID string, eventType domain.TaskHistoryEventType, oldTask, newTask *domain.Task) error {
+	var oldValue, newValue *string
+
+	if oldTask != nil {
+		data, _ := json.Marshal(oldTask)
+		str := string(data)
+		oldValue = &str
+	}
+
+	if newTask != nil {
+		data, _ := json.Marshal(newTask)
+		str := string(data)
+		newValue = &str
+	}
+
+	return s.logHistorySimple(ctx, userID, taskID, eventType, oldValue, newValue)
+}
+
 // logHistorySimple creates a history entry with simple values
 func (s *SubtaskService) logHistorySimple(ctx context.Context, userID, taskID string, eventType domain.TaskHistoryEventType, oldValue, newValue *string) error {
 	history := &domain.TaskHistory{
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// SubtaskService.Create Tests
+// =============================================================================
+
+func TestSubtaskService_Create_UnderSubtask(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewSubtaskService(mockTaskRepo, mockHistoryRepo)
+
+	parent := createTestSubtask("user-123", "story-1", "epic-1")
+	category := "Work"
+	parent.Category = &category
+	parent.PriorityScore = 60
+
+	mockTaskRepo.On("FindByID", mock.Anything, "story-1").Return(parent, nil)
+	mockTaskRepo.On("GetAncestorIDs", mock.Anything, "story-1").Return([]string{"epic-1"}, nil)
+	mockTaskRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Task")).Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	subtask, err := service.Create(context.Background(), "user-123", &domain.CreateSubtaskDTO{
+		ParentTaskID: "story-1",
+		Title:        "Step",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.TaskTypeSubtask, subtask.TaskType)
+	assert.Equal(t, "story-1", *subtask.ParentTaskID)
+	assert.Equal(t, "Work", *subtask.Category)
+	mockTaskRepo.AssertExpectations(t)
+}
+
+func TestSubtaskService_Create_DepthExceeded(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewSubtaskService(mockTaskRepo, new(MockTaskHistoryRepository))
+	service.SetMaxDepth(3)
+
+	mockTaskRepo.On("FindByID", mock.Anything, "step-1").Return(createTestSubtask("user-123", "step-1", "story-1"), nil)
+	mockTaskRepo.On("GetAncestorIDs", mock.Anything, "step-1").Return([]string{"story-1", "epic-1"}, nil)
+
+	// epic -> story -> step fills all 3 levels
+	_, err := service.Create(context.Background(), "user-123", &domain.CreateSubtaskDTO{ParentTaskID: "step-1", Title: "Detail"})
+	assert.ErrorIs(t, err, domain.ErrSubtaskDepthExceeded)
+	mockTaskRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestSubtaskService_Create_ParentNotFound(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewSubtaskService(mockTaskRepo, new(MockTaskHistoryRepository))
+
+	mockTaskRepo.On("FindByID", mock.Anything, "missing").Return(nil, domain.ErrTaskNotFound)
+
+	_, err := service.Create(context.Background(), "user-123", &domain.CreateSubtaskDTO{ParentTaskID: "missing", Title: "Step"})
+
+	assert.ErrorIs(t, err, domain.ErrParentNotFound)
+}
+
+// =============================================================================
+// SubtaskService.GetTree Tests
+// =============================================================================
+
+func TestSubtaskService_GetTree(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewSubtaskService(mockTaskRepo, new(MockTaskHistoryRepository))
+
+	root := createTestTask("user-123", "epic-1")
+	root.TaskType = domain.TaskTypeRegular
+	story := createTestSubtask("user-123", "story-1", "epic-1")
+	step := createTestSubtask("user-123", "step-1", "story-1")
+	step.Status = domain.TaskStatusDone
+
+	mockTaskRepo.On("FindByID", mock.Anything, "epic-1").Return(root, nil)
+	mockTaskRepo.On("GetSubtree", mock.Anything, "epic-1").Return([]*domain.Task{story, step}, nil)
+
+	tree, err := service.GetTree(context.Background(), "user-123", "epic-1")
+
+	require.NoError(t, err)
+	require.Len(t, tree.Subtasks, 1)
+	require.Len(t, tree.Subtasks[0].Subtasks, 1)
+	assert.Equal(t, "step-1", tree.Subtasks[0].Subtasks[0].ID)
+	assert.Equal(t, 2, tree.SubtaskInfo.DescendantCount)
+	assert.InDelta(t, 1.0, tree.SubtaskInfo.Progress, 0.001)
+	assert.Equal(t, 0, tree.SubtaskInfo.CompletedCount)
+}
+
+func TestSubtaskService_GetTree_OtherUsersTask(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewSubtaskService(mockTaskRepo, new(MockTaskHistoryRepository))
+
+	mockTaskRepo.On("FindByID", mock.Anything, "epic-1").Return(createTestTask("user-456", "epic-1"), nil)
+
+	_, err := service.GetTree(context.Background(), "user-123", "epic-1")
+
+	var forbiddenErr *domain.ForbiddenError
+	require.ErrorAs(t, err, &forbiddenErr)
+	mockTaskRepo.AssertNotCalled(t, "GetSubtree", mock.Anything, mock.Anything)
+}
+
+// =============================================================================
+// SubtaskService.CompleteSubtask Tests
+// =============================================================================
+
+func TestSubtaskService_CompleteSubtask_LogsSnapshots(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewSubtaskService(mockTaskRepo, mockHistoryRepo)
+
+	mockTaskRepo.On("FindByID", mock.Anything, "story-1").Return(createTestSubtask("user-123", "story-1", "epic-1"), nil)
+	mockTaskRepo.On("CountIncompleteSubtasks", mock.Anything, "story-1").Return(0, nil)
+	mockTaskRepo.On("CountIncompleteSubtasks", mock.Anything, "epic-1").Return(1, nil)
+	mockTaskRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	// Undo and the history diff need the task before and after completing
+	mockHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+		if h.EventType != domain.EventTaskCompleted || h.OldValue == nil || h.NewValue == nil {
+			return false
+		}
+		var before, after domain.Task
+		return json.Unmarshal([]byte(*h.OldValue), &before) == nil && json.Unmarshal([]byte(*h.NewValue), &after) == nil &&
+			before.Status == domain.TaskStatusTodo && after.Status == domain.TaskStatusDone
+	})).Return(nil)
+
+	response, err := service.CompleteSubtask(context.Background(), "user-123", "story-1")
+
+	require.NoError(t, err)
+	assert.False(t, response.AllSubtasksComplete)
+	mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestSubtaskService_CompleteSubtask_OpenChildren(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewSubtaskService(mockTaskRepo, new(MockTaskHistoryRepository))
+
+	mockTaskRepo.On("FindByID", mock.Anything, "story-1").Return(createTestSubtask("user-123", "story-1", "epic-1"), nil)
+	mockTaskRepo.On("CountIncompleteSubtasks", mock.Anything, "story-1").Return(2, nil)
+
+	_, err := service.CompleteSubtask(context.Background(), "user-123", "story-1")
+
+	assert.ErrorIs(t, err, domain.ErrCannotCompleteParent)
+	mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestSubtaskService_CompleteSubtask_VersionConflict(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewSubtaskService(mockTaskRepo, new(MockTaskHistoryRepository))
+
+	mockTaskRepo.On("FindByID", mock.Anything, "story-1").Return(createTestSubtask("user-123", "story-1", "epic-1"), nil)
+	mockTaskRepo.On("CountIncompleteSubtasks", mock.Anything, "story-1").Return(0, nil)
+	mockTaskRepo.On("Update", mock.Anything, mock.Anything).Return(domain.ErrTaskVersionConflict)
+
+	_, err := service.CompleteSubtask(context.Background(), "user-123", "story-1")
+
+	var preconditionErr *domain.PreconditionFailedError
+	require.ErrorAs(t, err, &preconditionErr)
+}
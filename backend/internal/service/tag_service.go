@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+	"github.com/notkevinvu/taskflow/backend/internal/validation"
+)
+
+// TagService handles tag business logic
+type TagService struct {
+	tagRepo ports.TagRepository
+}
+
+// NewTagService creates a new tag service
+func NewTagService(tagRepo ports.TagRepository) *TagService {
+	return &TagService{tagRepo: tagRepo}
+}
+
+// Create creates a new tag for the user
+func (s *TagService) Create(ctx context.Context, userID string, dto *domain.CreateTagDTO) (*domain.Tag, error) {
+	name, err := validation.ValidateTagName(dto.Name, "name")
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateTagColor(dto.Color); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tag := &domain.Tag{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Name:      name,
+		Color:     dto.Color,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	// Uniqueness is enforced by the case-insensitive index
+	if err := s.tagRepo.Create(ctx, tag); err != nil {
+		if err == domain.ErrTagDuplicateName {
+			return nil, err
+		}
+		return nil, domain.NewInternalError("failed to create tag", err)
+	}
+
+	return tag, nil
+}
+
+// Get retrieves a specific tag by ID, verifying ownership
+func (s *TagService) Get(ctx context.Context, userID, tagID string) (*domain.Tag, error) {
+	tag, err := s.tagRepo.FindByID(ctx, tagID)
+	if err != nil {
+		if err == domain.ErrTagNotFound {
+			return nil, err
+		}
+		return nil, domain.NewInternalError("failed to find tag", err)
+	}
+
+	// Verify ownership
+	if tag.UserID != userID {
+		return nil, domain.NewForbiddenError("tag", "access")
+	}
+
+	return tag, nil
+}
+
+// List retrieves all tags for a user
+func (s *TagService) List(ctx context.Context, userID string) ([]*domain.Tag, error) {
+	tags, err := s.tagRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to list tags", err)
+	}
+
+	return tags, nil
+}
+
+// Update renames or recolors a tag. A rename applies to every task carrying the tag.
+func (s *TagService) Update(ctx context.Context, userID, tagID string, dto *domain.UpdateTagDTO) (*domain.Tag, error) {
+	tag, err := s.Get(ctx, userID, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.Name != nil {
+		name, err := validation.ValidateTagName(*dto.Name, "name")
+		if err != nil {
+			return nil, err
+		}
+		tag.Name = name
+	}
+	if dto.Color != nil {
+		if err := domain.ValidateTagColor(dto.Color); err != nil {
+			return nil, err
+		}
+		tag.Color = dto.Color
+	}
+
+	if err := s.tagRepo.Update(ctx, tag); err != nil {
+		if err == domain.ErrTagDuplicateName || err == domain.ErrTagNotFound {
+			return nil, err
+		}
+		return nil, domain.NewInternalError("failed to update tag", err)
+	}
+
+	return tag, nil
+}
+
+// Delete removes a tag from every task and template, then deletes it
+func (s *TagService) Delete(ctx context.Context, userID, tagID string) error {
+	// Verify ownership by attempting to get the tag
+	if _, err := s.Get(ctx, userID, tagID); err != nil {
+		return err
+	}
+
+	if err := s.tagRepo.Delete(ctx, tagID, userID); err != nil {
+		if err == domain.ErrTagNotFound {
+			return err
+		}
+		return domain.NewInternalError("failed to delete tag", err)
+	}
+
+	return nil
+}
+
+// Merge folds the source tag into the target tag: every task carrying the source
+// ends up carrying the target, and the source is deleted
+func (s *TagService) Merge(ctx context.Context, userID, sourceID, targetID string) (*domain.MergeTagsResponse, error) {
+	if sourceID == targetID {
+		return nil, domain.ErrTagMergeSelf
+	}
+
+	// Verify ownership of both tags
+	if _, err := s.Get(ctx, userID, sourceID); err != nil {
+		return nil, err
+	}
+	if _, err := s.Get(ctx, userID, targetID); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.tagRepo.Merge(ctx, userID, sourceID, targetID)
+	if err != nil {
+		if err == domain.ErrTagNotFound {
+			return nil, err
+		}
+		return nil, domain.NewInternalError("failed to merge tags", err)
+	}
+
+	// Refetch so the task count reflects the merge
+	target, err := s.Get(ctx, userID, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.MergeTagsResponse{
+		Tag:          target,
+		UpdatedCount: updated,
+	}, nil
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// TagService.Create Tests
+// =============================================================================
+
+func TestTagService_Create_Success(t *testing.T) {
+	mockRepo := new(MockTagRepository)
+	service := NewTagService(mockRepo)
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Tag")).Return(nil)
+
+	tag, err := service.Create(context.Background(), "user-123", &domain.CreateTagDTO{
+		Name:  "  deep work ",
+		Color: stringPtr("#3b82f6"),
+	})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, tag.ID)
+	assert.Equal(t, "user-123", tag.UserID)
+	assert.Equal(t, "deep work", tag.Name)
+	assert.Equal(t, "#3b82f6", *tag.Color)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTagService_Create_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		dto  domain.CreateTagDTO
+	}{
+		{"empty name", domain.CreateTagDTO{Name: "   "}},
+		{"special characters", domain.CreateTagDTO{Name: "work!"}},
+		{"bad color", domain.CreateTagDTO{Name: "work", Color: stringPtr("red")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTagRepository)
+			service := NewTagService(mockRepo)
+
+			tag, err := service.Create(context.Background(), "user-123", &tt.dto)
+
+			assert.Nil(t, tag)
+			var validationErr *domain.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestTagService_Create_DuplicateName(t *testing.T) {
+	mockRepo := new(MockTagRepository)
+	service := NewTagService(mockRepo)
+
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return(domain.ErrTagDuplicateName)
+
+	tag, err := service.Create(context.Background(), "user-123", &domain.CreateTagDTO{Name: "Work"})
+
+	assert.Nil(t, tag)
+	assert.ErrorIs(t, err, domain.ErrTagDuplicateName)
+}
+
+// =============================================================================
+// TagService.Get / Update Tests
+// =============================================================================
+
+func TestTagService_Get_OtherUsersTag(t *testing.T) {
+	mockRepo := new(MockTagRepository)
+	service := NewTagService(mockRepo)
+
+	mockRepo.On("FindByID", mock.Anything, "tag-1").Return(&domain.Tag{ID: "tag-1", UserID: "user-456"}, nil)
+
+	tag, err := service.Get(context.Background(), "user-123", "tag-1")
+
+	assert.Nil(t, tag)
+	var forbiddenErr *domain.ForbiddenError
+	assert.ErrorAs(t, err, &forbiddenErr)
+}
+
+func TestTagService_Update_Rename(t *testing.T) {
+	mockRepo := new(MockTagRepository)
+	service := NewTagService(mockRepo)
+
+	mockRepo.On("FindByID", mock.Anything, "tag-1").Return(&domain.Tag{ID: "tag-1", UserID: "user-123", Name: "wrok"}, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(tag *domain.Tag) bool {
+		return tag.Name == "work"
+	})).Return(nil)
+
+	tag, err := service.Update(context.Background(), "user-123", "tag-1", &domain.UpdateTagDTO{Name: stringPtr("work")})
+
+	require.NoError(t, err)
+	assert.Equal(t, "work", tag.Name)
+	mockRepo.AssertExpectations(t)
+}
+
+// =============================================================================
+// TagService.Merge Tests
+// =============================================================================
+
+func TestTagService_Merge_Self(t *testing.T) {
+	mockRepo := new(MockTagRepository)
+	service := NewTagService(mockRepo)
+
+	result, err := service.Merge(context.Background(), "user-123", "tag-1", "tag-1")
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrTagMergeSelf)
+	mockRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTagService_Merge_Success(t *testing.T) {
+	mockRepo := new(MockTagRepository)
+	service := NewTagService(mockRepo)
+
+	source := &domain.Tag{ID: "tag-1", UserID: "user-123", Name: "urgnet", TaskCount: 3}
+	target := &domain.Tag{ID: "tag-2", UserID: "user-123", Name: "urgent", TaskCount: 5}
+	merged := &domain.Tag{ID: "tag-2", UserID: "user-123", Name: "urgent", TaskCount: 7}
+
+	mockRepo.On("FindByID", mock.Anything, "tag-1").Return(source, nil)
+	mockRepo.On("FindByID", mock.Anything, "tag-2").Return(target, nil).Once()
+	mockRepo.On("Merge", mock.Anything, "user-123", "tag-1", "tag-2").Return(3, nil)
+	mockRepo.On("FindByID", mock.Anything, "tag-2").Return(merged, nil).Once()
+
+	result, err := service.Merge(context.Background(), "user-123", "tag-1", "tag-2")
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.UpdatedCount)
+	assert.Equal(t, 7, result.Tag.TaskCount)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTagService_Merge_RepositoryError(t *testing.T) {
+	mockRepo := new(MockTagRepository)
+	service := NewTagService(mockRepo)
+
+	mockRepo.On("FindByID", mock.Anything, "tag-1").Return(&domain.Tag{ID: "tag-1", UserID: "user-123"}, nil)
+	mockRepo.On("FindByID", mock.Anything, "tag-2").Return(&domain.Tag{ID: "tag-2", UserID: "user-123"}, nil)
+	mockRepo.On("Merge", mock.Anything, "user-123", "tag-1", "tag-2").Return(0, errors.New("connection reset"))
+
+	result, err := service.Merge(context.Background(), "user-123", "tag-1", "tag-2")
+
+	assert.Nil(t, result)
+	var internalErr *domain.InternalError
+	assert.ErrorAs(t, err, &internalErr)
+}
@@ -3,8 +3,11 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -23,6 +26,8 @@ type TaskService struct {
 	subtaskService      ports.SubtaskService      // Optional: for subtask validation
 	dependencyService   ports.DependencyService   // Optional: for dependency validation
 	gamificationService ports.GamificationService // Optional: for gamification rewards
+	reminderService     ports.ReminderService     // Optional: for moving reminders with due dates
+	workflowService     ports.WorkflowService     // Optional: for custom statuses; the built-in workflow otherwise
 }
 
 // NewTaskService creates a new task service
@@ -54,6 +59,24 @@ func (s *TaskService) SetGamificationService(gamificationService ports.Gamificat
 	s.gamificationService = gamificationService
 }
 
+// SetReminderService sets the optional reminder service so reminders follow due date changes
+func (s *TaskService) SetReminderService(reminderService ports.ReminderService) {
+	s.reminderService = reminderService
+}
+
+// SetWorkflowService sets the optional workflow service so status changes follow the user's workflow
+func (s *TaskService) SetWorkflowService(workflowService ports.WorkflowService) {
+	s.workflowService = workflowService
+}
+
+// workflow returns the user's workflow, or the built-in one without a workflow service
+func (s *TaskService) workflow(ctx context.Context, userID string) (*domain.Workflow, error) {
+	if s.workflowService == nil {
+		return domain.DefaultWorkflow(), nil
+	}
+	return s.workflowService.GetWorkflow(ctx, userID)
+}
+
 // Create creates a new task
 func (s *TaskService) Create(ctx context.Context, userID string, dto *domain.CreateTaskDTO) (*domain.Task, error) {
 	// Validate title
@@ -86,6 +109,12 @@ func (s *TaskService) Create(ctx context.Context, userID string, dto *domain.Cre
 		return nil, err
 	}
 
+	// Validate tags (unknown tags are created when the task is saved)
+	tags, err := validation.ValidateTags(dto.Tags, "tags")
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
 
 	// Set default user priority if not provided (1-10 scale)
@@ -110,6 +139,8 @@ func (s *TaskService) Create(ctx context.Context, userID string, dto *domain.Cre
 		Category:        category,
 		Context:         contextVal,
 		RelatedPeople:   relatedPeople,
+		Tags:            tags,
+		DeferUntil:      dto.DeferUntil,
 		BumpCount:       0,
 		CreatedAt:       now,
 		UpdatedAt:       now,
@@ -161,8 +192,57 @@ func (s *TaskService) List(ctx context.Context, userID string, filter *domain.Ta
 	return s.taskRepo.List(ctx, userID, filter)
 }
 
+// ListPage retrieves a single page of tasks along with the total count for the filter
+// and an opaque cursor for the next page (nil when this is the last page)
+func (s *TaskService) ListPage(ctx context.Context, userID string, filter *domain.TaskListFilter) (*domain.TaskListPage, error) {
+	if filter == nil {
+		filter = &domain.TaskListFilter{}
+	}
+
+	// Fetch one extra row to find out whether another page exists
+	pageFilter := *filter
+	if filter.Limit > 0 {
+		pageFilter.Limit = filter.Limit + 1
+	}
+
+	tasks, err := s.taskRepo.List(ctx, userID, &pageFilter)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to list tasks", err)
+	}
+
+	totalCount, err := s.taskRepo.Count(ctx, userID, filter)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to count tasks", err)
+	}
+
+	page := &domain.TaskListPage{
+		Tasks:      tasks,
+		TotalCount: totalCount,
+	}
+
+	if filter.Limit > 0 && len(tasks) > filter.Limit {
+		page.Tasks = tasks[:filter.Limit]
+		nextCursor := domain.NewTaskCursor(page.Tasks[len(page.Tasks)-1], filter.SortOrder()).Encode()
+		page.NextCursor = &nextCursor
+	}
+
+	// Return empty array instead of null if no tasks
+	if page.Tasks == nil {
+		page.Tasks = []*domain.Task{}
+	}
+
+	return page, nil
+}
+
 // Update updates a task
+// PUT semantics: nil DTO fields are left unchanged (see Patch for clearing fields)
 func (s *TaskService) Update(ctx context.Context, userID, taskID string, dto *domain.UpdateTaskDTO) (*domain.Task, error) {
+	return s.Patch(ctx, userID, taskID, dto.ToMergePatch())
+}
+
+// Patch applies a JSON merge patch (RFC 7396) to a task.
+// Omitted members are left unchanged; null members clear optional fields.
+func (s *TaskService) Patch(ctx context.Context, userID, taskID string, patch *domain.TaskMergePatch) (*domain.Task, error) {
 	// Get existing task
 	task, err := s.taskRepo.FindByID(ctx, taskID)
 	if err != nil {
@@ -177,81 +257,190 @@ func (s *TaskService) Update(ctx context.Context, userID, taskID string, dto *do
 		return nil, domain.NewForbiddenError("task", "update")
 	}
 
+	// Reject the write if the client's copy is stale (If-Match)
+	if err := checkExpectedVersion(ctx, task); err != nil {
+		return nil, err
+	}
+
 	// Store old task for history
 	oldTask := *task
 
+	// Status changes must be to a status of the user's workflow, along an allowed transition
+	var workflow *domain.Workflow
+	if patch.Status.Set {
+		if workflow, err = s.workflow(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
 	// Apply updates with validation
-	if dto.Title != nil {
-		validated, err := validation.ValidateRequiredText(*dto.Title, 200, "title")
+	if err := applyTaskPatch(task, patch, workflow); err != nil {
+		return nil, err
+	}
+
+	task.UpdatedAt = time.Now()
+
+	// Recalculate priority
+	task.PriorityScore = s.calculatePriority(ctx, task)
+
+	// Save to database
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		if errors.Is(err, domain.ErrTaskVersionConflict) {
+			return nil, s.versionConflictError(ctx, taskID)
+		}
+		if errors.Is(err, domain.ErrWIPLimitExceeded) {
+			return nil, err // A board move into a full column
+		}
+		return nil, domain.NewInternalError("failed to update task", err)
+	}
+	s.refreshSubtreePriority(ctx, oldTask.PriorityScore, task)
+
+	// Log update in history (before/after snapshots)
+	if err := s.logHistory(ctx, userID, task.ID, updateEventType(&oldTask, task), &oldTask, task); err != nil {
+		// Log error but don't fail the request
+	}
+
+	s.rescheduleReminders(ctx, userID, &oldTask, task)
+
+	return task, nil
+}
+
+// updateEventType returns the history event for an update: status_changed when the status moved
+func updateEventType(oldTask, task *domain.Task) domain.TaskHistoryEventType {
+	if oldTask.Status != task.Status || oldTask.StatusKey() != task.StatusKey() {
+		return domain.EventStatusChanged
+	}
+	return domain.EventTaskUpdated
+}
+
+// rescheduleReminders moves the task's relative reminders if its due date changed (if reminder service is available)
+func (s *TaskService) rescheduleReminders(ctx context.Context, userID string, oldTask, task *domain.Task) {
+	if s.reminderService == nil {
+		return
+	}
+	if oldTask.DueDate == nil && task.DueDate == nil {
+		return
+	}
+	if oldTask.DueDate != nil && task.DueDate != nil && oldTask.DueDate.Equal(*task.DueDate) {
+		return
+	}
+
+	if err := s.reminderService.RescheduleForTask(ctx, task); err != nil {
+		slog.Warn("Failed to reschedule task reminders",
+			"user_id", userID, "task_id", task.ID, "error", err)
+	}
+}
+
+// applyStatus moves the task to a status of the workflow, if the workflow allows the
+// transition from its current status
+func applyStatus(task *domain.Task, key domain.TaskStatus, workflow *domain.Workflow) error {
+	status, ok := workflow.Status(key)
+	if !ok {
+		return domain.NewValidationError("status", fmt.Sprintf("%q is not a status of your workflow", key))
+	}
+	if !workflow.CanTransition(task.StatusKey(), key) {
+		return fmt.Errorf("%w: %s to %s", domain.ErrInvalidStatusTransition, task.StatusKey(), key)
+	}
+	// Custom statuses are stored with their category's built-in status
+	task.Status = status.BaseStatus()
+	task.WorkflowStatus = key
+	if task.Status == domain.TaskStatusDone && task.CompletedAt == nil {
+		now := time.Now()
+		task.CompletedAt = &now
+	}
+	return nil
+}
+
+// applyTaskPatch validates each present member of the patch and applies it to the task.
+// workflow is only needed when the patch sets the status.
+func applyTaskPatch(task *domain.Task, patch *domain.TaskMergePatch, workflow *domain.Workflow) error {
+	if patch.Title.Set {
+		if patch.Title.IsNull() {
+			return domain.NewValidationError("title", "cannot be null")
+		}
+		validated, err := validation.ValidateRequiredText(*patch.Title.Value, 200, "title")
 		if err != nil {
-			return nil, err
+			return err
 		}
 		task.Title = validated
 	}
-	if dto.Description != nil {
-		validated, err := validation.ValidateOptionalText(dto.Description, 2000, "description")
+	if patch.Description.Set {
+		validated, err := validation.ValidateOptionalText(patch.Description.Value, 2000, "description")
 		if err != nil {
-			return nil, err
+			return err
 		}
 		task.Description = validated
 	}
-	if dto.Status != nil {
-		task.Status = *dto.Status
-		if *dto.Status == domain.TaskStatusDone && task.CompletedAt == nil {
-			now := time.Now()
-			task.CompletedAt = &now
+	if patch.Status.Set {
+		if patch.Status.IsNull() {
+			return domain.NewValidationError("status", "cannot be null")
+		}
+		if err := applyStatus(task, *patch.Status.Value, workflow); err != nil {
+			return err
 		}
 	}
-	if dto.UserPriority != nil {
-		if err := validation.ValidatePriority(*dto.UserPriority); err != nil {
-			return nil, err
+	if patch.UserPriority.Set {
+		if patch.UserPriority.IsNull() {
+			return domain.NewValidationError("user_priority", "cannot be null")
+		}
+		if err := validation.ValidatePriority(*patch.UserPriority.Value); err != nil {
+			return err
 		}
-		task.UserPriority = *dto.UserPriority
+		task.UserPriority = *patch.UserPriority.Value
 	}
-	if dto.DueDate != nil {
-		task.DueDate = dto.DueDate
+	if patch.DueDate.Set {
+		task.DueDate = patch.DueDate.Value
 	}
-	if dto.EstimatedEffort != nil {
-		task.EstimatedEffort = dto.EstimatedEffort
+	if patch.DeferUntil.Set {
+		task.DeferUntil = patch.DeferUntil.Value
 	}
-	if dto.Category != nil {
-		validated, err := validation.ValidateCategory(dto.Category)
+	if patch.EstimatedEffort.Set {
+		if !patch.EstimatedEffort.IsNull() {
+			if err := patch.EstimatedEffort.Value.Validate(); err != nil {
+				return domain.NewValidationError("estimated_effort", err.Error())
+			}
+		}
+		task.EstimatedEffort = patch.EstimatedEffort.Value
+	}
+	if patch.Category.Set {
+		validated, err := validation.ValidateCategory(patch.Category.Value)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		task.Category = validated
 	}
-	if dto.Context != nil {
-		validated, err := validation.ValidateOptionalText(dto.Context, 500, "context")
+	if patch.Context.Set {
+		validated, err := validation.ValidateOptionalText(patch.Context.Value, 500, "context")
 		if err != nil {
-			return nil, err
+			return err
 		}
 		task.Context = validated
 	}
-	if dto.RelatedPeople != nil {
-		validated, err := validation.ValidateStringSlice(dto.RelatedPeople, 100, 20, "related_people")
+	if patch.RelatedPeople.Set {
+		var people []string
+		if patch.RelatedPeople.Value != nil {
+			people = *patch.RelatedPeople.Value
+		}
+		validated, err := validation.ValidateStringSlice(people, 100, 20, "related_people")
 		if err != nil {
-			return nil, err
+			return err
 		}
 		task.RelatedPeople = validated
 	}
-
-	task.UpdatedAt = time.Now()
-
-	// Recalculate priority
-	task.PriorityScore = s.priorityCalc.Calculate(task)
-
-	// Save to database
-	if err := s.taskRepo.Update(ctx, task); err != nil {
-		return nil, domain.NewInternalError("failed to update task", err)
-	}
-
-	// Log update in history
-	if err := s.logHistory(ctx, userID, task.ID, domain.EventTaskUpdated, &oldTask, task); err != nil {
-		// Log error but don't fail the request
+	if patch.Tags.Set {
+		var tags []string
+		if patch.Tags.Value != nil {
+			tags = *patch.Tags.Value
+		}
+		// Non-nil even when empty, so null or [] clears the task's tags
+		validated, err := validation.ValidateTags(tags, "tags")
+		if err != nil {
+			return err
+		}
+		task.Tags = validated
 	}
 
-	return task, nil
+	return nil
 }
 
 // Delete deletes a task
@@ -269,18 +458,79 @@ func (s *TaskService) Delete(ctx context.Context, userID, taskID string) error {
 		return domain.NewForbiddenError("task", "delete")
 	}
 
-	// Log deletion in history (before deleting)
-	if err := s.logHistory(ctx, userID, taskID, domain.EventTaskDeleted, task, nil); err != nil {
-		// Log error but continue with deletion
+	if err := checkExpectedVersion(ctx, task); err != nil {
+		return err
 	}
 
-	if err := s.taskRepo.Delete(ctx, taskID, userID); err != nil {
+	// The check above used an earlier read, so the delete itself enforces If-Match too
+	expectedVersion, _ := domain.ExpectedVersion(ctx)
+	if err := s.taskRepo.Delete(ctx, taskID, userID, expectedVersion); err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			return domain.NewNotFoundError("task", taskID)
+		}
+		if errors.Is(err, domain.ErrTaskVersionConflict) {
+			return s.versionConflictError(ctx, taskID)
+		}
 		return domain.NewInternalError("failed to delete task", err)
 	}
 
+	// Log deletion in history
+	deletedTask := *task
+	deletedAt := time.Now()
+	deletedTask.DeletedAt = &deletedAt
+	if err := s.logHistory(ctx, userID, taskID, domain.EventTaskDeleted, task, &deletedTask); err != nil {
+		// Log error but don't fail the deletion
+	}
+
 	return nil
 }
 
+// GetHistory retrieves a page of a task's history, newest first, with field-level changes.
+// History stays available while the task is in the trash.
+func (s *TaskService) GetHistory(ctx context.Context, userID, taskID string, filter *domain.TaskHistoryFilter) (*domain.TaskHistoryPage, error) {
+	task, err := s.taskRepo.FindByIDIncludingDeleted(ctx, taskID)
+	if errors.Is(err, domain.ErrTaskNotFound) {
+		return nil, domain.NewNotFoundError("task", taskID)
+	}
+	if err != nil {
+		return nil, domain.NewInternalError("failed to find task", err)
+	}
+	if task == nil {
+		return nil, domain.NewNotFoundError("task", taskID)
+	}
+	if task.UserID != userID {
+		return nil, domain.NewForbiddenError("task", "access")
+	}
+
+	for _, eventType := range filter.EventTypes {
+		if err := eventType.Validate(); err != nil {
+			return nil, domain.NewValidationError("event_type", err.Error())
+		}
+	}
+	if filter.Limit <= 0 || filter.Limit > domain.MaxTaskHistoryLimit {
+		return nil, domain.NewValidationError("limit", "must be between 1 and 100")
+	}
+	if filter.Offset < 0 {
+		return nil, domain.NewValidationError("offset", "must not be negative")
+	}
+
+	history, total, err := s.taskHistoryRepo.ListByTaskID(ctx, taskID, filter)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to list task history", err)
+	}
+
+	page := &domain.TaskHistoryPage{
+		History:    make([]*domain.TaskHistoryEntry, len(history)),
+		TotalCount: total,
+		Limit:      filter.Limit,
+		Offset:     filter.Offset,
+	}
+	for i, h := range history {
+		page.History[i] = h.ToEntry()
+	}
+	return page, nil
+}
+
 // Bump increments the bump counter for a task
 func (s *TaskService) Bump(ctx context.Context, userID, taskID string) (*domain.Task, error) {
 	// Get task
@@ -297,8 +547,12 @@ func (s *TaskService) Bump(ctx context.Context, userID, taskID string) (*domain.
 		return nil, domain.NewForbiddenError("task", "bump")
 	}
 
-	// Store old bump count
-	oldBumpCount := task.BumpCount
+	if err := checkExpectedVersion(ctx, task); err != nil {
+		return nil, err
+	}
+
+	// Store previous state for history
+	previousState := *task
 
 	// Increment bump count
 	if err := s.taskRepo.IncrementBumpCount(ctx, taskID, userID); err != nil {
@@ -312,21 +566,74 @@ func (s *TaskService) Bump(ctx context.Context, userID, taskID string) (*domain.
 	}
 
 	// Recalculate priority
-	task.PriorityScore = s.priorityCalc.Calculate(task)
+	task.PriorityScore = s.calculatePriority(ctx, task)
 	if err := s.taskRepo.Update(ctx, task); err != nil {
+		if errors.Is(err, domain.ErrTaskVersionConflict) {
+			return nil, s.versionConflictError(ctx, taskID)
+		}
 		return nil, domain.NewInternalError("failed to update task priority", err)
 	}
+	s.refreshSubtreePriority(ctx, previousState.PriorityScore, task)
 
 	// Log bump in history
-	oldValue := string(rune(oldBumpCount + '0'))
-	newValue := string(rune(task.BumpCount + '0'))
-	if err := s.logHistorySimple(ctx, userID, taskID, domain.EventTaskBumped, &oldValue, &newValue); err != nil {
+	if err := s.logHistory(ctx, userID, taskID, domain.EventTaskBumped, &previousState, task); err != nil {
 		// Log error but don't fail the request
 	}
 
 	return task, nil
 }
 
+// Snooze hides a task until a start date, given as a preset (resolved in the user's timezone)
+// or an explicit time. Unlike Bump it leaves the bump count alone, so snoozing carries no penalty.
+func (s *TaskService) Snooze(ctx context.Context, userID, taskID string, dto *domain.SnoozeTaskDTO) (*domain.Task, error) {
+	if (dto.Preset == nil) == (dto.Until == nil) {
+		return nil, domain.NewValidationError("snooze", "exactly one of preset or until is required")
+	}
+
+	now := time.Now()
+	var until time.Time
+	if dto.Preset != nil {
+		if err := dto.Preset.Validate(); err != nil {
+			return nil, domain.NewValidationError("preset", err.Error())
+		}
+		resolved, err := dto.Preset.Resolve(now, s.userLocation(ctx, userID))
+		if err != nil {
+			return nil, domain.NewValidationError("preset", err.Error())
+		}
+		until = resolved
+	} else {
+		if !dto.Until.After(now) {
+			return nil, domain.NewValidationError("until", "must be in the future")
+		}
+		until = *dto.Until
+	}
+
+	return s.Patch(ctx, userID, taskID, &domain.TaskMergePatch{
+		DeferUntil: domain.PatchField[time.Time]{Set: true, Value: &until},
+	})
+}
+
+// userLocation loads the user's timezone for resolving snooze presets, falling back to UTC
+func (s *TaskService) userLocation(ctx context.Context, userID string) *time.Location {
+	if s.gamificationService == nil {
+		return time.UTC
+	}
+
+	timezone, err := s.gamificationService.GetUserTimezone(ctx, userID)
+	if err != nil {
+		slog.Warn("Failed to get timezone for snooze, using UTC",
+			"user_id", userID, "error", err)
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		slog.Warn("Failed to load timezone for snooze, using UTC",
+			"timezone", timezone, "error", err)
+		return time.UTC
+	}
+	return loc
+}
+
 // Complete marks a task as complete
 func (s *TaskService) Complete(ctx context.Context, userID, taskID string) (*domain.Task, error) {
 	response, err := s.CompleteWithOptions(ctx, userID, taskID, nil)
@@ -352,6 +659,31 @@ func (s *TaskService) CompleteWithOptions(ctx context.Context, userID, taskID st
 		return nil, domain.NewForbiddenError("task", "complete")
 	}
 
+	if err := checkExpectedVersion(ctx, task); err != nil {
+		return nil, err
+	}
+
+	response, err := s.completeTask(ctx, userID, task, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Process gamification rewards asynchronously if gamification service is available.
+	// This allows the API to return immediately while gamification (multiple DB queries)
+	// processes in the background. User will see updated gamification on next dashboard visit.
+	// Errors are logged but not returned - gamification failures don't affect task completion.
+	if s.gamificationService != nil {
+		s.gamificationService.ProcessTaskCompletionAsync(userID, response.CompletedTask)
+	}
+
+	return response, nil
+}
+
+// completeTask validates and completes an already loaded, owned task and generates
+// the next recurring instance. Gamification is left to the caller.
+func (s *TaskService) completeTask(ctx context.Context, userID string, task *domain.Task, req *domain.TaskCompletionRequest) (*domain.TaskCompletionResponse, error) {
+	taskID := task.ID
+
 	// Block parent task completion if subtasks are incomplete (if subtask service is available)
 	if s.subtaskService != nil {
 		if err := s.subtaskService.ValidateParentCompletion(ctx, taskID); err != nil {
@@ -366,19 +698,37 @@ func (s *TaskService) CompleteWithOptions(ctx context.Context, userID, taskID st
 		}
 	}
 
+	// Completing follows the workflow like any other status change
+	workflow, err := s.workflow(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	done, ok := workflow.CompletionStatus(task.StatusKey())
+	if !ok {
+		return nil, fmt.Errorf("%w: %s to %s", domain.ErrInvalidStatusTransition, task.StatusKey(), domain.TaskStatusDone)
+	}
+
 	// Update task
+	previousState := *task
 	task.Status = domain.TaskStatusDone
+	task.WorkflowStatus = done.Key
 	now := time.Now()
 	task.CompletedAt = &now
 	task.UpdatedAt = now
 
 	// Save to database
 	if err := s.taskRepo.Update(ctx, task); err != nil {
+		if errors.Is(err, domain.ErrTaskVersionConflict) {
+			return nil, s.versionConflictError(ctx, taskID)
+		}
+		if errors.Is(err, domain.ErrWIPLimitExceeded) {
+			return nil, err // A board move into a full column
+		}
 		return nil, domain.NewInternalError("failed to update task", err)
 	}
 
 	// Log completion in history
-	if err := s.logHistorySimple(ctx, userID, taskID, domain.EventTaskCompleted, nil, nil); err != nil {
+	if err := s.logHistory(ctx, userID, taskID, domain.EventTaskCompleted, &previousState, task); err != nil {
 		slog.Warn("Failed to log task completion history",
 			"user_id", userID, "task_id", taskID, "error", err)
 	}
@@ -396,15 +746,15 @@ func (s *TaskService) CompleteWithOptions(ctx context.Context, userID, taskID st
 				"user_id", userID, "task_id", taskID, "series_id", *task.SeriesID, "error", err)
 		} else if nextTask != nil {
 			response.NextTask = nextTask
-		}
-	}
 
-	// Process gamification rewards asynchronously if gamification service is available.
-	// This allows the API to return immediately while gamification (multiple DB queries)
-	// processes in the background. User will see updated gamification on next dashboard visit.
-	// Errors are logged but not returned - gamification failures don't affect task completion.
-	if s.gamificationService != nil {
-		s.gamificationService.ProcessTaskCompletionAsync(userID, task)
+			// The next instance gets the same reminders, moved to its due date
+			if s.reminderService != nil {
+				if err := s.reminderService.CarryOver(ctx, task, nextTask); err != nil {
+					slog.Warn("Failed to carry reminders over to next recurring task",
+						"user_id", userID, "task_id", taskID, "next_task_id", nextTask.ID, "error", err)
+				}
+			}
+		}
 	}
 
 	return response, nil
@@ -428,8 +778,11 @@ func (s *TaskService) Restore(ctx context.Context, userID, taskID string) (*doma
 	if task.DeletedAt == nil {
 		return nil, domain.NewValidationError("status", "task is not deleted")
 	}
+	if s.parentTrashedSeparately(ctx, task) {
+		return nil, domain.NewValidationError("parent_task_id", "parent task is in the trash; restore it first")
+	}
 
-	// Restore the task
+	// Restore the task, together with the subtasks trashed with it
 	if err := s.taskRepo.Restore(ctx, taskID, userID); err != nil {
 		return nil, domain.NewInternalError("failed to restore task", err)
 	}
@@ -441,7 +794,7 @@ func (s *TaskService) Restore(ctx context.Context, userID, taskID string) (*doma
 	}
 
 	// Log history with proper restored event type
-	if err := s.logHistory(ctx, userID, taskID, domain.EventTaskRestored, nil, restoredTask); err != nil {
+	if err := s.logHistory(ctx, userID, taskID, domain.EventTaskRestored, task, restoredTask); err != nil {
 		slog.Warn("Failed to log task restoration history",
 			"user_id", userID, "task_id", taskID, "error", err)
 	}
@@ -449,6 +802,21 @@ func (s *TaskService) Restore(ctx context.Context, userID, taskID string) (*doma
 	return restoredTask, nil
 }
 
+// parentTrashedSeparately reports whether a trashed subtask's parent is in the trash
+// on its own, so restoring the subtask alone would leave it under a deleted parent.
+// A parent trashed together with the subtask is restored along with it.
+func (s *TaskService) parentTrashedSeparately(ctx context.Context, task *domain.Task) bool {
+	if task.TaskType != domain.TaskTypeSubtask || task.ParentTaskID == nil {
+		return false
+	}
+	parent, err := s.taskRepo.FindByIDIncludingDeleted(ctx, *task.ParentTaskID)
+	if err != nil || parent == nil || parent.DeletedAt == nil {
+		return false
+	}
+	return parent.DeletionGroupID == nil || task.DeletionGroupID == nil ||
+		*parent.DeletionGroupID != *task.DeletionGroupID
+}
+
 // Uncomplete reverses a task completion, setting it back to "todo" status
 func (s *TaskService) Uncomplete(ctx context.Context, userID, taskID string) (*domain.Task, error) {
 	task, err := s.taskRepo.FindByID(ctx, taskID)
@@ -470,23 +838,30 @@ func (s *TaskService) Uncomplete(ctx context.Context, userID, taskID string) (*d
 	// Store previous state for history
 	previousState := *task
 
-	// Reverse gamification asynchronously (before status change for accurate category)
-	if s.gamificationService != nil {
-		s.gamificationService.ProcessTaskUncompletionAsync(userID, task)
-	}
-
 	// Update task status
 	task.Status = domain.TaskStatusTodo
 	task.CompletedAt = nil
 	task.UpdatedAt = time.Now()
 
 	// Recalculate priority
-	task.PriorityScore = s.priorityCalc.Calculate(task)
+	task.PriorityScore = s.calculatePriority(ctx, task)
 
 	// Save
 	if err := s.taskRepo.Update(ctx, task); err != nil {
+		if errors.Is(err, domain.ErrTaskVersionConflict) {
+			return nil, s.versionConflictError(ctx, taskID)
+		}
+		if errors.Is(err, domain.ErrWIPLimitExceeded) {
+			return nil, err // A board move into a full column
+		}
 		return nil, domain.NewInternalError("failed to update task", err)
 	}
+	s.refreshSubtreePriority(ctx, previousState.PriorityScore, task)
+
+	// Reverse gamification asynchronously, from the completed state so the category matches
+	if s.gamificationService != nil {
+		s.gamificationService.ProcessTaskUncompletionAsync(userID, &previousState)
+	}
 
 	// Log history
 	if err := s.logHistory(ctx, userID, taskID, domain.EventTaskUncompleted, &previousState, task); err != nil {
@@ -571,6 +946,46 @@ func (s *TaskService) calculateBadgeColor(tasks []*domain.Task) string {
 	return "blue"
 }
 
+// checkExpectedVersion enforces an If-Match precondition carried by ctx
+// (see domain.WithExpectedVersion) against the stored task
+func checkExpectedVersion(ctx context.Context, task *domain.Task) error {
+	if expected, ok := domain.ExpectedVersion(ctx); ok && task.Version != expected {
+		return domain.NewPreconditionFailedError("task", task)
+	}
+	return nil
+}
+
+// calculatePriority scores a task; a subtask gets its parent's boost, as when it was created
+func (s *TaskService) calculatePriority(ctx context.Context, task *domain.Task) int {
+	if task.IsSubtask() {
+		if parent, err := s.taskRepo.FindByID(ctx, *task.ParentTaskID); err == nil && parent != nil {
+			return s.priorityCalc.CalculateForSubtask(task, parent.PriorityScore)
+		}
+	}
+	return s.priorityCalc.Calculate(task)
+}
+
+// refreshSubtreePriority passes a task's changed priority down to its subtasks.
+// Failures are logged; the subtasks catch up on their next write.
+func (s *TaskService) refreshSubtreePriority(ctx context.Context, oldScore int, task *domain.Task) {
+	if task.PriorityScore == oldScore || !task.CanHaveSubtasks() {
+		return
+	}
+	if err := propagatePriority(ctx, s.taskRepo, s.priorityCalc, task); err != nil {
+		slog.Warn("Failed to update subtask priorities", "task_id", task.ID, "error", err)
+	}
+}
+
+// versionConflictError re-reads a task that changed between our read and write,
+// so the client receives the current server copy to merge against
+func (s *TaskService) versionConflictError(ctx context.Context, taskID string) error {
+	current, err := s.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return domain.NewInternalError("failed to find task", err)
+	}
+	return domain.NewPreconditionFailedError("task", current)
+}
+
 // logHistory creates a history entry with full task data
 func (s *TaskService) logHistory(ctx context.Context, userID, taskID string, eventType domain.TaskHistoryEventType, oldTask, newTask *domain.Task) error {
 	var oldValue, newValue *string
@@ -626,11 +1041,29 @@ func (s *TaskService) RenameCategory(ctx context.Context, userID, oldName, newNa
 		return 0, domain.NewValidationError("new_name", "is required")
 	}
 
-	count, err := s.taskRepo.RenameCategoryForUser(ctx, userID, *validatedOld, *validatedNew)
+	// The renamed tasks come back as they were, so each task's history records the change
+	tasks, err := s.taskRepo.RenameCategoryForUser(ctx, userID, *validatedOld, *validatedNew)
 	if err != nil {
 		return 0, domain.NewInternalError("failed to rename category", err)
 	}
-	return count, nil
+
+	s.logCategoryChange(ctx, userID, tasks, validatedNew)
+	return len(tasks), nil
+}
+
+// logCategoryChange records a category rename or removal in the history of each task it moved
+func (s *TaskService) logCategoryChange(ctx context.Context, userID string, tasks []*domain.Task, category *string) {
+	now := time.Now()
+	for _, task := range tasks {
+		updated := *task
+		updated.Category = category
+		updated.UpdatedAt = now
+		updated.Version = task.Version + 1
+		if err := s.logHistory(ctx, userID, task.ID, domain.EventTaskUpdated, task, &updated); err != nil {
+			slog.Warn("Failed to log category change history",
+				"user_id", userID, "task_id", task.ID, "error", err)
+		}
+	}
 }
 
 // DeleteCategory removes a category from all tasks belonging to the user
@@ -645,44 +1078,116 @@ func (s *TaskService) DeleteCategory(ctx context.Context, userID, categoryName s
 		return 0, domain.NewValidationError("category_name", "is required")
 	}
 
-	count, err := s.taskRepo.DeleteCategoryForUser(ctx, userID, *validated)
+	tasks, err := s.taskRepo.DeleteCategoryForUser(ctx, userID, *validated)
 	if err != nil {
 		return 0, domain.NewInternalError("failed to delete category", err)
 	}
-	return count, nil
+
+	s.logCategoryChange(ctx, userID, tasks, nil)
+	return len(tasks), nil
 }
 
-// BulkDelete permanently deletes multiple tasks by their IDs
-// Returns a response with success count, failed IDs, and a message
+// BulkDelete moves up to 100 tasks to the trash the same way Delete does: each task is
+// soft-deleted with its subtasks in its own deletion group and gets a deleted history entry,
+// so the batch can be undone or restored, and is purged later with the rest of the trash.
+// Tasks that are missing or not owned are reported without aborting the batch.
 func (s *TaskService) BulkDelete(ctx context.Context, userID string, taskIDs []string) (*domain.BulkOperationResponse, error) {
+	taskIDs = uniqueIDs(taskIDs)
 	if len(taskIDs) == 0 {
 		return nil, domain.NewValidationError("task_ids", "must contain at least 1 item")
 	}
-	if len(taskIDs) > 100 {
+	if len(taskIDs) > domain.MaxBulkTaskIDs {
 		return nil, domain.NewValidationError("task_ids", "cannot contain more than 100 items")
 	}
 
-	successCount, failedIDs, err := s.taskRepo.BulkDelete(ctx, userID, taskIDs)
-	if err != nil {
-		return nil, domain.NewInternalError("failed to bulk delete tasks", err)
+	response := &domain.BulkOperationResponse{}
+	fail := func(taskID, reason string) {
+		response.FailedIDs = append(response.FailedIDs, taskID)
+		if response.Errors == nil {
+			response.Errors = make(map[string]string)
+		}
+		response.Errors[taskID] = reason
 	}
 
-	var message string
-	if len(failedIDs) == 0 {
-		message = "Successfully deleted " + strconv.Itoa(successCount) + " tasks"
+	tasks := make(map[string]*domain.Task, len(taskIDs))
+	for _, taskID := range taskIDs {
+		task, err := s.taskRepo.FindByID(ctx, taskID)
+		if err == nil && task != nil && task.UserID == userID {
+			tasks[taskID] = task
+		}
+	}
+
+	// Subtasks whose ancestor is also in the batch are trashed by the ancestor's cascade,
+	// in its deletion group, so restoring the ancestor brings the whole tree back
+	coveredBy := make(map[string]string)
+	for taskID, task := range tasks {
+		if !task.IsSubtask() {
+			continue
+		}
+		ancestors, err := s.taskRepo.GetAncestorIDs(ctx, taskID)
+		if err != nil {
+			slog.Warn("Failed to load ancestors for bulk delete", "user_id", userID, "task_id", taskID, "error", err)
+			continue
+		}
+		// Ancestors are nearest first, so the last match is the topmost one in the batch
+		for _, ancestorID := range ancestors {
+			if tasks[ancestorID] != nil {
+				coveredBy[taskID] = ancestorID
+			}
+		}
+	}
+
+	deleted := make(map[string]bool, len(tasks))
+	for _, taskID := range taskIDs {
+		task := tasks[taskID]
+		if task == nil {
+			fail(taskID, "task not found")
+			continue
+		}
+		if _, covered := coveredBy[taskID]; covered {
+			continue
+		}
+
+		if err := s.taskRepo.Delete(ctx, taskID, userID, 0); err != nil {
+			slog.Warn("Bulk delete failed for task", "user_id", userID, "task_id", taskID, "error", err)
+			fail(taskID, "failed to delete task")
+			continue
+		}
+		deleted[taskID] = true
+
+		deletedTask := *task
+		deletedAt := time.Now()
+		deletedTask.DeletedAt = &deletedAt
+		if err := s.logHistory(ctx, userID, taskID, domain.EventTaskDeleted, task, &deletedTask); err != nil {
+			slog.Warn("Failed to log bulk delete history", "user_id", userID, "task_id", taskID, "error", err)
+		}
+		response.SuccessCount++
+	}
+
+	for _, taskID := range taskIDs {
+		ancestorID, covered := coveredBy[taskID]
+		if !covered {
+			continue
+		}
+		if deleted[ancestorID] {
+			response.SuccessCount++
+		} else {
+			fail(taskID, "failed to delete task")
+		}
+	}
+
+	if len(response.FailedIDs) == 0 {
+		response.Message = "Successfully deleted " + strconv.Itoa(response.SuccessCount) + " tasks"
 	} else {
-		message = "Deleted " + strconv.Itoa(successCount) + " tasks. " + strconv.Itoa(len(failedIDs)) + " task(s) not found or not owned."
+		response.Message = "Deleted " + strconv.Itoa(response.SuccessCount) + " tasks. " + strconv.Itoa(len(response.FailedIDs)) + " task(s) not found or not owned."
 	}
 
-	return &domain.BulkOperationResponse{
-		SuccessCount: successCount,
-		FailedIDs:    failedIDs,
-		Message:      message,
-	}, nil
+	return response, nil
 }
 
-// BulkRestore restores multiple completed tasks to "todo" status
-// Returns a response with success count, failed IDs, and a message
+// BulkRestore restores multiple completed or trashed tasks to "todo" status.
+// Trashed tasks come back out of the trash together with the subtasks deleted with them,
+// all in one transaction. Returns a response with success count, failed IDs, and a message
 func (s *TaskService) BulkRestore(ctx context.Context, userID string, taskIDs []string) (*domain.BulkOperationResponse, error) {
 	if len(taskIDs) == 0 {
 		return nil, domain.NewValidationError("task_ids", "must contain at least 1 item")
@@ -691,21 +1196,413 @@ func (s *TaskService) BulkRestore(ctx context.Context, userID string, taskIDs []
 		return nil, domain.NewValidationError("task_ids", "cannot contain more than 100 items")
 	}
 
-	successCount, failedIDs, err := s.taskRepo.BulkUpdateStatus(ctx, userID, taskIDs, domain.TaskStatusTodo)
-	if err != nil {
-		return nil, domain.NewInternalError("failed to bulk restore tasks", err)
+	// Loaded first so each task's history records the change. Trashed tasks come back
+	// out of the trash with their subtasks, unless their parent was trashed on its own.
+	previous := make(map[string]*domain.Task, len(taskIDs))
+	requested := make(map[string]bool, len(taskIDs))
+	for _, taskID := range taskIDs {
+		requested[taskID] = true
+		if task, err := s.taskRepo.FindByIDIncludingDeleted(ctx, taskID); err == nil && task != nil && task.UserID == userID {
+			previous[taskID] = task
+		}
+	}
+	restorable := make([]string, 0, len(taskIDs))
+	errs := map[string]string{}
+	for _, taskID := range taskIDs {
+		task := previous[taskID]
+		parentRequested := task != nil && task.ParentTaskID != nil && requested[*task.ParentTaskID]
+		if task != nil && task.DeletedAt != nil && !parentRequested && s.parentTrashedSeparately(ctx, task) {
+			errs[taskID] = "parent task is in the trash; restore it first"
+			continue
+		}
+		restorable = append(restorable, taskID)
+	}
+
+	successCount := 0
+	failedIDs := make([]string, 0)
+	if len(restorable) > 0 {
+		var err error
+		successCount, failedIDs, err = s.taskRepo.BulkRestore(ctx, userID, restorable)
+		if err != nil {
+			return nil, domain.NewInternalError("failed to bulk restore tasks", err)
+		}
+	}
+
+	failed := make(map[string]bool, len(failedIDs))
+	for _, taskID := range failedIDs {
+		failed[taskID] = true
+	}
+	now := time.Now()
+	for _, taskID := range restorable {
+		task := previous[taskID]
+		if task == nil || failed[taskID] || (task.DeletedAt == nil && task.Status == domain.TaskStatusTodo) {
+			continue
+		}
+		restored := *task
+		restored.Status = domain.TaskStatusTodo
+		restored.DeletedAt = nil
+		restored.DeletionGroupID = nil
+		restored.UpdatedAt = now
+		restored.Version = task.Version + 1
+		eventType := updateEventType(task, &restored)
+		if task.DeletedAt != nil {
+			eventType = domain.EventTaskRestored
+		}
+		if err := s.logHistory(ctx, userID, taskID, eventType, task, &restored); err != nil {
+			slog.Warn("Failed to log bulk restore history",
+				"user_id", userID, "task_id", taskID, "error", err)
+		}
+	}
+	for _, taskID := range taskIDs {
+		if _, ok := errs[taskID]; ok {
+			failedIDs = append(failedIDs, taskID)
+		}
 	}
 
 	var message string
 	if len(failedIDs) == 0 {
 		message = "Successfully restored " + strconv.Itoa(successCount) + " tasks to active status"
 	} else {
-		message = "Restored " + strconv.Itoa(successCount) + " tasks. " + strconv.Itoa(len(failedIDs)) + " task(s) not found, not owned, or not completed."
+		message = "Restored " + strconv.Itoa(successCount) + " tasks. " + strconv.Itoa(len(failedIDs)) + " task(s) not found, not owned, or not restorable."
 	}
 
-	return &domain.BulkOperationResponse{
+	response := &domain.BulkOperationResponse{
 		SuccessCount: successCount,
 		FailedIDs:    failedIDs,
 		Message:      message,
-	}, nil
+	}
+	if len(errs) > 0 {
+		response.Errors = errs
+	}
+	return response, nil
+}
+
+// BulkUpdate applies the same patch to up to 100 tasks.
+// Each task is updated independently: priority is recalculated and history recorded per task,
+// and tasks that are missing, not owned or fail validation are reported without aborting the batch.
+func (s *TaskService) BulkUpdate(ctx context.Context, userID string, req *domain.BulkUpdateRequest) (*domain.BulkOperationResponse, error) {
+	taskIDs := uniqueIDs(req.TaskIDs)
+	if len(taskIDs) == 0 {
+		return nil, domain.NewValidationError("task_ids", "must contain at least 1 item")
+	}
+	if len(taskIDs) > domain.MaxBulkTaskIDs {
+		return nil, domain.NewValidationError("task_ids", "cannot contain more than 100 items")
+	}
+
+	patch, shiftDays, err := validateBulkTaskPatch(&req.Patch)
+	if err != nil {
+		return nil, err
+	}
+
+	// Status changes follow the user's workflow; transitions are checked per task
+	var workflow *domain.Workflow
+	if patch.Status != nil {
+		if workflow, err = s.workflow(ctx, userID); err != nil {
+			return nil, err
+		}
+		if _, ok := workflow.Status(*patch.Status); !ok {
+			return nil, domain.NewValidationError("status", fmt.Sprintf("%q is not a status of your workflow", *patch.Status))
+		}
+	}
+
+	response := &domain.BulkOperationResponse{}
+	fail := func(taskID, reason string) {
+		response.FailedIDs = append(response.FailedIDs, taskID)
+		if response.Errors == nil {
+			response.Errors = make(map[string]string)
+		}
+		response.Errors[taskID] = reason
+	}
+
+	for _, taskID := range taskIDs {
+		task, err := s.taskRepo.FindByID(ctx, taskID)
+		if err != nil || task == nil || task.UserID != userID {
+			fail(taskID, "task not found")
+			continue
+		}
+
+		oldTask := *task
+		if err := applyBulkTaskPatch(task, patch, shiftDays, workflow); err != nil {
+			fail(taskID, err.Error())
+			continue
+		}
+
+		task.UpdatedAt = time.Now()
+		task.PriorityScore = s.calculatePriority(ctx, task)
+
+		if err := s.taskRepo.Update(ctx, task); err != nil {
+			slog.Warn("Bulk update failed for task", "user_id", userID, "task_id", taskID, "error", err)
+			fail(taskID, "failed to update task")
+			continue
+		}
+		s.refreshSubtreePriority(ctx, oldTask.PriorityScore, task)
+
+		if err := s.logHistory(ctx, userID, taskID, updateEventType(&oldTask, task), &oldTask, task); err != nil {
+			slog.Warn("Failed to log bulk update history", "user_id", userID, "task_id", taskID, "error", err)
+		}
+		s.rescheduleReminders(ctx, userID, &oldTask, task)
+		response.SuccessCount++
+	}
+
+	if len(response.FailedIDs) == 0 {
+		response.Message = "Successfully updated " + strconv.Itoa(response.SuccessCount) + " tasks"
+	} else {
+		response.Message = "Updated " + strconv.Itoa(response.SuccessCount) + " tasks. " + strconv.Itoa(len(response.FailedIDs)) + " task(s) failed."
+	}
+
+	return response, nil
+}
+
+// BulkComplete completes up to 100 tasks with the same rules as completing them one by one.
+// Tasks are processed so blockers complete before their dependents, and parents whose
+// subtasks are in the same batch are retried once those subtasks are done.
+// Recurring tasks get their next instance; gamification runs once for the whole batch.
+func (s *TaskService) BulkComplete(ctx context.Context, userID string, taskIDs []string) (*domain.BulkCompleteResponse, error) {
+	taskIDs = uniqueIDs(taskIDs)
+	if len(taskIDs) == 0 {
+		return nil, domain.NewValidationError("task_ids", "must contain at least 1 item")
+	}
+	if len(taskIDs) > domain.MaxBulkTaskIDs {
+		return nil, domain.NewValidationError("task_ids", "cannot contain more than 100 items")
+	}
+
+	// Order blockers before the tasks they block (if dependency service is available)
+	if s.dependencyService != nil {
+		ordered, err := s.dependencyService.OrderForCompletion(ctx, userID, taskIDs)
+		if err != nil {
+			return nil, err
+		}
+		taskIDs = ordered
+	}
+
+	response := &domain.BulkCompleteResponse{}
+	results := make(map[string]*domain.BulkCompleteResult, len(taskIDs))
+	var completed []*domain.Task
+
+	pending := taskIDs
+	for len(pending) > 0 {
+		var deferred []string
+		for _, taskID := range pending {
+			result := &domain.BulkCompleteResult{TaskID: taskID}
+			results[taskID] = result
+
+			completion, err := s.bulkCompleteTask(ctx, userID, taskID)
+			if err != nil {
+				result.Error = bulkCompleteFailureReason(err)
+				// A parent may only be waiting on subtasks later in the batch
+				if errors.Is(err, domain.ErrCannotCompleteParent) {
+					deferred = append(deferred, taskID)
+				}
+				continue
+			}
+
+			result.Success = true
+			result.Error = ""
+			result.CompletedTask = completion.CompletedTask
+			result.NextTask = completion.NextTask
+			completed = append(completed, completion.CompletedTask)
+		}
+
+		// Stop once a pass makes no progress on the deferred parents
+		if len(deferred) == len(pending) {
+			break
+		}
+		pending = deferred
+	}
+
+	// Report results in processing order
+	for _, taskID := range taskIDs {
+		result := results[taskID]
+		response.Results = append(response.Results, result)
+		if result.Success {
+			response.SuccessCount++
+		} else {
+			response.FailedIDs = append(response.FailedIDs, taskID)
+		}
+	}
+
+	// Award gamification once for the whole batch. Failures are logged but don't
+	// affect the completions, matching single task completion.
+	if s.gamificationService != nil && len(completed) > 0 {
+		gamification, err := s.gamificationService.ProcessBulkTaskCompletion(ctx, userID, completed)
+		if err != nil {
+			slog.Warn("Failed to process bulk completion gamification",
+				"user_id", userID, "task_count", len(completed), "error", err)
+		} else {
+			response.Gamification = gamification
+		}
+	}
+
+	if len(response.FailedIDs) == 0 {
+		response.Message = "Successfully completed " + strconv.Itoa(response.SuccessCount) + " tasks"
+	} else {
+		response.Message = "Completed " + strconv.Itoa(response.SuccessCount) + " tasks. " + strconv.Itoa(len(response.FailedIDs)) + " task(s) failed."
+	}
+
+	return response, nil
+}
+
+// bulkCompleteTask loads and completes a single task of a bulk completion
+func (s *TaskService) bulkCompleteTask(ctx context.Context, userID, taskID string) (*domain.TaskCompletionResponse, error) {
+	task, err := s.taskRepo.FindByID(ctx, taskID)
+	if err != nil && !errors.Is(err, domain.ErrTaskNotFound) {
+		return nil, domain.NewInternalError("failed to find task", err)
+	}
+	if task == nil || task.UserID != userID {
+		return nil, domain.NewNotFoundError("task", taskID)
+	}
+	if task.Status == domain.TaskStatusDone {
+		return nil, domain.NewConflictError("task", "task is already completed")
+	}
+
+	return s.completeTask(ctx, userID, task, nil)
+}
+
+// bulkCompleteFailureReason turns a completion error into a per-task failure message
+// without exposing internal error details
+func bulkCompleteFailureReason(err error) string {
+	var notFoundErr *domain.NotFoundError
+	var conflictErr *domain.ConflictError
+	switch {
+	case errors.As(err, &notFoundErr):
+		return "task not found"
+	case errors.As(err, &conflictErr):
+		return conflictErr.Message
+	case errors.Is(err, domain.ErrCannotCompleteBlocked), errors.Is(err, domain.ErrCannotCompleteParent),
+		errors.Is(err, domain.ErrInvalidStatusTransition):
+		return err.Error()
+	default:
+		slog.Warn("Bulk complete failed for task", "error", err)
+		return "failed to complete task"
+	}
+}
+
+// validateBulkTaskPatch validates and sanitizes a bulk patch once, before it is applied to any task.
+// Returns the sanitized patch and the due date shift in days.
+func validateBulkTaskPatch(patch *domain.BulkTaskPatch) (*domain.BulkTaskPatch, int, error) {
+	if patch.IsEmpty() {
+		return nil, 0, domain.NewValidationError("patch", "must change at least one field")
+	}
+
+	sanitized := *patch
+
+	if patch.Category.Set {
+		validated, err := validation.ValidateCategory(patch.Category.Value)
+		if err != nil {
+			return nil, 0, err
+		}
+		sanitized.Category.Value = validated
+	}
+	if patch.UserPriority != nil {
+		if err := validation.ValidatePriority(*patch.UserPriority); err != nil {
+			return nil, 0, err
+		}
+	}
+	shiftDays := 0
+	if patch.DueDateShift != nil {
+		days, err := domain.ParseDueDateShift(*patch.DueDateShift)
+		if err != nil {
+			return nil, 0, err
+		}
+		shiftDays = days
+	}
+	if patch.EstimatedEffort.Set && !patch.EstimatedEffort.IsNull() {
+		if err := patch.EstimatedEffort.Value.Validate(); err != nil {
+			return nil, 0, domain.NewValidationError("estimated_effort", err.Error())
+		}
+	}
+	if patch.Context.Set {
+		validated, err := validation.ValidateOptionalText(patch.Context.Value, 500, "context")
+		if err != nil {
+			return nil, 0, err
+		}
+		sanitized.Context.Value = validated
+	}
+	if len(patch.AddRelatedPeople) > 0 {
+		validated, err := validation.ValidateStringSlice(patch.AddRelatedPeople, 100, 20, "add_related_people")
+		if err != nil {
+			return nil, 0, err
+		}
+		sanitized.AddRelatedPeople = validated
+	}
+	if len(patch.RemoveRelatedPeople) > 0 {
+		validated, err := validation.ValidateStringSlice(patch.RemoveRelatedPeople, 100, 20, "remove_related_people")
+		if err != nil {
+			return nil, 0, err
+		}
+		sanitized.RemoveRelatedPeople = validated
+	}
+
+	return &sanitized, shiftDays, nil
+}
+
+// applyBulkTaskPatch applies a validated bulk patch to a single task.
+// workflow is only needed when the patch sets the status.
+func applyBulkTaskPatch(task *domain.Task, patch *domain.BulkTaskPatch, shiftDays int, workflow *domain.Workflow) error {
+	if patch.Status != nil {
+		if err := applyStatus(task, *patch.Status, workflow); err != nil {
+			return err
+		}
+	}
+	if patch.Category.Set {
+		task.Category = patch.Category.Value
+	}
+	if patch.UserPriority != nil {
+		task.UserPriority = *patch.UserPriority
+	}
+	if shiftDays != 0 && task.DueDate != nil {
+		shifted := task.DueDate.AddDate(0, 0, shiftDays)
+		task.DueDate = &shifted
+	}
+	if patch.EstimatedEffort.Set {
+		task.EstimatedEffort = patch.EstimatedEffort.Value
+	}
+	if patch.Context.Set {
+		task.Context = patch.Context.Value
+	}
+
+	if len(patch.AddRelatedPeople) > 0 || len(patch.RemoveRelatedPeople) > 0 {
+		people := make([]string, 0, len(task.RelatedPeople)+len(patch.AddRelatedPeople))
+		for _, person := range task.RelatedPeople {
+			if !containsFold(patch.RemoveRelatedPeople, person) {
+				people = append(people, person)
+			}
+		}
+		for _, person := range patch.AddRelatedPeople {
+			if !containsFold(people, person) {
+				people = append(people, person)
+			}
+		}
+		// Re-validate the merged list so the per-task item limit still holds
+		validated, err := validation.ValidateStringSlice(people, 100, 20, "related_people")
+		if err != nil {
+			return err
+		}
+		task.RelatedPeople = validated
+	}
+
+	return nil
+}
+
+// containsFold reports whether values contains target, ignoring case
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueIDs returns ids with duplicates removed, preserving order
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
 }
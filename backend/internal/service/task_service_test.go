@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,6 +30,7 @@ func createTestTask(userID, taskID string) *domain.Task {
 		PriorityScore: 50,
 		CreatedAt:     now,
 		UpdatedAt:     now,
+		Version:       1,
 	}
 }
 
@@ -149,6 +153,47 @@ func TestTaskService_Create_InvalidPriority(t *testing.T) {
 	assert.Nil(t, task)
 }
 
+func TestTaskService_Create_WithTags(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	dto := &domain.CreateTaskDTO{
+		Title: "Test Task",
+		Tags:  []string{" urgent ", "deep-work", "URGENT"},
+	}
+
+	mockTaskRepo.On("Create", mock.Anything, mock.MatchedBy(func(task *domain.Task) bool {
+		return len(task.Tags) == 2 && task.Tags[0] == "urgent" && task.Tags[1] == "deep-work"
+	})).Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	task, err := service.Create(context.Background(), "user-123", dto)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"urgent", "deep-work"}, task.Tags)
+	mockTaskRepo.AssertExpectations(t)
+}
+
+func TestTaskService_Create_InvalidTag(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	dto := &domain.CreateTaskDTO{
+		Title: "Test Task",
+		Tags:  []string{"work", "#hashtag"},
+	}
+
+	task, err := service.Create(context.Background(), "user-123", dto)
+
+	assert.Nil(t, task)
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "tags[1]", validationErr.Field)
+	mockTaskRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
 func TestTaskService_Create_RepoError(t *testing.T) {
 	mockTaskRepo := new(MockTaskRepository)
 	mockHistoryRepo := new(MockTaskHistoryRepository)
@@ -357,6 +402,221 @@ func TestTaskService_Update_SetStatus_Done(t *testing.T) {
 	assert.NotNil(t, task.CompletedAt)
 }
 
+func TestTaskService_Update_IfMatchCurrent(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	existingTask := createTestTask("user-123", "task-456")
+	existingTask.Version = 3
+	newTitle := "Updated Title"
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(existingTask, nil)
+	mockTaskRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Task")).Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TaskHistory")).Return(nil)
+
+	ctx := domain.WithExpectedVersion(context.Background(), 3)
+	task, err := service.Update(ctx, "user-123", "task-456", &domain.UpdateTaskDTO{Title: &newTitle})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Updated Title", task.Title)
+	mockTaskRepo.AssertExpectations(t)
+}
+
+func TestTaskService_Update_IfMatchStale(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	existingTask := createTestTask("user-123", "task-456")
+	existingTask.Version = 4
+	newTitle := "Updated Title"
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(existingTask, nil)
+
+	ctx := domain.WithExpectedVersion(context.Background(), 3)
+	task, err := service.Update(ctx, "user-123", "task-456", &domain.UpdateTaskDTO{Title: &newTitle})
+
+	assert.Nil(t, task)
+	var preconditionErr *domain.PreconditionFailedError
+	require.ErrorAs(t, err, &preconditionErr)
+	// The server copy is returned untouched so the client can merge
+	current := preconditionErr.Current.(*domain.Task)
+	assert.Equal(t, 4, current.Version)
+	assert.Equal(t, "Test Task", current.Title)
+	mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestTaskService_Update_ConcurrentWrite(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	existingTask := createTestTask("user-123", "task-456")
+	concurrentTask := createTestTask("user-123", "task-456")
+	concurrentTask.Title = "Edited in another tab"
+	concurrentTask.Version = 2
+	newTitle := "Updated Title"
+
+	// Another write lands between our read and our write
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(existingTask, nil).Once()
+	mockTaskRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Task")).Return(domain.ErrTaskVersionConflict)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(concurrentTask, nil).Once()
+
+	task, err := service.Update(context.Background(), "user-123", "task-456", &domain.UpdateTaskDTO{Title: &newTitle})
+
+	assert.Nil(t, task)
+	var preconditionErr *domain.PreconditionFailedError
+	require.ErrorAs(t, err, &preconditionErr)
+	assert.Equal(t, concurrentTask, preconditionErr.Current)
+	mockHistoryRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+// =============================================================================
+// TaskService.Patch Tests
+// =============================================================================
+
+func TestTaskService_Patch_NullClearsFields(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	existingTask := createTestTask("user-123", "task-456")
+	dueDate := time.Now().Add(48 * time.Hour)
+	effort := domain.TaskEffortLarge
+	existingTask.DueDate = &dueDate
+	existingTask.EstimatedEffort = &effort
+	existingTask.Category = stringPtr("work")
+
+	var patch domain.TaskMergePatch
+	require.NoError(t, json.Unmarshal([]byte(`{"due_date": null, "estimated_effort": null, "category": null}`), &patch))
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(existingTask, nil)
+	mockTaskRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Task")).Return(nil)
+	// History records the before/after snapshots
+	mockHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+		return h.EventType == domain.EventTaskUpdated &&
+			h.OldValue != nil && strings.Contains(*h.OldValue, `"due_date"`) &&
+			h.NewValue != nil && !strings.Contains(*h.NewValue, `"due_date"`)
+	})).Return(nil)
+
+	task, err := service.Patch(context.Background(), "user-123", "task-456", &patch)
+
+	require.NoError(t, err)
+	assert.Nil(t, task.DueDate)
+	assert.Nil(t, task.EstimatedEffort)
+	assert.Nil(t, task.Category)
+	// Omitted members are untouched
+	assert.Equal(t, "Test Task", task.Title)
+	assert.Equal(t, "Test Description", *task.Description)
+	mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestTaskService_Patch_NullRequiredField(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	var patch domain.TaskMergePatch
+	require.NoError(t, json.Unmarshal([]byte(`{"title": null}`), &patch))
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+
+	task, err := service.Patch(context.Background(), "user-123", "task-456", &patch)
+
+	assert.Nil(t, task)
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "title", validationErr.Field)
+	mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestTaskService_Patch_InvalidEffort(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	var patch domain.TaskMergePatch
+	require.NoError(t, json.Unmarshal([]byte(`{"estimated_effort": "huge"}`), &patch))
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+
+	task, err := service.Patch(context.Background(), "user-123", "task-456", &patch)
+
+	assert.Nil(t, task)
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "estimated_effort", validationErr.Field)
+}
+
+func TestTaskService_Patch_DueDateChangeReschedulesReminders(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	mockReminderService := new(MockReminderService)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+	service.SetReminderService(mockReminderService)
+
+	var patch domain.TaskMergePatch
+	require.NoError(t, json.Unmarshal([]byte(`{"due_date": "2030-01-15T09:00:00Z"}`), &patch))
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	mockTaskRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Task")).Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockReminderService.On("RescheduleForTask", mock.Anything, mock.MatchedBy(func(task *domain.Task) bool {
+		return task.DueDate != nil && task.DueDate.Equal(time.Date(2030, 1, 15, 9, 0, 0, 0, time.UTC))
+	})).Return(nil)
+
+	_, err := service.Patch(context.Background(), "user-123", "task-456", &patch)
+
+	require.NoError(t, err)
+	mockReminderService.AssertExpectations(t)
+}
+
+func TestTaskService_Patch_UnchangedDueDateKeepsReminders(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	mockReminderService := new(MockReminderService)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+	service.SetReminderService(mockReminderService)
+
+	var patch domain.TaskMergePatch
+	require.NoError(t, json.Unmarshal([]byte(`{"title": "Renamed"}`), &patch))
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	mockTaskRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Task")).Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := service.Patch(context.Background(), "user-123", "task-456", &patch)
+
+	require.NoError(t, err)
+	mockReminderService.AssertNotCalled(t, "RescheduleForTask", mock.Anything, mock.Anything)
+}
+
+func TestTaskService_Patch_NullClearsTags(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	existingTask := createTestTask("user-123", "task-456")
+	existingTask.Tags = []string{"urgent"}
+
+	var patch domain.TaskMergePatch
+	require.NoError(t, json.Unmarshal([]byte(`{"tags": null}`), &patch))
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(existingTask, nil)
+	// An empty, non-nil slice tells the repository to remove every tag
+	mockTaskRepo.On("Update", mock.Anything, mock.MatchedBy(func(task *domain.Task) bool {
+		return task.Tags != nil && len(task.Tags) == 0
+	})).Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	task, err := service.Patch(context.Background(), "user-123", "task-456", &patch)
+
+	require.NoError(t, err)
+	assert.Empty(t, task.Tags)
+	mockTaskRepo.AssertExpectations(t)
+}
+
 // =============================================================================
 // TaskService.Delete Tests
 // =============================================================================
@@ -372,7 +632,7 @@ func TestTaskService_Delete_Success(t *testing.T) {
 
 	mockTaskRepo.On("FindByID", mock.Anything, taskID).Return(existingTask, nil)
 	mockHistoryRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TaskHistory")).Return(nil)
-	mockTaskRepo.On("Delete", mock.Anything, taskID, userID).Return(nil)
+	mockTaskRepo.On("Delete", mock.Anything, taskID, userID, mock.Anything).Return(nil)
 
 	err := service.Delete(context.Background(), userID, taskID)
 
@@ -402,11 +662,353 @@ func TestTaskService_Delete_Forbidden(t *testing.T) {
 
 	mockTaskRepo.On("FindByID", mock.Anything, taskID).Return(existingTask, nil)
 
-	err := service.Delete(context.Background(), "user-123", taskID)
+	err := service.Delete(context.Background(), "user-123", taskID)
+
+	assert.Error(t, err)
+	var forbiddenErr *domain.ForbiddenError
+	assert.True(t, errors.As(err, &forbiddenErr))
+}
+
+func TestTaskService_Delete_IfMatchStale(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	existingTask := createTestTask("user-123", "task-456")
+	existingTask.Version = 2
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(existingTask, nil)
+
+	err := service.Delete(domain.WithExpectedVersion(context.Background(), 1), "user-123", "task-456")
+
+	var preconditionErr *domain.PreconditionFailedError
+	assert.ErrorAs(t, err, &preconditionErr)
+	mockTaskRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskService_Delete_IfMatchChangedBeforeDelete(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	existingTask := createTestTask("user-123", "task-456")
+	existingTask.Version = 2
+
+	// The task matches when read but is written by someone else before the delete runs
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(existingTask, nil)
+	mockTaskRepo.On("Delete", mock.Anything, "task-456", "user-123", 2).Return(domain.ErrTaskVersionConflict)
+
+	err := service.Delete(domain.WithExpectedVersion(context.Background(), 2), "user-123", "task-456")
+
+	var preconditionErr *domain.PreconditionFailedError
+	assert.ErrorAs(t, err, &preconditionErr)
+	mockHistoryRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+// =============================================================================
+// TaskService.Restore Tests
+// =============================================================================
+
+func createTrashedSubtask(userID, taskID, parentID, groupID string) *domain.Task {
+	task := createTestTask(userID, taskID)
+	deletedAt := time.Now()
+	task.TaskType = domain.TaskTypeSubtask
+	task.ParentTaskID = &parentID
+	task.DeletedAt = &deletedAt
+	task.DeletionGroupID = &groupID
+	return task
+}
+
+func TestTaskService_Restore_SubtaskTrashedWithParentRestoresTree(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	subtask := createTrashedSubtask("user-123", "sub-1", "parent-1", "group-1")
+	parent := createTestTask("user-123", "parent-1")
+	parent.DeletedAt = subtask.DeletedAt
+	parent.DeletionGroupID = subtask.DeletionGroupID
+	restored := createTestTask("user-123", "sub-1")
+
+	mockTaskRepo.On("FindByIDIncludingDeleted", mock.Anything, "sub-1").Return(subtask, nil)
+	mockTaskRepo.On("FindByIDIncludingDeleted", mock.Anything, "parent-1").Return(parent, nil)
+	mockTaskRepo.On("Restore", mock.Anything, "sub-1", "user-123").Return(nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "sub-1").Return(restored, nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TaskHistory")).Return(nil)
+
+	task, err := service.Restore(context.Background(), "user-123", "sub-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "sub-1", task.ID)
+	mockTaskRepo.AssertExpectations(t)
+}
+
+func TestTaskService_Restore_SubtaskOfSeparatelyTrashedParent(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewTaskService(mockTaskRepo, new(MockTaskHistoryRepository))
+
+	subtask := createTrashedSubtask("user-123", "sub-1", "parent-1", "group-1")
+	parent := createTestTask("user-123", "parent-1")
+	parentDeletedAt := time.Now()
+	parentGroup := "group-2"
+	parent.DeletedAt = &parentDeletedAt
+	parent.DeletionGroupID = &parentGroup
+
+	mockTaskRepo.On("FindByIDIncludingDeleted", mock.Anything, "sub-1").Return(subtask, nil)
+	mockTaskRepo.On("FindByIDIncludingDeleted", mock.Anything, "parent-1").Return(parent, nil)
+
+	_, err := service.Restore(context.Background(), "user-123", "sub-1")
+
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "parent_task_id", validationErr.Field)
+	mockTaskRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// =============================================================================
+// TaskService.GetHistory Tests
+// =============================================================================
+
+func TestTaskService_GetHistory_ReturnsFieldChanges(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	oldCount, newCount := "10", "11"
+	stored := []*domain.TaskHistory{{
+		ID:        "history-1",
+		TaskID:    "task-456",
+		EventType: domain.EventTaskBumped,
+		OldValue:  &oldCount,
+		NewValue:  &newCount,
+	}}
+	filter := &domain.TaskHistoryFilter{EventTypes: []domain.TaskHistoryEventType{domain.EventTaskBumped}, Limit: 20}
+
+	// History of a task in the trash is still readable
+	deletedTask := createTestTask("user-123", "task-456")
+	deletedAt := time.Now()
+	deletedTask.DeletedAt = &deletedAt
+	mockTaskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-456").Return(deletedTask, nil)
+	mockHistoryRepo.On("ListByTaskID", mock.Anything, "task-456", filter).Return(stored, 21, nil)
+
+	page, err := service.GetHistory(context.Background(), "user-123", "task-456", filter)
+
+	require.NoError(t, err)
+	assert.Equal(t, 21, page.TotalCount)
+	require.Len(t, page.History, 1)
+	assert.Equal(t, []domain.FieldChange{{Field: "bump_count", Old: json.RawMessage(`10`), New: json.RawMessage(`11`)}}, page.History[0].Changes)
+}
+
+func TestTaskService_GetHistory_TaskNotFound(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewTaskService(mockTaskRepo, new(MockTaskHistoryRepository))
+
+	mockTaskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-456").Return(nil, domain.ErrTaskNotFound)
+
+	page, err := service.GetHistory(context.Background(), "user-123", "task-456", &domain.TaskHistoryFilter{Limit: 20})
+
+	assert.Nil(t, page)
+	var notFoundErr *domain.NotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+}
+
+func TestTaskService_GetHistory_Validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *domain.TaskHistoryFilter
+		field  string
+	}{
+		{"unknown event type", &domain.TaskHistoryFilter{EventTypes: []domain.TaskHistoryEventType{"renamed"}, Limit: 20}, "event_type"},
+		{"limit too large", &domain.TaskHistoryFilter{Limit: 101}, "limit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockHistoryRepo := new(MockTaskHistoryRepository)
+			service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+			mockTaskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+
+			_, err := service.GetHistory(context.Background(), "user-123", "task-456", tt.filter)
+
+			var validationErr *domain.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+			mockHistoryRepo.AssertNotCalled(t, "ListByTaskID", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestTaskService_GetHistory_OtherUsersTask(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+	mockTaskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-456").Return(createTestTask("other-user", "task-456"), nil)
+
+	_, err := service.GetHistory(context.Background(), "user-123", "task-456", &domain.TaskHistoryFilter{Limit: 20})
+
+	var forbiddenErr *domain.ForbiddenError
+	assert.ErrorAs(t, err, &forbiddenErr)
+}
+
+func TestTaskService_Patch_StatusChangeLogsStatusEvent(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	var patch domain.TaskMergePatch
+	require.NoError(t, json.Unmarshal([]byte(`{"status": "in_progress"}`), &patch))
+
+	task := createTestTask("user-123", "task-456")
+	task.WorkflowStatus = domain.TaskStatusTodo
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(task, nil)
+	mockTaskRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Task")).Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+		changes := h.Changes()
+		// The workflow status moves with the built-in one
+		return h.EventType == domain.EventStatusChanged && len(changes) == 2 &&
+			changes[0].Field == "status" && changes[1].Field == "workflow_status"
+	})).Return(nil)
+
+	_, err := service.Patch(context.Background(), "user-123", "task-456", &patch)
+
+	require.NoError(t, err)
+	mockHistoryRepo.AssertExpectations(t)
+}
+
+func newWorkflowTaskService(statuses []domain.WorkflowStatus) (*TaskService, *MockTaskRepository, *MockTaskHistoryRepository) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	mockWorkflowRepo := new(MockWorkflowRepository)
+	mockWorkflowRepo.On("GetStatuses", mock.Anything, "user-123").Return(statuses, nil)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+	service.SetWorkflowService(NewWorkflowService(mockWorkflowRepo))
+	return service, mockTaskRepo, mockHistoryRepo
+}
+
+func TestTaskService_Patch_CustomStatus(t *testing.T) {
+	service, mockTaskRepo, mockHistoryRepo := newWorkflowTaskService(reviewWorkflow)
+	status := domain.TaskStatus("in_review")
+
+	task := createTestTask("user-123", "task-456")
+	task.Status = domain.TaskStatusInProgress
+	task.WorkflowStatus = domain.TaskStatusInProgress
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(task, nil)
+	mockTaskRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Task")).Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+		return h.EventType == domain.EventStatusChanged
+	})).Return(nil)
+
+	updated, err := service.Update(context.Background(), "user-123", "task-456", &domain.UpdateTaskDTO{Status: &status})
+
+	require.NoError(t, err)
+	assert.Equal(t, status, updated.WorkflowStatus)
+	// Stored with its category's built-in status
+	assert.Equal(t, domain.TaskStatusInProgress, updated.Status)
+	mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestTaskService_Patch_CustomDoneStatusCompletes(t *testing.T) {
+	service, mockTaskRepo, mockHistoryRepo := newWorkflowTaskService(reviewWorkflow)
+	status := domain.TaskStatus("shipped")
+
+	task := createTestTask("user-123", "task-456")
+	task.Status = domain.TaskStatusInProgress
+	task.WorkflowStatus = "in_review"
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(task, nil)
+	mockTaskRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Task")).Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	updated, err := service.Update(context.Background(), "user-123", "task-456", &domain.UpdateTaskDTO{Status: &status})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.TaskStatusDone, updated.Status)
+	assert.NotNil(t, updated.CompletedAt)
+}
+
+func TestTaskService_Patch_TransitionNotAllowed(t *testing.T) {
+	service, mockTaskRepo, _ := newWorkflowTaskService(reviewWorkflow)
+	status := domain.TaskStatus("backlog")
+
+	task := createTestTask("user-123", "task-456")
+	task.Status = domain.TaskStatusInProgress
+	task.WorkflowStatus = "in_review"
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(task, nil)
+
+	_, err := service.Update(context.Background(), "user-123", "task-456", &domain.UpdateTaskDTO{Status: &status})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidStatusTransition)
+	mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestTaskService_Patch_BuiltInWorkflowAllowsAnyTransition(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+	status := domain.TaskStatusDone
+
+	task := createTestTask("user-123", "task-456")
+	task.Status = domain.TaskStatusBlocked
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(task, nil)
+	mockTaskRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	mockTaskRepo.On("GetSubtree", mock.Anything, "task-456").Return([]*domain.Task{}, nil).Maybe()
+	mockHistoryRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	updated, err := service.Update(context.Background(), "user-123", "task-456", &domain.UpdateTaskDTO{Status: &status})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.TaskStatusDone, updated.Status)
+}
+
+func TestTaskService_Complete_FollowsWorkflow(t *testing.T) {
+	service, mockTaskRepo, mockHistoryRepo := newWorkflowTaskService(reviewWorkflow)
+
+	task := createTestTask("user-123", "task-456")
+	task.Status = domain.TaskStatusInProgress
+	task.WorkflowStatus = "in_review"
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(task, nil)
+	mockTaskRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	completed, err := service.Complete(context.Background(), "user-123", "task-456")
+
+	require.NoError(t, err)
+	// Lands in the first done status the workflow allows
+	assert.Equal(t, domain.TaskStatus("shipped"), completed.WorkflowStatus)
+}
+
+func TestTaskService_Complete_TransitionNotAllowed(t *testing.T) {
+	service, mockTaskRepo, _ := newWorkflowTaskService([]domain.WorkflowStatus{
+		{Key: domain.TaskStatusTodo, Name: "To Do", Category: domain.StatusCategoryOpen,
+			Transitions: []domain.TaskStatus{"in_review"}},
+		{Key: "in_review", Name: "In Review", Category: domain.StatusCategoryActive},
+		{Key: domain.TaskStatusDone, Name: "Done", Category: domain.StatusCategoryDone},
+	})
+
+	task := createTestTask("user-123", "task-456")
+	task.WorkflowStatus = domain.TaskStatusTodo
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(task, nil)
+
+	_, err := service.Complete(context.Background(), "user-123", "task-456")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidStatusTransition)
+	mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestTaskService_Patch_StatusNotInWorkflow(t *testing.T) {
+	service, mockTaskRepo, _ := newWorkflowTaskService(reviewWorkflow)
+	status := domain.TaskStatusTodo
+
+	task := createTestTask("user-123", "task-456")
+	task.WorkflowStatus = "backlog"
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(task, nil)
+
+	_, err := service.Update(context.Background(), "user-123", "task-456", &domain.UpdateTaskDTO{Status: &status})
 
-	assert.Error(t, err)
-	var forbiddenErr *domain.ForbiddenError
-	assert.True(t, errors.As(err, &forbiddenErr))
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "status", validationErr.Field)
+	mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 }
 
 // =============================================================================
@@ -467,10 +1069,120 @@ func TestTaskService_Bump_Forbidden(t *testing.T) {
 	assert.Nil(t, task)
 }
 
+// =============================================================================
+// TaskService.Snooze Tests
+// =============================================================================
+
+func TestTaskService_Snooze_Preset(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	mockGamification := new(MockGamificationService)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+	service.SetGamificationService(mockGamification)
+
+	userID := "user-123"
+	taskID := "task-456"
+	existingTask := createTestTask(userID, taskID)
+	existingTask.BumpCount = 2
+
+	mockGamification.On("GetUserTimezone", mock.Anything, userID).Return("America/New_York", nil)
+	mockTaskRepo.On("FindByID", mock.Anything, taskID).Return(existingTask, nil)
+	mockTaskRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Task")).Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TaskHistory")).Return(nil)
+
+	preset := domain.SnoozeTomorrow
+	task, err := service.Snooze(context.Background(), userID, taskID, &domain.SnoozeTaskDTO{Preset: &preset})
+
+	require.NoError(t, err)
+	require.NotNil(t, task.DeferUntil)
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	deferUntil := task.DeferUntil.In(loc)
+	assert.Equal(t, 9, deferUntil.Hour())
+	assert.True(t, task.IsDeferred(time.Now()))
+	assert.Equal(t, 2, task.BumpCount, "snoozing must not count as a bump")
+	mockTaskRepo.AssertNotCalled(t, "IncrementBumpCount", mock.Anything, mock.Anything, mock.Anything)
+	mockTaskRepo.AssertExpectations(t)
+}
+
+func TestTaskService_Snooze_Until(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	userID := "user-123"
+	taskID := "task-456"
+	until := time.Now().Add(72 * time.Hour).Truncate(time.Second)
+
+	mockTaskRepo.On("FindByID", mock.Anything, taskID).Return(createTestTask(userID, taskID), nil)
+	mockTaskRepo.On("Update", mock.Anything, mock.MatchedBy(func(task *domain.Task) bool {
+		return task.DeferUntil != nil && task.DeferUntil.Equal(until)
+	})).Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TaskHistory")).Return(nil)
+
+	task, err := service.Snooze(context.Background(), userID, taskID, &domain.SnoozeTaskDTO{Until: &until})
+
+	require.NoError(t, err)
+	assert.Equal(t, until, *task.DeferUntil)
+	mockTaskRepo.AssertExpectations(t)
+}
+
+func TestTaskService_Snooze_Validation(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	tonight := domain.SnoozeTonight
+	invalid := domain.SnoozePreset("someday")
+
+	tests := []struct {
+		name  string
+		dto   *domain.SnoozeTaskDTO
+		field string
+	}{
+		{"neither preset nor until", &domain.SnoozeTaskDTO{}, "snooze"},
+		{"both preset and until", &domain.SnoozeTaskDTO{Preset: &tonight, Until: &future}, "snooze"},
+		{"unknown preset", &domain.SnoozeTaskDTO{Preset: &invalid}, "preset"},
+		{"until in the past", &domain.SnoozeTaskDTO{Until: &past}, "until"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			service := NewTaskService(mockTaskRepo, new(MockTaskHistoryRepository))
+
+			task, err := service.Snooze(context.Background(), "user-123", "task-456", tt.dto)
+
+			assert.Nil(t, task)
+			var validationErr *domain.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+			mockTaskRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+		})
+	}
+}
+
 // =============================================================================
 // TaskService.Complete Tests
 // =============================================================================
 
+func TestTaskService_Uncomplete_VersionConflict(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	gamification := new(MockGamificationService)
+	service := NewTaskService(mockTaskRepo, new(MockTaskHistoryRepository))
+	service.SetGamificationService(gamification)
+
+	task := createTestTask("user-123", "task-456")
+	task.Status = domain.TaskStatusDone
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(task, nil)
+	mockTaskRepo.On("Update", mock.Anything, mock.Anything).Return(domain.ErrTaskVersionConflict)
+
+	_, err := service.Uncomplete(context.Background(), "user-123", "task-456")
+
+	var preconditionErr *domain.PreconditionFailedError
+	require.ErrorAs(t, err, &preconditionErr)
+	// Nothing changed, so the completion rewards stay
+	gamification.AssertNotCalled(t, "ProcessTaskUncompletionAsync", mock.Anything, mock.Anything)
+}
+
 func TestTaskService_Complete_Success(t *testing.T) {
 	mockTaskRepo := new(MockTaskRepository)
 	mockHistoryRepo := new(MockTaskHistoryRepository)
@@ -610,14 +1322,22 @@ func TestTaskService_RenameCategory_Success(t *testing.T) {
 	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
 
 	userID := "user-123"
+	oldCategory := "old-name"
+	task := &domain.Task{ID: "task-1", UserID: userID, Title: "Filed", Category: &oldCategory, Version: 3}
 
-	mockTaskRepo.On("RenameCategoryForUser", mock.Anything, userID, "old-name", "new-name").Return(5, nil)
+	mockTaskRepo.On("RenameCategoryForUser", mock.Anything, userID, "old-name", "new-name").Return([]*domain.Task{task}, nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+		changes := h.Changes()
+		return h.TaskID == "task-1" && h.EventType == domain.EventTaskUpdated &&
+			len(changes) == 1 && changes[0].Field == "category" && string(changes[0].New) == `"new-name"`
+	})).Return(nil)
 
 	count, err := service.RenameCategory(context.Background(), userID, "old-name", "new-name")
 
 	assert.NoError(t, err)
-	assert.Equal(t, 5, count)
+	assert.Equal(t, 1, count)
 	mockTaskRepo.AssertExpectations(t)
+	mockHistoryRepo.AssertExpectations(t)
 }
 
 func TestTaskService_RenameCategory_EmptyOldName(t *testing.T) {
@@ -652,14 +1372,25 @@ func TestTaskService_DeleteCategory_Success(t *testing.T) {
 	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
 
 	userID := "user-123"
+	category := "category-to-delete"
+	tasks := []*domain.Task{
+		{ID: "task-1", UserID: userID, Title: "One", Category: &category, Version: 1},
+		{ID: "task-2", UserID: userID, Title: "Two", Category: &category, Version: 4},
+	}
 
-	mockTaskRepo.On("DeleteCategoryForUser", mock.Anything, userID, "category-to-delete").Return(3, nil)
+	mockTaskRepo.On("DeleteCategoryForUser", mock.Anything, userID, "category-to-delete").Return(tasks, nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+		changes := h.Changes()
+		return h.EventType == domain.EventTaskUpdated &&
+			len(changes) == 1 && changes[0].Field == "category" && string(changes[0].New) == "null"
+	})).Return(nil).Twice()
 
 	count, err := service.DeleteCategory(context.Background(), userID, "category-to-delete")
 
 	assert.NoError(t, err)
-	assert.Equal(t, 3, count)
+	assert.Equal(t, 2, count)
 	mockTaskRepo.AssertExpectations(t)
+	mockHistoryRepo.AssertExpectations(t)
 }
 
 func TestTaskService_DeleteCategory_EmptyName(t *testing.T) {
@@ -792,6 +1523,98 @@ func TestTaskService_List_RepoError(t *testing.T) {
 	mockTaskRepo.AssertExpectations(t)
 }
 
+// =============================================================================
+// TaskService.ListPage Tests
+// =============================================================================
+
+func TestTaskService_ListPage_HasNextPage(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	now := time.Now()
+	filter := &domain.TaskListFilter{Limit: 2}
+	repoTasks := []*domain.Task{
+		{ID: "00000000-0000-0000-0000-000000000001", UserID: "user-123", PriorityScore: 90, CreatedAt: now},
+		{ID: "00000000-0000-0000-0000-000000000002", UserID: "user-123", PriorityScore: 80, CreatedAt: now},
+		{ID: "00000000-0000-0000-0000-000000000003", UserID: "user-123", PriorityScore: 70, CreatedAt: now},
+	}
+
+	// Repository is asked for one extra row to detect the next page
+	mockTaskRepo.On("List", mock.Anything, "user-123", mock.MatchedBy(func(f *domain.TaskListFilter) bool {
+		return f.Limit == 3
+	})).Return(repoTasks, nil)
+	mockTaskRepo.On("Count", mock.Anything, "user-123", filter).Return(7, nil)
+
+	page, err := service.ListPage(context.Background(), "user-123", filter)
+
+	require.NoError(t, err)
+	require.Len(t, page.Tasks, 2)
+	assert.Equal(t, 7, page.TotalCount)
+	require.NotNil(t, page.NextCursor)
+
+	cursor, err := domain.DecodeTaskCursor(*page.NextCursor, domain.DefaultTaskSort)
+	require.NoError(t, err)
+	assert.Equal(t, "00000000-0000-0000-0000-000000000002", cursor.ID)
+	assert.Equal(t, 80, cursor.Values[0])
+	assert.Equal(t, 2, filter.Limit) // Caller's filter is not modified
+	mockTaskRepo.AssertExpectations(t)
+}
+
+func TestTaskService_ListPage_LastPage(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	filter := &domain.TaskListFilter{Limit: 5}
+	repoTasks := []*domain.Task{
+		{ID: "task-1", UserID: "user-123"},
+	}
+
+	mockTaskRepo.On("List", mock.Anything, "user-123", mock.Anything).Return(repoTasks, nil)
+	mockTaskRepo.On("Count", mock.Anything, "user-123", filter).Return(6, nil)
+
+	page, err := service.ListPage(context.Background(), "user-123", filter)
+
+	require.NoError(t, err)
+	assert.Len(t, page.Tasks, 1)
+	assert.Equal(t, 6, page.TotalCount)
+	assert.Nil(t, page.NextCursor)
+	mockTaskRepo.AssertExpectations(t)
+}
+
+func TestTaskService_ListPage_EmptyResult(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	mockTaskRepo.On("List", mock.Anything, "user-123", mock.Anything).Return(nil, nil)
+	mockTaskRepo.On("Count", mock.Anything, "user-123", mock.Anything).Return(0, nil)
+
+	page, err := service.ListPage(context.Background(), "user-123", nil)
+
+	require.NoError(t, err)
+	assert.NotNil(t, page.Tasks)
+	assert.Empty(t, page.Tasks)
+	assert.Nil(t, page.NextCursor)
+}
+
+func TestTaskService_ListPage_CountError(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	mockTaskRepo.On("List", mock.Anything, "user-123", mock.Anything).Return([]*domain.Task{}, nil)
+	mockTaskRepo.On("Count", mock.Anything, "user-123", mock.Anything).Return(0, errors.New("database error"))
+
+	page, err := service.ListPage(context.Background(), "user-123", &domain.TaskListFilter{Limit: 20})
+
+	assert.Error(t, err)
+	assert.Nil(t, page)
+	var internalErr *domain.InternalError
+	assert.ErrorAs(t, err, &internalErr)
+}
+
 // =============================================================================
 // TaskService.Complete Additional Tests
 // =============================================================================
@@ -869,3 +1692,419 @@ func TestTaskService_GetCalendar_RepoError(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+// =============================================================================
+// TaskService.BulkUpdate Tests
+// =============================================================================
+
+func TestTaskService_BulkUpdate_PartialFailure(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	dueDate := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	owned := createTestTask("user-123", "task-1")
+	owned.DueDate = &dueDate
+	owned.RelatedPeople = []string{"Ana", "Ben"}
+	noDueDate := createTestTask("user-123", "task-2")
+	notOwned := createTestTask("other-user", "task-3")
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(owned, nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-2").Return(noDueDate, nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-3").Return(notOwned, nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-4").Return(nil, domain.ErrTaskNotFound)
+	mockTaskRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Task")).Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TaskHistory")).Return(nil)
+
+	priority := 9
+	shift := "+2d"
+	response, err := service.BulkUpdate(context.Background(), "user-123", &domain.BulkUpdateRequest{
+		TaskIDs: []string{"task-1", "task-2", "task-3", "task-4", "task-1"},
+		Patch: domain.BulkTaskPatch{
+			UserPriority:        &priority,
+			DueDateShift:        &shift,
+			AddRelatedPeople:    []string{"Cleo", "ana"},
+			RemoveRelatedPeople: []string{"ben"},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, response.SuccessCount)
+	assert.ElementsMatch(t, []string{"task-3", "task-4"}, response.FailedIDs)
+	assert.Equal(t, "task not found", response.Errors["task-3"])
+
+	assert.Equal(t, 9, owned.UserPriority)
+	assert.Equal(t, dueDate.AddDate(0, 0, 2), *owned.DueDate)
+	assert.Equal(t, []string{"Ana", "Cleo"}, owned.RelatedPeople)
+	assert.Nil(t, noDueDate.DueDate)
+	// Duplicate IDs are processed once; history is written per updated task
+	mockTaskRepo.AssertNumberOfCalls(t, "Update", 2)
+	mockHistoryRepo.AssertNumberOfCalls(t, "Create", 2)
+}
+
+func TestTaskService_BulkUpdate_ForbiddenTransitionFailsTask(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	mockWorkflowRepo := new(MockWorkflowRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+	service.SetWorkflowService(NewWorkflowService(mockWorkflowRepo))
+
+	// Shipping is only allowed from review
+	mockWorkflowRepo.On("GetStatuses", mock.Anything, "user-123").Return([]domain.WorkflowStatus{
+		{Key: domain.TaskStatusTodo, Name: "To Do", Category: domain.StatusCategoryOpen,
+			Transitions: []domain.TaskStatus{"in_review"}},
+		{Key: "in_review", Name: "In Review", Category: domain.StatusCategoryActive},
+		{Key: "shipped", Name: "Shipped", Category: domain.StatusCategoryDone},
+	}, nil).Once()
+
+	todo := createTestTask("user-123", "task-1")
+	todo.WorkflowStatus = domain.TaskStatusTodo
+	inReview := createTestTask("user-123", "task-2")
+	inReview.Status = domain.TaskStatusInProgress
+	inReview.WorkflowStatus = "in_review"
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(todo, nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-2").Return(inReview, nil)
+	mockTaskRepo.On("Update", mock.Anything, inReview).Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TaskHistory")).Return(nil)
+
+	shipped := domain.TaskStatus("shipped")
+	response, err := service.BulkUpdate(context.Background(), "user-123", &domain.BulkUpdateRequest{
+		TaskIDs: []string{"task-1", "task-2"},
+		Patch:   domain.BulkTaskPatch{Status: &shipped},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, response.SuccessCount)
+	assert.Equal(t, []string{"task-1"}, response.FailedIDs)
+	assert.Contains(t, response.Errors["task-1"], "todo to shipped")
+	assert.Equal(t, domain.TaskStatusDone, inReview.Status)
+	assert.Equal(t, shipped, inReview.WorkflowStatus)
+	assert.NotNil(t, inReview.CompletedAt)
+	mockTaskRepo.AssertNumberOfCalls(t, "Update", 1)
+	mockWorkflowRepo.AssertExpectations(t)
+}
+
+func TestTaskService_BulkUpdate_ClearsCategory(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	task := createTestTask("user-123", "task-1")
+	task.Category = stringPtr("work")
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(task, nil)
+	mockTaskRepo.On("Update", mock.Anything, task).Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TaskHistory")).Return(nil)
+
+	var req domain.BulkUpdateRequest
+	require.NoError(t, json.Unmarshal([]byte(`{"task_ids": ["task-1"], "patch": {"category": null}}`), &req))
+
+	response, err := service.BulkUpdate(context.Background(), "user-123", &req)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, response.SuccessCount)
+	assert.Nil(t, task.Category)
+}
+
+func TestTaskService_BulkUpdate_InvalidPatch(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	shift := "next week"
+	tests := []struct {
+		name  string
+		patch domain.BulkTaskPatch
+		field string
+	}{
+		{name: "empty patch", patch: domain.BulkTaskPatch{}, field: "patch"},
+		{name: "bad shift", patch: domain.BulkTaskPatch{DueDateShift: &shift}, field: "due_date_shift"},
+		{name: "bad priority", patch: domain.BulkTaskPatch{UserPriority: func() *int { p := 11; return &p }()}, field: "user_priority"},
+		{name: "unknown status", patch: domain.BulkTaskPatch{Status: func() *domain.TaskStatus { s := domain.TaskStatus("shipped"); return &s }()}, field: "status"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := service.BulkUpdate(context.Background(), "user-123", &domain.BulkUpdateRequest{
+				TaskIDs: []string{"task-1"},
+				Patch:   tt.patch,
+			})
+
+			assert.Nil(t, response)
+			var validationErr *domain.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+	mockTaskRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+// =============================================================================
+// TaskService.BulkComplete Tests
+// =============================================================================
+
+func TestTaskService_BulkComplete_BlockerFirst(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	mockDependencyRepo := new(MockDependencyRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+	service.SetDependencyService(NewDependencyService(mockDependencyRepo, mockTaskRepo))
+
+	userID := "user-123"
+	blocked := createTestTask(userID, "task-blocked")
+	blocked.TaskType = domain.TaskTypeRegular
+	blocker := createTestTask(userID, "task-blocker")
+	blocker.TaskType = domain.TaskTypeRegular
+
+	// task-blocked is blocked by task-blocker, but is listed first
+	mockDependencyRepo.On("GetDependencyGraph", mock.Anything, userID).
+		Return(map[string][]string{"task-blocked": {"task-blocker"}}, nil)
+	mockDependencyRepo.On("CountIncompleteBlockers", mock.Anything, mock.Anything).Return(0, nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-blocked").Return(blocked, nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-blocker").Return(blocker, nil)
+	mockTaskRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Task")).Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TaskHistory")).Return(nil)
+
+	response, err := service.BulkComplete(context.Background(), userID, []string{"task-blocked", "task-blocker"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, response.SuccessCount)
+	assert.Empty(t, response.FailedIDs)
+	require.Len(t, response.Results, 2)
+	assert.Equal(t, "task-blocker", response.Results[0].TaskID)
+	assert.Equal(t, "task-blocked", response.Results[1].TaskID)
+	assert.Equal(t, domain.TaskStatusDone, blocked.Status)
+	assert.Equal(t, domain.TaskStatusDone, blocker.Status)
+}
+
+func TestTaskService_BulkComplete_ReportsFailures(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	mockDependencyRepo := new(MockDependencyRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+	service.SetDependencyService(NewDependencyService(mockDependencyRepo, mockTaskRepo))
+
+	userID := "user-123"
+	done := createTestTask(userID, "task-done")
+	done.Status = domain.TaskStatusDone
+	blocked := createTestTask(userID, "task-blocked")
+	blocked.TaskType = domain.TaskTypeRegular
+
+	mockDependencyRepo.On("GetDependencyGraph", mock.Anything, userID).Return(map[string][]string{}, nil)
+	mockDependencyRepo.On("CountIncompleteBlockers", mock.Anything, "task-blocked").Return(1, nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-missing").Return(nil, domain.ErrTaskNotFound)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-other").Return(createTestTask("other-user", "task-other"), nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-done").Return(done, nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-blocked").Return(blocked, nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-broken").Return(nil, errors.New("db down"))
+
+	response, err := service.BulkComplete(context.Background(), userID,
+		[]string{"task-missing", "task-other", "task-done", "task-blocked", "task-broken", "task-missing"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, response.SuccessCount)
+	assert.Equal(t, []string{"task-missing", "task-other", "task-done", "task-blocked", "task-broken"}, response.FailedIDs)
+	assert.Equal(t, "task not found", response.Results[0].Error)
+	assert.Equal(t, "task not found", response.Results[1].Error)
+	assert.Equal(t, "task is already completed", response.Results[2].Error)
+	assert.Equal(t, domain.ErrCannotCompleteBlocked.Error(), response.Results[3].Error)
+	assert.Equal(t, "failed to complete task", response.Results[4].Error)
+	mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestTaskService_BulkComplete_ParentAfterSubtasks(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+	service.SetSubtaskService(NewSubtaskService(mockTaskRepo, mockHistoryRepo))
+
+	userID := "user-123"
+	parent := createTestTask(userID, "task-parent")
+	parent.TaskType = domain.TaskTypeRegular
+	subtask := createTestTask(userID, "task-subtask")
+	subtask.TaskType = domain.TaskTypeSubtask
+	subtask.ParentTaskID = &parent.ID
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-parent").Return(parent, nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-subtask").Return(subtask, nil)
+	// The subtask is still open on the first attempt at the parent
+	mockTaskRepo.On("CountIncompleteSubtasks", mock.Anything, "task-parent").Return(1, nil).Once()
+	mockTaskRepo.On("CountIncompleteSubtasks", mock.Anything, "task-parent").Return(0, nil)
+	mockTaskRepo.On("CountIncompleteSubtasks", mock.Anything, "task-subtask").Return(0, nil)
+	mockTaskRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Task")).Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TaskHistory")).Return(nil)
+
+	response, err := service.BulkComplete(context.Background(), userID, []string{"task-parent", "task-subtask"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, response.SuccessCount)
+	assert.Empty(t, response.FailedIDs)
+	assert.True(t, response.Results[0].Success)
+	assert.Empty(t, response.Results[0].Error)
+	assert.Equal(t, domain.TaskStatusDone, parent.Status)
+}
+
+func TestTaskService_BulkComplete_TooManyIDs(t *testing.T) {
+	service := NewTaskService(new(MockTaskRepository), new(MockTaskHistoryRepository))
+
+	taskIDs := make([]string, domain.MaxBulkTaskIDs+1)
+	for i := range taskIDs {
+		taskIDs[i] = "task-" + strconv.Itoa(i)
+	}
+
+	response, err := service.BulkComplete(context.Background(), "user-123", taskIDs)
+
+	assert.Nil(t, response)
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+// =============================================================================
+// TaskService.BulkDelete Tests
+// =============================================================================
+
+func TestTaskService_BulkDelete_SoftDeletesWithHistory(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(&domain.Task{ID: "task-1", UserID: "user-123", Title: "Mine"}, nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "task-2").Return(&domain.Task{ID: "task-2", UserID: "other-user"}, nil)
+	mockTaskRepo.On("Delete", mock.Anything, "task-1", "user-123", mock.Anything).Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+		return h.TaskID == "task-1" && h.EventType == domain.EventTaskDeleted && h.OldValue != nil && h.NewValue != nil
+	})).Return(nil).Once()
+
+	response, err := service.BulkDelete(context.Background(), "user-123", []string{"task-1", "task-2", "task-1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, response.SuccessCount)
+	assert.Equal(t, []string{"task-2"}, response.FailedIDs)
+	assert.Equal(t, "task not found", response.Errors["task-2"])
+	mockTaskRepo.AssertNotCalled(t, "Delete", mock.Anything, "task-2", mock.Anything)
+	mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestTaskService_BulkDelete_SubtasksGoWithRequestedAncestor(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	parentID := "parent-1"
+	childID := "child-1"
+	parent := &domain.Task{ID: parentID, UserID: "user-123", TaskType: domain.TaskTypeRegular}
+	child := &domain.Task{ID: childID, UserID: "user-123", TaskType: domain.TaskTypeSubtask, ParentTaskID: &parentID}
+	grandchild := &domain.Task{ID: "grandchild-1", UserID: "user-123", TaskType: domain.TaskTypeSubtask, ParentTaskID: &childID}
+
+	mockTaskRepo.On("FindByID", mock.Anything, "grandchild-1").Return(grandchild, nil)
+	mockTaskRepo.On("FindByID", mock.Anything, childID).Return(child, nil)
+	mockTaskRepo.On("FindByID", mock.Anything, parentID).Return(parent, nil)
+	mockTaskRepo.On("GetAncestorIDs", mock.Anything, "grandchild-1").Return([]string{childID, parentID}, nil)
+	mockTaskRepo.On("GetAncestorIDs", mock.Anything, childID).Return([]string{parentID}, nil)
+	// One delete trashes the whole tree in a single deletion group
+	mockTaskRepo.On("Delete", mock.Anything, parentID, "user-123", mock.Anything).Return(nil).Once()
+	mockHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+		return h.TaskID == parentID && h.EventType == domain.EventTaskDeleted
+	})).Return(nil).Once()
+
+	response, err := service.BulkDelete(context.Background(), "user-123", []string{"grandchild-1", childID, parentID})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, response.SuccessCount)
+	assert.Empty(t, response.FailedIDs)
+	mockTaskRepo.AssertExpectations(t)
+	mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestTaskService_BulkDelete_CoveredSubtaskFailsWithAncestor(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewTaskService(mockTaskRepo, new(MockTaskHistoryRepository))
+
+	parentID := "parent-1"
+	mockTaskRepo.On("FindByID", mock.Anything, parentID).Return(&domain.Task{ID: parentID, UserID: "user-123"}, nil)
+	mockTaskRepo.On("FindByID", mock.Anything, "child-1").Return(&domain.Task{
+		ID: "child-1", UserID: "user-123", TaskType: domain.TaskTypeSubtask, ParentTaskID: &parentID,
+	}, nil)
+	mockTaskRepo.On("GetAncestorIDs", mock.Anything, "child-1").Return([]string{parentID}, nil)
+	mockTaskRepo.On("Delete", mock.Anything, parentID, "user-123", mock.Anything).Return(errors.New("db down"))
+
+	response, err := service.BulkDelete(context.Background(), "user-123", []string{parentID, "child-1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, response.SuccessCount)
+	assert.ElementsMatch(t, []string{parentID, "child-1"}, response.FailedIDs)
+}
+
+func TestTaskService_BulkDelete_LeavesTasksInTrash(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-1").Return(&domain.Task{ID: "task-1", UserID: "user-123"}, nil)
+	mockTaskRepo.On("Delete", mock.Anything, "task-1", "user-123", mock.Anything).Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	response, err := service.BulkDelete(context.Background(), "user-123", []string{"task-1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, response.SuccessCount)
+	// Tasks and their attachment blobs are purged with the trash, not when they are trashed
+	mockTaskRepo.AssertNotCalled(t, "PurgeTrash", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// =============================================================================
+// TaskService.BulkRestore Tests
+// =============================================================================
+
+func TestTaskService_BulkRestore_RestoresTrashedAndCompletedTasks(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockHistoryRepo := new(MockTaskHistoryRepository)
+	service := NewTaskService(mockTaskRepo, mockHistoryRepo)
+
+	trashed := createTestTask("user-123", "task-1")
+	deletedAt := time.Now()
+	group := "group-1"
+	trashed.DeletedAt = &deletedAt
+	trashed.DeletionGroupID = &group
+	completed := createTestTask("user-123", "task-2")
+	completed.Status = domain.TaskStatusDone
+
+	mockTaskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-1").Return(trashed, nil)
+	mockTaskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-2").Return(completed, nil)
+	mockTaskRepo.On("BulkRestore", mock.Anything, "user-123", []string{"task-1", "task-2"}).Return(2, []string{}, nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+		return h.TaskID == "task-1" && h.EventType == domain.EventTaskRestored
+	})).Return(nil).Once()
+	mockHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+		return h.TaskID == "task-2" && h.EventType == domain.EventStatusChanged
+	})).Return(nil).Once()
+
+	response, err := service.BulkRestore(context.Background(), "user-123", []string{"task-1", "task-2"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, response.SuccessCount)
+	assert.Empty(t, response.FailedIDs)
+	mockTaskRepo.AssertExpectations(t)
+	mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestTaskService_BulkRestore_SkipsSubtaskOfSeparatelyTrashedParent(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewTaskService(mockTaskRepo, new(MockTaskHistoryRepository))
+
+	subtask := createTrashedSubtask("user-123", "sub-1", "parent-1", "group-1")
+	parent := createTestTask("user-123", "parent-1")
+	parentDeletedAt := time.Now()
+	parent.DeletedAt = &parentDeletedAt
+
+	mockTaskRepo.On("FindByIDIncludingDeleted", mock.Anything, "sub-1").Return(subtask, nil)
+	mockTaskRepo.On("FindByIDIncludingDeleted", mock.Anything, "parent-1").Return(parent, nil)
+
+	response, err := service.BulkRestore(context.Background(), "user-123", []string{"sub-1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, response.SuccessCount)
+	assert.Equal(t, []string{"sub-1"}, response.FailedIDs)
+	assert.Contains(t, response.Errors["sub-1"], "parent task is in the trash")
+	mockTaskRepo.AssertNotCalled(t, "BulkRestore", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/notkevinvu/taskflow/backend/internal/domain"
 	"github.com/notkevinvu/taskflow/backend/internal/ports"
+	"github.com/notkevinvu/taskflow/backend/internal/validation"
 )
 
 // TaskTemplateService handles task template business logic
@@ -51,6 +52,7 @@ func (s *TaskTemplateService) Create(ctx context.Context, userID string, dto *do
 		UserPriority:    userPriority,
 		Context:         dto.Context,
 		RelatedPeople:   dto.RelatedPeople,
+		Tags:            dto.Tags,
 		DueDateOffset:   dto.DueDateOffset,
 		CreatedAt:       now,
 		UpdatedAt:       now,
@@ -61,6 +63,13 @@ func (s *TaskTemplateService) Create(ctx context.Context, userID string, dto *do
 		template.RelatedPeople = []string{}
 	}
 
+	// Validate tags (always non-nil, as the column is NOT NULL)
+	tags, err := validation.ValidateTags(template.Tags, "tags")
+	if err != nil {
+		return nil, err
+	}
+	template.Tags = tags
+
 	// Validate template
 	if err := template.Validate(); err != nil {
 		return nil, err
@@ -106,7 +115,14 @@ func (s *TaskTemplateService) List(ctx context.Context, userID string) ([]*domai
 }
 
 // Update updates an existing template
+// PUT semantics: nil DTO fields are left unchanged (see Patch for clearing fields)
 func (s *TaskTemplateService) Update(ctx context.Context, userID, templateID string, dto *domain.UpdateTaskTemplateDTO) (*domain.TaskTemplate, error) {
+	return s.Patch(ctx, userID, templateID, dto.ToMergePatch())
+}
+
+// Patch applies a JSON merge patch (RFC 7396) to a template.
+// Omitted members are left unchanged; null members clear optional fields.
+func (s *TaskTemplateService) Patch(ctx context.Context, userID, templateID string, patch *domain.TaskTemplateMergePatch) (*domain.TaskTemplate, error) {
 	// Get existing template
 	template, err := s.Get(ctx, userID, templateID)
 	if err != nil {
@@ -114,47 +130,38 @@ func (s *TaskTemplateService) Update(ctx context.Context, userID, templateID str
 	}
 
 	// Check for duplicate name if name is being changed
-	if dto.Name != nil && *dto.Name != template.Name {
-		exists, err := s.templateRepo.ExistsByNameExcludingID(ctx, userID, *dto.Name, templateID)
+	if patch.Name.Set {
+		if patch.Name.IsNull() {
+			return nil, domain.NewValidationError("name", "cannot be null")
+		}
+		name, err := validation.ValidateRequiredText(*patch.Name.Value, 100, "name")
 		if err != nil {
-			return nil, domain.NewInternalError("failed to check template name", err)
+			return nil, err
 		}
-		if exists {
-			return nil, domain.ErrTemplateDuplicateName
+		if name != template.Name {
+			exists, err := s.templateRepo.ExistsByNameExcludingID(ctx, userID, name, templateID)
+			if err != nil {
+				return nil, domain.NewInternalError("failed to check template name", err)
+			}
+			if exists {
+				return nil, domain.ErrTemplateDuplicateName
+			}
+			template.Name = name
 		}
-		template.Name = *dto.Name
 	}
 
-	// Apply updates (only update fields that are provided)
-	if dto.Title != nil {
-		template.Title = *dto.Title
-	}
-	if dto.Description != nil {
-		template.Description = dto.Description
-	}
-	if dto.Category != nil {
-		template.Category = dto.Category
-	}
-	if dto.EstimatedEffort != nil {
-		template.EstimatedEffort = dto.EstimatedEffort
-	}
-	if dto.UserPriority != nil {
-		template.UserPriority = *dto.UserPriority
-	}
-	if dto.Context != nil {
-		template.Context = dto.Context
-	}
-	if dto.RelatedPeople != nil {
-		template.RelatedPeople = dto.RelatedPeople
-	}
-	if dto.DueDateOffset != nil {
-		template.DueDateOffset = dto.DueDateOffset
+	// Apply the remaining members with validation
+	if err := applyTemplatePatch(template, patch); err != nil {
+		return nil, err
 	}
 
-	// Ensure RelatedPeople is not nil
+	// Ensure RelatedPeople and Tags are not nil
 	if template.RelatedPeople == nil {
 		template.RelatedPeople = []string{}
 	}
+	if template.Tags == nil {
+		template.Tags = []string{}
+	}
 
 	// Validate updated template
 	if err := template.Validate(); err != nil {
@@ -175,6 +182,82 @@ func (s *TaskTemplateService) Update(ctx context.Context, userID, templateID str
 	return template, nil
 }
 
+// applyTemplatePatch validates each present member of the patch (other than name)
+// and applies it to the template
+func applyTemplatePatch(template *domain.TaskTemplate, patch *domain.TaskTemplateMergePatch) error {
+	if patch.Title.Set {
+		if patch.Title.IsNull() {
+			return domain.NewValidationError("title", "cannot be null")
+		}
+		validated, err := validation.ValidateRequiredText(*patch.Title.Value, 200, "title")
+		if err != nil {
+			return err
+		}
+		template.Title = validated
+	}
+	if patch.Description.Set {
+		validated, err := validation.ValidateOptionalText(patch.Description.Value, 2000, "description")
+		if err != nil {
+			return err
+		}
+		template.Description = validated
+	}
+	if patch.Category.Set {
+		validated, err := validation.ValidateCategory(patch.Category.Value)
+		if err != nil {
+			return err
+		}
+		template.Category = validated
+	}
+	if patch.EstimatedEffort.Set {
+		template.EstimatedEffort = patch.EstimatedEffort.Value
+	}
+	if patch.UserPriority.Set {
+		if patch.UserPriority.IsNull() {
+			return domain.NewValidationError("user_priority", "cannot be null")
+		}
+		if err := validation.ValidatePriority(*patch.UserPriority.Value); err != nil {
+			return err
+		}
+		template.UserPriority = *patch.UserPriority.Value
+	}
+	if patch.Context.Set {
+		validated, err := validation.ValidateOptionalText(patch.Context.Value, 500, "context")
+		if err != nil {
+			return err
+		}
+		template.Context = validated
+	}
+	if patch.RelatedPeople.Set {
+		var people []string
+		if patch.RelatedPeople.Value != nil {
+			people = *patch.RelatedPeople.Value
+		}
+		validated, err := validation.ValidateStringSlice(people, 100, 20, "related_people")
+		if err != nil {
+			return err
+		}
+		template.RelatedPeople = validated
+	}
+	if patch.Tags.Set {
+		var tags []string
+		if patch.Tags.Value != nil {
+			tags = *patch.Tags.Value
+		}
+		validated, err := validation.ValidateTags(tags, "tags")
+		if err != nil {
+			return err
+		}
+		template.Tags = validated
+	}
+	if patch.DueDateOffset.Set {
+		// Range is checked by TaskTemplate.Validate
+		template.DueDateOffset = patch.DueDateOffset.Value
+	}
+
+	return nil
+}
+
 // Delete removes a template
 func (s *TaskTemplateService) Delete(ctx context.Context, userID, templateID string) error {
 	// Verify ownership by attempting to get the template
@@ -228,6 +311,9 @@ func (s *TaskTemplateService) CreateTaskFromTemplate(ctx context.Context, userID
 		if overrides.RelatedPeople != nil {
 			dto.RelatedPeople = overrides.RelatedPeople
 		}
+		if overrides.Tags != nil {
+			dto.Tags = overrides.Tags
+		}
 		if overrides.DueDate != nil {
 			dto.DueDate = overrides.DueDate
 		}
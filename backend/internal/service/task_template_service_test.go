@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+func createTestTemplate(userID, templateID string) *domain.TaskTemplate {
+	effort := domain.TaskEffortSmall
+	offset := 3
+	return &domain.TaskTemplate{
+		ID:              templateID,
+		UserID:          userID,
+		Name:            "Weekly report",
+		Title:           "Write weekly report",
+		Category:        stringPtr("work"),
+		EstimatedEffort: &effort,
+		UserPriority:    5,
+		RelatedPeople:   []string{"Sam"},
+		DueDateOffset:   &offset,
+	}
+}
+
+func decodeTemplatePatch(t *testing.T, body string) *domain.TaskTemplateMergePatch {
+	var patch domain.TaskTemplateMergePatch
+	require.NoError(t, json.Unmarshal([]byte(body), &patch))
+	return &patch
+}
+
+// =============================================================================
+// TaskTemplateService.Patch Tests
+// =============================================================================
+
+func TestTaskTemplateService_Patch_NullClearsFields(t *testing.T) {
+	mockRepo := new(MockTaskTemplateRepository)
+	service := NewTaskTemplateService(mockRepo)
+
+	mockRepo.On("FindByID", mock.Anything, "tmpl-1").Return(createTestTemplate("user-123", "tmpl-1"), nil)
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.TaskTemplate")).Return(nil)
+
+	patch := decodeTemplatePatch(t, `{"due_date_offset": null, "estimated_effort": null, "category": null, "title": "Write the weekly report"}`)
+	template, err := service.Patch(context.Background(), "user-123", "tmpl-1", patch)
+
+	require.NoError(t, err)
+	assert.Nil(t, template.DueDateOffset)
+	assert.Nil(t, template.EstimatedEffort)
+	assert.Nil(t, template.Category)
+	assert.Equal(t, "Write the weekly report", template.Title)
+	// Omitted members are untouched
+	assert.Equal(t, []string{"Sam"}, template.RelatedPeople)
+	assert.Equal(t, "Weekly report", template.Name)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTaskTemplateService_Patch_NullRequiredField(t *testing.T) {
+	mockRepo := new(MockTaskTemplateRepository)
+	service := NewTaskTemplateService(mockRepo)
+
+	mockRepo.On("FindByID", mock.Anything, "tmpl-1").Return(createTestTemplate("user-123", "tmpl-1"), nil)
+
+	template, err := service.Patch(context.Background(), "user-123", "tmpl-1", decodeTemplatePatch(t, `{"name": null}`))
+
+	assert.Nil(t, template)
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "name", validationErr.Field)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestTaskTemplateService_Patch_DuplicateName(t *testing.T) {
+	mockRepo := new(MockTaskTemplateRepository)
+	service := NewTaskTemplateService(mockRepo)
+
+	mockRepo.On("FindByID", mock.Anything, "tmpl-1").Return(createTestTemplate("user-123", "tmpl-1"), nil)
+	mockRepo.On("ExistsByNameExcludingID", mock.Anything, "user-123", "Standup", "tmpl-1").Return(true, nil)
+
+	template, err := service.Patch(context.Background(), "user-123", "tmpl-1", decodeTemplatePatch(t, `{"name": " Standup "}`))
+
+	assert.Nil(t, template)
+	assert.ErrorIs(t, err, domain.ErrTemplateDuplicateName)
+}
+
+func TestTaskTemplateService_Update_KeepsPutSemantics(t *testing.T) {
+	mockRepo := new(MockTaskTemplateRepository)
+	service := NewTaskTemplateService(mockRepo)
+
+	mockRepo.On("FindByID", mock.Anything, "tmpl-1").Return(createTestTemplate("user-123", "tmpl-1"), nil)
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.TaskTemplate")).Return(nil)
+
+	priority := 8
+	template, err := service.Update(context.Background(), "user-123", "tmpl-1", &domain.UpdateTaskTemplateDTO{
+		UserPriority: &priority,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 8, template.UserPriority)
+	// Fields absent from a PUT body are never cleared
+	assert.NotNil(t, template.DueDateOffset)
+	assert.NotNil(t, template.Category)
+}
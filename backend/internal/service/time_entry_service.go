@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+	"github.com/notkevinvu/taskflow/backend/internal/validation"
+)
+
+// TimeEntryService handles time tracking business logic
+type TimeEntryService struct {
+	timeEntryRepo ports.TimeEntryRepository
+	taskService   ports.TaskService
+	now           func() time.Time
+}
+
+// NewTimeEntryService creates a new time entry service
+func NewTimeEntryService(timeEntryRepo ports.TimeEntryRepository, taskService ports.TaskService) *TimeEntryService {
+	return &TimeEntryService{
+		timeEntryRepo: timeEntryRepo,
+		taskService:   taskService,
+		now:           time.Now,
+	}
+}
+
+// StartTimer starts the user's timer on a task they own.
+// Fails with ErrTimerAlreadyRunning if any of the user's timers is running.
+func (s *TimeEntryService) StartTimer(ctx context.Context, userID, taskID string, dto *domain.StartTimerDTO) (*domain.TimeEntry, error) {
+	if _, err := s.taskService.Get(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	note, err := validation.ValidateOptionalText(dto.Note, domain.MaxTimeEntryNoteLength, "note")
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	entry := &domain.TimeEntry{
+		ID:        uuid.New().String(),
+		TaskID:    taskID,
+		UserID:    userID,
+		StartedAt: now,
+		Source:    domain.TimeEntrySourceTimer,
+		Note:      note,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	// The database allows one running entry per user, so concurrent starts cannot both win
+	if err := s.timeEntryRepo.Create(ctx, entry); err != nil {
+		if err == domain.ErrTimerAlreadyRunning {
+			return nil, err
+		}
+		return nil, domain.NewInternalError("failed to start timer", err)
+	}
+
+	return entry, nil
+}
+
+// StopTimer stops the user's timer if it is running on the task.
+// The task is not looked up, so a timer left running on a task in the trash can be stopped.
+func (s *TimeEntryService) StopTimer(ctx context.Context, userID, taskID string) (*domain.TimeEntry, error) {
+	running, err := s.timeEntryRepo.FindRunningByUserID(ctx, userID)
+	if err != nil {
+		if err == domain.ErrTimeEntryNotFound {
+			return nil, domain.ErrTimerNotRunning
+		}
+		return nil, domain.NewInternalError("failed to find running timer", err)
+	}
+	if running.TaskID != taskID {
+		return nil, domain.ErrTimerNotRunning
+	}
+
+	entry, err := s.timeEntryRepo.Stop(ctx, running.ID, userID, s.now())
+	if err != nil {
+		if err == domain.ErrTimerNotRunning {
+			return nil, err
+		}
+		return nil, domain.NewInternalError("failed to stop timer", err)
+	}
+
+	return entry, nil
+}
+
+// GetRunningTimer returns the user's running timer, or nil if none is running
+func (s *TimeEntryService) GetRunningTimer(ctx context.Context, userID string) (*domain.TimeEntry, error) {
+	entry, err := s.timeEntryRepo.FindRunningByUserID(ctx, userID)
+	if err != nil {
+		if err == domain.ErrTimeEntryNotFound {
+			return nil, nil
+		}
+		return nil, domain.NewInternalError("failed to find running timer", err)
+	}
+	return entry, nil
+}
+
+// CreateEntry logs time spent on a task after the fact
+func (s *TimeEntryService) CreateEntry(ctx context.Context, userID, taskID string, dto *domain.CreateTimeEntryDTO) (*domain.TimeEntry, error) {
+	if _, err := s.taskService.Get(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	endedAt, err := manualEntryEnd(dto, now)
+	if err != nil {
+		return nil, err
+	}
+
+	note, err := validation.ValidateOptionalText(dto.Note, domain.MaxTimeEntryNoteLength, "note")
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &domain.TimeEntry{
+		ID:              uuid.New().String(),
+		TaskID:          taskID,
+		UserID:          userID,
+		StartedAt:       dto.StartedAt,
+		EndedAt:         &endedAt,
+		DurationSeconds: int64(endedAt.Sub(dto.StartedAt).Seconds()),
+		Source:          domain.TimeEntrySourceManual,
+		Note:            note,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	if err := s.timeEntryRepo.Create(ctx, entry); err != nil {
+		return nil, domain.NewInternalError("failed to create time entry", err)
+	}
+
+	return entry, nil
+}
+
+// manualEntryEnd validates a manual entry's interval and returns its end time
+func manualEntryEnd(dto *domain.CreateTimeEntryDTO, now time.Time) (time.Time, error) {
+	if dto.StartedAt.IsZero() {
+		return time.Time{}, domain.NewValidationError("started_at", "is required")
+	}
+
+	var endedAt time.Time
+	switch {
+	case dto.EndedAt != nil && dto.DurationMinutes != nil:
+		return time.Time{}, domain.NewValidationError("ended_at", "cannot be combined with duration_minutes")
+	case dto.EndedAt != nil:
+		endedAt = *dto.EndedAt
+	case dto.DurationMinutes != nil:
+		if *dto.DurationMinutes <= 0 {
+			return time.Time{}, domain.NewValidationError("duration_minutes", "must be positive")
+		}
+		endedAt = dto.StartedAt.Add(time.Duration(*dto.DurationMinutes) * time.Minute)
+	default:
+		return time.Time{}, domain.NewValidationError("ended_at", "either ended_at or duration_minutes is required")
+	}
+
+	if !endedAt.After(dto.StartedAt) {
+		return time.Time{}, domain.NewValidationError("ended_at", "must be after started_at")
+	}
+	if endedAt.Sub(dto.StartedAt) > domain.MaxManualTimeEntryDuration {
+		return time.Time{}, domain.NewValidationError("ended_at", "a single entry cannot exceed 24 hours")
+	}
+	if endedAt.After(now) {
+		return time.Time{}, domain.NewValidationError("ended_at", "cannot be in the future")
+	}
+
+	return endedAt, nil
+}
+
+// List retrieves a task's time entries, most recent first, with the total tracked time
+func (s *TimeEntryService) List(ctx context.Context, userID, taskID string) (*domain.TimeEntryListResponse, error) {
+	if _, err := s.taskService.Get(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.timeEntryRepo.FindByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to list time entries", err)
+	}
+
+	// Matches Task.TrackedSeconds: a running timer is not counted until it stops
+	var total int64
+	for _, entry := range entries {
+		if entry.EndedAt != nil {
+			total += entry.DurationSeconds
+		}
+	}
+
+	return &domain.TimeEntryListResponse{
+		Entries:      entries,
+		TotalCount:   len(entries),
+		TotalSeconds: total,
+	}, nil
+}
+
+// DeleteEntry removes a time entry. Deleting a running entry discards the timer.
+// Ownership is checked on the entry, so entries of a task in the trash can be deleted too.
+func (s *TimeEntryService) DeleteEntry(ctx context.Context, userID, taskID, entryID string) error {
+	entry, err := s.timeEntryRepo.FindByID(ctx, entryID)
+	if err != nil {
+		if err == domain.ErrTimeEntryNotFound {
+			return err
+		}
+		return domain.NewInternalError("failed to find time entry", err)
+	}
+
+	// An entry addressed through another task's URL does not exist there
+	if entry.TaskID != taskID {
+		return domain.ErrTimeEntryNotFound
+	}
+	if entry.UserID != userID {
+		return domain.NewForbiddenError("time entry", "access")
+	}
+
+	if err := s.timeEntryRepo.Delete(ctx, entryID, userID); err != nil {
+		if err == domain.ErrTimeEntryNotFound {
+			return err
+		}
+		return domain.NewInternalError("failed to delete time entry", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+var testTimeEntryNow = time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC)
+
+func newTimeEntryService() (*TimeEntryService, *MockTimeEntryRepository, *MockTaskRepository) {
+	mockTimeEntryRepo := new(MockTimeEntryRepository)
+	mockTaskRepo := new(MockTaskRepository)
+	taskService := NewTaskService(mockTaskRepo, new(MockTaskHistoryRepository))
+	service := NewTimeEntryService(mockTimeEntryRepo, taskService)
+	service.now = func() time.Time { return testTimeEntryNow }
+	return service, mockTimeEntryRepo, mockTaskRepo
+}
+
+func createRunningTimeEntry(userID, taskID, entryID string) *domain.TimeEntry {
+	return &domain.TimeEntry{
+		ID:        entryID,
+		TaskID:    taskID,
+		UserID:    userID,
+		StartedAt: testTimeEntryNow.Add(-25 * time.Minute),
+		Source:    domain.TimeEntrySourceTimer,
+	}
+}
+
+// =============================================================================
+// TimeEntryService.StartTimer / StopTimer Tests
+// =============================================================================
+
+func TestTimeEntryService_StartTimer_Success(t *testing.T) {
+	service, mockTimeEntryRepo, mockTaskRepo := newTimeEntryService()
+
+	note := "  drafting  "
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	mockTimeEntryRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.TimeEntry) bool {
+		return e.TaskID == "task-456" && e.UserID == "user-123" && e.EndedAt == nil &&
+			e.Source == domain.TimeEntrySourceTimer && e.StartedAt.Equal(testTimeEntryNow)
+	})).Return(nil)
+
+	entry, err := service.StartTimer(context.Background(), "user-123", "task-456", &domain.StartTimerDTO{Note: &note})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, entry.ID)
+	require.NotNil(t, entry.Note)
+	assert.Equal(t, "drafting", *entry.Note)
+	mockTimeEntryRepo.AssertExpectations(t)
+}
+
+func TestTimeEntryService_StartTimer_AlreadyRunning(t *testing.T) {
+	service, mockTimeEntryRepo, mockTaskRepo := newTimeEntryService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	mockTimeEntryRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TimeEntry")).Return(domain.ErrTimerAlreadyRunning)
+
+	entry, err := service.StartTimer(context.Background(), "user-123", "task-456", &domain.StartTimerDTO{})
+
+	assert.Nil(t, entry)
+	assert.ErrorIs(t, err, domain.ErrTimerAlreadyRunning)
+}
+
+func TestTimeEntryService_StartTimer_OtherUsersTask(t *testing.T) {
+	service, mockTimeEntryRepo, mockTaskRepo := newTimeEntryService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-456", "task-456"), nil)
+
+	entry, err := service.StartTimer(context.Background(), "user-123", "task-456", &domain.StartTimerDTO{})
+
+	assert.Nil(t, entry)
+	var forbiddenErr *domain.ForbiddenError
+	assert.ErrorAs(t, err, &forbiddenErr)
+	mockTimeEntryRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestTimeEntryService_StopTimer_Success(t *testing.T) {
+	service, mockTimeEntryRepo, _ := newTimeEntryService()
+
+	running := createRunningTimeEntry("user-123", "task-456", "entry-1")
+	stopped := *running
+	stopped.EndedAt = &testTimeEntryNow
+	stopped.DurationSeconds = 25 * 60
+
+	mockTimeEntryRepo.On("FindRunningByUserID", mock.Anything, "user-123").Return(running, nil)
+	mockTimeEntryRepo.On("Stop", mock.Anything, "entry-1", "user-123", testTimeEntryNow).Return(&stopped, nil)
+
+	entry, err := service.StopTimer(context.Background(), "user-123", "task-456")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(25*60), entry.DurationSeconds)
+	mockTimeEntryRepo.AssertExpectations(t)
+}
+
+func TestTimeEntryService_StopTimer_TaskDeletedWhileRunning(t *testing.T) {
+	service, mockTimeEntryRepo, mockTaskRepo := newTimeEntryService()
+
+	running := createRunningTimeEntry("user-123", "task-456", "entry-1")
+	stopped := *running
+	stopped.EndedAt = &testTimeEntryNow
+
+	// The task is in the trash, but its timer still blocks new ones until stopped
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(nil, domain.ErrTaskNotFound)
+	mockTimeEntryRepo.On("FindRunningByUserID", mock.Anything, "user-123").Return(running, nil)
+	mockTimeEntryRepo.On("Stop", mock.Anything, "entry-1", "user-123", testTimeEntryNow).Return(&stopped, nil)
+
+	entry, err := service.StopTimer(context.Background(), "user-123", "task-456")
+
+	require.NoError(t, err)
+	assert.NotNil(t, entry.EndedAt)
+	mockTimeEntryRepo.AssertExpectations(t)
+}
+
+func TestTimeEntryService_StopTimer_RunningOnAnotherTask(t *testing.T) {
+	service, mockTimeEntryRepo, _ := newTimeEntryService()
+
+	mockTimeEntryRepo.On("FindRunningByUserID", mock.Anything, "user-123").
+		Return(createRunningTimeEntry("user-123", "task-789", "entry-1"), nil)
+
+	entry, err := service.StopTimer(context.Background(), "user-123", "task-456")
+
+	assert.Nil(t, entry)
+	assert.ErrorIs(t, err, domain.ErrTimerNotRunning)
+	mockTimeEntryRepo.AssertNotCalled(t, "Stop", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTimeEntryService_StopTimer_NothingRunning(t *testing.T) {
+	service, mockTimeEntryRepo, _ := newTimeEntryService()
+
+	mockTimeEntryRepo.On("FindRunningByUserID", mock.Anything, "user-123").Return(nil, domain.ErrTimeEntryNotFound)
+
+	_, err := service.StopTimer(context.Background(), "user-123", "task-456")
+
+	assert.ErrorIs(t, err, domain.ErrTimerNotRunning)
+}
+
+func TestTimeEntryService_GetRunningTimer_NoneRunning(t *testing.T) {
+	service, mockTimeEntryRepo, _ := newTimeEntryService()
+
+	mockTimeEntryRepo.On("FindRunningByUserID", mock.Anything, "user-123").Return(nil, domain.ErrTimeEntryNotFound)
+
+	entry, err := service.GetRunningTimer(context.Background(), "user-123")
+
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+}
+
+// =============================================================================
+// TimeEntryService.CreateEntry Tests
+// =============================================================================
+
+func TestTimeEntryService_CreateEntry_WithDuration(t *testing.T) {
+	service, mockTimeEntryRepo, mockTaskRepo := newTimeEntryService()
+
+	startedAt := testTimeEntryNow.Add(-2 * time.Hour)
+	minutes := 45
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	mockTimeEntryRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TimeEntry")).Return(nil)
+
+	entry, err := service.CreateEntry(context.Background(), "user-123", "task-456", &domain.CreateTimeEntryDTO{
+		StartedAt:       startedAt,
+		DurationMinutes: &minutes,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, entry.EndedAt)
+	assert.Equal(t, startedAt.Add(45*time.Minute), *entry.EndedAt)
+	assert.Equal(t, int64(45*60), entry.DurationSeconds)
+	assert.Equal(t, domain.TimeEntrySourceManual, entry.Source)
+}
+
+func TestTimeEntryService_CreateEntry_Validation(t *testing.T) {
+	startedAt := testTimeEntryNow.Add(-3 * time.Hour)
+	before := startedAt.Add(-time.Minute)
+	future := testTimeEntryNow.Add(time.Hour)
+	tooLong := startedAt.Add(25 * time.Hour)
+	ended := startedAt.Add(time.Hour)
+	zero := 0
+	thirty := 30
+
+	tests := []struct {
+		name  string
+		dto   domain.CreateTimeEntryDTO
+		field string
+	}{
+		{name: "no end or duration", dto: domain.CreateTimeEntryDTO{StartedAt: startedAt}, field: "ended_at"},
+		{name: "both end and duration", dto: domain.CreateTimeEntryDTO{StartedAt: startedAt, EndedAt: &ended, DurationMinutes: &thirty}, field: "ended_at"},
+		{name: "end before start", dto: domain.CreateTimeEntryDTO{StartedAt: startedAt, EndedAt: &before}, field: "ended_at"},
+		{name: "ends in the future", dto: domain.CreateTimeEntryDTO{StartedAt: startedAt, EndedAt: &future}, field: "ended_at"},
+		{name: "longer than a day", dto: domain.CreateTimeEntryDTO{StartedAt: tooLong.Add(-50 * time.Hour), EndedAt: &tooLong}, field: "ended_at"},
+		{name: "zero duration", dto: domain.CreateTimeEntryDTO{StartedAt: startedAt, DurationMinutes: &zero}, field: "duration_minutes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockTimeEntryRepo, mockTaskRepo := newTimeEntryService()
+			mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+
+			entry, err := service.CreateEntry(context.Background(), "user-123", "task-456", &tt.dto)
+
+			assert.Nil(t, entry)
+			var validationErr *domain.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+			mockTimeEntryRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// =============================================================================
+// TimeEntryService.List / DeleteEntry Tests
+// =============================================================================
+
+func TestTimeEntryService_List_TotalsFinishedEntries(t *testing.T) {
+	service, mockTimeEntryRepo, mockTaskRepo := newTimeEntryService()
+
+	ended := testTimeEntryNow.Add(-time.Hour)
+	entries := []*domain.TimeEntry{
+		createRunningTimeEntry("user-123", "task-456", "entry-running"),
+		{ID: "entry-1", TaskID: "task-456", UserID: "user-123", EndedAt: &ended, DurationSeconds: 1800},
+		{ID: "entry-2", TaskID: "task-456", UserID: "user-123", EndedAt: &ended, DurationSeconds: 600},
+	}
+	entries[0].DurationSeconds = 1500
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(createTestTask("user-123", "task-456"), nil)
+	mockTimeEntryRepo.On("FindByTaskID", mock.Anything, "task-456").Return(entries, nil)
+
+	response, err := service.List(context.Background(), "user-123", "task-456")
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, response.TotalCount)
+	assert.Equal(t, int64(2400), response.TotalSeconds)
+}
+
+func TestTimeEntryService_DeleteEntry_WrongTask(t *testing.T) {
+	service, mockTimeEntryRepo, _ := newTimeEntryService()
+
+	mockTimeEntryRepo.On("FindByID", mock.Anything, "entry-1").
+		Return(createRunningTimeEntry("user-123", "task-456", "entry-1"), nil)
+
+	err := service.DeleteEntry(context.Background(), "user-123", "task-789", "entry-1")
+
+	assert.ErrorIs(t, err, domain.ErrTimeEntryNotFound)
+	mockTimeEntryRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTimeEntryService_DeleteEntry_RepoError(t *testing.T) {
+	service, mockTimeEntryRepo, _ := newTimeEntryService()
+
+	mockTimeEntryRepo.On("FindByID", mock.Anything, "entry-1").
+		Return(createRunningTimeEntry("user-123", "task-456", "entry-1"), nil)
+	mockTimeEntryRepo.On("Delete", mock.Anything, "entry-1", "user-123").Return(errors.New("db down"))
+
+	err := service.DeleteEntry(context.Background(), "user-123", "task-456", "entry-1")
+
+	var internalErr *domain.InternalError
+	assert.ErrorAs(t, err, &internalErr)
+}
+
+func TestTimeEntryService_DeleteEntry_TaskDeletedWhileRunning(t *testing.T) {
+	service, mockTimeEntryRepo, mockTaskRepo := newTimeEntryService()
+
+	mockTaskRepo.On("FindByID", mock.Anything, "task-456").Return(nil, domain.ErrTaskNotFound)
+	mockTimeEntryRepo.On("FindByID", mock.Anything, "entry-1").
+		Return(createRunningTimeEntry("user-123", "task-456", "entry-1"), nil)
+	mockTimeEntryRepo.On("Delete", mock.Anything, "entry-1", "user-123").Return(nil)
+
+	err := service.DeleteEntry(context.Background(), "user-123", "task-456", "entry-1")
+
+	require.NoError(t, err)
+	mockTimeEntryRepo.AssertExpectations(t)
+}
+
+func TestTimeEntryService_DeleteEntry_OtherUsersEntry(t *testing.T) {
+	service, mockTimeEntryRepo, _ := newTimeEntryService()
+
+	mockTimeEntryRepo.On("FindByID", mock.Anything, "entry-1").
+		Return(createRunningTimeEntry("other-user", "task-456", "entry-1"), nil)
+
+	err := service.DeleteEntry(context.Background(), "user-123", "task-456", "entry-1")
+
+	var forbiddenErr *domain.ForbiddenError
+	assert.ErrorAs(t, err, &forbiddenErr)
+	mockTimeEntryRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+)
+
+// TrashService lists soft-deleted tasks, deletes them permanently and purges
+// tasks that have been in the trash longer than the retention period
+type TrashService struct {
+	taskRepo          ports.TaskRepository
+	retentionDays     int                     // 0 disables the retention purge
+	attachmentService ports.AttachmentService // Optional: for attachment blob cleanup
+}
+
+// NewTrashService creates a new trash service
+func NewTrashService(taskRepo ports.TaskRepository, retentionDays int) *TrashService {
+	return &TrashService{
+		taskRepo:      taskRepo,
+		retentionDays: retentionDays,
+	}
+}
+
+// SetAttachmentService sets the optional attachment service so purged tasks' attachment blobs are removed
+func (s *TrashService) SetAttachmentService(attachmentService ports.AttachmentService) {
+	s.attachmentService = attachmentService
+}
+
+// List returns a page of the user's trash, most recently deleted first
+func (s *TrashService) List(ctx context.Context, userID string, limit, offset int) (*domain.TrashPage, error) {
+	tasks, total, err := s.taskRepo.FindTrash(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to list trash", err)
+	}
+
+	return &domain.TrashPage{
+		Tasks:         tasks,
+		TotalCount:    total,
+		Limit:         limit,
+		Offset:        offset,
+		RetentionDays: s.retentionDays,
+	}, nil
+}
+
+// DeletePermanently removes a task in the trash, and its subtasks, for good
+func (s *TrashService) DeletePermanently(ctx context.Context, userID, taskID string) error {
+	task, err := s.taskRepo.FindByIDIncludingDeleted(ctx, taskID)
+	if errors.Is(err, domain.ErrTaskNotFound) {
+		return domain.NewNotFoundError("task", taskID)
+	}
+	if err != nil {
+		return domain.NewInternalError("failed to find task", err)
+	}
+	if task.UserID != userID {
+		return domain.NewForbiddenError("task", "delete")
+	}
+	// Live tasks go through the trash first so a mistaken delete can be restored
+	if task.DeletedAt == nil {
+		return domain.NewValidationError("status", "task is not in the trash")
+	}
+
+	if _, err := s.taskRepo.PurgeTrash(ctx, userID, &domain.TrashPurge{
+		Reason: domain.TaskPurgeReasonPermanent,
+		TaskID: &taskID,
+	}); err != nil {
+		return domain.NewInternalError("failed to delete task permanently", err)
+	}
+
+	s.purgeAttachmentBlobs(ctx)
+	return nil
+}
+
+// Empty permanently deletes everything in the user's trash
+func (s *TrashService) Empty(ctx context.Context, userID string) (*domain.EmptyTrashResponse, error) {
+	deleted, err := s.taskRepo.PurgeTrash(ctx, userID, &domain.TrashPurge{
+		Reason: domain.TaskPurgeReasonEmptyTrash,
+	})
+	if err != nil {
+		return nil, domain.NewInternalError("failed to empty trash", err)
+	}
+
+	if deleted > 0 {
+		s.purgeAttachmentBlobs(ctx)
+	}
+	return &domain.EmptyTrashResponse{DeletedCount: deleted}, nil
+}
+
+// PurgeExpired permanently deletes tasks that have been in the trash longer than the
+// retention period, one user at a time. Each purge is recorded in task_purges.
+func (s *TrashService) PurgeExpired(ctx context.Context) (*domain.TrashPurgeResult, error) {
+	startTime := time.Now()
+	result := &domain.TrashPurgeResult{}
+
+	if s.retentionDays <= 0 {
+		return result, nil
+	}
+
+	cutoff := startTime.AddDate(0, 0, -s.retentionDays)
+	slog.Info("[Trash] Starting retention purge", "retention_days", s.retentionDays, "cutoff", cutoff)
+
+	userIDs, err := s.taskRepo.FindUsersWithTrashBefore(ctx, cutoff)
+	if err != nil {
+		slog.Error("[Trash] Failed to find users with expired trash", "error", err)
+		return nil, err
+	}
+
+	for _, userID := range userIDs {
+		// Stop between users on shutdown; each user's purge is a single transaction
+		if ctx.Err() != nil {
+			result.Duration = time.Since(startTime)
+			return result, ctx.Err()
+		}
+
+		deleted, err := s.taskRepo.PurgeTrash(ctx, userID, &domain.TrashPurge{
+			Reason:        domain.TaskPurgeReasonRetention,
+			DeletedBefore: &cutoff,
+		})
+		if err != nil {
+			slog.Error("[Trash] Failed to purge expired trash",
+				"user_id", userID,
+				"error", err,
+			)
+			result.FailedCount++
+			continue
+		}
+
+		result.UserCount++
+		result.DeletedCount += deleted
+		slog.Info("[Trash] Purged expired trash",
+			"user_id", userID,
+			"task_count", deleted,
+		)
+	}
+
+	if result.DeletedCount > 0 {
+		s.purgeAttachmentBlobs(ctx)
+	}
+
+	result.Duration = time.Since(startTime)
+	slog.Info("[Trash] Retention purge completed",
+		"users", result.UserCount,
+		"deleted", result.DeletedCount,
+		"failed", result.FailedCount,
+		"duration", result.Duration,
+	)
+
+	return result, nil
+}
+
+// purgeAttachmentBlobs removes the blobs queued when purged tasks' attachments were
+// deleted. Failures are logged and the keys stay queued for the next purge.
+func (s *TrashService) purgeAttachmentBlobs(ctx context.Context) {
+	if s.attachmentService == nil {
+		return
+	}
+
+	if _, err := s.attachmentService.PurgeDeletedBlobs(ctx); err != nil {
+		slog.Warn("[Trash] Failed to purge attachment blobs", "error", err)
+	}
+}
+
+// RunPurgeLoop starts a background loop that purges expired trash at the specified interval.
+// It blocks until the context is cancelled.
+func (s *TrashService) RunPurgeLoop(ctx context.Context, interval time.Duration) {
+	slog.Info("[Trash] Starting purge loop", "interval", interval, "retention_days", s.retentionDays)
+
+	// Run immediately on start
+	if _, err := s.PurgeExpired(ctx); err != nil {
+		slog.Error("[Trash] Initial purge failed", "error", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("[Trash] Purge loop stopped")
+			return
+		case <-ticker.C:
+			if _, err := s.PurgeExpired(ctx); err != nil {
+				slog.Error("[Trash] Scheduled purge failed", "error", err)
+			}
+		}
+	}
+}
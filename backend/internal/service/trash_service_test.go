@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createTrashedTask(userID, taskID string, deletedAt time.Time) *domain.Task {
+	task := createTestTask(userID, taskID)
+	task.DeletedAt = &deletedAt
+	return task
+}
+
+// =============================================================================
+// TrashService.List Tests
+// =============================================================================
+
+func TestTrashService_List_ReturnsPageWithRetention(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewTrashService(mockTaskRepo, 30)
+
+	trashed := []*domain.Task{createTrashedTask("user-123", "task-1", time.Now())}
+	mockTaskRepo.On("FindTrash", mock.Anything, "user-123", 20, 40).Return(trashed, 41, nil)
+
+	page, err := service.List(context.Background(), "user-123", 20, 40)
+
+	require.NoError(t, err)
+	assert.Equal(t, trashed, page.Tasks)
+	assert.Equal(t, 41, page.TotalCount)
+	assert.Equal(t, 20, page.Limit)
+	assert.Equal(t, 40, page.Offset)
+	assert.Equal(t, 30, page.RetentionDays)
+}
+
+// =============================================================================
+// TrashService.DeletePermanently Tests
+// =============================================================================
+
+func TestTrashService_DeletePermanently_PurgesTrashedTask(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockAttachmentService := new(MockAttachmentService)
+	service := NewTrashService(mockTaskRepo, 30)
+	service.SetAttachmentService(mockAttachmentService)
+
+	mockTaskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-1").
+		Return(createTrashedTask("user-123", "task-1", time.Now()), nil)
+	mockTaskRepo.On("PurgeTrash", mock.Anything, "user-123", mock.MatchedBy(func(p *domain.TrashPurge) bool {
+		return p.Reason == domain.TaskPurgeReasonPermanent && p.TaskID != nil && *p.TaskID == "task-1" && p.DeletedBefore == nil
+	})).Return(3, nil)
+	mockAttachmentService.On("PurgeDeletedBlobs", mock.Anything).Return(0, nil)
+
+	err := service.DeletePermanently(context.Background(), "user-123", "task-1")
+
+	require.NoError(t, err)
+	mockTaskRepo.AssertExpectations(t)
+	mockAttachmentService.AssertExpectations(t)
+}
+
+func TestTrashService_DeletePermanently_RejectsLiveTask(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewTrashService(mockTaskRepo, 30)
+
+	mockTaskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-1").
+		Return(createTestTask("user-123", "task-1"), nil)
+
+	err := service.DeletePermanently(context.Background(), "user-123", "task-1")
+
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	mockTaskRepo.AssertNotCalled(t, "PurgeTrash", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTrashService_DeletePermanently_OtherUsersTask(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewTrashService(mockTaskRepo, 30)
+
+	mockTaskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-1").
+		Return(createTrashedTask("user-999", "task-1", time.Now()), nil)
+
+	err := service.DeletePermanently(context.Background(), "user-123", "task-1")
+
+	var forbiddenErr *domain.ForbiddenError
+	require.ErrorAs(t, err, &forbiddenErr)
+	mockTaskRepo.AssertNotCalled(t, "PurgeTrash", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTrashService_DeletePermanently_NotFound(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewTrashService(mockTaskRepo, 30)
+
+	mockTaskRepo.On("FindByIDIncludingDeleted", mock.Anything, "missing").
+		Return(nil, domain.ErrTaskNotFound)
+
+	err := service.DeletePermanently(context.Background(), "user-123", "missing")
+
+	var notFoundErr *domain.NotFoundError
+	require.ErrorAs(t, err, &notFoundErr)
+}
+
+// =============================================================================
+// TrashService.Empty Tests
+// =============================================================================
+
+func TestTrashService_Empty_PurgesAllTrash(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewTrashService(mockTaskRepo, 30)
+
+	mockTaskRepo.On("PurgeTrash", mock.Anything, "user-123", &domain.TrashPurge{
+		Reason: domain.TaskPurgeReasonEmptyTrash,
+	}).Return(5, nil)
+
+	result, err := service.Empty(context.Background(), "user-123")
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.DeletedCount)
+	mockTaskRepo.AssertExpectations(t)
+}
+
+// =============================================================================
+// TrashService.PurgeExpired Tests
+// =============================================================================
+
+func TestTrashService_PurgeExpired_PurgesEachUserBeforeCutoff(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockAttachmentService := new(MockAttachmentService)
+	service := NewTrashService(mockTaskRepo, 30)
+	service.SetAttachmentService(mockAttachmentService)
+
+	expectedCutoff := time.Now().AddDate(0, 0, -30)
+	nearCutoff := func(cutoff time.Time) bool {
+		return cutoff.Sub(expectedCutoff).Abs() < time.Minute
+	}
+	retention := func(p *domain.TrashPurge) bool {
+		return p.Reason == domain.TaskPurgeReasonRetention && p.TaskID == nil &&
+			p.DeletedBefore != nil && nearCutoff(*p.DeletedBefore)
+	}
+
+	mockTaskRepo.On("FindUsersWithTrashBefore", mock.Anything, mock.MatchedBy(nearCutoff)).
+		Return([]string{"user-1", "user-2", "user-3"}, nil)
+	mockTaskRepo.On("PurgeTrash", mock.Anything, "user-1", mock.MatchedBy(retention)).Return(2, nil)
+	mockTaskRepo.On("PurgeTrash", mock.Anything, "user-2", mock.MatchedBy(retention)).Return(0, errors.New("db error"))
+	mockTaskRepo.On("PurgeTrash", mock.Anything, "user-3", mock.MatchedBy(retention)).Return(4, nil)
+	mockAttachmentService.On("PurgeDeletedBlobs", mock.Anything).Return(1, nil)
+
+	result, err := service.PurgeExpired(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.UserCount)
+	assert.Equal(t, 6, result.DeletedCount)
+	assert.Equal(t, 1, result.FailedCount)
+	mockTaskRepo.AssertExpectations(t)
+	mockAttachmentService.AssertExpectations(t)
+}
+
+func TestTrashService_PurgeExpired_DisabledWithoutRetention(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewTrashService(mockTaskRepo, 0)
+
+	result, err := service.PurgeExpired(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.DeletedCount)
+	mockTaskRepo.AssertNotCalled(t, "FindUsersWithTrashBefore", mock.Anything, mock.Anything)
+}
+
+func TestTrashService_PurgeExpired_FindUsersError(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := NewTrashService(mockTaskRepo, 30)
+
+	mockTaskRepo.On("FindUsersWithTrashBefore", mock.Anything, mock.Anything).
+		Return(nil, errors.New("db error"))
+
+	result, err := service.PurgeExpired(context.Background())
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/domain/priority"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+)
+
+// UndoService reverts a user's recent task operations. Each request that changes tasks
+// records its history entries under one operation ID; undo puts every task the operation
+// touched back to the snapshot taken before it, provided nothing has changed them since.
+type UndoService struct {
+	taskRepo            ports.TaskRepository
+	taskHistoryRepo     ports.TaskHistoryRepository
+	priorityCalc        *priority.Calculator
+	gamificationService ports.GamificationService // Optional: for reversing completion rewards
+	reminderService     ports.ReminderService     // Optional: for moving reminders with due dates
+	now                 func() time.Time
+}
+
+// NewUndoService creates a new undo service
+func NewUndoService(taskRepo ports.TaskRepository, taskHistoryRepo ports.TaskHistoryRepository) *UndoService {
+	return &UndoService{
+		taskRepo:        taskRepo,
+		taskHistoryRepo: taskHistoryRepo,
+		priorityCalc:    priority.NewCalculator(),
+		now:             time.Now,
+	}
+}
+
+// SetGamificationService sets the optional gamification service so undoing a completion
+// takes back its rewards, as Uncomplete does
+func (s *UndoService) SetGamificationService(gamificationService ports.GamificationService) {
+	s.gamificationService = gamificationService
+}
+
+// SetReminderService sets the optional reminder service so reminders follow restored due dates
+func (s *UndoService) SetReminderService(reminderService ports.ReminderService) {
+	s.reminderService = reminderService
+}
+
+// taskReversal is how one task is put back: the state before the operation (nil if the
+// operation created it), the state it left behind, and the task as stored now
+type taskReversal struct {
+	taskID      string
+	before      *domain.Task
+	after       *domain.Task
+	current     *domain.Task
+	completed   bool // The operation completed the task
+	uncompleted bool // The operation uncompleted the task
+}
+
+// ListOperations returns the user's most recent operations that undo can target, newest first
+func (s *UndoService) ListOperations(ctx context.Context, userID string) ([]*domain.TaskOperation, error) {
+	operations, err := s.taskHistoryRepo.ListOperations(ctx, userID, domain.MaxUndoableOperations)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to list recent operations", err)
+	}
+	return operations, nil
+}
+
+// Undo reverts one of the user's recent operations: the one named in the request, or the
+// latest that is neither undone nor itself an undo. The undo is recorded as an operation of
+// its own, so undoing it redoes the original.
+func (s *UndoService) Undo(ctx context.Context, userID string, req *domain.UndoRequest) (*domain.UndoResult, error) {
+	if _, ok := domain.OperationID(ctx); !ok {
+		ctx = domain.WithOperationID(ctx, uuid.New().String())
+	}
+
+	operations, err := s.taskHistoryRepo.ListOperations(ctx, userID, domain.MaxUndoableOperations)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to list recent operations", err)
+	}
+	target, err := selectUndoTarget(operations, req.OperationID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.taskHistoryRepo.FindByOperationID(ctx, userID, target.ID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to find operation history", err)
+	}
+	reversals, err := s.planUndo(ctx, userID, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	// Claim the operation so a concurrent undo of it fails instead of reverting twice
+	claimed, err := s.taskHistoryRepo.MarkOperationUndone(ctx, userID, target.ID, s.now())
+	if err != nil {
+		return nil, domain.NewInternalError("failed to mark operation undone", err)
+	}
+	if !claimed {
+		return nil, domain.ErrOperationAlreadyUndone
+	}
+
+	undoOperationID, _ := domain.OperationID(ctx)
+	tasks := make([]*domain.Task, 0, len(reversals))
+	for _, reversal := range reversals {
+		task, err := s.revert(ctx, userID, target.ID, reversal)
+		if err != nil {
+			// Release the claim so undoing again finishes the job: tasks already
+			// back in their earlier state are skipped when the undo is planned
+			if clearErr := s.taskHistoryRepo.ClearOperationUndone(ctx, userID, target.ID); clearErr != nil {
+				slog.Warn("Failed to release undo claim",
+					"user_id", userID, "operation_id", target.ID, "error", clearErr)
+			}
+			if len(tasks) == 0 {
+				return nil, err
+			}
+			reverted := make([]string, len(tasks))
+			for i, task := range tasks {
+				reverted[i] = task.ID
+			}
+			return nil, &domain.PartialUndoError{
+				OperationID:     target.ID,
+				UndoOperationID: undoOperationID,
+				RevertedTaskIDs: reverted,
+				Err:             err,
+			}
+		}
+		tasks = append(tasks, task)
+	}
+
+	// Undoing an undo brings the original operation back, so it can be undone again
+	if target.RevertsOperationID != nil {
+		if err := s.taskHistoryRepo.ClearOperationUndone(ctx, userID, *target.RevertsOperationID); err != nil {
+			slog.Warn("Failed to mark redone operation as not undone",
+				"user_id", userID, "operation_id", *target.RevertsOperationID, "error", err)
+		}
+	}
+
+	return &domain.UndoResult{
+		OperationID:     target.ID,
+		UndoOperationID: undoOperationID,
+		Tasks:           tasks,
+	}, nil
+}
+
+// selectUndoTarget picks the operation to undo from the user's recent operations
+func selectUndoTarget(operations []*domain.TaskOperation, operationID *string) (*domain.TaskOperation, error) {
+	for _, op := range operations {
+		if operationID != nil {
+			if op.ID != *operationID {
+				continue
+			}
+			if op.UndoneAt != nil {
+				return nil, domain.ErrOperationAlreadyUndone
+			}
+			return op, nil
+		}
+		if op.UndoneAt == nil && op.RevertsOperationID == nil {
+			return op, nil
+		}
+	}
+	return nil, domain.ErrOperationNotFound
+}
+
+// planUndo works out, per task, the state to go back to and checks that every task is
+// still exactly as the operation left it. Tasks are returned newest change first.
+func (s *UndoService) planUndo(ctx context.Context, userID string, entries []*domain.TaskHistory) ([]*taskReversal, error) {
+	byTask := make(map[string]*taskReversal)
+	var order []string
+	for _, entry := range entries {
+		if !entry.EventType.IsUndoable() {
+			continue
+		}
+
+		after := domain.DecodeTaskSnapshot(entry.NewValue)
+		if after == nil {
+			return nil, fmt.Errorf("%w: task %s has no recorded state for its %s event", domain.ErrOperationNotUndoable, entry.TaskID, entry.EventType)
+		}
+
+		reversal, ok := byTask[entry.TaskID]
+		if !ok {
+			reversal = &taskReversal{taskID: entry.TaskID}
+			// The first entry holds the state before the operation; created tasks had none
+			if entry.EventType != domain.EventTaskCreated {
+				reversal.before = domain.DecodeTaskSnapshot(entry.OldValue)
+				if reversal.before == nil {
+					return nil, fmt.Errorf("%w: task %s has no recorded state before its %s event", domain.ErrOperationNotUndoable, entry.TaskID, entry.EventType)
+				}
+			}
+			byTask[entry.TaskID] = reversal
+			order = append(order, entry.TaskID)
+		}
+		reversal.after = after
+		reversal.completed = reversal.completed || entry.EventType == domain.EventTaskCompleted
+		reversal.uncompleted = reversal.uncompleted || entry.EventType == domain.EventTaskUncompleted
+	}
+	if len(order) == 0 {
+		return nil, domain.ErrOperationNotUndoable
+	}
+
+	reversals := make([]*taskReversal, 0, len(order))
+	for i := len(order) - 1; i >= 0; i-- {
+		reversal := byTask[order[i]]
+
+		current, err := s.taskRepo.FindByIDIncludingDeleted(ctx, reversal.taskID)
+		if err != nil && !errors.Is(err, domain.ErrTaskNotFound) {
+			return nil, domain.NewInternalError("failed to find task", err)
+		}
+		if current == nil {
+			return nil, fmt.Errorf("%w: task %s no longer exists", domain.ErrUndoConflict, reversal.taskID)
+		}
+		if current.UserID != userID {
+			return nil, domain.NewForbiddenError("task", "undo")
+		}
+		// Already back where the operation found it, e.g. by an undo that stopped partway
+		if alreadyReverted(current, reversal.before) {
+			continue
+		}
+		if !domain.SameTaskState(current, reversal.after) {
+			return nil, fmt.Errorf("%w: task %s was modified", domain.ErrUndoConflict, reversal.taskID)
+		}
+
+		reversal.current = current
+		reversals = append(reversals, reversal)
+	}
+
+	return reversals, nil
+}
+
+// alreadyReverted reports whether a task is already in its state from before the operation;
+// a task the operation created is reverted once it is in the trash
+func alreadyReverted(current, before *domain.Task) bool {
+	if before == nil {
+		return current.DeletedAt != nil
+	}
+	return domain.SameTaskState(current, before)
+}
+
+// revert puts one task back to its state before the operation and records the change
+func (s *UndoService) revert(ctx context.Context, userID, operationID string, reversal *taskReversal) (*domain.Task, error) {
+	current := reversal.current
+	before := reversal.before
+	// The version the task is at after each write, so the final delete can guard against other writers
+	version := current.Version
+
+	// Restore first: a task coming back from the trash gets its old fields below
+	if before != nil && before.DeletedAt == nil && current.DeletedAt != nil {
+		if err := s.taskRepo.Restore(ctx, current.ID, userID); err != nil {
+			return nil, domain.NewInternalError("failed to restore task", err)
+		}
+	}
+
+	if before != nil {
+		task, err := s.taskRepo.FindByIDIncludingDeleted(ctx, current.ID)
+		if err != nil {
+			return nil, domain.NewInternalError("failed to find task", err)
+		}
+
+		version = task.Version
+
+		compared := *task
+		compared.DeletedAt = before.DeletedAt
+		if !domain.SameTaskState(&compared, before) {
+			updated := *task
+			domain.RestoreTaskState(&updated, before)
+			updated.UpdatedAt = s.now()
+			updated.PriorityScore = s.priorityCalc.Calculate(&updated)
+			if err := s.taskRepo.Update(ctx, &updated); err != nil {
+				if errors.Is(err, domain.ErrTaskVersionConflict) {
+					return nil, fmt.Errorf("%w: task %s was modified", domain.ErrUndoConflict, current.ID)
+				}
+				return nil, domain.NewInternalError("failed to update task", err)
+			}
+			version = updated.Version
+		}
+	}
+
+	// Tasks the operation created, or took out of the trash, go back to the trash
+	if (before == nil || before.DeletedAt != nil) && current.DeletedAt == nil {
+		if err := s.taskRepo.Delete(ctx, current.ID, userID, version); err != nil {
+			if errors.Is(err, domain.ErrTaskVersionConflict) {
+				return nil, fmt.Errorf("%w: task %s was modified", domain.ErrUndoConflict, current.ID)
+			}
+			return nil, domain.NewInternalError("failed to delete task", err)
+		}
+	}
+
+	reverted, err := s.taskRepo.FindByIDIncludingDeleted(ctx, current.ID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to find task", err)
+	}
+
+	eventType := undoEventType(reversal, reverted)
+	switch {
+	case eventType == domain.EventTaskUncompleted && s.gamificationService != nil:
+		s.gamificationService.ProcessTaskUncompletionAsync(userID, current)
+	case eventType == domain.EventTaskCompleted && s.gamificationService != nil:
+		s.gamificationService.ProcessTaskCompletionAsync(userID, reverted)
+	}
+
+	if s.reminderService != nil && !sameDueDate(current.DueDate, reverted.DueDate) {
+		if err := s.reminderService.RescheduleForTask(ctx, reverted); err != nil {
+			slog.Warn("Failed to reschedule task reminders",
+				"user_id", userID, "task_id", reverted.ID, "error", err)
+		}
+	}
+
+	if err := s.logHistory(ctx, userID, operationID, eventType, current, reverted); err != nil {
+		slog.Warn("Failed to log undo history",
+			"user_id", userID, "task_id", reverted.ID, "error", err)
+	}
+
+	return reverted, nil
+}
+
+// undoEventType names what reverting a task did to it. Taking back a completion or
+// uncompletion is itself an uncompletion or completion, so gamification follows it.
+func undoEventType(reversal *taskReversal, reverted *domain.Task) domain.TaskHistoryEventType {
+	current := reversal.current
+	switch {
+	case current.DeletedAt == nil && reverted.DeletedAt != nil:
+		return domain.EventTaskDeleted
+	case current.DeletedAt != nil && reverted.DeletedAt == nil:
+		return domain.EventTaskRestored
+	case reversal.completed && current.Status == domain.TaskStatusDone && reverted.Status != domain.TaskStatusDone:
+		return domain.EventTaskUncompleted
+	case reversal.uncompleted && current.Status != domain.TaskStatusDone && reverted.Status == domain.TaskStatusDone:
+		return domain.EventTaskCompleted
+	default:
+		return updateEventType(current, reverted)
+	}
+}
+
+// sameDueDate reports whether two optional due dates are equal
+func sameDueDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// logHistory records a reverted task, linked to the operation it reverted
+func (s *UndoService) logHistory(ctx context.Context, userID, operationID string, eventType domain.TaskHistoryEventType, oldTask, newTask *domain.Task) error {
+	oldValue, _ := json.Marshal(oldTask)
+	newValue, _ := json.Marshal(newTask)
+	oldStr, newStr := string(oldValue), string(newValue)
+
+	return s.taskHistoryRepo.Create(ctx, &domain.TaskHistory{
+		ID:                 uuid.New().String(),
+		UserID:             userID,
+		TaskID:             newTask.ID,
+		EventType:          eventType,
+		OldValue:           &oldStr,
+		NewValue:           &newStr,
+		CreatedAt:          s.now(),
+		RevertsOperationID: &operationID,
+	})
+}
@@ -0,0 +1,416 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+const (
+	undoUserID      = "user-123"
+	undoOperationID = "11111111-1111-1111-1111-111111111111"
+)
+
+type undoTestDeps struct {
+	taskRepo    *MockTaskRepository
+	historyRepo *MockTaskHistoryRepository
+}
+
+func newUndoService() (*UndoService, *undoTestDeps) {
+	deps := &undoTestDeps{
+		taskRepo:    new(MockTaskRepository),
+		historyRepo: new(MockTaskHistoryRepository),
+	}
+	return NewUndoService(deps.taskRepo, deps.historyRepo), deps
+}
+
+func snapshotOf(task *domain.Task) *string {
+	if task == nil {
+		return nil
+	}
+	data, _ := json.Marshal(task)
+	value := string(data)
+	return &value
+}
+
+func undoEntry(eventType domain.TaskHistoryEventType, before, after *domain.Task) *domain.TaskHistory {
+	operationID := undoOperationID
+	return &domain.TaskHistory{
+		ID:          "history-" + after.ID + "-" + string(eventType),
+		UserID:      undoUserID,
+		TaskID:      after.ID,
+		EventType:   eventType,
+		OldValue:    snapshotOf(before),
+		NewValue:    snapshotOf(after),
+		CreatedAt:   time.Now(),
+		OperationID: &operationID,
+	}
+}
+
+func undoTask(id, title string) *domain.Task {
+	return &domain.Task{
+		ID:           id,
+		UserID:       undoUserID,
+		Title:        title,
+		Status:       domain.TaskStatusTodo,
+		UserPriority: 5,
+		Version:      2,
+	}
+}
+
+// =============================================================================
+// UndoService.Undo Tests
+// =============================================================================
+
+func TestUndoService_Undo_RevertsLatestOperation(t *testing.T) {
+	service, deps := newUndoService()
+	ctx := domain.WithOperationID(context.Background(), "undo-op")
+
+	before := undoTask("task-1", "Old title")
+	after := undoTask("task-1", "New title")
+	after.Tags = []string{"urgent"}
+	current := *after
+	current.Version = 3
+	reverted := *before
+	reverted.Version = 4
+
+	// The newest operation is an undo, which is skipped when no ID is given
+	otherUndo := "22222222-2222-2222-2222-222222222222"
+	deps.historyRepo.On("ListOperations", mock.Anything, undoUserID, domain.MaxUndoableOperations).Return([]*domain.TaskOperation{
+		{ID: "33333333-3333-3333-3333-333333333333", RevertsOperationID: &otherUndo},
+		{ID: undoOperationID},
+	}, nil)
+	deps.historyRepo.On("FindByOperationID", mock.Anything, undoUserID, undoOperationID).Return([]*domain.TaskHistory{
+		undoEntry(domain.EventTaskUpdated, before, after),
+	}, nil)
+	deps.taskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-1").Return(&current, nil).Twice()
+	deps.historyRepo.On("MarkOperationUndone", mock.Anything, undoUserID, undoOperationID, mock.Anything).Return(true, nil)
+	deps.taskRepo.On("Update", mock.Anything, mock.MatchedBy(func(task *domain.Task) bool {
+		// Tags are cleared explicitly, since nil would leave them in place
+		return task.Title == "Old title" && task.Tags != nil && len(task.Tags) == 0
+	})).Return(nil)
+	deps.taskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-1").Return(&reverted, nil).Once()
+	deps.historyRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+		return h.TaskID == "task-1" && h.EventType == domain.EventTaskUpdated &&
+			h.RevertsOperationID != nil && *h.RevertsOperationID == undoOperationID
+	})).Return(nil)
+
+	result, err := service.Undo(ctx, undoUserID, &domain.UndoRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, undoOperationID, result.OperationID)
+	assert.Equal(t, "undo-op", result.UndoOperationID)
+	require.Len(t, result.Tasks, 1)
+	assert.Equal(t, "Old title", result.Tasks[0].Title)
+	deps.taskRepo.AssertExpectations(t)
+	deps.historyRepo.AssertExpectations(t)
+}
+
+func TestUndoService_Undo_CompletionRemovesNextRecurringInstance(t *testing.T) {
+	service, deps := newUndoService()
+	gamification := new(MockGamificationService)
+	service.SetGamificationService(gamification)
+
+	completedAt := time.Now().Add(-time.Minute)
+	before := undoTask("task-1", "Water plants")
+	after := undoTask("task-1", "Water plants")
+	after.Status = domain.TaskStatusDone
+	after.CompletedAt = &completedAt
+	next := undoTask("task-2", "Water plants")
+
+	uncompleted := *before
+	deletedAt := time.Now()
+	trashedNext := *next
+	trashedNext.DeletedAt = &deletedAt
+
+	deps.historyRepo.On("ListOperations", mock.Anything, undoUserID, domain.MaxUndoableOperations).Return([]*domain.TaskOperation{
+		{ID: undoOperationID},
+	}, nil)
+	deps.historyRepo.On("FindByOperationID", mock.Anything, undoUserID, undoOperationID).Return([]*domain.TaskHistory{
+		undoEntry(domain.EventTaskCompleted, before, after),
+		undoEntry(domain.EventTaskCreated, nil, next),
+	}, nil)
+	deps.taskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-2").Return(next, nil).Once()
+	deps.taskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-1").Return(after, nil).Twice()
+	deps.historyRepo.On("MarkOperationUndone", mock.Anything, undoUserID, undoOperationID, mock.Anything).Return(true, nil)
+	deps.taskRepo.On("Delete", mock.Anything, "task-2", undoUserID, mock.Anything).Return(nil)
+	deps.taskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-2").Return(&trashedNext, nil).Once()
+	deps.taskRepo.On("Update", mock.Anything, mock.MatchedBy(func(task *domain.Task) bool {
+		return task.ID == "task-1" && task.Status == domain.TaskStatusTodo && task.CompletedAt == nil
+	})).Return(nil)
+	deps.taskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-1").Return(&uncompleted, nil).Once()
+	gamification.On("ProcessTaskUncompletionAsync", undoUserID, mock.Anything).Return()
+	deps.historyRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+		return h.TaskID == "task-2" && h.EventType == domain.EventTaskDeleted
+	})).Return(nil)
+	deps.historyRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+		return h.TaskID == "task-1" && h.EventType == domain.EventTaskUncompleted
+	})).Return(nil)
+
+	result, err := service.Undo(context.Background(), undoUserID, &domain.UndoRequest{})
+
+	require.NoError(t, err)
+	require.Len(t, result.Tasks, 2)
+	assert.Equal(t, "task-2", result.Tasks[0].ID)
+	assert.NotNil(t, result.Tasks[0].DeletedAt)
+	assert.Equal(t, domain.TaskStatusTodo, result.Tasks[1].Status)
+	// Without a request operation the undo gets an ID of its own, so it can be redone
+	assert.NotEmpty(t, result.UndoOperationID)
+	deps.taskRepo.AssertExpectations(t)
+	deps.historyRepo.AssertExpectations(t)
+	gamification.AssertExpectations(t)
+}
+
+func TestUndoService_Undo_RestoresDeletedTask(t *testing.T) {
+	service, deps := newUndoService()
+
+	before := undoTask("task-1", "Oops")
+	after := undoTask("task-1", "Oops")
+	deletedAt := time.Now()
+	after.DeletedAt = &deletedAt
+	restored := *before
+	restored.Version = 4
+
+	deps.historyRepo.On("ListOperations", mock.Anything, undoUserID, domain.MaxUndoableOperations).Return([]*domain.TaskOperation{
+		{ID: undoOperationID},
+	}, nil)
+	deps.historyRepo.On("FindByOperationID", mock.Anything, undoUserID, undoOperationID).Return([]*domain.TaskHistory{
+		undoEntry(domain.EventTaskDeleted, before, after),
+	}, nil)
+	deps.taskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-1").Return(after, nil).Once()
+	deps.historyRepo.On("MarkOperationUndone", mock.Anything, undoUserID, undoOperationID, mock.Anything).Return(true, nil)
+	deps.taskRepo.On("Restore", mock.Anything, "task-1", undoUserID).Return(nil)
+	deps.taskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-1").Return(&restored, nil)
+	deps.historyRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+		return h.EventType == domain.EventTaskRestored
+	})).Return(nil)
+
+	result, err := service.Undo(context.Background(), undoUserID, &domain.UndoRequest{})
+
+	require.NoError(t, err)
+	require.Len(t, result.Tasks, 1)
+	assert.Nil(t, result.Tasks[0].DeletedAt)
+	// The fields were unchanged, so nothing beyond the restore is written
+	deps.taskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	deps.historyRepo.AssertExpectations(t)
+}
+
+func TestUndoService_Undo_RestoresBulkDeletedTasks(t *testing.T) {
+	service, deps := newUndoService()
+
+	// A bulk delete logs one deleted entry per task under the same operation
+	var entries []*domain.TaskHistory
+	for _, id := range []string{"task-1", "task-2"} {
+		before := undoTask(id, "Bulk")
+		after := undoTask(id, "Bulk")
+		deletedAt := time.Now()
+		after.DeletedAt = &deletedAt
+		restored := *before
+		restored.Version = 4
+		entries = append(entries, undoEntry(domain.EventTaskDeleted, before, after))
+
+		deps.taskRepo.On("FindByIDIncludingDeleted", mock.Anything, id).Return(after, nil).Once()
+		deps.taskRepo.On("Restore", mock.Anything, id, undoUserID).Return(nil).Once()
+		deps.taskRepo.On("FindByIDIncludingDeleted", mock.Anything, id).Return(&restored, nil)
+	}
+
+	deps.historyRepo.On("ListOperations", mock.Anything, undoUserID, domain.MaxUndoableOperations).Return([]*domain.TaskOperation{
+		{ID: undoOperationID},
+	}, nil)
+	deps.historyRepo.On("FindByOperationID", mock.Anything, undoUserID, undoOperationID).Return(entries, nil)
+	deps.historyRepo.On("MarkOperationUndone", mock.Anything, undoUserID, undoOperationID, mock.Anything).Return(true, nil)
+	deps.historyRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+		return h.EventType == domain.EventTaskRestored
+	})).Return(nil).Twice()
+
+	result, err := service.Undo(context.Background(), undoUserID, &domain.UndoRequest{})
+
+	require.NoError(t, err)
+	require.Len(t, result.Tasks, 2)
+	for _, task := range result.Tasks {
+		assert.Nil(t, task.DeletedAt)
+	}
+	deps.taskRepo.AssertNumberOfCalls(t, "Restore", 2)
+	deps.historyRepo.AssertExpectations(t)
+}
+func TestUndoService_Undo_ConflictWhenTaskChangedSince(t *testing.T) {
+	service, deps := newUndoService()
+
+	before := undoTask("task-1", "Old title")
+	after := undoTask("task-1", "New title")
+	current := undoTask("task-1", "Edited again")
+
+	deps.historyRepo.On("ListOperations", mock.Anything, undoUserID, domain.MaxUndoableOperations).Return([]*domain.TaskOperation{
+		{ID: undoOperationID},
+	}, nil)
+	deps.historyRepo.On("FindByOperationID", mock.Anything, undoUserID, undoOperationID).Return([]*domain.TaskHistory{
+		undoEntry(domain.EventTaskUpdated, before, after),
+	}, nil)
+	deps.taskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-1").Return(current, nil)
+
+	result, err := service.Undo(context.Background(), undoUserID, &domain.UndoRequest{})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrUndoConflict)
+	deps.historyRepo.AssertNotCalled(t, "MarkOperationUndone", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	deps.taskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUndoService_Undo_PartialFailureReleasesClaim(t *testing.T) {
+	service, deps := newUndoService()
+	ctx := domain.WithOperationID(context.Background(), "undo-op")
+
+	before1, after1 := undoTask("task-1", "Old 1"), undoTask("task-1", "New 1")
+	before2, after2 := undoTask("task-2", "Old 2"), undoTask("task-2", "New 2")
+	reverted2 := *before2
+	reverted2.Version = 3
+
+	deps.historyRepo.On("ListOperations", mock.Anything, undoUserID, domain.MaxUndoableOperations).Return([]*domain.TaskOperation{
+		{ID: undoOperationID},
+	}, nil)
+	deps.historyRepo.On("FindByOperationID", mock.Anything, undoUserID, undoOperationID).Return([]*domain.TaskHistory{
+		undoEntry(domain.EventTaskUpdated, before1, after1),
+		undoEntry(domain.EventTaskUpdated, before2, after2),
+	}, nil)
+	deps.historyRepo.On("MarkOperationUndone", mock.Anything, undoUserID, undoOperationID, mock.Anything).Return(true, nil)
+	// Newest change first: task-2 is reverted, then task-1 fails
+	deps.taskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-2").Return(after2, nil).Twice()
+	deps.taskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-2").Return(&reverted2, nil)
+	deps.taskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-1").Return(after1, nil)
+	deps.taskRepo.On("Update", mock.Anything, mock.MatchedBy(func(task *domain.Task) bool { return task.ID == "task-2" })).Return(nil)
+	deps.taskRepo.On("Update", mock.Anything, mock.MatchedBy(func(task *domain.Task) bool { return task.ID == "task-1" })).Return(errors.New("db down"))
+	deps.historyRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	deps.historyRepo.On("ClearOperationUndone", mock.Anything, undoUserID, undoOperationID).Return(nil)
+
+	result, err := service.Undo(ctx, undoUserID, &domain.UndoRequest{})
+
+	assert.Nil(t, result)
+	var partialErr *domain.PartialUndoError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, []string{"task-2"}, partialErr.RevertedTaskIDs)
+	assert.Equal(t, "undo-op", partialErr.UndoOperationID)
+	// The operation stays undoable so the rest can be reverted
+	deps.historyRepo.AssertCalled(t, "ClearOperationUndone", mock.Anything, undoUserID, undoOperationID)
+}
+
+func TestUndoService_Undo_SkipsTasksAlreadyReverted(t *testing.T) {
+	service, deps := newUndoService()
+
+	before1, after1 := undoTask("task-1", "Old 1"), undoTask("task-1", "New 1")
+	before2, after2 := undoTask("task-2", "Old 2"), undoTask("task-2", "New 2")
+	reverted1 := *before1
+	reverted1.Version = 3
+
+	deps.historyRepo.On("ListOperations", mock.Anything, undoUserID, domain.MaxUndoableOperations).Return([]*domain.TaskOperation{
+		{ID: undoOperationID},
+	}, nil)
+	deps.historyRepo.On("FindByOperationID", mock.Anything, undoUserID, undoOperationID).Return([]*domain.TaskHistory{
+		undoEntry(domain.EventTaskUpdated, before1, after1),
+		undoEntry(domain.EventTaskUpdated, before2, after2),
+	}, nil)
+	// An earlier undo that stopped partway already put task-2 back
+	deps.taskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-2").Return(before2, nil)
+	deps.taskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-1").Return(after1, nil).Twice()
+	deps.taskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-1").Return(&reverted1, nil)
+	deps.historyRepo.On("MarkOperationUndone", mock.Anything, undoUserID, undoOperationID, mock.Anything).Return(true, nil)
+	deps.taskRepo.On("Update", mock.Anything, mock.MatchedBy(func(task *domain.Task) bool { return task.ID == "task-1" })).Return(nil)
+	deps.historyRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	result, err := service.Undo(context.Background(), undoUserID, &domain.UndoRequest{})
+
+	require.NoError(t, err)
+	require.Len(t, result.Tasks, 1)
+	assert.Equal(t, "task-1", result.Tasks[0].ID)
+	deps.taskRepo.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestUndoService_Undo_SelectsRequestedOperation(t *testing.T) {
+	undoneAt := time.Now()
+	operations := []*domain.TaskOperation{
+		{ID: "33333333-3333-3333-3333-333333333333"},
+		{ID: undoOperationID, UndoneAt: &undoneAt},
+	}
+
+	tests := []struct {
+		name        string
+		operationID string
+		wantErr     error
+	}{
+		{"already undone", undoOperationID, domain.ErrOperationAlreadyUndone},
+		{"not among recent operations", "44444444-4444-4444-4444-444444444444", domain.ErrOperationNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, deps := newUndoService()
+			deps.historyRepo.On("ListOperations", mock.Anything, undoUserID, domain.MaxUndoableOperations).Return(operations, nil)
+
+			operationID := tt.operationID
+			_, err := service.Undo(context.Background(), undoUserID, &domain.UndoRequest{OperationID: &operationID})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			deps.historyRepo.AssertNotCalled(t, "FindByOperationID", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUndoService_Undo_CommentOnlyOperationIsNotUndoable(t *testing.T) {
+	service, deps := newUndoService()
+	body := `{"body":"hello"}`
+	operationID := undoOperationID
+
+	deps.historyRepo.On("ListOperations", mock.Anything, undoUserID, domain.MaxUndoableOperations).Return([]*domain.TaskOperation{
+		{ID: undoOperationID},
+	}, nil)
+	deps.historyRepo.On("FindByOperationID", mock.Anything, undoUserID, undoOperationID).Return([]*domain.TaskHistory{
+		{ID: "history-1", TaskID: "task-1", EventType: domain.EventCommentAdded, NewValue: &body, OperationID: &operationID},
+	}, nil)
+
+	_, err := service.Undo(context.Background(), undoUserID, &domain.UndoRequest{})
+
+	assert.ErrorIs(t, err, domain.ErrOperationNotUndoable)
+}
+
+func TestUndoService_Undo_RedoMarksOriginalNotUndone(t *testing.T) {
+	service, deps := newUndoService()
+	redoTarget := "55555555-5555-5555-5555-555555555555"
+
+	// The undo put the old title back; undoing it puts the new title back again
+	before := undoTask("task-1", "New title")
+	after := undoTask("task-1", "Old title")
+	redone := *before
+	redone.Version = 5
+
+	entry := undoEntry(domain.EventTaskUpdated, before, after)
+	entry.OperationID = &redoTarget
+	original := undoOperationID
+	entry.RevertsOperationID = &original
+
+	deps.historyRepo.On("ListOperations", mock.Anything, undoUserID, domain.MaxUndoableOperations).Return([]*domain.TaskOperation{
+		{ID: redoTarget, RevertsOperationID: &original},
+	}, nil)
+	deps.historyRepo.On("FindByOperationID", mock.Anything, undoUserID, redoTarget).Return([]*domain.TaskHistory{entry}, nil)
+	deps.taskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-1").Return(after, nil).Twice()
+	deps.historyRepo.On("MarkOperationUndone", mock.Anything, undoUserID, redoTarget, mock.Anything).Return(true, nil)
+	deps.taskRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	deps.taskRepo.On("FindByIDIncludingDeleted", mock.Anything, "task-1").Return(&redone, nil).Once()
+	deps.historyRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	deps.historyRepo.On("ClearOperationUndone", mock.Anything, undoUserID, undoOperationID).Return(nil)
+
+	result, err := service.Undo(context.Background(), undoUserID, &domain.UndoRequest{OperationID: &redoTarget})
+
+	require.NoError(t, err)
+	assert.Equal(t, "New title", result.Tasks[0].Title)
+	deps.historyRepo.AssertExpectations(t)
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/notkevinvu/taskflow/backend/internal/ports"
+)
+
+// WorkflowService manages each user's workflow: the statuses their tasks move through
+// and the transitions allowed between them
+type WorkflowService struct {
+	workflowRepo ports.WorkflowRepository
+}
+
+// NewWorkflowService creates a new workflow service
+func NewWorkflowService(workflowRepo ports.WorkflowRepository) *WorkflowService {
+	return &WorkflowService{workflowRepo: workflowRepo}
+}
+
+// GetWorkflow returns the user's workflow, or the built-in one if they have not defined one
+func (s *WorkflowService) GetWorkflow(ctx context.Context, userID string) (*domain.Workflow, error) {
+	statuses, err := s.workflowRepo.GetStatuses(ctx, userID)
+	if err != nil {
+		return nil, domain.NewInternalError("failed to get workflow", err)
+	}
+	if len(statuses) == 0 {
+		return domain.DefaultWorkflow(), nil
+	}
+	return &domain.Workflow{Statuses: statuses, Custom: true}, nil
+}
+
+// UpdateWorkflow replaces the user's workflow. Tasks in statuses that are removed move
+// to the status given in the mapping, or else to the first status of their category.
+func (s *WorkflowService) UpdateWorkflow(ctx context.Context, userID string, dto *domain.UpdateWorkflowDTO) (*domain.Workflow, error) {
+	workflow := &domain.Workflow{Statuses: dto.Statuses, Custom: true}
+	if err := workflow.Validate(); err != nil {
+		return nil, err
+	}
+
+	current, err := s.GetWorkflow(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := dto.ValidateMapping(current, workflow); err != nil {
+		return nil, err
+	}
+
+	if err := s.workflowRepo.ReplaceStatuses(ctx, userID, workflow.Statuses, dto.StatusMapping); err != nil {
+		return nil, domain.NewInternalError("failed to save workflow", err)
+	}
+	return workflow, nil
+}
+
+// ResetWorkflow restores the built-in workflow, moving every task to the built-in status
+// its custom status was based on
+func (s *WorkflowService) ResetWorkflow(ctx context.Context, userID string) (*domain.Workflow, error) {
+	if err := s.workflowRepo.DeleteStatuses(ctx, userID); err != nil {
+		return nil, domain.NewInternalError("failed to reset workflow", err)
+	}
+	return domain.DefaultWorkflow(), nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/notkevinvu/taskflow/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// WorkflowService.GetWorkflow Tests
+// =============================================================================
+
+func TestWorkflowService_GetWorkflow_DefaultsToBuiltIn(t *testing.T) {
+	mockWorkflowRepo := new(MockWorkflowRepository)
+	service := NewWorkflowService(mockWorkflowRepo)
+
+	mockWorkflowRepo.On("GetStatuses", mock.Anything, "user-123").Return([]domain.WorkflowStatus{}, nil)
+
+	workflow, err := service.GetWorkflow(context.Background(), "user-123")
+
+	require.NoError(t, err)
+	assert.False(t, workflow.Custom)
+	assert.Equal(t, domain.DefaultWorkflow().Statuses, workflow.Statuses)
+}
+
+func TestWorkflowService_GetWorkflow_Custom(t *testing.T) {
+	mockWorkflowRepo := new(MockWorkflowRepository)
+	service := NewWorkflowService(mockWorkflowRepo)
+
+	mockWorkflowRepo.On("GetStatuses", mock.Anything, "user-123").Return(reviewWorkflow, nil)
+
+	workflow, err := service.GetWorkflow(context.Background(), "user-123")
+
+	require.NoError(t, err)
+	assert.True(t, workflow.Custom)
+	assert.Len(t, workflow.Statuses, len(reviewWorkflow))
+}
+
+// =============================================================================
+// WorkflowService.UpdateWorkflow Tests
+// =============================================================================
+
+func TestWorkflowService_UpdateWorkflow_Success(t *testing.T) {
+	mockWorkflowRepo := new(MockWorkflowRepository)
+	service := NewWorkflowService(mockWorkflowRepo)
+	mapping := map[domain.TaskStatus]domain.TaskStatus{domain.TaskStatusOnHold: "backlog"}
+
+	mockWorkflowRepo.On("GetStatuses", mock.Anything, "user-123").Return([]domain.WorkflowStatus{}, nil)
+	mockWorkflowRepo.On("ReplaceStatuses", mock.Anything, "user-123", reviewWorkflow, mapping).Return(nil)
+
+	workflow, err := service.UpdateWorkflow(context.Background(), "user-123", &domain.UpdateWorkflowDTO{
+		Statuses:      reviewWorkflow,
+		StatusMapping: mapping,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, workflow.Custom)
+	mockWorkflowRepo.AssertExpectations(t)
+}
+
+func TestWorkflowService_UpdateWorkflow_InvalidWorkflow(t *testing.T) {
+	mockWorkflowRepo := new(MockWorkflowRepository)
+	service := NewWorkflowService(mockWorkflowRepo)
+
+	// No done status
+	_, err := service.UpdateWorkflow(context.Background(), "user-123", &domain.UpdateWorkflowDTO{
+		Statuses: reviewWorkflow[:3],
+	})
+
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	mockWorkflowRepo.AssertNotCalled(t, "ReplaceStatuses", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWorkflowService_UpdateWorkflow_MappingAcrossCategories(t *testing.T) {
+	mockWorkflowRepo := new(MockWorkflowRepository)
+	service := NewWorkflowService(mockWorkflowRepo)
+
+	mockWorkflowRepo.On("GetStatuses", mock.Anything, "user-123").Return([]domain.WorkflowStatus{}, nil)
+
+	// Blocked tasks are active; they cannot be moved back to the backlog
+	_, err := service.UpdateWorkflow(context.Background(), "user-123", &domain.UpdateWorkflowDTO{
+		Statuses:      reviewWorkflow,
+		StatusMapping: map[domain.TaskStatus]domain.TaskStatus{domain.TaskStatusBlocked: "backlog"},
+	})
+
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "status_mapping", validationErr.Field)
+	mockWorkflowRepo.AssertNotCalled(t, "ReplaceStatuses", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// =============================================================================
+// WorkflowService.ResetWorkflow Tests
+// =============================================================================
+
+func TestWorkflowService_ResetWorkflow(t *testing.T) {
+	mockWorkflowRepo := new(MockWorkflowRepository)
+	service := NewWorkflowService(mockWorkflowRepo)
+
+	mockWorkflowRepo.On("DeleteStatuses", mock.Anything, "user-123").Return(nil)
+
+	workflow, err := service.ResetWorkflow(context.Background(), "user-123")
+
+	require.NoError(t, err)
+	assert.False(t, workflow.Custom)
+	mockWorkflowRepo.AssertExpectations(t)
+}
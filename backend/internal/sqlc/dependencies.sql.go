@@ -54,6 +54,7 @@ FROM task_dependencies td
 INNER JOIN tasks t ON t.id = td.blocked_by_id
 WHERE td.task_id = $1
   AND t.status != 'done'
+  AND t.deleted_at IS NULL
 `
 
 // Count incomplete blockers for a single task
@@ -70,6 +71,7 @@ FROM task_dependencies td
 INNER JOIN tasks t ON t.id = td.blocked_by_id
 WHERE td.task_id = ANY($1::uuid[])
   AND t.status != 'done'
+  AND t.deleted_at IS NULL
 GROUP BY td.task_id
 `
 
@@ -131,6 +133,7 @@ SELECT t.id, t.title, t.status, td.created_at
 FROM task_dependencies td
 INNER JOIN tasks t ON t.id = td.blocked_by_id
 WHERE td.task_id = $1
+  AND t.deleted_at IS NULL
 ORDER BY td.created_at ASC
 `
 
@@ -142,6 +145,8 @@ type GetBlockerTasksRow struct {
 }
 
 // Get all tasks that block the given task (with task details)
+// Trashed tasks are left out of the blocker/blocking queries but their edges are kept,
+// so restoring a task brings its dependencies back
 func (q *Queries) GetBlockerTasks(ctx context.Context, taskID pgtype.UUID) ([]GetBlockerTasksRow, error) {
 	rows, err := q.db.Query(ctx, getBlockerTasks, taskID)
 	if err != nil {
@@ -172,6 +177,7 @@ SELECT t.id, t.title, t.status, td.created_at
 FROM task_dependencies td
 INNER JOIN tasks t ON t.id = td.task_id
 WHERE td.blocked_by_id = $1
+  AND t.deleted_at IS NULL
 ORDER BY td.created_at ASC
 `
 
@@ -221,6 +227,7 @@ type GetDependencyGraphRow struct {
 }
 
 // Get all dependency relationships for a user's tasks
+// Includes trashed tasks so a restore can never complete a cycle
 func (q *Queries) GetDependencyGraph(ctx context.Context, userID pgtype.UUID) ([]GetDependencyGraphRow, error) {
 	rows, err := q.db.Query(ctx, getDependencyGraph, userID)
 	if err != nil {
@@ -242,8 +249,11 @@ func (q *Queries) GetDependencyGraph(ctx context.Context, userID pgtype.UUID) ([
 }
 
 const getTasksBlockedByTask = `-- name: GetTasksBlockedByTask :many
-SELECT task_id FROM task_dependencies
-WHERE blocked_by_id = $1
+SELECT td.task_id
+FROM task_dependencies td
+INNER JOIN tasks t ON t.id = td.task_id
+WHERE td.blocked_by_id = $1
+  AND t.deleted_at IS NULL
 `
 
 // Get task IDs that are blocked by the given task
@@ -294,7 +304,7 @@ func (q *Queries) RemoveDependency(ctx context.Context, arg RemoveDependencyPara
 const verifyTasksExistForUser = `-- name: VerifyTasksExistForUser :one
 
 SELECT COUNT(*)::int FROM tasks
-WHERE id IN ($1, $2) AND user_id = $3
+WHERE id IN ($1, $2) AND user_id = $3 AND deleted_at IS NULL
 `
 
 type VerifyTasksExistForUserParams struct {
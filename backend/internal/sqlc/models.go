@@ -259,6 +259,9 @@ type Task struct {
 	CompletedAt     pgtype.Timestamptz `json:"completed_at"`
 	SeriesID        pgtype.UUID        `json:"series_id"`
 	ParentTaskID    pgtype.UUID        `json:"parent_task_id"`
+	DeferUntil      pgtype.Timestamptz `json:"defer_until"`
+	Rank            string             `json:"rank"`
+	WorkflowStatus  string             `json:"workflow_status"`
 }
 
 type TaskDependency struct {
@@ -268,13 +271,16 @@ type TaskDependency struct {
 }
 
 type TaskHistory struct {
-	ID        pgtype.UUID          `json:"id"`
-	UserID    pgtype.UUID          `json:"user_id"`
-	TaskID    pgtype.UUID          `json:"task_id"`
-	EventType TaskHistoryEventType `json:"event_type"`
-	OldValue  *string              `json:"old_value"`
-	NewValue  *string              `json:"new_value"`
-	CreatedAt pgtype.Timestamptz   `json:"created_at"`
+	ID                 pgtype.UUID          `json:"id"`
+	UserID             pgtype.UUID          `json:"user_id"`
+	TaskID             pgtype.UUID          `json:"task_id"`
+	EventType          TaskHistoryEventType `json:"event_type"`
+	OldValue           *string              `json:"old_value"`
+	NewValue           *string              `json:"new_value"`
+	CreatedAt          pgtype.Timestamptz   `json:"created_at"`
+	OperationID        pgtype.UUID          `json:"operation_id"`
+	UndoneAt           pgtype.Timestamptz   `json:"undone_at"`
+	RevertsOperationID pgtype.UUID          `json:"reverts_operation_id"`
 }
 
 type TaskSeries struct {
@@ -3,7 +3,7 @@
 -- name: VerifyTasksExistForUser :one
 -- Verify both tasks exist and belong to the user (returns count, should be 2)
 SELECT COUNT(*)::int FROM tasks
-WHERE id IN ($1, $2) AND user_id = $3;
+WHERE id IN ($1, $2) AND user_id = $3 AND deleted_at IS NULL;
 
 -- name: AddDependency :exec
 -- Add a new dependency relationship
@@ -28,10 +28,13 @@ SELECT EXISTS(
 
 -- name: GetBlockerTasks :many
 -- Get all tasks that block the given task (with task details)
+-- Trashed tasks are left out of the blocker/blocking queries but their edges are kept,
+-- so restoring a task brings its dependencies back
 SELECT t.id, t.title, t.status, td.created_at
 FROM task_dependencies td
 INNER JOIN tasks t ON t.id = td.blocked_by_id
 WHERE td.task_id = $1
+  AND t.deleted_at IS NULL
 ORDER BY td.created_at ASC;
 
 -- name: GetBlockingTasks :many
@@ -40,6 +43,7 @@ SELECT t.id, t.title, t.status, td.created_at
 FROM task_dependencies td
 INNER JOIN tasks t ON t.id = td.task_id
 WHERE td.blocked_by_id = $1
+  AND t.deleted_at IS NULL
 ORDER BY td.created_at ASC;
 
 -- name: GetBlockerIDs :many
@@ -49,6 +53,7 @@ WHERE task_id = $1;
 
 -- name: GetDependencyGraph :many
 -- Get all dependency relationships for a user's tasks
+-- Includes trashed tasks so a restore can never complete a cycle
 SELECT td.task_id, td.blocked_by_id
 FROM task_dependencies td
 INNER JOIN tasks t ON t.id = td.task_id
@@ -60,12 +65,16 @@ SELECT COUNT(*)::int
 FROM task_dependencies td
 INNER JOIN tasks t ON t.id = td.blocked_by_id
 WHERE td.task_id = $1
-  AND t.status != 'done';
+  AND t.status != 'done'
+  AND t.deleted_at IS NULL;
 
 -- name: GetTasksBlockedByTask :many
 -- Get task IDs that are blocked by the given task
-SELECT task_id FROM task_dependencies
-WHERE blocked_by_id = $1;
+SELECT td.task_id
+FROM task_dependencies td
+INNER JOIN tasks t ON t.id = td.task_id
+WHERE td.blocked_by_id = $1
+  AND t.deleted_at IS NULL;
 
 -- name: CountIncompleteBlockersBatch :many
 -- Batch query: count incomplete blockers for multiple tasks at once
@@ -75,4 +84,5 @@ FROM task_dependencies td
 INNER JOIN tasks t ON t.id = td.blocked_by_id
 WHERE td.task_id = ANY($1::uuid[])
   AND t.status != 'done'
+  AND t.deleted_at IS NULL
 GROUP BY td.task_id;
@@ -1,8 +1,8 @@
 -- Task History queries for sqlc code generation
 
 -- name: CreateTaskHistory :exec
-INSERT INTO task_history (id, user_id, task_id, event_type, old_value, new_value, created_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7);
+INSERT INTO task_history (id, user_id, task_id, event_type, old_value, new_value, created_at, operation_id, reverts_operation_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
 
 -- name: GetTaskHistoryByTaskID :many
 SELECT id, user_id, task_id, event_type, old_value, new_value, created_at
@@ -1,12 +1,14 @@
 -- Task queries for sqlc code generation
 
--- name: CreateTask :exec
+-- name: CreateTask :one
 INSERT INTO tasks (
     id, user_id, title, description, status, user_priority,
     due_date, estimated_effort, category, context, related_people,
-    priority_score, bump_count, created_at, updated_at, series_id, parent_task_id
+    priority_score, bump_count, created_at, updated_at, series_id, parent_task_id,
+    defer_until, rank
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17);
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+RETURNING workflow_status;
 
 -- name: GetTaskByID :one
 SELECT id, user_id, title, description, status, user_priority,
@@ -44,6 +46,7 @@ WHERE user_id = $1
       OR (due_date IS NOT NULL AND due_date < NOW() - INTERVAL '3 days')
   )
   AND status != 'done'
+  AND (defer_until IS NULL OR defer_until <= NOW())
 ORDER BY priority_score DESC;
 
 -- name: GetCategories :many
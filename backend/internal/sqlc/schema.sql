@@ -79,7 +79,13 @@ CREATE TABLE tasks (
     completed_at TIMESTAMP WITH TIME ZONE,
     -- Recurrence fields
     series_id UUID REFERENCES task_series(id) ON DELETE SET NULL,
-    parent_task_id UUID REFERENCES tasks(id) ON DELETE SET NULL
+    parent_task_id UUID REFERENCES tasks(id) ON DELETE SET NULL,
+    -- Start date: hidden from default lists until then
+    defer_until TIMESTAMP WITH TIME ZONE,
+    -- Position in the manually ordered list (fractional index)
+    rank TEXT COLLATE "C" NOT NULL,
+    -- Status key in the user's workflow; status holds its built-in base status
+    workflow_status TEXT NOT NULL
 );
 
 -- Add foreign key from task_series to tasks after tasks table exists
@@ -109,7 +115,10 @@ CREATE TABLE task_history (
     event_type task_history_event_type NOT NULL,
     old_value TEXT,
     new_value TEXT,
-    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    operation_id UUID,
+    undone_at TIMESTAMP WITH TIME ZONE,
+    reverts_operation_id UUID
 );
 
 -- Create indexes for task_history
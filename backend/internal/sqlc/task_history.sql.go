@@ -13,18 +13,20 @@ import (
 
 const createTaskHistory = `-- name: CreateTaskHistory :exec
 
-INSERT INTO task_history (id, user_id, task_id, event_type, old_value, new_value, created_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO task_history (id, user_id, task_id, event_type, old_value, new_value, created_at, operation_id, reverts_operation_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 `
 
 type CreateTaskHistoryParams struct {
-	ID        pgtype.UUID          `json:"id"`
-	UserID    pgtype.UUID          `json:"user_id"`
-	TaskID    pgtype.UUID          `json:"task_id"`
-	EventType TaskHistoryEventType `json:"event_type"`
-	OldValue  *string              `json:"old_value"`
-	NewValue  *string              `json:"new_value"`
-	CreatedAt pgtype.Timestamptz   `json:"created_at"`
+	ID                 pgtype.UUID          `json:"id"`
+	UserID             pgtype.UUID          `json:"user_id"`
+	TaskID             pgtype.UUID          `json:"task_id"`
+	EventType          TaskHistoryEventType `json:"event_type"`
+	OldValue           *string              `json:"old_value"`
+	NewValue           *string              `json:"new_value"`
+	CreatedAt          pgtype.Timestamptz   `json:"created_at"`
+	OperationID        pgtype.UUID          `json:"operation_id"`
+	RevertsOperationID pgtype.UUID          `json:"reverts_operation_id"`
 }
 
 // Task History queries for sqlc code generation
@@ -37,6 +39,8 @@ func (q *Queries) CreateTaskHistory(ctx context.Context, arg CreateTaskHistoryPa
 		arg.OldValue,
 		arg.NewValue,
 		arg.CreatedAt,
+		arg.OperationID,
+		arg.RevertsOperationID,
 	)
 	return err
 }
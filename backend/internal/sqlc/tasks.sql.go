@@ -11,14 +11,16 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const createTask = `-- name: CreateTask :exec
+const createTask = `-- name: CreateTask :one
 
 INSERT INTO tasks (
     id, user_id, title, description, status, user_priority,
     due_date, estimated_effort, category, context, related_people,
-    priority_score, bump_count, created_at, updated_at, series_id, parent_task_id
+    priority_score, bump_count, created_at, updated_at, series_id, parent_task_id,
+    defer_until, rank
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+RETURNING workflow_status
 `
 
 type CreateTaskParams struct {
@@ -39,11 +41,13 @@ type CreateTaskParams struct {
 	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
 	SeriesID        pgtype.UUID        `json:"series_id"`
 	ParentTaskID    pgtype.UUID        `json:"parent_task_id"`
+	DeferUntil      pgtype.Timestamptz `json:"defer_until"`
+	Rank            string             `json:"rank"`
 }
 
 // Task queries for sqlc code generation
-func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) error {
-	_, err := q.db.Exec(ctx, createTask,
+func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (string, error) {
+	row := q.db.QueryRow(ctx, createTask,
 		arg.ID,
 		arg.UserID,
 		arg.Title,
@@ -61,8 +65,12 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) error {
 		arg.UpdatedAt,
 		arg.SeriesID,
 		arg.ParentTaskID,
+		arg.DeferUntil,
+		arg.Rank,
 	)
-	return err
+	var workflow_status string
+	err := row.Scan(&workflow_status)
+	return workflow_status, err
 }
 
 const deleteCategoryForUser = `-- name: DeleteCategoryForUser :exec
@@ -189,6 +197,7 @@ WHERE user_id = $1
       OR (due_date IS NOT NULL AND due_date < NOW() - INTERVAL '3 days')
   )
   AND status != 'done'
+  AND (defer_until IS NULL OR defer_until <= NOW())
 ORDER BY priority_score DESC
 `
 
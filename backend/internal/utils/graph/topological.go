@@ -0,0 +1,38 @@
+package graph
+
+// DependencyOrder orders nodes so that every node comes after the nodes it
+// depends on, directly or through intermediate nodes that are not in the list.
+// edges is a map of node -> list of nodes it depends on.
+// Independent nodes keep their input order; cycles are broken arbitrarily
+// but every input node appears exactly once.
+func DependencyOrder(nodes []string, edges map[string][]string) []string {
+	wanted := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		wanted[node] = true
+	}
+
+	ordered := make([]string, 0, len(nodes))
+	visited := make(map[string]bool)
+
+	// Post-order DFS: a node is emitted only after everything it depends on
+	var visit func(node string)
+	visit = func(node string) {
+		if visited[node] {
+			return
+		}
+		visited[node] = true
+		for _, dep := range edges[node] {
+			visit(dep)
+		}
+		if wanted[node] {
+			ordered = append(ordered, node)
+			delete(wanted, node) // Emit duplicates in the input only once
+		}
+	}
+
+	for _, node := range nodes {
+		visit(node)
+	}
+
+	return ordered
+}
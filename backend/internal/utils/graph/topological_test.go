@@ -0,0 +1,55 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDependencyOrder_NoEdges(t *testing.T) {
+	got := DependencyOrder([]string{"A", "B", "C"}, map[string][]string{})
+
+	if want := []string{"A", "B", "C"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DependencyOrder() = %v, want %v", got, want)
+	}
+}
+
+func TestDependencyOrder_BlockerFirst(t *testing.T) {
+	// A depends on B, B depends on C
+	edges := map[string][]string{
+		"A": {"B"},
+		"B": {"C"},
+	}
+
+	got := DependencyOrder([]string{"A", "B", "C"}, edges)
+
+	if want := []string{"C", "B", "A"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DependencyOrder() = %v, want %v", got, want)
+	}
+}
+
+func TestDependencyOrder_TransitiveThroughOutsideNode(t *testing.T) {
+	// A depends on X (not requested), X depends on B
+	edges := map[string][]string{
+		"A": {"X"},
+		"X": {"B"},
+	}
+
+	got := DependencyOrder([]string{"A", "B"}, edges)
+
+	if want := []string{"B", "A"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DependencyOrder() = %v, want %v", got, want)
+	}
+}
+
+func TestDependencyOrder_CycleAndDuplicates(t *testing.T) {
+	edges := map[string][]string{
+		"A": {"B"},
+		"B": {"A"},
+	}
+
+	got := DependencyOrder([]string{"A", "B", "A"}, edges)
+
+	if len(got) != 2 {
+		t.Fatalf("Expected each node exactly once, got %v", got)
+	}
+}
@@ -48,6 +48,49 @@ func ValidateRequiredText(text string, maxLength int, fieldName string) (string,
 	return sanitized, nil
 }
 
+// ValidateMarkdown validates required multi-line text such as a comment body.
+// Unlike SanitizeText it allows newlines and tabs; other control characters are rejected.
+func ValidateMarkdown(text string, maxLength int, fieldName string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", domain.NewValidationError(fieldName, "is required")
+	}
+
+	runes := []rune(text)
+	if len(runes) > maxLength {
+		return "", domain.NewValidationError(fieldName,
+			fmt.Sprintf("exceeds maximum length of %d characters", maxLength))
+	}
+
+	for i, r := range runes {
+		if unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t' {
+			return "", domain.NewValidationError(fieldName,
+				fmt.Sprintf("contains invalid control character at position %d", i))
+		}
+	}
+
+	return text, nil
+}
+
+// ValidateFilename validates an uploaded file's name.
+// Any directory components sent by the client are dropped, keeping only the base name.
+func ValidateFilename(name string, fieldName string) (string, error) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	sanitized, err := ValidateRequiredText(name, 255, fieldName)
+	if err != nil {
+		return "", err
+	}
+	if sanitized == "." || sanitized == ".." {
+		return "", domain.NewValidationError(fieldName, "is not a valid file name")
+	}
+
+	return sanitized, nil
+}
+
 // ValidateOptionalText validates optional text fields
 func ValidateOptionalText(text *string, maxLength int, fieldName string) (*string, error) {
 	if text == nil || *text == "" {
@@ -138,6 +181,51 @@ func ValidateCategory(category *string) (*string, error) {
 	return &sanitized, nil
 }
 
+// ValidateTagName validates and sanitizes a tag name.
+// Tags follow the same character rules as categories.
+func ValidateTagName(name string, fieldName string) (string, error) {
+	sanitized, err := ValidateRequiredText(name, 50, fieldName)
+	if err != nil {
+		return "", err
+	}
+
+	validTag := regexp.MustCompile(`^[a-zA-Z0-9\s\-_]+$`)
+	if !validTag.MatchString(sanitized) {
+		return "", domain.NewValidationError(fieldName,
+			"can only contain letters, numbers, spaces, hyphens, and underscores")
+	}
+
+	return sanitized, nil
+}
+
+// ValidateTags validates and sanitizes a list of tag names.
+// Duplicates (ignoring case) are dropped, keeping the first spelling.
+// Always returns a non-nil slice so an empty list clears a task's tags.
+func ValidateTags(tags []string, fieldName string) ([]string, error) {
+	if len(tags) > domain.MaxTaskTags {
+		return nil, domain.NewValidationError(fieldName,
+			fmt.Sprintf("cannot exceed %d items", domain.MaxTaskTags))
+	}
+
+	sanitized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for i, tag := range tags {
+		cleaned, err := ValidateTagName(tag, fmt.Sprintf("%s[%d]", fieldName, i))
+		if err != nil {
+			return nil, err
+		}
+
+		key := strings.ToLower(cleaned)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		sanitized = append(sanitized, cleaned)
+	}
+
+	return sanitized, nil
+}
+
 // ValidateStringSlice validates and sanitizes a slice of strings
 func ValidateStringSlice(slice []string, maxLength int, maxItems int, fieldName string) ([]string, error) {
 	if len(slice) == 0 {
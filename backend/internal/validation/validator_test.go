@@ -256,6 +256,64 @@ func TestValidateRequiredText(t *testing.T) {
 	}
 }
 
+func TestValidateMarkdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{name: "multi-line markdown", text: "## Progress\n\n- [x] draft\n\t- [ ] review\r\n", want: "## Progress\n\n- [x] draft\n\t- [ ] review", wantErr: false},
+		{name: "empty", text: "", wantErr: true},
+		{name: "only whitespace", text: " \n\t ", wantErr: true},
+		{name: "null byte", text: "note\x00", wantErr: true},
+		{name: "too long", text: strings.Repeat("a", 101), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateMarkdown(tt.text, 100, "body")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateMarkdown() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ValidateMarkdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateFilename(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		want     string
+		wantErr  bool
+	}{
+		{name: "plain name", filename: "report.pdf", want: "report.pdf", wantErr: false},
+		{name: "unix path", filename: "/home/me/report.pdf", want: "report.pdf", wantErr: false},
+		{name: "windows path", filename: `C:\Users\me\report.pdf`, want: "report.pdf", wantErr: false},
+		{name: "trailing slash", filename: "dir/", wantErr: true},
+		{name: "dot dot", filename: "a/..", wantErr: true},
+		{name: "control character", filename: "bad\nname.txt", wantErr: true},
+		{name: "too long", filename: strings.Repeat("a", 256), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateFilename(tt.filename, "file")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateFilename() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ValidateFilename() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestValidateStringSlice(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -292,3 +350,63 @@ func TestValidateStringSlice(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "trims and keeps order",
+			tags: []string{" urgent ", "client-a"},
+			want: []string{"urgent", "client-a"},
+		},
+		{
+			name: "drops case-insensitive duplicates",
+			tags: []string{"Urgent", "urgent", "URGENT"},
+			want: []string{"Urgent"},
+		},
+		{
+			name: "empty list clears",
+			tags: []string{},
+			want: []string{},
+		},
+		{
+			name:    "empty tag",
+			tags:    []string{"  "},
+			wantErr: true,
+		},
+		{
+			name:    "invalid characters",
+			tags:    []string{"a/b"},
+			wantErr: true,
+		},
+		{
+			name:    "too many tags",
+			tags:    make([]string, 21),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateTags(tt.tags, "tags")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateTags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ValidateTags() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("ValidateTags()[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
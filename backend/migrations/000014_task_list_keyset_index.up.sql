@@ -0,0 +1,8 @@
+-- Support keyset (cursor) pagination on the main task list
+-- The list is ordered by (priority_score DESC, created_at DESC, id DESC) and
+-- cursors compare against that full tuple, so the index needs id as a tiebreaker
+
+CREATE INDEX IF NOT EXISTS idx_tasks_user_list_keyset
+ON tasks(user_id, priority_score DESC, created_at DESC, id DESC)
+WHERE deleted_at IS NULL
+  AND (task_type IS NULL OR task_type != 'subtask');
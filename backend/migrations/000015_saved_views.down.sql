@@ -0,0 +1,5 @@
+-- Rollback: Remove saved views
+
+DROP TRIGGER IF EXISTS update_saved_views_updated_at ON saved_views;
+DROP INDEX IF EXISTS idx_saved_views_user_position;
+DROP TABLE IF EXISTS saved_views;
@@ -0,0 +1,40 @@
+-- Migration: Add saved views
+-- Named task list filters ("smart lists") that users can re-open in one click
+
+CREATE TABLE saved_views (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- View metadata
+    name VARCHAR(100) NOT NULL,
+    icon VARCHAR(50),
+    position INTEGER NOT NULL DEFAULT 0 CHECK (position >= 0),
+
+    -- Serialized task list filter (status, category, search, priority range, due range, sort)
+    filter JSONB NOT NULL DEFAULT '{}'::jsonb,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    -- Ensure unique view names per user
+    CONSTRAINT unique_user_view_name UNIQUE(user_id, name)
+);
+
+-- Index for listing a user's views in display order
+CREATE INDEX idx_saved_views_user_position ON saved_views(user_id, position);
+
+-- Auto-update trigger for updated_at
+CREATE TRIGGER update_saved_views_updated_at
+    BEFORE UPDATE ON saved_views
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Block PostgREST access (see 000013_enable_rls)
+ALTER TABLE saved_views ENABLE ROW LEVEL SECURITY;
+REVOKE ALL ON saved_views FROM anon;
+REVOKE ALL ON saved_views FROM authenticated;
+
+-- Documentation
+COMMENT ON TABLE saved_views IS 'User-defined saved task list filters (smart lists)';
+COMMENT ON COLUMN saved_views.filter IS 'Serialized SavedViewFilter; search/sort kept as typed so relative dates resolve at read time';
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS increment_tasks_version ON tasks;
+DROP FUNCTION IF EXISTS increment_task_version();
+ALTER TABLE tasks DROP COLUMN IF EXISTS version;
@@ -0,0 +1,19 @@
+-- Optimistic concurrency control for tasks
+-- Every write to a task row increments its version, which the API exposes as
+-- the task's ETag and checks against If-Match before modifying the task
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+CREATE OR REPLACE FUNCTION increment_task_version() RETURNS trigger AS $$
+BEGIN
+    NEW.version = OLD.version + 1;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+-- Bump on every UPDATE so no write path (bulk updates, bump, restore) can
+-- change a task without invalidating outstanding ETags
+CREATE TRIGGER increment_tasks_version
+    BEFORE UPDATE ON tasks
+    FOR EACH ROW
+    EXECUTE FUNCTION increment_task_version();
@@ -0,0 +1,7 @@
+-- Rollback: Remove tags
+
+ALTER TABLE task_templates DROP COLUMN IF EXISTS tags;
+DROP TABLE IF EXISTS task_tags;
+DROP TRIGGER IF EXISTS update_tags_updated_at ON tags;
+DROP INDEX IF EXISTS idx_tags_user_name;
+DROP TABLE IF EXISTS tags;
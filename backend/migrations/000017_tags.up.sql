@@ -0,0 +1,49 @@
+-- Migration: Add tags
+-- Free-form, many-to-many labels that complement the single task category
+
+CREATE TABLE tags (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Tag metadata
+    name VARCHAR(50) NOT NULL,
+    color VARCHAR(7) CHECK (color ~ '^#[0-9a-fA-F]{6}$'),
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Tag names are unique per user, ignoring case ("Urgent" and "urgent" are the same tag)
+CREATE UNIQUE INDEX idx_tags_user_name ON tags(user_id, LOWER(name));
+
+CREATE TABLE task_tags (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, tag_id)
+);
+
+-- Index for finding the tasks carrying a tag (filtering, counts, merges)
+CREATE INDEX idx_task_tags_tag_id ON task_tags(tag_id);
+
+-- Templates store tag names; they are resolved to tags when the task is created
+ALTER TABLE task_templates ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+-- Auto-update trigger for updated_at
+CREATE TRIGGER update_tags_updated_at
+    BEFORE UPDATE ON tags
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Block PostgREST access (see 000013_enable_rls)
+ALTER TABLE tags ENABLE ROW LEVEL SECURITY;
+REVOKE ALL ON tags FROM anon;
+REVOKE ALL ON tags FROM authenticated;
+ALTER TABLE task_tags ENABLE ROW LEVEL SECURITY;
+REVOKE ALL ON task_tags FROM anon;
+REVOKE ALL ON task_tags FROM authenticated;
+
+-- Documentation
+COMMENT ON TABLE tags IS 'User-defined task labels; a task can carry many tags';
+COMMENT ON TABLE task_tags IS 'Join table assigning tags to tasks';
+COMMENT ON COLUMN task_templates.tags IS 'Tag names applied to tasks created from the template';
@@ -0,0 +1,10 @@
+-- Rollback: Remove task comments
+-- NOTE: PostgreSQL cannot drop enum values; comment_added/comment_removed remain
+-- in task_history_event_type (see 000011_add_task_statuses.down.sql)
+
+DELETE FROM task_history WHERE event_type IN ('comment_added', 'comment_removed');
+DROP TABLE IF EXISTS task_comment_edits;
+DROP TRIGGER IF EXISTS update_task_comments_updated_at ON task_comments;
+DROP INDEX IF EXISTS idx_task_comments_user_id;
+DROP INDEX IF EXISTS idx_task_comments_task_created;
+DROP TABLE IF EXISTS task_comments;
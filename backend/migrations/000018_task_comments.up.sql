@@ -0,0 +1,56 @@
+-- Migration: Add task comments
+-- Markdown progress notes on a task, with edit history and soft delete
+
+CREATE TABLE task_comments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Comment content (markdown)
+    body TEXT NOT NULL CHECK (char_length(body) BETWEEN 1 AND 10000),
+
+    -- Timestamps
+    edited_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Index for listing and counting a task's live comments in order
+CREATE INDEX idx_task_comments_task_created ON task_comments(task_id, created_at)
+    WHERE deleted_at IS NULL;
+
+-- Index for removing a user's comments on account deletion
+CREATE INDEX idx_task_comments_user_id ON task_comments(user_id);
+
+-- Previous bodies of edited comments, newest last
+CREATE TABLE task_comment_edits (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    comment_id UUID NOT NULL REFERENCES task_comments(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    edited_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_task_comment_edits_comment ON task_comment_edits(comment_id, edited_at);
+
+-- Auto-update trigger for updated_at
+CREATE TRIGGER update_task_comments_updated_at
+    BEFORE UPDATE ON task_comments
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- History events for comments
+ALTER TYPE task_history_event_type ADD VALUE IF NOT EXISTS 'comment_added';
+ALTER TYPE task_history_event_type ADD VALUE IF NOT EXISTS 'comment_removed';
+
+-- Block PostgREST access (see 000013_enable_rls)
+ALTER TABLE task_comments ENABLE ROW LEVEL SECURITY;
+REVOKE ALL ON task_comments FROM anon;
+REVOKE ALL ON task_comments FROM authenticated;
+ALTER TABLE task_comment_edits ENABLE ROW LEVEL SECURITY;
+REVOKE ALL ON task_comment_edits FROM anon;
+REVOKE ALL ON task_comment_edits FROM authenticated;
+
+-- Documentation
+COMMENT ON TABLE task_comments IS 'Markdown comments on tasks; deleted_at marks soft-deleted comments';
+COMMENT ON TABLE task_comment_edits IS 'Previous bodies of edited task comments';
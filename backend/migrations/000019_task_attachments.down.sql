@@ -0,0 +1,9 @@
+-- Rollback: Remove task attachments
+-- NOTE: blobs still in the blob store are not removed
+
+DROP TRIGGER IF EXISTS queue_task_attachment_blob_deletion ON task_attachments;
+DROP FUNCTION IF EXISTS queue_attachment_blob_deletion();
+DROP TABLE IF EXISTS attachment_blob_deletions;
+DROP INDEX IF EXISTS idx_task_attachments_user_id;
+DROP INDEX IF EXISTS idx_task_attachments_task_id;
+DROP TABLE IF EXISTS task_attachments;
@@ -0,0 +1,58 @@
+-- Migration: Add task attachments
+-- File metadata lives here; the files themselves live in the blob store (see BLOB_STORE)
+
+CREATE TABLE task_attachments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- File metadata
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+    storage_key TEXT NOT NULL UNIQUE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Index for listing a task's attachments
+CREATE INDEX idx_task_attachments_task_id ON task_attachments(task_id, created_at);
+
+-- Index for per-user quota checks
+CREATE INDEX idx_task_attachments_user_id ON task_attachments(user_id);
+
+-- Blobs whose attachment rows are gone, waiting to be removed from the blob store.
+-- Rows land here however the attachment was deleted: directly, with its task,
+-- or with its user (anonymous user cleanup), so blobs are never orphaned.
+CREATE TABLE attachment_blob_deletions (
+    storage_key TEXT PRIMARY KEY,
+    queued_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE OR REPLACE FUNCTION queue_attachment_blob_deletion()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO attachment_blob_deletions (storage_key)
+    VALUES (OLD.storage_key)
+    ON CONFLICT (storage_key) DO NOTHING;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER queue_task_attachment_blob_deletion
+    AFTER DELETE ON task_attachments
+    FOR EACH ROW
+    EXECUTE FUNCTION queue_attachment_blob_deletion();
+
+-- Block PostgREST access (see 000013_enable_rls)
+ALTER TABLE task_attachments ENABLE ROW LEVEL SECURITY;
+REVOKE ALL ON task_attachments FROM anon;
+REVOKE ALL ON task_attachments FROM authenticated;
+ALTER TABLE attachment_blob_deletions ENABLE ROW LEVEL SECURITY;
+REVOKE ALL ON attachment_blob_deletions FROM anon;
+REVOKE ALL ON attachment_blob_deletions FROM authenticated;
+
+-- Documentation
+COMMENT ON TABLE task_attachments IS 'Files attached to tasks; content is stored in the blob store under storage_key';
+COMMENT ON TABLE attachment_blob_deletions IS 'Blob keys of deleted attachments, drained by AttachmentService.PurgeDeletedBlobs';
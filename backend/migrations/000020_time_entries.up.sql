@@ -0,0 +1,45 @@
+-- Migration: Add time tracking
+-- Time actually spent on tasks, from start/stop timers or logged manually
+
+CREATE TABLE time_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Tracked interval; ended_at is NULL while a timer is running
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE,
+    source VARCHAR(10) NOT NULL CHECK (source IN ('timer', 'manual')),
+    note TEXT CHECK (note IS NULL OR char_length(note) <= 500),
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT time_entries_valid_interval CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+
+-- At most one running timer per user
+CREATE UNIQUE INDEX idx_time_entries_one_running ON time_entries(user_id)
+    WHERE ended_at IS NULL;
+
+-- Index for listing and totalling a task's entries
+CREATE INDEX idx_time_entries_task_started ON time_entries(task_id, started_at);
+
+-- Index for per-user time analytics over a period
+CREATE INDEX idx_time_entries_user_started ON time_entries(user_id, started_at);
+
+-- Auto-update trigger for updated_at
+CREATE TRIGGER update_time_entries_updated_at
+    BEFORE UPDATE ON time_entries
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Block PostgREST access (see 000013_enable_rls)
+ALTER TABLE time_entries ENABLE ROW LEVEL SECURITY;
+REVOKE ALL ON time_entries FROM anon;
+REVOKE ALL ON time_entries FROM authenticated;
+
+-- Documentation
+COMMENT ON TABLE time_entries IS 'Time spent on tasks; a NULL ended_at marks the user''s running timer';
+COMMENT ON COLUMN time_entries.source IS 'timer: started and stopped live; manual: logged after the fact';
@@ -0,0 +1,18 @@
+-- Down migration for 000021_focus_sessions
+
+DROP TRIGGER IF EXISTS update_focus_sessions_updated_at ON focus_sessions;
+DROP INDEX IF EXISTS idx_focus_sessions_user_completed;
+DROP INDEX IF EXISTS idx_focus_sessions_task_started;
+DROP INDEX IF EXISTS idx_focus_sessions_one_active;
+DROP TABLE IF EXISTS focus_sessions;
+
+-- PostgreSQL does not support removing enum values (see 000011 down migration);
+-- 'focus_master' stays in achievement_type, but earned badges are removed
+DELETE FROM user_achievements WHERE achievement_type = 'focus_master';
+
+ALTER TABLE user_preferences
+DROP COLUMN IF EXISTS focus_counts_toward_achievements,
+DROP COLUMN IF EXISTS focus_sessions_until_long_break,
+DROP COLUMN IF EXISTS focus_long_break_minutes,
+DROP COLUMN IF EXISTS focus_short_break_minutes,
+DROP COLUMN IF EXISTS focus_work_minutes;
@@ -0,0 +1,69 @@
+-- Migration: Add focus sessions (Pomodoro)
+-- Timed work sessions on a task, with the user's work/break lengths in user_preferences
+
+-- Focus settings on user_preferences
+ALTER TABLE user_preferences
+ADD COLUMN IF NOT EXISTS focus_work_minutes INTEGER NOT NULL DEFAULT 25
+    CHECK (focus_work_minutes BETWEEN 1 AND 180),
+ADD COLUMN IF NOT EXISTS focus_short_break_minutes INTEGER NOT NULL DEFAULT 5
+    CHECK (focus_short_break_minutes BETWEEN 1 AND 60),
+ADD COLUMN IF NOT EXISTS focus_long_break_minutes INTEGER NOT NULL DEFAULT 15
+    CHECK (focus_long_break_minutes BETWEEN 1 AND 120),
+ADD COLUMN IF NOT EXISTS focus_sessions_until_long_break INTEGER NOT NULL DEFAULT 4
+    CHECK (focus_sessions_until_long_break BETWEEN 1 AND 12),
+ADD COLUMN IF NOT EXISTS focus_counts_toward_achievements BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- Achievement for completed focus sessions
+-- Note: PostgreSQL allows adding values to enums, but not removing them
+ALTER TYPE achievement_type ADD VALUE IF NOT EXISTS 'focus_master';
+
+CREATE TABLE focus_sessions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Session state
+    status VARCHAR(20) NOT NULL DEFAULT 'running'
+        CHECK (status IN ('running', 'paused', 'completed', 'interrupted')),
+    planned_minutes INTEGER NOT NULL CHECK (planned_minutes BETWEEN 1 AND 180),
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    paused_at TIMESTAMP WITH TIME ZONE,                 -- Set while paused
+    paused_seconds INTEGER NOT NULL DEFAULT 0 CHECK (paused_seconds >= 0), -- Time spent in finished pauses
+    ended_at TIMESTAMP WITH TIME ZONE,                  -- Set once completed or interrupted
+    focused_seconds INTEGER NOT NULL DEFAULT 0 CHECK (focused_seconds >= 0), -- Final unpaused time
+    interrupt_reason TEXT CHECK (interrupt_reason IS NULL OR char_length(interrupt_reason) <= 500),
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT focus_sessions_paused_at_matches_status CHECK ((status = 'paused') = (paused_at IS NOT NULL)),
+    CONSTRAINT focus_sessions_ended_at_matches_status CHECK ((status IN ('completed', 'interrupted')) = (ended_at IS NOT NULL))
+);
+
+-- At most one active (running or paused) session per user
+CREATE UNIQUE INDEX idx_focus_sessions_one_active ON focus_sessions(user_id)
+    WHERE status IN ('running', 'paused');
+
+-- Index for listing and counting a task's sessions
+CREATE INDEX idx_focus_sessions_task_started ON focus_sessions(task_id, started_at);
+
+-- Index for the focus heatmap and achievement counts
+CREATE INDEX idx_focus_sessions_user_completed ON focus_sessions(user_id, started_at)
+    WHERE status = 'completed';
+
+-- Auto-update trigger for updated_at
+CREATE TRIGGER update_focus_sessions_updated_at
+    BEFORE UPDATE ON focus_sessions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Block PostgREST access (see 000013_enable_rls)
+ALTER TABLE focus_sessions ENABLE ROW LEVEL SECURITY;
+REVOKE ALL ON focus_sessions FROM anon;
+REVOKE ALL ON focus_sessions FROM authenticated;
+
+-- Documentation
+COMMENT ON TABLE focus_sessions IS 'Pomodoro-style focus sessions on tasks; a user has at most one running or paused session';
+COMMENT ON COLUMN focus_sessions.focused_seconds IS 'Time between start and end minus pauses, set when the session ends';
+COMMENT ON COLUMN user_preferences.focus_counts_toward_achievements IS 'Whether completed focus sessions count toward the focus_master achievement';
@@ -0,0 +1,6 @@
+-- Down migration for 000022_task_defer_until
+
+DROP INDEX IF EXISTS idx_tasks_user_defer_until;
+
+ALTER TABLE tasks
+DROP COLUMN IF EXISTS defer_until;
@@ -0,0 +1,13 @@
+-- Migration: Add defer_until (start date) to tasks
+-- A deferred task is hidden from the default task list and at-risk queries
+-- until its start date, and its age for time decay is measured from that date
+
+ALTER TABLE tasks
+ADD COLUMN IF NOT EXISTS defer_until TIMESTAMP WITH TIME ZONE;
+
+-- Supports the "not deferred" filter; most tasks are never deferred
+CREATE INDEX IF NOT EXISTS idx_tasks_user_defer_until
+ON tasks(user_id, defer_until)
+WHERE defer_until IS NOT NULL AND deleted_at IS NULL;
+
+COMMENT ON COLUMN tasks.defer_until IS 'Start date: task is hidden from default lists and at-risk queries until this time; NULL = not deferred';
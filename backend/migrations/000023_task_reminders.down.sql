@@ -0,0 +1,6 @@
+-- Down migration for 000023_task_reminders
+
+DROP TRIGGER IF EXISTS update_task_reminders_updated_at ON task_reminders;
+DROP INDEX IF EXISTS idx_task_reminders_task;
+DROP INDEX IF EXISTS idx_task_reminders_due;
+DROP TABLE IF EXISTS task_reminders;
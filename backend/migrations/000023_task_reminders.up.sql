@@ -0,0 +1,53 @@
+-- Migration: Add task reminders
+-- Per-task reminders delivered by a background scheduler over a notification channel
+
+CREATE TABLE task_reminders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- When to fire: an absolute time, or minutes before the task's due date.
+    -- remind_at is the resolved fire time; NULL while a relative reminder's task has no due date
+    offset_minutes INTEGER CHECK (offset_minutes IS NULL OR offset_minutes BETWEEN 0 AND 525600),
+    remind_at TIMESTAMP WITH TIME ZONE,
+
+    -- Delivery
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('email', 'webhook')),
+    target TEXT CHECK (target IS NULL OR char_length(target) <= 2000),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    locked_until TIMESTAMP WITH TIME ZONE, -- Lease held by the scheduler instance delivering it
+    sent_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT task_reminders_absolute_has_time CHECK (offset_minutes IS NOT NULL OR remind_at IS NOT NULL),
+    CONSTRAINT task_reminders_sent_has_time CHECK (status != 'sent' OR sent_at IS NOT NULL)
+);
+
+-- Index for the scheduler's due-reminder scan
+CREATE INDEX idx_task_reminders_due ON task_reminders(remind_at)
+    WHERE status = 'pending' AND remind_at IS NOT NULL;
+
+-- Index for listing and rescheduling a task's reminders
+CREATE INDEX idx_task_reminders_task ON task_reminders(task_id);
+
+-- Auto-update trigger for updated_at
+CREATE TRIGGER update_task_reminders_updated_at
+    BEFORE UPDATE ON task_reminders
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Block PostgREST access (see 000013_enable_rls)
+ALTER TABLE task_reminders ENABLE ROW LEVEL SECURITY;
+REVOKE ALL ON task_reminders FROM anon;
+REVOKE ALL ON task_reminders FROM authenticated;
+
+-- Documentation
+COMMENT ON TABLE task_reminders IS 'Task reminders; the scheduler claims due pending rows with a lease so each is delivered by one instance';
+COMMENT ON COLUMN task_reminders.offset_minutes IS 'Relative reminder: minutes before due_date; remind_at moves when the due date changes. NULL = absolute';
+COMMENT ON COLUMN task_reminders.target IS 'Email address or webhook URL; NULL email target = the account email';
+COMMENT ON COLUMN task_reminders.locked_until IS 'Delivery lease; a crashed instance''s claim expires and the reminder is retried';
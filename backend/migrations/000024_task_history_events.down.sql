@@ -0,0 +1,5 @@
+-- Rollback: Task history API
+-- NOTE: PostgreSQL cannot drop enum values; restored/uncompleted remain
+-- in task_history_event_type (see 000011_add_task_statuses.down.sql)
+
+DROP INDEX IF EXISTS idx_task_history_task_created;
@@ -0,0 +1,9 @@
+-- Migration: Task history API
+-- Restore and uncomplete have always logged these events, but the enum never had them,
+-- so those history entries failed to insert
+
+ALTER TYPE task_history_event_type ADD VALUE IF NOT EXISTS 'restored';
+ALTER TYPE task_history_event_type ADD VALUE IF NOT EXISTS 'uncompleted';
+
+-- Paging one task's history newest first
+CREATE INDEX IF NOT EXISTS idx_task_history_task_created ON task_history(task_id, created_at DESC, id DESC);
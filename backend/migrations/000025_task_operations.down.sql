@@ -0,0 +1,7 @@
+-- Rollback: Undo for task operations
+
+DROP INDEX IF EXISTS idx_task_history_user_operation;
+
+ALTER TABLE task_history DROP COLUMN IF EXISTS reverts_operation_id;
+ALTER TABLE task_history DROP COLUMN IF EXISTS undone_at;
+ALTER TABLE task_history DROP COLUMN IF EXISTS operation_id;
@@ -0,0 +1,12 @@
+-- Migration: Undo for task operations
+-- Every history entry written by one request shares that request's operation ID,
+-- so the whole operation can be found and reverted together
+
+ALTER TABLE task_history ADD COLUMN operation_id UUID;
+ALTER TABLE task_history ADD COLUMN undone_at TIMESTAMP WITH TIME ZONE;
+-- Entries written by an undo point at the operation they reverted
+ALTER TABLE task_history ADD COLUMN reverts_operation_id UUID;
+
+-- Finding a user's recent operations and the entries of one operation
+CREATE INDEX IF NOT EXISTS idx_task_history_user_operation ON task_history(user_id, operation_id, created_at DESC)
+    WHERE operation_id IS NOT NULL;
@@ -0,0 +1,4 @@
+-- Rollback: Trash view and retention purge
+
+DROP INDEX IF EXISTS idx_tasks_deleted;
+DROP TABLE IF EXISTS task_purges;
@@ -0,0 +1,17 @@
+-- Migration: Trash view and retention purge
+-- Audit log of permanently deleted tasks, kept after the user is gone (like anonymous_user_cleanups)
+
+CREATE TABLE task_purges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    task_count INTEGER NOT NULL DEFAULT 0, -- Including subtasks purged with their parent
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('permanent', 'empty_trash', 'retention')),
+    purged_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Index for purge audit queries
+CREATE INDEX idx_task_purges_purged_at ON task_purges(purged_at DESC);
+
+-- Listing the trash newest first and finding tasks past retention
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted ON tasks(user_id, deleted_at DESC)
+WHERE deleted_at IS NOT NULL;
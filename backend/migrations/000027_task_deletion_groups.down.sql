@@ -0,0 +1,5 @@
+-- Rollback: Cascading soft delete
+-- Subtasks trashed together with their parent stay in the trash
+
+DROP INDEX IF EXISTS idx_tasks_deletion_group;
+ALTER TABLE tasks DROP COLUMN IF EXISTS deletion_group_id;
@@ -0,0 +1,21 @@
+-- Migration: Cascading soft delete
+-- Deleting a task also trashes its subtasks; every row trashed by one delete shares a
+-- deletion group so a restore brings the whole tree back together
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS deletion_group_id UUID DEFAULT NULL;
+
+-- Restoring a deletion group
+CREATE INDEX IF NOT EXISTS idx_tasks_deletion_group ON tasks (user_id, deletion_group_id)
+WHERE deletion_group_id IS NOT NULL;
+
+-- Subtasks left behind by a deleted parent before deletes cascaded join its group
+UPDATE tasks SET deletion_group_id = id WHERE deleted_at IS NOT NULL;
+UPDATE tasks st
+SET deleted_at = p.deleted_at, deletion_group_id = p.deletion_group_id
+FROM tasks p
+WHERE st.parent_task_id = p.id
+  AND st.series_id IS NULL
+  AND st.deleted_at IS NULL
+  AND p.deleted_at IS NOT NULL;
+
+COMMENT ON COLUMN tasks.deletion_group_id IS 'Shared by all tasks trashed by one delete (a task and its subtasks); NULL when not deleted';
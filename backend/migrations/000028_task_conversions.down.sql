@@ -0,0 +1,5 @@
+-- Rollback: Task type conversions
+-- NOTE: PostgreSQL cannot drop enum values; 'converted' remains
+-- in task_history_event_type (see 000011_add_task_statuses.down.sql)
+
+SELECT 1;
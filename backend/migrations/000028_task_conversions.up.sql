@@ -0,0 +1,4 @@
+-- Migration: Task type conversions
+-- Converting a task between regular, subtask and recurring is logged as its own event
+
+ALTER TYPE task_history_event_type ADD VALUE IF NOT EXISTS 'converted';
@@ -0,0 +1,5 @@
+-- Rollback: Multi-level task hierarchies
+-- Nested subtasks are left in place; the API no longer creates them
+
+DROP TRIGGER IF EXISTS set_tasks_task_type ON tasks;
+DROP FUNCTION IF EXISTS set_task_type();
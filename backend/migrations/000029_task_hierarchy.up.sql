@@ -0,0 +1,32 @@
+-- Migration: Multi-level task hierarchies
+-- Subtasks may now have subtasks of their own, up to TASK_MAX_DEPTH levels.
+-- task_type was only ever written as its default, so subtasks were stored as
+-- 'regular'; it is now derived from the relationship columns on every write.
+
+CREATE OR REPLACE FUNCTION set_task_type() RETURNS trigger AS $$
+BEGIN
+    NEW.task_type = CASE
+        WHEN NEW.series_id IS NOT NULL THEN 'recurring'::task_type
+        WHEN NEW.parent_task_id IS NOT NULL THEN 'subtask'::task_type
+        ELSE 'regular'::task_type
+    END;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER set_tasks_task_type
+    BEFORE INSERT OR UPDATE OF series_id, parent_task_id ON tasks
+    FOR EACH ROW
+    EXECUTE FUNCTION set_task_type();
+
+-- Backfill rows written before the trigger existed
+UPDATE tasks SET task_type = CASE
+        WHEN series_id IS NOT NULL THEN 'recurring'::task_type
+        WHEN parent_task_id IS NOT NULL THEN 'subtask'::task_type
+        ELSE 'regular'::task_type
+    END
+WHERE task_type IS DISTINCT FROM CASE
+        WHEN series_id IS NOT NULL THEN 'recurring'::task_type
+        WHEN parent_task_id IS NOT NULL THEN 'subtask'::task_type
+        ELSE 'regular'::task_type
+    END;
@@ -0,0 +1,7 @@
+-- Down migration for 000030_task_ranks
+
+DROP INDEX IF EXISTS idx_tasks_parent_rank;
+DROP INDEX IF EXISTS idx_tasks_user_rank;
+
+ALTER TABLE tasks
+DROP COLUMN IF EXISTS rank;
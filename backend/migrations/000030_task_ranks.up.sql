@@ -0,0 +1,36 @@
+-- Migration: Manual ordering of tasks
+-- Each task has a rank in its list (a user's top-level tasks, or the subtasks of
+-- one parent). Ranks are fractional indexes compared byte by byte, so a move only
+-- rewrites the moved task's rank (see domain.RankBetween).
+
+ALTER TABLE tasks
+ADD COLUMN IF NOT EXISTS rank TEXT COLLATE "C";
+
+-- Existing lists keep the order they were shown in: top-level tasks by priority,
+-- subtasks oldest first. Ranks are zero-padded positions with a trailing 'V', since
+-- a rank may not end in the lowest digit.
+WITH ordered AS (
+    SELECT id, ROW_NUMBER() OVER (
+        PARTITION BY user_id, CASE WHEN task_type = 'subtask' THEN parent_task_id END
+        ORDER BY CASE WHEN task_type = 'subtask' THEN created_at END ASC,
+                 priority_score DESC, created_at DESC, id DESC
+    ) AS position
+    FROM tasks
+)
+UPDATE tasks
+SET rank = LPAD(ordered.position::text, 10, '0') || 'V'
+FROM ordered
+WHERE tasks.id = ordered.id;
+
+ALTER TABLE tasks
+ALTER COLUMN rank SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_user_rank
+ON tasks(user_id, rank)
+WHERE deleted_at IS NULL AND task_type != 'subtask';
+
+CREATE INDEX IF NOT EXISTS idx_tasks_parent_rank
+ON tasks(parent_task_id, rank)
+WHERE deleted_at IS NULL AND task_type = 'subtask';
+
+COMMENT ON COLUMN tasks.rank IS 'Position in the manually ordered list of the task (top-level tasks per user, or subtasks per parent); compared in byte order';
@@ -0,0 +1,4 @@
+-- Down migration for 000031_board_wip_limits
+
+ALTER TABLE user_preferences
+DROP COLUMN IF EXISTS board_wip_limits;
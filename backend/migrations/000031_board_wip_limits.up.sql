@@ -0,0 +1,7 @@
+-- Migration: Add board WIP limits
+-- Per-status caps on how many tasks a board column may hold, e.g. {"in_progress": 3}.
+-- Statuses without an entry are unlimited.
+
+ALTER TABLE user_preferences
+ADD COLUMN IF NOT EXISTS board_wip_limits JSONB NOT NULL DEFAULT '{}'::jsonb
+    CHECK (jsonb_typeof(board_wip_limits) = 'object');
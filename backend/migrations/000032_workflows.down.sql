@@ -0,0 +1,13 @@
+-- Down migration for 000032_workflows
+-- Tasks keep their built-in status; custom statuses are dropped
+
+DROP TRIGGER IF EXISTS sync_tasks_workflow_status ON tasks;
+DROP FUNCTION IF EXISTS sync_task_workflow_status();
+DROP FUNCTION IF EXISTS task_status_category(task_status);
+
+DROP INDEX IF EXISTS idx_tasks_user_workflow_status;
+
+ALTER TABLE tasks
+DROP COLUMN IF EXISTS workflow_status;
+
+DROP TABLE IF EXISTS workflow_statuses;
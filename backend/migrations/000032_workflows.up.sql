@@ -0,0 +1,72 @@
+-- Migration: User-defined workflows
+-- Users may replace the built-in statuses with their own (e.g. "in_review"). Each
+-- custom status belongs to a category (open, active or done) and is stored on the
+-- task as workflow_status, while tasks.status keeps the matching built-in status so
+-- analytics, at-risk detection and gamification keep working unchanged.
+
+CREATE TABLE IF NOT EXISTS workflow_statuses (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status_key TEXT NOT NULL CHECK (status_key ~ '^[a-z][a-z0-9_]{0,29}$'),
+    name VARCHAR(50) NOT NULL,
+    category TEXT NOT NULL CHECK (category IN ('open', 'active', 'done')),
+    base_status task_status NOT NULL,
+    position INTEGER NOT NULL,
+    -- Statuses a task may move to from this one; NULL allows any
+    transitions TEXT[],
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, status_key)
+);
+
+COMMENT ON TABLE workflow_statuses IS 'Custom task statuses per user; users without rows use the built-in statuses';
+
+ALTER TABLE tasks
+ADD COLUMN IF NOT EXISTS workflow_status TEXT;
+
+-- Every existing task is in the built-in status of the same name
+UPDATE tasks SET workflow_status = status::text WHERE workflow_status IS NULL;
+
+ALTER TABLE tasks
+ALTER COLUMN workflow_status SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_user_workflow_status
+ON tasks(user_id, workflow_status)
+WHERE deleted_at IS NULL;
+
+COMMENT ON COLUMN tasks.workflow_status IS 'Status key in the user''s workflow; status holds its built-in base status';
+
+-- Category of a built-in status (see domain.TaskStatus.Category)
+CREATE OR REPLACE FUNCTION task_status_category(status task_status) RETURNS TEXT AS $$
+    SELECT CASE status
+        WHEN 'done' THEN 'done'
+        WHEN 'in_progress' THEN 'active'
+        WHEN 'blocked' THEN 'active'
+        ELSE 'open'
+    END
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Writes that only set the built-in status (completing, reopening, bulk updates,
+-- recurring and duplicated tasks) get the first workflow status of the same category,
+-- preferring one with the same base status.
+CREATE OR REPLACE FUNCTION sync_task_workflow_status() RETURNS trigger AS $$
+BEGIN
+    IF NEW.workflow_status IS NULL
+       OR (TG_OP = 'UPDATE' AND NEW.status IS DISTINCT FROM OLD.status
+           AND NEW.workflow_status IS NOT DISTINCT FROM OLD.workflow_status) THEN
+        NEW.workflow_status = COALESCE(
+            (SELECT ws.status_key
+             FROM workflow_statuses ws
+             WHERE ws.user_id = NEW.user_id
+               AND ws.category = task_status_category(NEW.status)
+             ORDER BY (ws.base_status = NEW.status) DESC, ws.position
+             LIMIT 1),
+            NEW.status::text
+        );
+    END IF;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER sync_tasks_workflow_status
+    BEFORE INSERT OR UPDATE OF status, workflow_status ON tasks
+    FOR EACH ROW
+    EXECUTE FUNCTION sync_task_workflow_status();
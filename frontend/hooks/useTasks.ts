@@ -13,6 +13,7 @@ export type { TaskFilters } from '@/lib/queryKeys';
 interface TaskListResponse {
   tasks: Task[];
   total_count: number;
+  next_cursor: string | null;
 }
 
 export function useTasks(filters?: Parameters<typeof taskKeys.list>[0]) {